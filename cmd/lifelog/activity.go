@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	restclient "github.com/elhamza90/lifelog/internal/http/rest/client"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+	"github.com/elhamza90/lifelog/internal/usecase/listing"
+)
+
+// activityHeader is the header of activities tables
+var activityHeader = []string{"ID", "TIME", "DURATION", "LABEL", "PLACE"}
+
+// activityRows returns table rows of activities
+func activityRows(activities []server.JSONRespListActivity) [][]string {
+	rows := make([][]string, len(activities))
+	for i, act := range activities {
+		rows[i] = []string{
+			strconv.Itoa(int(act.ID)),
+			act.Time.Local().Format("2006-01-02 15:04"),
+			act.Duration.String(),
+			act.Label,
+			act.Place,
+		}
+	}
+	return rows
+}
+
+// activityListCmd prints activities in a date range
+func activityListCmd(c *client, out printer, args []string) error {
+	flags := newFlagSet("activity list")
+	from := flags.String("from", "", "start date of the range ( default: server default )")
+	to := flags.String("to", "", "end date of the range ( default: now )")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	r, err := parseRange(*from, *to, time.Now())
+	if err != nil {
+		return err
+	}
+	api, err := c.loggedIn()
+	if err != nil {
+		return err
+	}
+	activities := []server.JSONRespListActivity{}
+	p := restclient.PageOptions{Limit: listing.MaxPageLimit}
+	for {
+		page, err := api.Activities(r, p)
+		if err != nil {
+			return err
+		}
+		activities = append(activities, page.Items...)
+		if page.Next == "" {
+			break
+		}
+		p.Cursor = page.Next
+	}
+	return out.print(activities, activityHeader, activityRows(activities))
+}
+
+// activityAddCmd creates an activity.
+// Default time is so the activity ends now.
+func activityAddCmd(c *client, out printer, args []string) error {
+	flags := newFlagSet("activity add")
+	var tags stringList
+	label := flags.String("label", "", "label of the activity ( required )")
+	place := flags.String("place", "", "place of the activity")
+	desc := flags.String("desc", "", "description of the activity")
+	timeStr := flags.String("time", "", "start time of the activity ( default: now - duration )")
+	duration := flags.Duration("duration", 0, "duration of the activity ( ex: 1h30m )")
+	flags.Var(&tags, "tag", "name of a tag ( repeatable )")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *label == "" {
+		return fmt.Errorf("-label is required")
+	}
+	now := time.Now()
+	t := now.Add(-*duration)
+	if *timeStr != "" {
+		var err error
+		if t, err = parseTime(*timeStr, now); err != nil {
+			return err
+		}
+	}
+	api, err := c.loggedIn()
+	if err != nil {
+		return err
+	}
+	ids, err := tagIds(c, tags)
+	if err != nil {
+		return err
+	}
+	created, err := api.AddActivity(server.JSONReqActivity{
+		Label:    *label,
+		Place:    *place,
+		Desc:     *desc,
+		Time:     t,
+		Duration: *duration,
+		TagIds:   ids,
+	})
+	if err != nil {
+		return err
+	}
+	var respAct server.JSONRespListActivity
+	respAct.From(created)
+	return out.print(respAct, activityHeader, activityRows([]server.JSONRespListActivity{respAct}))
+}
+
+// activityRmCmd deletes the activity with the id given as argument
+func activityRmCmd(c *client, out printer, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: lifelog activity rm ID")
+	}
+	id, err := strconv.ParseUint(args[0], 10, 0)
+	if err != nil {
+		return fmt.Errorf("activity ID must be a number")
+	}
+	api, err := c.loggedIn()
+	if err != nil {
+		return err
+	}
+	if err := api.DeleteActivity(domain.ActivityID(id)); err != nil {
+		return err
+	}
+	return out.message(fmt.Sprintf("Deleted activity %d", id))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// errUserMissing is returned when no user name is given
+var errUserMissing error = errors.New("user name is missing ( -user flag or LFLG_USER )")
+
+// credentialsFlags defines the flags of the user name, given by flag
+// or by the environment variable LFLG_USER, and of the password
+func credentialsFlags(flags *flag.FlagSet) (*string, *string) {
+	user := flags.String("user", os.Getenv("LFLG_USER"), "user name")
+	password := flags.String("password", os.Getenv("LFLG_PASSWORD"), "password ( read from stdin if missing )")
+	return user, password
+}
+
+// readPassword returns the given password or reads it from stdin if empty
+func readPassword(password string) (string, error) {
+	if password != "" {
+		return password, nil
+	}
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// registerCmd creates a user account with a password given by flag,
+// by the environment variable LFLG_PASSWORD or read from stdin
+func registerCmd(c *client, out printer, args []string) error {
+	flags := newFlagSet("register")
+	user, password := credentialsFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *user == "" {
+		return errUserMissing
+	}
+	pass, err := readPassword(*password)
+	if err != nil {
+		return err
+	}
+	usr, err := c.api.Register(*user, pass)
+	if err != nil {
+		return err
+	}
+	return out.message("Registered user " + usr.Name + " on " + c.server)
+}
+
+// loginCmd logs in with a password given by flag, by the environment
+// variable LFLG_PASSWORD or read from stdin, and caches the tokens
+// restricted to the scopes given by flag ( if any )
+func loginCmd(c *client, out printer, args []string) error {
+	flags := newFlagSet("login")
+	user, password := credentialsFlags(flags)
+	var scopes stringList
+	flags.Var(&scopes, "scope", "scope of the tokens ( ex: expenses:read, repeatable, default: all )")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *user == "" {
+		return errUserMissing
+	}
+	pass, err := readPassword(*password)
+	if err != nil {
+		return err
+	}
+	if err := c.login(*user, pass, scopes); err != nil {
+		return err
+	}
+	return out.message("Logged in to " + c.server + " as " + strings.ToLower(*user))
+}
+
+// logoutCmd ends the session, or all sessions of the user
+// with the -all flag, and removes cached tokens
+func logoutCmd(c *client, out printer, args []string) error {
+	flags := newFlagSet("logout")
+	all := flags.Bool("all", false, "log out of all sessions")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := c.logout(*all); err != nil {
+		return err
+	}
+	if *all {
+		return out.message("Logged out of all sessions")
+	}
+	return out.message("Logged out")
+}
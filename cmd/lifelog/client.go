@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	restclient "github.com/elhamza90/lifelog/internal/http/rest/client"
+)
+
+// tokens are the access & refresh tokens returned by the server at login.
+// They are cached in a file so commands do not need to log in again.
+type tokens struct {
+	Server  string `json:"server"`
+	Access  string `json:"at"`
+	Refresh string `json:"rt"`
+}
+
+// errNotLoggedIn is returned when a command needs tokens and there are none
+var errNotLoggedIn error = errors.New("not logged in: run lifelog login")
+
+// tokensPath returns the path of the file caching the tokens.
+// It can be set with the environment variable LFLG_TOKENS.
+func tokensPath() (string, error) {
+	if path := os.Getenv("LFLG_TOKENS"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "lifelog", "tokens.json"), nil
+}
+
+// loadTokens reads cached tokens. Missing cache means no tokens.
+func loadTokens(path string) (tokens, error) {
+	var tk tokens
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return tk, nil
+	}
+	if err != nil {
+		return tk, err
+	}
+	err = json.Unmarshal(content, &tk)
+	return tk, err
+}
+
+// saveTokens caches tokens in a file only readable by the user
+func saveTokens(path string, tk tokens) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	content, err := json.Marshal(tk)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, content, 0600)
+}
+
+// client calls the lifelog REST API with cached tokens.
+// Tokens refreshed by the API client are cached again.
+type client struct {
+	server     string
+	tokensPath string
+	api        *restclient.Client
+}
+
+// newClient returns a client of the given server using cached tokens
+// and the API key of the environment variable LFLG_API_KEY ( if any ).
+// Tokens cached for another server are ignored.
+func newClient(server string) (*client, error) {
+	path, err := tokensPath()
+	if err != nil {
+		return nil, err
+	}
+	tk, err := loadTokens(path)
+	if err != nil {
+		return nil, err
+	}
+	server = strings.TrimRight(server, "/")
+	c := &client{server: server, tokensPath: path, api: restclient.New(server, nil)}
+	if tk.Server == server {
+		c.api.SetTokens(restclient.Tokens{Access: tk.Access, Refresh: tk.Refresh})
+	}
+	c.api.SetAPIKey(os.Getenv("LFLG_API_KEY"))
+	// A failure to cache refreshed tokens only means refreshing again next time
+	c.api.OnRefresh = func(tk restclient.Tokens) {
+		saveTokens(c.tokensPath, tokens{Server: c.server, Access: tk.Access, Refresh: tk.Refresh})
+	}
+	return c, nil
+}
+
+// loggedIn returns the API client or errNotLoggedIn if there are no tokens
+// nor API key
+func (c *client) loggedIn() (*restclient.Client, error) {
+	if c.api.Tokens().Access == "" && os.Getenv("LFLG_API_KEY") == "" {
+		return nil, errNotLoggedIn
+	}
+	return c.api, nil
+}
+
+// login authenticates the user with the password and caches the returned tokens
+// restricted to the given scopes ( if any )
+func (c *client) login(user string, password string, scopes []string) error {
+	granted := make([]domain.Scope, len(scopes))
+	for i, s := range scopes {
+		granted[i] = domain.Scope(s)
+	}
+	tk, err := c.api.Login(user, password, granted...)
+	if err != nil {
+		return err
+	}
+	return saveTokens(c.tokensPath, tokens{Server: c.server, Access: tk.Access, Refresh: tk.Refresh})
+}
+
+// logout revokes the tokens on the server, of this session or of all
+// sessions if all is set, and removes cached tokens.
+// Cached tokens are removed even if the server could not revoke them.
+func (c *client) logout(all bool) error {
+	var err error
+	if c.api.Tokens().Refresh != "" {
+		if all {
+			err = c.api.LogoutAll()
+		} else {
+			err = c.api.Logout()
+		}
+	}
+	c.api.SetTokens(restclient.Tokens{})
+	if err := os.Remove(c.tokensPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return err
+}
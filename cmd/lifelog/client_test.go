@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+func TestTokensCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lifelog", "tokens.json")
+	// Missing cache means no tokens
+	tk, err := loadTokens(path)
+	if err != nil || tk != (tokens{}) {
+		t.Fatalf("\nExpected no tokens\nReturned: %+v, %v", tk, err)
+	}
+	saved := tokens{Server: "http://localhost:8080", Access: "access", Refresh: "refresh"}
+	if err := saveTokens(path, saved); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Fatalf("\nExpected Permissions: %v\nReturned Permissions: %v", os.FileMode(0600), perm)
+	}
+	if tk, err := loadTokens(path); err != nil || tk != saved {
+		t.Fatalf("\nExpected Tokens: %+v\nReturned Tokens: %+v, %v", saved, tk, err)
+	}
+}
+
+// run runs the command with given arguments on a client of the testing server
+// and returns its output
+func run(t *testing.T, jsonOutput bool, args ...string) (string, error) {
+	cmd, cmdArgs, ok := findCommand(args)
+	if !ok {
+		t.Fatalf("\nUnknown command: %v", args)
+	}
+	c, err := newClient(srv.URL)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	var buf bytes.Buffer
+	err = cmd(c, printer{w: &buf, json: jsonOutput}, cmdArgs)
+	return buf.String(), err
+}
+
+func TestTagCommands(t *testing.T) {
+	defer func() {
+		repo.Users = map[domain.UserID]domain.User{}
+		repo.Tags = map[domain.TagID]domain.Tag{}
+	}()
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	os.Setenv("LFLG_TOKENS", path)
+	defer os.Unsetenv("LFLG_TOKENS")
+	if _, err := run(t, false, "tag", "list"); !errors.Is(err, errNotLoggedIn) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", errNotLoggedIn, err)
+	}
+	// Register & log in cache the tokens for the server
+	if _, err := run(t, false, "register", "-user", "cli", "-password", "cli-password"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if _, err := run(t, false, "login", "-user", "cli", "-password", "cli-password"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if tk, err := loadTokens(path); err != nil || tk.Server != srv.URL || tk.Access == "" || tk.Refresh == "" {
+		t.Fatalf("\nExpected tokens cached for %s\nReturned: %+v, %v", srv.URL, tk, err)
+	}
+	// Add & list tags with cached tokens
+	if _, err := run(t, false, "tag", "add", "sport"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	out, err := run(t, false, "tag", "list")
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || strings.Fields(lines[0])[1] != "NAME" || strings.Fields(lines[1])[1] != "sport" {
+		t.Fatalf("\nExpected a table of tag sport\nReturned:\n%s", out)
+	}
+	out, err = run(t, true, "tag", "list")
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	var tags []server.JSONRespListTag
+	if err := json.Unmarshal([]byte(out), &tags); err != nil || len(tags) != 1 || tags[0].Name != "sport" {
+		t.Fatalf("\nExpected json of tag sport\nReturned: %s, %v", out, err)
+	}
+	// Remove by name
+	if _, err := run(t, false, "tag", "rm", "sport"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if _, err := repo.FindTagByID(tags[0].ID); err == nil {
+		t.Fatal("\nExpected tag sport to be deleted")
+	}
+	// Log out removes cached tokens
+	if _, err := run(t, false, "logout"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("\nExpected cached tokens to be removed\nReturned Error: %v", err)
+	}
+}
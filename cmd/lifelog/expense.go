@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	restclient "github.com/elhamza90/lifelog/internal/http/rest/client"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+	"github.com/elhamza90/lifelog/internal/usecase/listing"
+)
+
+// expenseHeader is the header of expenses tables
+var expenseHeader = []string{"ID", "TIME", "LABEL", "VALUE", "UNIT", "ACTIVITY"}
+
+// expenseRows returns table rows of expenses
+func expenseRows(expenses []server.JSONRespListExpense) [][]string {
+	rows := make([][]string, len(expenses))
+	for i, exp := range expenses {
+		act := ""
+		if exp.ActivityID > 0 {
+			act = strconv.Itoa(int(exp.ActivityID))
+		}
+		rows[i] = []string{
+			strconv.Itoa(int(exp.ID)),
+			exp.Time.Local().Format("2006-01-02 15:04"),
+			exp.Label,
+			exp.Value.String(),
+			string(exp.Unit),
+			act,
+		}
+	}
+	return rows
+}
+
+// expenseListCmd prints expenses in a date range or of a tag
+func expenseListCmd(c *client, out printer, args []string) error {
+	flags := newFlagSet("expense list")
+	from := flags.String("from", "", "start date of the range ( default: server default )")
+	to := flags.String("to", "", "end date of the range ( default: now )")
+	tagName := flags.String("tag", "", "list expenses of this tag instead of a date range")
+	currency := flags.String("currency", "", "convert values to this currency")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	r, err := parseRange(*from, *to, time.Now())
+	if err != nil {
+		return err
+	}
+	api, err := c.loggedIn()
+	if err != nil {
+		return err
+	}
+	fetch := func(p restclient.PageOptions) (restclient.ExpensesPage, error) {
+		return api.Expenses(r, domain.Currency(*currency), p)
+	}
+	if *tagName != "" {
+		ids, err := tagIds(c, []string{*tagName})
+		if err != nil {
+			return err
+		}
+		fetch = func(p restclient.PageOptions) (restclient.ExpensesPage, error) {
+			return api.TagExpenses(ids[0], domain.Currency(*currency), p)
+		}
+	}
+	expenses := []server.JSONRespListExpense{}
+	p := restclient.PageOptions{Limit: listing.MaxPageLimit}
+	for {
+		page, err := fetch(p)
+		if err != nil {
+			return err
+		}
+		expenses = append(expenses, page.Items...)
+		if page.Next == "" {
+			break
+		}
+		p.Cursor = page.Next
+	}
+	return out.print(expenses, expenseHeader, expenseRows(expenses))
+}
+
+// expenseAddCmd creates an expense
+func expenseAddCmd(c *client, out printer, args []string) error {
+	flags := newFlagSet("expense add")
+	var tags stringList
+	label := flags.String("label", "", "label of the expense ( required )")
+	value := flags.String("value", "", "value of the expense in the currency, ex: 12.50 ( required )")
+	unit := flags.String("unit", "", "ISO-4217 currency of the value, ex: EUR ( required )")
+	timeStr := flags.String("time", "", "time of the expense ( default: now )")
+	activityID := flags.Uint("activity", 0, "ID of the activity of the expense")
+	flags.Var(&tags, "tag", "name of a tag ( repeatable )")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *label == "" || *value == "" || *unit == "" {
+		return fmt.Errorf("-label, -value and -unit are required")
+	}
+	now := time.Now()
+	t := now
+	if *timeStr != "" {
+		var err error
+		if t, err = parseTime(*timeStr, now); err != nil {
+			return err
+		}
+	}
+	api, err := c.loggedIn()
+	if err != nil {
+		return err
+	}
+	ids, err := tagIds(c, tags)
+	if err != nil {
+		return err
+	}
+	created, err := api.AddExpense(server.JSONReqExpense{
+		Label:      *label,
+		Value:      json.Number(*value),
+		Unit:       domain.Currency(*unit),
+		Time:       t,
+		ActivityID: domain.ActivityID(*activityID),
+		TagIds:     ids,
+	})
+	if err != nil {
+		return err
+	}
+	respExp := server.JSONRespListExpense{
+		ID:         created.ID,
+		Label:      created.Label,
+		Time:       created.Time,
+		Value:      created.Value,
+		Unit:       created.Unit,
+		ActivityID: created.ActivityID,
+	}
+	return out.print(respExp, expenseHeader, expenseRows([]server.JSONRespListExpense{respExp}))
+}
+
+// expenseRmCmd deletes the expense with the id given as argument
+func expenseRmCmd(c *client, out printer, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: lifelog expense rm ID")
+	}
+	id, err := strconv.ParseUint(args[0], 10, 0)
+	if err != nil {
+		return fmt.Errorf("expense ID must be a number")
+	}
+	api, err := c.loggedIn()
+	if err != nil {
+		return err
+	}
+	if err := api.DeleteExpense(domain.ExpenseID(id)); err != nil {
+		return err
+	}
+	return out.message(fmt.Sprintf("Deleted expense %d", id))
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"strings"
+	"time"
+
+	restclient "github.com/elhamza90/lifelog/internal/http/rest/client"
+)
+
+// stringList is a flag that can be repeated
+type stringList []string
+
+// String returns the values separated by commas
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+// Set adds a value. Comma separated values are split.
+func (l *stringList) Set(v string) error {
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			*l = append(*l, s)
+		}
+	}
+	return nil
+}
+
+// errTimeFormat is returned when a time flag can not be parsed
+var errTimeFormat error = errors.New(`time must be RFC3339, "yyyy-mm-dd hh:mm", yyyy-mm-dd or hh:mm`)
+
+// parseTime parses a time flag. Times without offset are local.
+// A time of day only ( hh:mm ) is today.
+func parseTime(str string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, str); err == nil {
+		return t, nil
+	}
+	for _, frmt := range []string{"2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(frmt, str, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.ParseInLocation("15:04", str, time.Local); err == nil {
+		y, m, d := now.Date()
+		return time.Date(y, m, d, t.Hour(), t.Minute(), 0, 0, time.Local), nil
+	}
+	return time.Time{}, errTimeFormat
+}
+
+// newFlagSet returns a flag set of a command which returns parsing errors
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+// parseRange parses the from & to flags of a list.
+// Empty flags are left to the server defaults.
+// A "to" date without time includes the whole day.
+func parseRange(from string, to string, now time.Time) (restclient.TimeRange, error) {
+	var (
+		r   restclient.TimeRange
+		err error
+	)
+	if from != "" {
+		if r.From, err = parseTime(from, now); err != nil {
+			return r, err
+		}
+	}
+	if to != "" {
+		if r.To, err = parseTime(to, now); err != nil {
+			return r, err
+		}
+		if _, err := time.ParseInLocation("2006-01-02", to, time.Local); err == nil {
+			r.To = r.To.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+	return r, nil
+}
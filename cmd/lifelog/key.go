@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+// keyRows returns table rows of API keys
+func keyRows(keys []server.JSONRespAPIKey) [][]string {
+	rows := make([][]string, len(keys))
+	for i, k := range keys {
+		exp := "never"
+		if k.ExpiresAt != nil {
+			exp = k.ExpiresAt.Local().Format("2006-01-02 15:04")
+		}
+		rows[i] = []string{strconv.Itoa(int(k.ID)), k.Name, k.Prefix + "...", string(k.Scope), exp}
+	}
+	return rows
+}
+
+// keyHeader is the header of API keys tables
+var keyHeader = []string{"ID", "NAME", "KEY", "SCOPE", "EXPIRES"}
+
+// keyListCmd prints the API keys of the user
+func keyListCmd(c *client, out printer, args []string) error {
+	api, err := c.loggedIn()
+	if err != nil {
+		return err
+	}
+	keys, err := api.APIKeys()
+	if err != nil {
+		return err
+	}
+	return out.print(keys, keyHeader, keyRows(keys))
+}
+
+// keyAddCmd creates an API key and prints it once
+func keyAddCmd(c *client, out printer, args []string) error {
+	flags := newFlagSet("key add")
+	name := flags.String("name", "", "name of the key")
+	scope := flags.String("scope", string(domain.APIKeyScopeRead), "scope of the key: read or read-write")
+	expires := flags.String("expires", "", "expiry date of the key ( default: never )")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	req := server.JSONReqAPIKey{Name: *name, Scope: domain.APIKeyScope(*scope)}
+	if *expires != "" {
+		exp, err := parseTime(*expires, time.Now())
+		if err != nil {
+			return err
+		}
+		req.ExpiresAt = &exp
+	}
+	api, err := c.loggedIn()
+	if err != nil {
+		return err
+	}
+	created, err := api.AddAPIKey(req)
+	if err != nil {
+		return err
+	}
+	if out.json {
+		return out.print(created, nil, nil)
+	}
+	if err := out.print(created, keyHeader, keyRows([]server.JSONRespAPIKey{created.JSONRespAPIKey})); err != nil {
+		return err
+	}
+	return out.message("Key ( shown only once ): " + created.Key)
+}
+
+// keyRmCmd revokes the API key with the id given as argument
+func keyRmCmd(c *client, out printer, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: lifelog key rm ID")
+	}
+	id, err := strconv.ParseUint(args[0], 10, 0)
+	if err != nil {
+		return fmt.Errorf("invalid key ID %s", args[0])
+	}
+	api, err := c.loggedIn()
+	if err != nil {
+		return err
+	}
+	if err := api.DeleteAPIKey(domain.APIKeyID(id)); err != nil {
+		return err
+	}
+	return out.message(fmt.Sprintf("Revoked key %d", id))
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// usage describes the commands of the client
+const usage string = `Usage: lifelog [-server URL] [-json] <command> [flags]
+
+Commands:
+  register -user NAME [-password PASS]
+                              create a user account ( password is read from stdin if missing )
+  login -user NAME [-password PASS] [-scope SCOPE]...
+                              log in and cache tokens ( password is read from stdin if missing )
+  logout [-all]               end the session ( or all sessions ) and remove cached tokens
+  key list
+  key add -name NAME [-scope read|read-write] [-expires DATE]
+  key rm ID
+  tag list
+  tag add NAME
+  tag rm ID|NAME
+  activity list [-from DATE] [-to DATE]
+  activity add -label LABEL [-place P] [-desc D] [-time T] [-duration 1h30m] [-tag NAME]...
+  activity rm ID
+  expense list [-from DATE] [-to DATE] [-tag NAME] [-currency CUR]
+  expense add -label LABEL -value 12.50 -unit EUR [-time T] [-activity ID] [-tag NAME]...
+  expense rm ID
+
+Dates & times are RFC3339, "yyyy-mm-dd hh:mm", yyyy-mm-dd or hh:mm ( today )
+in local time. Default time is now. A -to date includes the whole day.
+The user name defaults to the environment variable LFLG_USER.
+Tag, activity & expense commands use the API key of the environment variable
+LFLG_API_KEY if set, instead of cached tokens.
+The server URL defaults to the environment variable LFLG_SERVER or http://localhost:8080.`
+
+// defaultServer returns the url of the server used when -server flag is missing
+func defaultServer() string {
+	if server := os.Getenv("LFLG_SERVER"); server != "" {
+		return server
+	}
+	return "http://localhost:8080"
+}
+
+// command runs a subcommand with its arguments
+type command func(c *client, out printer, args []string) error
+
+// commands maps "group action" and single word commands to their functions
+var commands = map[string]command{
+	"register":      registerCmd,
+	"login":         loginCmd,
+	"logout":        logoutCmd,
+	"key list":      keyListCmd,
+	"key add":       keyAddCmd,
+	"key rm":        keyRmCmd,
+	"tag list":      tagListCmd,
+	"tag add":       tagAddCmd,
+	"tag rm":        tagRmCmd,
+	"activity list": activityListCmd,
+	"activity add":  activityAddCmd,
+	"activity rm":   activityRmCmd,
+	"expense list":  expenseListCmd,
+	"expense add":   expenseAddCmd,
+	"expense rm":    expenseRmCmd,
+}
+
+// findCommand returns the command named by the first arguments and its remaining arguments
+func findCommand(args []string) (command, []string, bool) {
+	if len(args) >= 1 {
+		if cmd, ok := commands[args[0]]; ok {
+			return cmd, args[1:], true
+		}
+	}
+	if len(args) >= 2 {
+		if cmd, ok := commands[args[0]+" "+args[1]]; ok {
+			return cmd, args[2:], true
+		}
+	}
+	return nil, nil, false
+}
+
+func main() {
+	flag.Usage = func() { fmt.Fprintln(os.Stderr, usage) }
+	server := flag.String("server", defaultServer(), "url of the lifelog server")
+	jsonOutput := flag.Bool("json", false, "print results as json instead of tables")
+	flag.Parse()
+	cmd, args, ok := findCommand(flag.Args())
+	if !ok {
+		flag.Usage()
+		os.Exit(2)
+	}
+	c, err := newClient(*server)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+	out := printer{w: os.Stdout, json: *jsonOutput}
+	if err := cmd(c, out, args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+	"github.com/elhamza90/lifelog/internal/store/memory"
+	"github.com/elhamza90/lifelog/internal/usecase/adding"
+	"github.com/elhamza90/lifelog/internal/usecase/archiving"
+	"github.com/elhamza90/lifelog/internal/usecase/auditing"
+	"github.com/elhamza90/lifelog/internal/usecase/auth"
+	"github.com/elhamza90/lifelog/internal/usecase/deleting"
+	"github.com/elhamza90/lifelog/internal/usecase/editing"
+	"github.com/elhamza90/lifelog/internal/usecase/exchanging"
+	"github.com/elhamza90/lifelog/internal/usecase/listing"
+	"github.com/elhamza90/lifelog/internal/usecase/reporting"
+	"github.com/elhamza90/lifelog/internal/usecase/scheduling"
+	"github.com/elhamza90/lifelog/internal/usecase/searching"
+	"github.com/elhamza90/lifelog/internal/usecase/throttling"
+	"github.com/elhamza90/lifelog/internal/usecase/transferring"
+	"github.com/elhamza90/lifelog/internal/usecase/trashing"
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	srv  *httptest.Server
+	repo memory.Repository
+)
+
+func TestMain(m *testing.M) {
+	log.SetLevel(log.ErrorLevel)
+	// Define JWT Secrets in Env Vars
+	os.Setenv("LFLG_JWT_ACCESS_SECRET", "test-access-secret")
+	os.Setenv("LFLG_JWT_REFRESH_SECRET", "test-refresh-secret")
+	// Commands must use cached tokens only
+	os.Unsetenv("LFLG_API_KEY")
+	// Init Interactors and Repository
+	repo = memory.NewRepository()
+	lister := listing.NewService(&repo)
+	adder := adding.NewService(&repo, domain.DefaultPolicy())
+	editor := editing.NewService(&repo, domain.DefaultPolicy())
+	deletor := deleting.NewService(&repo)
+	authenticator := auth.NewService(&repo)
+	reporter := reporting.NewService(&repo)
+	searcher := searching.NewService(&repo)
+	exchanger := exchanging.NewService(&repo)
+	scheduler := scheduling.NewService(&repo, domain.DefaultPolicy())
+	transferrer := transferring.NewService(&repo, domain.DefaultPolicy())
+	archiver := archiving.NewService(&repo, domain.DefaultPolicy())
+	trasher := trashing.NewService(&repo)
+	auditor := auditing.NewService(&repo)
+	throttlingConfig := throttling.DefaultConfig()
+	throttlingConfig.Requests = 0
+	throttler := throttling.NewService(memory.Throttles{}, nil, throttlingConfig)
+	hnd := server.NewHandler(&lister, &adder, &editor, &deletor, &authenticator, &reporter, &searcher, &exchanger, &scheduler, &transferrer, &archiver, &trasher, &auditor, &throttler)
+	// Tests seed the repository directly so all users share its data
+	forUser := func(domain.UserID) *server.Handler { return hnd }
+	// Init Router & Test Server
+	router := echo.New()
+	if err := server.RegisterRoutes(router, hnd, forUser); err != nil {
+		os.Exit(1)
+	}
+	srv = httptest.NewServer(router)
+	code := m.Run()
+	srv.Close()
+	os.Exit(code)
+}
+
+func TestFindCommand(t *testing.T) {
+	tests := map[string]struct {
+		args         []string
+		expectedArgs []string
+		expectedOk   bool
+	}{
+		"Single Word":    {[]string{"login", "-user", "hamza"}, []string{"-user", "hamza"}, true},
+		"Group & Action": {[]string{"tag", "add", "sport"}, []string{"sport"}, true},
+		"No Arguments":   {[]string{"tag", "list"}, []string{}, true},
+		"Unknown Action": {[]string{"tag", "edit", "sport"}, nil, false},
+		"Group Only":     {[]string{"tag"}, nil, false},
+		"Empty":          {[]string{}, nil, false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmd, args, ok := findCommand(test.args)
+			if ok != test.expectedOk || (ok && cmd == nil) {
+				t.Fatalf("\nExpected Found: %v\nReturned Found: %v", test.expectedOk, ok)
+			}
+			if ok && !reflect.DeepEqual(args, test.expectedArgs) {
+				t.Fatalf("\nExpected Args: %v\nReturned Args: %v", test.expectedArgs, args)
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2020, 10, 5, 18, 30, 0, 0, time.Local)
+	tests := map[string]struct {
+		str          string
+		expectedTime time.Time
+		expectedErr  error
+	}{
+		"RFC3339":       {"2020-10-01T08:00:00Z", time.Date(2020, 10, 1, 8, 0, 0, 0, time.UTC), nil},
+		"Date & Time":   {"2020-10-01 08:15", time.Date(2020, 10, 1, 8, 15, 0, 0, time.Local), nil},
+		"Date":          {"2020-10-01", time.Date(2020, 10, 1, 0, 0, 0, 0, time.Local), nil},
+		"Time Of Today": {"08:15", time.Date(2020, 10, 5, 8, 15, 0, 0, time.Local), nil},
+		"Invalid":       {"01/10/2020", time.Time{}, errTimeFormat},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := parseTime(test.str, now)
+			if err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if !res.Equal(test.expectedTime) {
+				t.Fatalf("\nExpected Time: %v\nReturned Time: %v", test.expectedTime, res)
+			}
+		})
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	now := time.Date(2020, 10, 5, 18, 30, 0, 0, time.Local)
+	// A "to" date includes the whole day
+	r, err := parseRange("2020-10-01", "2020-10-02", now)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	expectedTo := time.Date(2020, 10, 3, 0, 0, 0, 0, time.Local).Add(-time.Nanosecond)
+	if !r.From.Equal(time.Date(2020, 10, 1, 0, 0, 0, 0, time.Local)) || !r.To.Equal(expectedTo) {
+		t.Fatalf("\nExpected Range: 2020-10-01 ~ %v\nReturned Range: %v ~ %v", expectedTo, r.From, r.To)
+	}
+	// A "to" time is kept
+	if r, _ := parseRange("", "2020-10-02 08:00", now); !r.From.IsZero() || !r.To.Equal(time.Date(2020, 10, 2, 8, 0, 0, 0, time.Local)) {
+		t.Fatalf("\nExpected Range: ~ 2020-10-02 08:00\nReturned Range: %v ~ %v", r.From, r.To)
+	}
+	if _, err := parseRange("yesterday", "", now); err != errTimeFormat {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", errTimeFormat, err)
+	}
+}
+
+func TestStringList(t *testing.T) {
+	flags := newFlagSet("test")
+	flags.SetOutput(ioutil.Discard)
+	var tags stringList
+	flags.Var(&tags, "tag", "")
+	if err := flags.Parse([]string{"-tag", "sport", "-tag", "food, ,travel"}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	expected := stringList{"sport", "food", "travel"}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("\nExpected Values: %v\nReturned Values: %v", expected, tags)
+	}
+	// Parsing errors are returned instead of exiting
+	if err := flags.Parse([]string{"-unknown"}); err == nil {
+		t.Fatal("\nExpected an error for an unknown flag")
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	restclient "github.com/elhamza90/lifelog/internal/http/rest/client"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+	"github.com/elhamza90/lifelog/internal/usecase/listing"
+)
+
+// fetchTags returns all tags
+func fetchTags(c *client) ([]server.JSONRespListTag, error) {
+	api, err := c.loggedIn()
+	if err != nil {
+		return nil, err
+	}
+	tags := []server.JSONRespListTag{}
+	p := restclient.PageOptions{Limit: listing.MaxPageLimit}
+	for {
+		page, err := api.Tags(p)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, page.Items...)
+		if page.Next == "" {
+			return tags, nil
+		}
+		p.Cursor = page.Next
+	}
+}
+
+// tagIds returns the ids of the tags with given names
+func tagIds(c *client, names []string) ([]domain.TagID, error) {
+	ids := []domain.TagID{}
+	if len(names) == 0 {
+		return ids, nil
+	}
+	tags, err := fetchTags(c)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]domain.TagID, len(tags))
+	for _, t := range tags {
+		byName[t.Name] = t.ID
+	}
+	for _, name := range names {
+		id, ok := byName[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown tag %s", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// tagRows returns table rows of tags
+func tagRows(tags []server.JSONRespListTag) [][]string {
+	rows := make([][]string, len(tags))
+	for i, t := range tags {
+		rows[i] = []string{strconv.Itoa(int(t.ID)), t.Name}
+	}
+	return rows
+}
+
+// tagHeader is the header of tags tables
+var tagHeader = []string{"ID", "NAME"}
+
+// tagListCmd prints all tags
+func tagListCmd(c *client, out printer, args []string) error {
+	tags, err := fetchTags(c)
+	if err != nil {
+		return err
+	}
+	return out.print(tags, tagHeader, tagRows(tags))
+}
+
+// tagAddCmd creates a tag with the name given as argument
+func tagAddCmd(c *client, out printer, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: lifelog tag add NAME")
+	}
+	api, err := c.loggedIn()
+	if err != nil {
+		return err
+	}
+	created, err := api.AddTag(server.JSONReqTag{Name: args[0]})
+	if err != nil {
+		return err
+	}
+	var respTag server.JSONRespListTag
+	respTag.From(created)
+	return out.print(respTag, tagHeader, tagRows([]server.JSONRespListTag{respTag}))
+}
+
+// tagRmCmd deletes the tag with the id or name given as argument
+func tagRmCmd(c *client, out printer, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: lifelog tag rm ID|NAME")
+	}
+	api, err := c.loggedIn()
+	if err != nil {
+		return err
+	}
+	var id domain.TagID
+	if n, err := strconv.ParseUint(args[0], 10, 0); err == nil {
+		id = domain.TagID(n)
+	} else {
+		ids, err := tagIds(c, args)
+		if err != nil {
+			return err
+		}
+		id = ids[0]
+	}
+	if err := api.DeleteTag(id); err != nil {
+		return err
+	}
+	return out.message(fmt.Sprintf("Deleted tag %d", id))
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store/db"
+	"github.com/elhamza90/lifelog/internal/usecase/archiving"
+)
+
+// usage describes the subcommands of the server
+const usage string = `Usage:
+  server                     start the REST API server
+  server backup -u user [-o file]    write an archive of all entities of the user ( default: stdout )
+  server restore -u user [-i file]   restore an archive into an empty store and trash of the user ( default: stdin )
+                                     restored entities are given new IDs, their relations are kept`
+
+// runCommand runs the subcommand with given arguments
+func runCommand(repo db.Repository, policy domain.Policy, args []string) error {
+	switch args[0] {
+	case "backup":
+		return backupCommand(repo, policy, args[1:])
+	case "restore":
+		return restoreCommand(repo, policy, args[1:])
+	default:
+		return errors.New("unknown command " + args[0] + "\n" + usage)
+	}
+}
+
+// userArchiver returns the archiving service of the user with given name
+func userArchiver(repo db.Repository, policy domain.Policy, name string) (archiving.Service, error) {
+	if name == "" {
+		return archiving.Service{}, errors.New("user name is missing ( -u flag )")
+	}
+	usr, err := repo.FindUserByName(strings.ToLower(name))
+	if err != nil {
+		return archiving.Service{}, err
+	}
+	userRepo := repo.ForUser(usr.ID)
+	return archiving.NewService(&userRepo, policy), nil
+}
+
+// backupCommand writes an archive of all entities of a user to a file or stdout
+func backupCommand(repo db.Repository, policy domain.Policy, args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	user := flags.String("u", "", "name of the user")
+	output := flags.String("o", "", "archive file to write ( default: stdout )")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	archiver, err := userArchiver(repo, policy, *user)
+	if err != nil {
+		return err
+	}
+	archive, err := archiver.Backup(time.Now())
+	if err != nil {
+		return err
+	}
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(archive); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Backed up %d tags, %d activities and %d expenses\n", len(archive.Tags), len(archive.Activities), len(archive.Expenses))
+	return nil
+}
+
+// restoreCommand restores an archive of a user read from a file or stdin.
+// Restored entities are given new IDs and their relations are kept.
+func restoreCommand(repo db.Repository, policy domain.Policy, args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	user := flags.String("u", "", "name of the user")
+	input := flags.String("i", "", "archive file to read ( default: stdin )")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	archiver, err := userArchiver(repo, policy, *user)
+	if err != nil {
+		return err
+	}
+	var r io.Reader = os.Stdin
+	if *input != "" {
+		f, err := os.Open(*input)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	var archive archiving.Archive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return err
+	}
+	if err := archiver.Restore(archive, domain.PrincipalCommand); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Restored %d tags, %d activities and %d expenses\n", len(archive.Tags), len(archive.Activities), len(archive.Expenses))
+	return nil
+}
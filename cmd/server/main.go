@@ -4,14 +4,27 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/elhamza90/lifelog/internal/domain"
 	"github.com/elhamza90/lifelog/internal/http/rest/server"
 	"github.com/elhamza90/lifelog/internal/store/db"
+	"github.com/elhamza90/lifelog/internal/store/memory"
 	"github.com/elhamza90/lifelog/internal/usecase/adding"
+	"github.com/elhamza90/lifelog/internal/usecase/archiving"
+	"github.com/elhamza90/lifelog/internal/usecase/auditing"
 	"github.com/elhamza90/lifelog/internal/usecase/auth"
 	"github.com/elhamza90/lifelog/internal/usecase/deleting"
 	"github.com/elhamza90/lifelog/internal/usecase/editing"
+	"github.com/elhamza90/lifelog/internal/usecase/exchanging"
 	"github.com/elhamza90/lifelog/internal/usecase/listing"
+	"github.com/elhamza90/lifelog/internal/usecase/reporting"
+	"github.com/elhamza90/lifelog/internal/usecase/scheduling"
+	"github.com/elhamza90/lifelog/internal/usecase/searching"
+	"github.com/elhamza90/lifelog/internal/usecase/throttling"
+	"github.com/elhamza90/lifelog/internal/usecase/transferring"
+	"github.com/elhamza90/lifelog/internal/usecase/trashing"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
@@ -34,9 +47,146 @@ func getDbConn() (string, error) {
 	return conn, nil
 }
 
-// hash_var_name specifies the name of the environment variable where the password bcrypt hash is stored
+// getSchedulerInterval retrieves the interval between two scheduler runs from environment.
+// It defaults to one hour.
+func getSchedulerInterval() (time.Duration, error) {
+	intervalStr := os.Getenv("LFLG_SCHEDULER_INTERVAL")
+	if intervalStr == "" {
+		return time.Hour, nil
+	}
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil {
+		return 0, err
+	}
+	if interval <= 0 {
+		return 0, errors.New("Scheduler interval must be positive")
+	}
+	return interval, nil
+}
+
+// forEachUser calls fn with the repository of every user.
+// Errors are only logged.
+func forEachUser(repo db.Repository, fn func(usr domain.User, userRepo db.Repository)) {
+	users, err := repo.FindAllUsers()
+	if err != nil {
+		logrus.Error("Error while listing users : " + err.Error())
+		return
+	}
+	for _, usr := range users {
+		fn(usr, repo.ForUser(usr.ID))
+	}
+}
+
+// runScheduler materializes due occurrences of recurring templates of every user
+// at startup and then at every tick of the given interval.
+func runScheduler(repo db.Repository, policy domain.Policy, interval time.Duration) {
+	run := func() {
+		forEachUser(repo, func(usr domain.User, userRepo db.Repository) {
+			created, err := scheduling.NewService(&userRepo, policy).Run(time.Now())
+			if err != nil {
+				logrus.Error("Error while running scheduler for user " + usr.Name + " : " + err.Error())
+			} else {
+				logrus.Infof("Scheduler created %d occurrences for user %s", created, usr.Name)
+			}
+		})
+	}
+	run()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		run()
+	}
+}
+
+// getTrashMaxAge retrieves from environment how long deleted items stay in the trash
+// before being purged automatically. It defaults to 30 days and 0 disables the purge.
+func getTrashMaxAge() (time.Duration, error) {
+	maxAgeStr := os.Getenv("LFLG_TRASH_MAX_AGE")
+	if maxAgeStr == "" {
+		return 30 * 24 * time.Hour, nil
+	}
+	maxAge, err := time.ParseDuration(maxAgeStr)
+	if err != nil {
+		return 0, err
+	}
+	if maxAge < 0 {
+		return 0, errors.New("Trash max age must not be negative")
+	}
+	return maxAge, nil
+}
+
+// runTrashPurge permanently deletes items older than maxAge from the trash
+// of every user at startup and then every hour.
+func runTrashPurge(repo db.Repository, maxAge time.Duration) {
+	run := func() {
+		forEachUser(repo, func(usr domain.User, userRepo db.Repository) {
+			purged, err := trashing.NewService(&userRepo).Purge(time.Now().Add(-maxAge), domain.PrincipalTrashPurge)
+			if err != nil {
+				logrus.Error("Error while purging trash of user " + usr.Name + " : " + err.Error())
+			} else {
+				logrus.Infof("Purged %d items from trash of user %s", purged, usr.Name)
+			}
+		})
+	}
+	run()
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for range ticker.C {
+		run()
+	}
+}
+
+// runTokenPurge deletes expired refresh tokens of all users
+// at startup and then every hour.
+func runTokenPurge(repo db.Repository) {
+	authenticator := auth.NewService(&repo)
+	run := func() {
+		purged, err := authenticator.PurgeRefreshTokens(time.Now())
+		if err != nil {
+			logrus.Error("Error while purging refresh tokens : " + err.Error())
+			return
+		}
+		logrus.Infof("Purged %d expired refresh tokens", purged)
+	}
+	run()
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for range ticker.C {
+		run()
+	}
+}
+
+// hash_var_name specifies the name of the environment variable where the bcrypt hash
+// of the password used before user accounts existed is stored
 const hash_var_name string = "LFLG_PASS_HASH"
 
+// getLegacyUserName retrieves from environment the name of the user
+// owning the data stored before user accounts existed. It defaults to "admin".
+func getLegacyUserName() string {
+	if name := os.Getenv("LFLG_LEGACY_USER"); name != "" {
+		return strings.ToLower(name)
+	}
+	return "admin"
+}
+
+// newHandler constructs the services using given repository and returns their handler
+func newHandler(repo db.Repository, policy domain.Policy, throttler throttling.Service) *server.Handler {
+	lister := listing.NewService(&repo)
+	adder := adding.NewService(&repo, policy)
+	editor := editing.NewService(&repo, policy)
+	deletor := deleting.NewService(&repo)
+	authenticator := auth.NewService(&repo)
+	reporter := reporting.NewService(&repo)
+	searcher := searching.NewService(&repo)
+	exchanger := exchanging.NewService(&repo)
+	scheduler := scheduling.NewService(&repo, policy)
+	transferrer := transferring.NewService(&repo, policy)
+	archiver := archiving.NewService(&repo, policy)
+	trasher := trashing.NewService(&repo)
+	auditor := auditing.NewService(&repo)
+	return server.NewHandler(&lister, &adder, &editor, &deletor, &authenticator, &reporter, &searcher, &exchanger, &scheduler, &transferrer, &archiver, &trasher, &auditor, &throttler)
+}
+
 func main() {
 	dbConn, err := getDbConn()
 	if err != nil {
@@ -48,25 +198,70 @@ func main() {
 		fmt.Println("failed to connect database")
 		os.Exit(1)
 	}
-	if err := grmDb.AutoMigrate(&db.Tag{}, &db.Expense{}, &db.Activity{}); err != nil {
+	if err := db.MigrateExpenseAmounts(grmDb); err != nil {
+		fmt.Printf("Error Migrating Expense Amounts:\n\t%s\n", err)
+		os.Exit(1)
+	}
+	if err := db.MigrateRateOwners(grmDb); err != nil {
+		fmt.Printf("Error Migrating Exchange Rate Owners:\n\t%s\n", err)
+		os.Exit(1)
+	}
+	if err := grmDb.AutoMigrate(&db.Tag{}, &db.Expense{}, &db.Activity{}, &db.ExchangeRate{}, &db.Budget{}, &db.Template{}, &db.Occurrence{}, &db.AuditEvent{}, &db.User{}, &db.RefreshToken{}, &db.APIKey{}); err != nil {
 		fmt.Printf("Error Auto-Migrating Tables:\n\t%s\n", err)
 		os.Exit(1)
 	}
+	if hash := os.Getenv(hash_var_name); hash != "" {
+		if err := db.MigrateLegacyUser(grmDb, getLegacyUserName(), hash); err != nil {
+			fmt.Printf("Error Migrating Legacy User:\n\t%s\n", err)
+			os.Exit(1)
+		}
+	}
+	if err := db.CreateSearchIndexes(grmDb); err != nil {
+		fmt.Printf("Error Creating Search Indexes:\n\t%s\n", err)
+		os.Exit(1)
+	}
 
 	repo := db.NewRepository(grmDb)
 
-	lister := listing.NewService(&repo)
-	adder := adding.NewService(&repo)
-	editor := editing.NewService(&repo)
-	deletor := deleting.NewService(&repo)
-	authenticator := auth.NewService(hash_var_name)
+	policy, err := loadPolicy()
+	if err != nil {
+		fmt.Printf("could not load validation policy: %s\n", err)
+		os.Exit(1)
+	}
 
-	hnd := server.NewHandler(&lister, &adder, &editor, &deletor, &authenticator)
+	// Run subcommand instead of server if any
+	if len(os.Args) > 1 {
+		if err := runCommand(repo, policy, os.Args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Rate limits & login lockouts are kept in memory and shared by all handlers
+	throttlingConfig, err := loadThrottlingConfig()
+	if err != nil {
+		fmt.Printf("could not load rate limiting configuration: %s\n", err)
+		os.Exit(1)
+	}
+	throttler := throttling.NewService(memory.Throttles{}, nil, throttlingConfig)
+	go runThrottlePurge(throttler)
+	go runTokenPurge(repo)
+
+	hnd := newHandler(repo, policy, throttler)
+	forUser := func(id domain.UserID) *server.Handler {
+		return newHandler(repo.ForUser(id), policy, throttler)
+	}
 
 	router := echo.New()
+	router.IPExtractor, err = getIPExtractor()
+	if err != nil {
+		fmt.Printf("could not read trusted proxy setting: %s\n", err)
+		os.Exit(1)
+	}
 
 	// Setup Routes
-	if err := server.RegisterRoutes(router, hnd); err != nil {
+	if err := server.RegisterRoutes(router, hnd, forUser); err != nil {
 		os.Exit(1)
 	}
 
@@ -87,6 +282,24 @@ func main() {
 		}
 	})
 
+	// Start Scheduler of recurring templates
+	interval, err := getSchedulerInterval()
+	if err != nil {
+		fmt.Printf("could not read scheduler interval: %s\n", err)
+		os.Exit(1)
+	}
+	go runScheduler(repo, policy, interval)
+
+	// Start automatic purge of the trash
+	maxAge, err := getTrashMaxAge()
+	if err != nil {
+		fmt.Printf("could not read trash max age: %s\n", err)
+		os.Exit(1)
+	}
+	if maxAge > 0 {
+		go runTrashPurge(repo, maxAge)
+	}
+
 	port := ":8080"
 	router.Start(port)
 }
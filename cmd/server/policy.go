@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// policyFileVarName specifies the name of the environment variable
+// where the path of the json validation policy file is stored
+const policyFileVarName string = "LFLG_POLICY_FILE"
+
+// policyLimitVars maps the names of environment variables
+// to the limits of the validation policy they override
+func policyLimitVars(p *domain.Policy) map[string]*int {
+	return map[string]*int{
+		"LFLG_POLICY_ACTIVITY_LABEL_MIN": &p.ActivityLabelMinLen,
+		"LFLG_POLICY_ACTIVITY_LABEL_MAX": &p.ActivityLabelMaxLen,
+		"LFLG_POLICY_ACTIVITY_PLACE_MAX": &p.ActivityPlaceMaxLen,
+		"LFLG_POLICY_ACTIVITY_DESC_MAX":  &p.ActivityDescMaxLen,
+		"LFLG_POLICY_EXPENSE_LABEL_MIN":  &p.ExpenseLabelMinLen,
+		"LFLG_POLICY_EXPENSE_LABEL_MAX":  &p.ExpenseLabelMaxLen,
+		"LFLG_POLICY_TAG_NAME_MIN":       &p.TagNameMinLen,
+		"LFLG_POLICY_TAG_NAME_MAX":       &p.TagNameMaxLen,
+	}
+}
+
+// policyFutureVarName specifies the name of the environment variable
+// which allows activities & expenses in the future ( true / false )
+const policyFutureVarName string = "LFLG_POLICY_FUTURE_TIME"
+
+// loadPolicy loads the validation policy of entities:
+//	- default limits
+//	- overridden by the json file at LFLG_POLICY_FILE ( if set )
+//	- overridden by LFLG_POLICY_* environment variables
+func loadPolicy() (domain.Policy, error) {
+	p := domain.DefaultPolicy()
+	if path := os.Getenv(policyFileVarName); path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return p, err
+		}
+		defer file.Close()
+		dec := json.NewDecoder(file)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&p); err != nil {
+			return p, fmt.Errorf("invalid policy file %s: %w", path, err)
+		}
+	}
+	for name, limit := range policyLimitVars(&p) {
+		str := os.Getenv(name)
+		if str == "" {
+			continue
+		}
+		val, err := strconv.Atoi(str)
+		if err != nil {
+			return p, fmt.Errorf("%s must be an integer", name)
+		}
+		*limit = val
+	}
+	if str := os.Getenv(policyFutureVarName); str != "" {
+		future, err := strconv.ParseBool(str)
+		if err != nil {
+			return p, fmt.Errorf("%s must be true or false", policyFutureVarName)
+		}
+		p.FutureTime = future
+	}
+	return p, p.Validate()
+}
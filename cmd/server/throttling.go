@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/usecase/throttling"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// throttlingCountVars maps the names of environment variables
+// to the counts of the throttling configuration they override
+func throttlingCountVars(c *throttling.Config) map[string]*int {
+	return map[string]*int{
+		"LFLG_RATE_LIMIT_REQUESTS": &c.Requests,
+		"LFLG_LOGIN_MAX_FAILURES":  &c.MaxFailures,
+	}
+}
+
+// throttlingDurationVars maps the names of environment variables
+// to the durations of the throttling configuration they override
+func throttlingDurationVars(c *throttling.Config) map[string]*time.Duration {
+	return map[string]*time.Duration{
+		"LFLG_RATE_LIMIT_WINDOW": &c.Window,
+		"LFLG_LOGIN_LOCKOUT":     &c.Lockout,
+		"LFLG_LOGIN_MAX_LOCKOUT": &c.MaxLockout,
+	}
+}
+
+// loadThrottlingConfig loads the limits of rate limiting & login lockouts:
+//	- default limits
+//	- overridden by LFLG_RATE_LIMIT_* & LFLG_LOGIN_* environment variables
+// A count of 0 disables the corresponding limit.
+func loadThrottlingConfig() (throttling.Config, error) {
+	c := throttling.DefaultConfig()
+	for name, count := range throttlingCountVars(&c) {
+		str := os.Getenv(name)
+		if str == "" {
+			continue
+		}
+		val, err := strconv.Atoi(str)
+		if err != nil {
+			return c, fmt.Errorf("%s must be an integer", name)
+		}
+		*count = val
+	}
+	for name, dur := range throttlingDurationVars(&c) {
+		str := os.Getenv(name)
+		if str == "" {
+			continue
+		}
+		val, err := time.ParseDuration(str)
+		if err != nil {
+			return c, fmt.Errorf("%s must be a duration", name)
+		}
+		*dur = val
+	}
+	return c, c.Validate()
+}
+
+// getIPExtractor returns how client IP addresses are read from requests.
+// X-Forwarded-For is only trusted if LFLG_BEHIND_PROXY is true:
+// otherwise clients could forge it to escape rate limits.
+func getIPExtractor() (echo.IPExtractor, error) {
+	str := os.Getenv("LFLG_BEHIND_PROXY")
+	if str == "" {
+		return echo.ExtractIPDirect(), nil
+	}
+	proxy, err := strconv.ParseBool(str)
+	if err != nil {
+		return nil, errors.New("LFLG_BEHIND_PROXY must be true or false")
+	}
+	if proxy {
+		return echo.ExtractIPFromXFFHeader(), nil
+	}
+	return echo.ExtractIPDirect(), nil
+}
+
+// runThrottlePurge forgets idle rate limiting & lockout keys every hour
+func runThrottlePurge(throttler throttling.Service) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for range ticker.C {
+		purged, err := throttler.Purge()
+		if err != nil {
+			logrus.Error("Error while purging throttles : " + err.Error())
+			continue
+		}
+		logrus.Infof("Purged %d throttles", purged)
+	}
+}
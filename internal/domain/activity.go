@@ -1,7 +1,6 @@
 package domain
 
 import (
-	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -24,6 +23,7 @@ type Activity struct {
 	Desc     string
 	Time     time.Time
 	Duration time.Duration
+	Status   Status
 	Tags     []Tag
 }
 
@@ -37,12 +37,50 @@ const (
 
 // Errors
 var (
-	ErrActivityLabelLength error = fmt.Errorf("Activity Label must be %d ~ %d long", ActivityLabelMinLen, ActivityLabelMaxLen)
-	ErrActivityPlaceLength error = fmt.Errorf("Activity Place must be maximum %d long", ActivityPlaceMaxLen)
-	ErrActivityDescLength  error = fmt.Errorf("Activity Description must be maximum %d long", ActivityDescMaxLen)
-	ErrActivityTimeFuture  error = errors.New("Activity Time + Duration can not result in future date")
+	ErrActivityLabelLength error = activityLabelLengthError(ActivityLabelMinLen, ActivityLabelMaxLen)
+	ErrActivityPlaceLength error = activityPlaceLengthError(ActivityPlaceMaxLen)
+	ErrActivityDescLength  error = activityDescLengthError(ActivityDescMaxLen)
+	ErrActivityTimeFuture  error = &Error{
+		Kind:    KindInvalid,
+		Code:    "activity_time_future",
+		Message: "Activity Time + Duration can not result in future date unless planned",
+		Field:   "time",
+	}
 )
 
+// activityLabelLengthError returns the error of an activity label out of given length limits
+func activityLabelLengthError(min int, max int) error {
+	return &Error{
+		Kind:    KindInvalid,
+		Code:    "activity_label_length",
+		Message: fmt.Sprintf("Activity Label must be %d ~ %d long", min, max),
+		Field:   "label",
+		Details: map[string]interface{}{"min": min, "max": max},
+	}
+}
+
+// activityPlaceLengthError returns the error of an activity place longer than max
+func activityPlaceLengthError(max int) error {
+	return &Error{
+		Kind:    KindInvalid,
+		Code:    "activity_place_length",
+		Message: fmt.Sprintf("Activity Place must be maximum %d long", max),
+		Field:   "place",
+		Details: map[string]interface{}{"max": max},
+	}
+}
+
+// activityDescLengthError returns the error of an activity description longer than max
+func activityDescLengthError(max int) error {
+	return &Error{
+		Kind:    KindInvalid,
+		Code:    "activity_desc_length",
+		Message: fmt.Sprintf("Activity Description must be maximum %d long", max),
+		Field:   "desc",
+		Details: map[string]interface{}{"max": max},
+	}
+}
+
 // ************* Methods *************
 
 // String returns a one line string representation of an activity
@@ -51,27 +89,49 @@ func (act Activity) String() string {
 }
 
 // Validate checks primitive, non-db-related fields for validity
-// It also transforms place to lowercase
-func (act *Activity) Validate() error {
+// with the limits of given policy and returns all violations as ValidationErrors.
+// It also transforms place to lowercase and an empty status to done
+func (act *Activity) Validate(p Policy) error {
 	now := time.Now()
+	errs := ValidationErrors{}
 	// Check Label Length
-	if len(act.Label) < ActivityLabelMinLen || len(act.Label) > ActivityLabelMaxLen {
-		return ErrActivityLabelLength
+	if len(act.Label) < p.ActivityLabelMinLen || len(act.Label) > p.ActivityLabelMaxLen {
+		errs.Add(activityLabelLengthError(p.ActivityLabelMinLen, p.ActivityLabelMaxLen))
 	}
 	// Check Place Length
-	if len(act.Place) > ActivityPlaceMaxLen {
-		return ErrActivityPlaceLength
+	if len(act.Place) > p.ActivityPlaceMaxLen {
+		errs.Add(activityPlaceLengthError(p.ActivityPlaceMaxLen))
 	}
-	act.Place = strings.ToLower(act.Place)
 	// Transform Place to Lowercase
+	act.Place = strings.ToLower(act.Place)
 	// Check Desc Length
-	if len(act.Desc) > ActivityDescMaxLen {
-		return ErrActivityDescLength
+	if len(act.Desc) > p.ActivityDescMaxLen {
+		errs.Add(activityDescLengthError(p.ActivityDescMaxLen))
+	}
+	// Check Status
+	st, err := ParseStatus(string(act.Status))
+	if err != nil {
+		errs.Add(err)
+	} else {
+		act.Status = st
+	}
+	// Check TimeEnd not future unless planned
+	if timeEnd := act.Time.Add(act.Duration); !p.FutureTime && act.Status != StatusPlanned && timeEnd.After(now) {
+		errs.Add(ErrActivityTimeFuture)
+	}
+	return errs.Err()
+}
+
+// Complete transitions a planned activity to done.
+// If it ends after now, it is moved to end now.
+// It returns ErrStatusNotPlanned if the activity is not planned.
+func (act *Activity) Complete(now time.Time) error {
+	if act.Status != StatusPlanned {
+		return ErrStatusNotPlanned
 	}
-	// Check TimeEnd not future
-	if timeEnd := act.Time.Add(act.Duration); timeEnd.After(now) {
-		return ErrActivityTimeFuture
+	if act.Time.Add(act.Duration).After(now) {
+		act.Time = now.Add(-act.Duration)
 	}
-	// Everything is good
+	act.Status = StatusDone
 	return nil
 }
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Amount is a value-object representing an exact amount of money
+// in the minor unit of its currency ( ex: cents for EUR, yens for JPY ).
+type Amount int64
+
+// Errors
+var (
+	ErrAmountFormat error = &Error{
+		Kind:    KindInvalid,
+		Code:    "amount_format",
+		Message: "Amount must be a decimal number ( ex: 12.50 )",
+	}
+	ErrAmountPrecision error = &Error{
+		Kind:    KindInvalid,
+		Code:    "amount_precision",
+		Message: "Amount has more decimals than the minor unit of its currency",
+	}
+)
+
+// ************* Methods *************
+
+// ParseAmount parses a decimal number ( ex: "12.5" ) to an amount in the minor
+// unit of the given currency ( ex: 1250 for EUR ) without any rounding.
+// It returns ErrAmountPrecision if the number has more decimals than the currency.
+func ParseAmount(str string, cur Currency) (Amount, error) {
+	str = strings.TrimSpace(str)
+	neg := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(strings.TrimPrefix(str, "-"), "+")
+	intPart, fracPart := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, ErrAmountFormat
+	}
+	digits := cur.MinorUnits()
+	// Trailing zeros do not change the amount ( ex: 12.500 EUR )
+	fracPart = strings.TrimRight(fracPart, "0")
+	if len(fracPart) > digits {
+		return 0, ErrAmountPrecision
+	}
+	fracPart += strings.Repeat("0", digits-len(fracPart))
+	num := strings.TrimLeft(intPart+fracPart, "0")
+	if num == "" {
+		return 0, nil
+	}
+	minor, err := strconv.ParseInt(num, 10, 64)
+	if err != nil {
+		return 0, ErrAmountFormat
+	}
+	if neg {
+		minor = -minor
+	}
+	return Amount(minor), nil
+}
+
+// isDigits checks the given string contains only decimal digits
+func isDigits(str string) bool {
+	for _, r := range str {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Format returns the amount as a decimal number with the number
+// of decimals of the given currency ( ex: "12.50" for 1250 EUR ).
+func (a Amount) Format(cur Currency) string {
+	digits := cur.MinorUnits()
+	sign := ""
+	minor := int64(a)
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	str := strconv.FormatInt(minor, 10)
+	if digits == 0 {
+		return sign + str
+	}
+	if len(str) <= digits {
+		str = strings.Repeat("0", digits-len(str)+1) + str
+	}
+	return sign + str[:len(str)-digits] + "." + str[len(str)-digits:]
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "testing"
+
+func TestParseAmount(t *testing.T) {
+	tests := map[string]struct {
+		str         string
+		cur         Currency
+		expected    Amount
+		expectedErr error
+	}{
+		"Integer":            {str: "12", cur: "EUR", expected: 1200},
+		"Decimals":           {str: "12.5", cur: "EUR", expected: 1250},
+		"Trailing Zeros":     {str: "0.100", cur: "EUR", expected: 10},
+		"No Integer Part":    {str: ".99", cur: "EUR", expected: 99},
+		"Negative":           {str: "-3.05", cur: "EUR", expected: -305},
+		"Zero Minor Units":   {str: "1500", cur: "JPY", expected: 1500},
+		"Three Minor Units":  {str: "1.234", cur: "KWD", expected: 1234},
+		"Too Many Decimals":  {str: "12.505", cur: "EUR", expectedErr: ErrAmountPrecision},
+		"Decimals For JPY":   {str: "1500.5", cur: "JPY", expectedErr: ErrAmountPrecision},
+		"Empty":              {str: "", cur: "EUR", expectedErr: ErrAmountFormat},
+		"Exponent":           {str: "1e3", cur: "EUR", expectedErr: ErrAmountFormat},
+		"Comma Separator":    {str: "12,50", cur: "EUR", expectedErr: ErrAmountFormat},
+		"Overflowing Amount": {str: "99999999999999999999", cur: "EUR", expectedErr: ErrAmountFormat},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := ParseAmount(test.str, test.cur)
+			if err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if res != test.expected {
+				t.Fatalf("\nExpected: %d\nReturned: %d", test.expected, res)
+			}
+		})
+	}
+}
+
+func TestAmountFormat(t *testing.T) {
+	tests := map[string]struct {
+		amount   Amount
+		cur      Currency
+		expected string
+	}{
+		"Cents":            {amount: 1250, cur: "EUR", expected: "12.50"},
+		"Less Than One":    {amount: 5, cur: "EUR", expected: "0.05"},
+		"Negative":         {amount: -305, cur: "EUR", expected: "-3.05"},
+		"Zero Minor Units": {amount: 1500, cur: "JPY", expected: "1500"},
+		"Three Digits":     {amount: 1234, cur: "KWD", expected: "1.234"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if res := test.amount.Format(test.cur); res != test.expected {
+				t.Fatalf("\nExpected: %s\nReturned: %s", test.expected, res)
+			}
+		})
+	}
+}
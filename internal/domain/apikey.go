@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIKeyID is a value-object representing Id of an API key
+type APIKeyID uint
+
+// String returns a string representation of the id
+func (id APIKeyID) String() string {
+	return strconv.Itoa(int(id))
+}
+
+// APIKeyScope is a value-object representing what an API key can do
+type APIKeyScope string
+
+// API key scopes
+const (
+	APIKeyScopeRead      APIKeyScope = "read"       // Only read requests
+	APIKeyScopeReadWrite APIKeyScope = "read-write" // All requests
+)
+
+// APIKeyNameMaxLength represents the maximum length of an API key name
+const APIKeyNameMaxLength int = 50
+
+// APIKey entity.
+// It authenticates scripts & integrations of a user without login.
+// Only the hash of the key is stored: the key itself is shown once at creation.
+type APIKey struct {
+	ID        APIKeyID
+	UserID    UserID
+	Name      string
+	Prefix    string // First characters of the key to recognize it
+	Hash      string
+	Scope     APIKeyScope
+	ExpiresAt time.Time // Zero if the key never expires
+	CreatedAt time.Time
+}
+
+// Errors
+var (
+	ErrAPIKeyNameLength error = &Error{
+		Kind:    KindInvalid,
+		Code:    "api_key_name_length",
+		Message: fmt.Sprintf("API key name must be between 1 and %d characters", APIKeyNameMaxLength),
+		Field:   "name",
+	}
+	ErrAPIKeyScope error = &Error{
+		Kind:    KindInvalid,
+		Code:    "api_key_scope",
+		Message: fmt.Sprintf("API key scope must be %s or %s", APIKeyScopeRead, APIKeyScopeReadWrite),
+		Field:   "scope",
+	}
+)
+
+// ************* Methods *************
+
+// String returns a one-line representation of an API key
+func (k APIKey) String() string {
+	exp := "never"
+	if !k.ExpiresAt.IsZero() {
+		exp = k.ExpiresAt.Format("2006-01-02 15:04")
+	}
+	return fmt.Sprintf("[%d | %s | %s... | %s | user %d | expires %s]", k.ID, k.Name, k.Prefix, k.Scope, k.UserID, exp)
+}
+
+// Validate checks primitive, non-db-related fields for validity
+// It also trims the name
+func (k *APIKey) Validate() error {
+	k.Name = strings.TrimSpace(k.Name)
+	if len(k.Name) == 0 || len(k.Name) > APIKeyNameMaxLength {
+		return ErrAPIKeyNameLength
+	}
+	if k.Scope != APIKeyScopeRead && k.Scope != APIKeyScopeReadWrite {
+		return ErrAPIKeyScope
+	}
+	return nil
+}
+
+// Expired returns whether the key has an expiry before t
+func (k APIKey) Expired(t time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !k.ExpiresAt.After(t)
+}
+
+// Scopes returns the scopes granted by the key:
+// read on every resource or everything
+func (k APIKey) Scopes() Scopes {
+	if k.Scope == APIKeyScopeReadWrite {
+		return Scopes{ScopeAll}
+	}
+	res := make(Scopes, len(ScopeResources))
+	for i, resource := range ScopeResources {
+		res[i] = NewScope(resource, ScopeRead)
+	}
+	return res
+}
+
+// Allows returns whether the scope of the key allows requests with given http method
+func (k APIKey) Allows(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return true
+	}
+	return k.Scope == APIKeyScopeReadWrite
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPIKeyValidate(t *testing.T) {
+	tests := map[string]struct {
+		key         APIKey
+		expectedErr error
+	}{
+		"Read":          {key: APIKey{Name: " script ", Scope: APIKeyScopeRead}},
+		"Read Write":    {key: APIKey{Name: "scanner", Scope: APIKeyScopeReadWrite}},
+		"Empty Name":    {key: APIKey{Name: "  ", Scope: APIKeyScopeRead}, expectedErr: ErrAPIKeyNameLength},
+		"Long Name":     {key: APIKey{Name: strings.Repeat("a", APIKeyNameMaxLength+1), Scope: APIKeyScopeRead}, expectedErr: ErrAPIKeyNameLength},
+		"Unknown Scope": {key: APIKey{Name: "script", Scope: "admin"}, expectedErr: ErrAPIKeyScope},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.key.Validate(); err != test.expectedErr {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestAPIKeyExpired(t *testing.T) {
+	now := time.Now()
+	tests := map[string]struct {
+		expiresAt time.Time
+		expected  bool
+	}{
+		"No Expiry": {expected: false},
+		"Future":    {expiresAt: now.Add(time.Hour), expected: false},
+		"Past":      {expiresAt: now.Add(-time.Hour), expected: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if res := (APIKey{ExpiresAt: test.expiresAt}).Expired(now); res != test.expected {
+				t.Fatalf("\nExpected: %v\nReturned: %v", test.expected, res)
+			}
+		})
+	}
+}
+
+func TestAPIKeyAllows(t *testing.T) {
+	read, readWrite := APIKey{Scope: APIKeyScopeRead}, APIKey{Scope: APIKeyScopeReadWrite}
+	for _, method := range []string{"GET", "HEAD"} {
+		if !read.Allows(method) || !readWrite.Allows(method) {
+			t.Fatalf("\nExpected %s to be allowed by all scopes", method)
+		}
+	}
+	for _, method := range []string{"POST", "PUT", "DELETE"} {
+		if read.Allows(method) || !readWrite.Allows(method) {
+			t.Fatalf("\nExpected %s to be allowed by read-write scope only", method)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// AuditEventID is an object-value representing ID of an audit event
+type AuditEventID uint
+
+// String returns a string representation of the id
+func (id AuditEventID) String() string {
+	return strconv.Itoa(int(id))
+}
+
+// AuditEntity specifies the kind of entity an audit event refers to
+type AuditEntity string
+
+// Audited Entities
+const (
+	AuditTag      AuditEntity = "tag"
+	AuditActivity AuditEntity = "activity"
+	AuditExpense  AuditEntity = "expense"
+)
+
+// AuditAction specifies the change recorded by an audit event
+type AuditAction string
+
+// Audit Actions
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditImport  AuditAction = "import"  // Created by a CSV import or an archive restore
+	AuditRestore AuditAction = "restore" // Taken out of the trash
+	AuditPurge   AuditAction = "purge"   // Permanently deleted from the trash
+)
+
+// Principals of the changes made by the server itself.
+// They can not be taken for user names which have no colon.
+const (
+	PrincipalScheduler  string = "system:scheduler"   // Materializations of recurring templates
+	PrincipalTrashPurge string = "system:trash-purge" // Automatic purges of the trash
+	PrincipalCommand    string = "system:command"     // Server subcommands run by an operator
+)
+
+// AuditEvent records a change of an entity by a principal.
+// Before is empty when the entity appears ( creation, import or restore )
+// and After is empty when it disappears ( deletion or purge ).
+type AuditEvent struct {
+	ID        AuditEventID
+	Entity    AuditEntity
+	EntityID  uint // TagID, ActivityID or ExpenseID depending on Entity
+	Action    AuditAction
+	Principal string // Authenticated user who made the change or server principal
+	Time      time.Time
+	Before    json.RawMessage // JSON snapshot of the entity before the change
+	After     json.RawMessage // JSON snapshot of the entity after the change
+}
+
+// String returns a one-line representation of an audit event
+func (ev AuditEvent) String() string {
+	return fmt.Sprintf("[%s %s %d | by %s | %s]", ev.Action, ev.Entity, ev.EntityID, ev.Principal, ev.Time.Format("2006-01-02 15:04"))
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// BudgetID is a value-object representing Id of a budget
+type BudgetID uint
+
+// String returns a string representation of the id
+func (id BudgetID) String() string {
+	return strconv.Itoa(int(id))
+}
+
+// Budget entity.
+// It limits the amount spent in each period on expenses with a tag
+// or on all expenses if it has no tag.
+type Budget struct {
+	ID       BudgetID
+	TagID    TagID // Foreign Key ( 0 means all expenses )
+	Period   Period
+	Amount   Amount // In the minor unit of the currency
+	Currency Currency
+}
+
+// Errors
+var (
+	ErrBudgetAmount error = &Error{
+		Kind:    KindInvalid,
+		Code:    "budget_amount",
+		Message: "Budget Amount must be strictly positive",
+		Field:   "amount",
+	}
+)
+
+// ************* Methods *************
+
+// String returns a one-line representation of a budget
+func (b Budget) String() string {
+	return fmt.Sprintf("[%d | tag %s | %s %s per %s]", b.ID, b.TagID, b.Amount.Format(b.Currency), b.Currency, b.Period)
+}
+
+// Validate checks primitive, non-db-related fields for validity
+// It also transforms currency to an uppercase ISO-4217 currency code
+func (b *Budget) Validate() error {
+	// Check Period is known
+	if err := b.Period.Validate(); err != nil {
+		return err
+	}
+	// Check amount strictly positive
+	if b.Amount <= 0 {
+		return ErrBudgetAmount
+	}
+	// Check Currency
+	cur, err := ParseCurrency(string(b.Currency))
+	if err != nil {
+		return err
+	}
+	b.Currency = cur
+	// Everything is good
+	return nil
+}
+
+// BudgetStatus is a value-object representing the amount spent
+// on a budget in its current period compared to its limit.
+type BudgetStatus struct {
+	Budget    Budget
+	From      time.Time // Start of the current period
+	To        time.Time // End of the current period
+	Spent     Amount    // Spent so far in the period ( in the budget currency )
+	Remaining Amount    // Negative when over budget
+	Projected Amount    // Spent at the end of the period at the current pace
+}
+
+// Status returns the status of the budget in the period containing now
+// given the amount spent so far in this period.
+// The projected amount extrapolates the amount spent linearly
+// to the whole period.
+func (b Budget) Status(spent Amount, now time.Time) BudgetStatus {
+	st := BudgetStatus{
+		Budget:    b,
+		From:      b.Period.Start(now),
+		To:        b.Period.End(now),
+		Spent:     spent,
+		Remaining: b.Amount - spent,
+		Projected: spent,
+	}
+	elapsed := now.Sub(st.From)
+	if elapsed > 0 {
+		ratio := float64(st.To.Sub(st.From)) / float64(elapsed)
+		st.Projected = Amount(math.Round(float64(spent) * ratio))
+	}
+	return st
+}
+
+// OverBudget checks if more than the budget amount was spent
+func (st BudgetStatus) OverBudget() bool {
+	return st.Spent > st.Budget.Amount
+}
+
+// ProjectedOverspend checks if more than the budget amount
+// will be spent by the end of the period at the current pace
+func (st BudgetStatus) ProjectedOverspend() bool {
+	return st.Projected > st.Budget.Amount
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBudgetValidate(t *testing.T) {
+	tests := map[string]struct {
+		budget      Budget
+		expectedErr error
+	}{
+		"Correct":          {budget: Budget{Period: PeriodMonth, Amount: 30000, Currency: "eur"}},
+		"Unknown Period":   {budget: Budget{Period: "fortnight", Amount: 30000, Currency: "EUR"}, expectedErr: ErrPeriodInvalid},
+		"Zero Amount":      {budget: Budget{Period: PeriodMonth, Amount: 0, Currency: "EUR"}, expectedErr: ErrBudgetAmount},
+		"Unknown Currency": {budget: Budget{Period: PeriodMonth, Amount: 30000, Currency: "euro"}, expectedErr: ErrCurrencyInvalid},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.budget.Validate(); err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestBudgetStatus(t *testing.T) {
+	b := Budget{Period: PeriodMonth, Amount: 30000, Currency: "EUR"}
+	// 10 days out of the 30 days of november have elapsed
+	now := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		spent             Amount
+		expectedProjected Amount
+		expectedOver      bool
+		expectedOverspend bool
+		expectedRemaining Amount
+	}{
+		"Under Budget":        {spent: 5000, expectedProjected: 15000, expectedRemaining: 25000},
+		"Projected Overspend": {spent: 15000, expectedProjected: 45000, expectedOverspend: true, expectedRemaining: 15000},
+		"Over Budget":         {spent: 31000, expectedProjected: 93000, expectedOver: true, expectedOverspend: true, expectedRemaining: -1000},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			st := b.Status(test.spent, now)
+			if !st.From.Equal(time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)) || st.To.Month() != time.November {
+				t.Fatalf("\nUnexpected Period: %s ~ %s", st.From, st.To)
+			}
+			if st.Remaining != test.expectedRemaining {
+				t.Fatalf("\nExpected Remaining: %d\nReturned: %d", test.expectedRemaining, st.Remaining)
+			}
+			if st.Projected != test.expectedProjected {
+				t.Fatalf("\nExpected Projected: %d\nReturned: %d", test.expectedProjected, st.Projected)
+			}
+			if st.OverBudget() != test.expectedOver || st.ProjectedOverspend() != test.expectedOverspend {
+				t.Fatalf("\nExpected Over Budget: %v, Projected Overspend: %v\nReturned: %v, %v", test.expectedOver, test.expectedOverspend, st.OverBudget(), st.ProjectedOverspend())
+			}
+		})
+	}
+}
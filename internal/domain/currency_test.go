@@ -0,0 +1,63 @@
+package domain
+
+import "testing"
+
+func TestParseCurrency(t *testing.T) {
+	tests := map[string]struct {
+		code        string
+		expected    Currency
+		expectedErr error
+	}{
+		"Uppercase":    {code: "EUR", expected: "EUR"},
+		"Lowercase":    {code: "usd", expected: "USD"},
+		"Spaces":       {code: " mad ", expected: "MAD"},
+		"Unknown Code": {code: "EU", expectedErr: ErrCurrencyInvalid},
+		"Name":         {code: "Dollar", expectedErr: ErrCurrencyInvalid},
+		"Empty":        {code: "", expectedErr: ErrCurrencyInvalid},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cur, err := ParseCurrency(test.code)
+			if err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if cur != test.expected {
+				t.Fatalf("\nExpected: %s\nReturned: %s", test.expected, cur)
+			}
+		})
+	}
+}
+
+func TestParseLegacyCurrency(t *testing.T) {
+	tests := map[string]struct {
+		unit        string
+		expected    Currency
+		expectedErr error
+	}{
+		"Code":         {unit: "eur", expected: "EUR"},
+		"Symbol":       {unit: "€", expected: "EUR"},
+		"Name":         {unit: " Dollars ", expected: "USD"},
+		"Abbreviation": {unit: "DH", expected: "MAD"},
+		"Unknown Unit": {unit: "bitcoins", expectedErr: ErrCurrencyInvalid},
+		"Empty":        {unit: "", expectedErr: ErrCurrencyInvalid},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cur, err := ParseLegacyCurrency(test.unit)
+			if err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if cur != test.expected {
+				t.Fatalf("\nExpected: %s\nReturned: %s", test.expected, cur)
+			}
+		})
+	}
+}
+
+func TestCurrencyMinorUnits(t *testing.T) {
+	for cur, expected := range map[Currency]int{"EUR": 2, "JPY": 0, "KWD": 3, "eu": 2} {
+		if res := cur.MinorUnits(); res != expected {
+			t.Fatalf("\nExpected %s minor units: %d\nReturned: %d", cur, expected, res)
+		}
+	}
+}
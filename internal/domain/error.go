@@ -0,0 +1,42 @@
+package domain
+
+// ErrorKind classifies errors by the reason they occurred.
+type ErrorKind int
+
+// Kinds of errors
+const (
+	KindInternal        ErrorKind = iota // the operation failed for an unexpected reason
+	KindInvalid                          // the input is invalid
+	KindUnauthorized                     // authentication failed
+	KindNotFound                         // a resource does not exist
+	KindConflict                         // the operation conflicts with the current state
+	KindUnprocessable                    // the operation can not be performed on the resource
+	KindForbidden                        // the authenticated user is not allowed to perform the operation
+	KindTooManyRequests                  // the client must wait before retrying
+)
+
+// Error is an error with a machine-readable code:
+//	- Kind: reason of the error
+//	- Code: identifier of the error ( ex: tag_name_duplicate )
+//	- Message: human-readable description of the error
+//	- Field: name of the invalid input field ( if any )
+//	- Details: additional information ( ex: allowed lengths )
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+	Field   string
+	Details map[string]interface{}
+}
+
+// Error returns the message of the error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is an *Error with the same code,
+// so copies of an error ( ex: with more details ) match it with errors.Is.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
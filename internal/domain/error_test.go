@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorIs(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		target   error
+		expected bool
+	}{
+		"Same Error":     {err: ErrTagNameLen, target: ErrTagNameLen, expected: true},
+		"Wrapped Error":  {err: fmt.Errorf("importing row 3: %w", ErrTagNameLen), target: ErrTagNameLen, expected: true},
+		"Same Code":      {err: &Error{Code: "tag_name_length"}, target: ErrTagNameLen, expected: true},
+		"Other Code":     {err: ErrTagNameDuplicate, target: ErrTagNameLen, expected: false},
+		"Untyped Target": {err: ErrTagNameLen, target: errors.New(ErrTagNameLen.Error()), expected: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if res := errors.Is(test.err, test.target); res != test.expected {
+				t.Fatalf("\nExpected: %v\nReturned: %v", test.expected, res)
+			}
+		})
+	}
+}
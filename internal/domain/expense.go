@@ -1,10 +1,8 @@
 package domain
 
 import (
-	"errors"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 )
 
@@ -21,9 +19,10 @@ type Expense struct {
 	ID         ExpenseID
 	Label      string
 	Time       time.Time
-	Value      float32
-	Unit       string
+	Value      Amount // In the minor unit of the currency
+	Unit       Currency
 	ActivityID ActivityID // Foreign Key
+	Status     Status
 	Tags       []Tag
 }
 
@@ -31,49 +30,88 @@ type Expense struct {
 const (
 	ExpenseLabelMinLen int = 3
 	ExpenseLabelMaxLen int = 50
-	ExpenseUnitMinLen  int = 2
-	ExpenseUnitMaxLen  int = 10
 )
 
 // Errors
 var (
-	ErrExpenseLabelLength = fmt.Errorf("Expense Label must be %d ~ %d characters long", ExpenseLabelMinLen, ExpenseLabelMaxLen)
-	ErrExpenseValue       = errors.New("Expense Value must be strictly positive")
-	ErrExpenseUnitLength  = fmt.Errorf("Expense Unit must %d ~ %d long", ExpenseUnitMinLen, ExpenseUnitMaxLen)
-	ErrExpenseTimeFuture  = errors.New("Expense Time can not be future")
+	ErrExpenseLabelLength error = expenseLabelLengthError(ExpenseLabelMinLen, ExpenseLabelMaxLen)
+	ErrExpenseValue       error = &Error{
+		Kind:    KindInvalid,
+		Code:    "expense_value",
+		Message: "Expense Value must be strictly positive",
+		Field:   "value",
+	}
+	ErrExpenseTimeFuture error = &Error{
+		Kind:    KindInvalid,
+		Code:    "expense_time_future",
+		Message: "Expense Time can not be future unless planned",
+		Field:   "time",
+	}
 )
 
+// expenseLabelLengthError returns the error of an expense label out of given length limits
+func expenseLabelLengthError(min int, max int) error {
+	return &Error{
+		Kind:    KindInvalid,
+		Code:    "expense_label_length",
+		Message: fmt.Sprintf("Expense Label must be %d ~ %d characters long", min, max),
+		Field:   "label",
+		Details: map[string]interface{}{"min": min, "max": max},
+	}
+}
+
 // ************* Methods *************
 
 // String returns a one-line representation of an expense
 func (exp Expense) String() string {
-	return fmt.Sprintf("[%d | %s (%2.f %s) | %s]", exp.ID, exp.Label, exp.Value, exp.Unit, exp.Time.Format("2006-01-02"))
+	return fmt.Sprintf("[%d | %s (%s %s) | %s]", exp.ID, exp.Label, exp.Value.Format(exp.Unit), exp.Unit, exp.Time.Format("2006-01-02"))
 }
 
 // Validate checks primitive, non-db-related fields for validity
-// It also transforms unit to lowercase
-func (exp *Expense) Validate() error {
+// with the limits of given policy and returns all violations as ValidationErrors.
+// It also transforms unit to an uppercase ISO-4217 currency code and an empty status to done
+func (exp *Expense) Validate(p Policy) error {
 	now := time.Now()
+	errs := ValidationErrors{}
 	// Check Label length
 	labelLen := len(exp.Label)
-	if labelLen < ExpenseLabelMinLen || labelLen > ExpenseLabelMaxLen {
-		return ErrExpenseLabelLength
+	if labelLen < p.ExpenseLabelMinLen || labelLen > p.ExpenseLabelMaxLen {
+		errs.Add(expenseLabelLengthError(p.ExpenseLabelMinLen, p.ExpenseLabelMaxLen))
 	}
-	// Check Time is not future
-	if exp.Time.After(now) {
-		return ErrExpenseTimeFuture
+	// Check Status
+	st, err := ParseStatus(string(exp.Status))
+	if err != nil {
+		errs.Add(err)
+	} else {
+		exp.Status = st
+	}
+	// Check Time is not future unless planned
+	if !p.FutureTime && exp.Status != StatusPlanned && exp.Time.After(now) {
+		errs.Add(ErrExpenseTimeFuture)
 	}
 	// Check value strictly positive
 	if exp.Value <= 0 {
-		return ErrExpenseValue
+		errs.Add(ErrExpenseValue)
+	}
+	// Check Unit is a currency
+	if cur, err := ParseCurrency(string(exp.Unit)); err != nil {
+		errs.AddField("unit", err)
+	} else {
+		exp.Unit = cur
+	}
+	return errs.Err()
+}
+
+// Complete transitions a planned expense to done.
+// If its time is after now, it is moved to now.
+// It returns ErrStatusNotPlanned if the expense is not planned.
+func (exp *Expense) Complete(now time.Time) error {
+	if exp.Status != StatusPlanned {
+		return ErrStatusNotPlanned
 	}
-	// Check Unit length
-	unitLen := len(exp.Unit)
-	if unitLen < ExpenseUnitMinLen || unitLen > ExpenseUnitMaxLen {
-		return ErrExpenseUnitLength
+	if exp.Time.After(now) {
+		exp.Time = now
 	}
-	// Transform unit to lowercase
-	exp.Unit = strings.ToLower(exp.Unit)
-	// Everything is good
+	exp.Status = StatusDone
 	return nil
 }
@@ -0,0 +1,81 @@
+package domain
+
+import "time"
+
+// Period is a value-object representing a calendar period
+// used to group entities (day, week, month or year).
+type Period string
+
+// Periods
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+	PeriodYear  Period = "year"
+)
+
+// periodKeyFormat specifies the format of the key identifying a period.
+// A period is identified by the date it starts on.
+const periodKeyFormat string = "2006-01-02"
+
+// Errors
+var (
+	ErrPeriodInvalid error = &Error{
+		Kind:    KindInvalid,
+		Code:    "period_invalid",
+		Message: "Period must be one of day, week, month, year",
+		Field:   "period",
+	}
+)
+
+// ************* Methods *************
+
+// Validate checks the period is one of the known periods
+func (p Period) Validate() error {
+	switch p {
+	case PeriodDay, PeriodWeek, PeriodMonth, PeriodYear:
+		return nil
+	default:
+		return ErrPeriodInvalid
+	}
+}
+
+// Start returns the start of the period containing the given time.
+// Weeks start on monday.
+func (p Period) Start(t time.Time) time.Time {
+	y, m, d := t.Date()
+	switch p {
+	case PeriodWeek:
+		// time.Weekday starts on sunday
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, t.Location())
+	case PeriodMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	case PeriodYear:
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// End returns the last instant of the period containing the given time.
+func (p Period) End(t time.Time) time.Time {
+	start := p.Start(t)
+	switch p {
+	case PeriodWeek:
+		start = start.AddDate(0, 0, 7)
+	case PeriodMonth:
+		start = start.AddDate(0, 1, 0)
+	case PeriodYear:
+		start = start.AddDate(1, 0, 0)
+	default:
+		start = start.AddDate(0, 0, 1)
+	}
+	return start.Add(-time.Nanosecond)
+}
+
+// Key returns a string identifying the period containing the given time.
+// It is the date the period starts on formatted as yyyy-mm-dd.
+func (p Period) Key(t time.Time) string {
+	return p.Start(t).Format(periodKeyFormat)
+}
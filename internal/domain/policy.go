@@ -0,0 +1,54 @@
+package domain
+
+// Policy specifies the limits of the validation rules of entities.
+// Lengths are in characters. FutureTime allows activities & expenses in the future.
+type Policy struct {
+	ActivityLabelMinLen int  `json:"activityLabelMinLen"`
+	ActivityLabelMaxLen int  `json:"activityLabelMaxLen"`
+	ActivityPlaceMaxLen int  `json:"activityPlaceMaxLen"`
+	ActivityDescMaxLen  int  `json:"activityDescMaxLen"`
+	ExpenseLabelMinLen  int  `json:"expenseLabelMinLen"`
+	ExpenseLabelMaxLen  int  `json:"expenseLabelMaxLen"`
+	TagNameMinLen       int  `json:"tagNameMinLen"`
+	TagNameMaxLen       int  `json:"tagNameMaxLen"`
+	FutureTime          bool `json:"futureTime"`
+}
+
+// DefaultPolicy returns the policy with the default limits
+// ( see constants of activities, expenses and tags ).
+func DefaultPolicy() Policy {
+	return Policy{
+		ActivityLabelMinLen: ActivityLabelMinLen,
+		ActivityLabelMaxLen: ActivityLabelMaxLen,
+		ActivityPlaceMaxLen: ActivityPlaceMaxLen,
+		ActivityDescMaxLen:  ActivityDescMaxLen,
+		ExpenseLabelMinLen:  ExpenseLabelMinLen,
+		ExpenseLabelMaxLen:  ExpenseLabelMaxLen,
+		TagNameMinLen:       TagNameMinLength,
+		TagNameMaxLen:       TagNameMaxLength,
+	}
+}
+
+// ErrPolicyInvalid is returned when the limits of a policy are inconsistent.
+var ErrPolicyInvalid error = &Error{
+	Kind:    KindInvalid,
+	Code:    "policy_invalid",
+	Message: "Policy limits must be positive and minimums can not exceed maximums",
+}
+
+// Validate checks that the limits are positive
+// and that minimum lengths do not exceed maximum ones.
+func (p Policy) Validate() error {
+	for _, limits := range [][2]int{
+		{p.ActivityLabelMinLen, p.ActivityLabelMaxLen},
+		{0, p.ActivityPlaceMaxLen},
+		{0, p.ActivityDescMaxLen},
+		{p.ExpenseLabelMinLen, p.ExpenseLabelMaxLen},
+		{p.TagNameMinLen, p.TagNameMaxLen},
+	} {
+		if limits[0] < 0 || limits[1] <= 0 || limits[0] > limits[1] {
+			return ErrPolicyInvalid
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPolicyValidate(t *testing.T) {
+	tests := map[string]struct {
+		policy      func(*Policy)
+		expectedErr error
+	}{
+		"Default":         {policy: func(p *Policy) {}},
+		"Min Above Max":   {policy: func(p *Policy) { p.TagNameMinLen = p.TagNameMaxLen + 1 }, expectedErr: ErrPolicyInvalid},
+		"Negative Min":    {policy: func(p *Policy) { p.ExpenseLabelMinLen = -1 }, expectedErr: ErrPolicyInvalid},
+		"Zero Max":        {policy: func(p *Policy) { p.ActivityDescMaxLen = 0 }, expectedErr: ErrPolicyInvalid},
+		"Shorter Minimum": {policy: func(p *Policy) { p.ActivityLabelMinLen = 2 }},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := DefaultPolicy()
+			test.policy(&p)
+			if err := p.Validate(); err != test.expectedErr {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestPolicyLimits(t *testing.T) {
+	p := DefaultPolicy()
+	p.ActivityLabelMinLen = 3
+	p.FutureTime = true
+	act := Activity{Label: "Gym", Time: time.Now().Add(time.Hour)}
+	if err := act.Validate(DefaultPolicy()); !errors.Is(err, ErrActivityLabelLength) || !errors.Is(err, ErrActivityTimeFuture) {
+		t.Fatalf("\nExpected Err: %v & %v\nReturned Err: %v", ErrActivityLabelLength, ErrActivityTimeFuture, err)
+	}
+	if err := act.Validate(p); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	// Errors specify the limits of the policy
+	act.Label = "Go"
+	var ve ValidationErrors
+	if err := act.Validate(p); !errors.As(err, &ve) || ve["label"][0].(*Error).Details["min"] != 3 {
+		t.Fatalf("\nExpected label error with min length 3\nReturned Err: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ExchangeRate is a value-object representing the rate
+// of a currency ( Base ) in another one ( Quote ) on a given date:
+// 1 Base = Rate Quote
+type ExchangeRate struct {
+	Base  Currency
+	Quote Currency
+	Date  time.Time // Day the rate applies from ( UTC midnight )
+	Rate  float64
+}
+
+// ErrExchangeRateValue is returned when an exchange rate is not strictly positive
+var ErrExchangeRateValue error = &Error{
+	Kind:    KindInvalid,
+	Code:    "exchange_rate_value",
+	Message: "Exchange Rate must be strictly positive",
+	Field:   "rate",
+}
+
+// ************* Methods *************
+
+// String returns a one-line representation of an exchange rate
+func (er ExchangeRate) String() string {
+	return fmt.Sprintf("[%s | 1 %s = %f %s]", er.Date.Format("2006-01-02"), er.Base, er.Rate, er.Quote)
+}
+
+// Validate checks currencies are valid & the rate is strictly positive.
+// It also truncates the date to the start of its day in UTC.
+func (er *ExchangeRate) Validate() error {
+	if err := er.Base.Validate(); err != nil {
+		return err
+	}
+	if err := er.Quote.Validate(); err != nil {
+		return err
+	}
+	if er.Rate <= 0 {
+		return ErrExchangeRateValue
+	}
+	y, m, d := er.Date.UTC().Date()
+	er.Date = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return nil
+}
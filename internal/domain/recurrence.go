@@ -0,0 +1,105 @@
+package domain
+
+import "time"
+
+// Frequency is a value-object representing how often a rule recurs
+type Frequency string
+
+// Frequencies
+const (
+	FrequencyDaily   Frequency = "daily"
+	FrequencyWeekly  Frequency = "weekly"
+	FrequencyMonthly Frequency = "monthly"
+)
+
+// RecurrenceRule is a value-object describing recurring times ( like an iCalendar RRULE ).
+// Occurrences happen every Interval days, weeks or months starting at Start
+// ( which also gives their time of day ) and up to Until if it is set.
+// Monthly occurrences happen on the day of month of Start
+// or on the last day of shorter months.
+type RecurrenceRule struct {
+	Freq     Frequency
+	Interval int
+	Start    time.Time
+	Until    time.Time // Zero means forever
+}
+
+// Errors
+var (
+	ErrRecurrenceFreq error = &Error{
+		Kind:    KindInvalid,
+		Code:    "recurrence_freq",
+		Message: "Recurrence frequency must be one of daily, weekly, monthly",
+		Field:   "freq",
+	}
+	ErrRecurrenceInterval error = &Error{
+		Kind:    KindInvalid,
+		Code:    "recurrence_interval",
+		Message: "Recurrence interval must be strictly positive",
+		Field:   "interval",
+	}
+	ErrRecurrenceUntil error = &Error{
+		Kind:    KindInvalid,
+		Code:    "recurrence_until",
+		Message: "Recurrence end must be after its start",
+		Field:   "until",
+	}
+)
+
+// ************* Methods *************
+
+// Validate checks the rule fields for validity
+func (r RecurrenceRule) Validate() error {
+	switch r.Freq {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+	default:
+		return ErrRecurrenceFreq
+	}
+	if r.Interval <= 0 {
+		return ErrRecurrenceInterval
+	}
+	if !r.Until.IsZero() && r.Until.Before(r.Start) {
+		return ErrRecurrenceUntil
+	}
+	return nil
+}
+
+// Occurrence returns the time of the n-th occurrence of the rule ( starting at 0 )
+// It does not check the occurrence is before Until.
+func (r RecurrenceRule) Occurrence(n int) time.Time {
+	steps := n * r.Interval
+	switch r.Freq {
+	case FrequencyWeekly:
+		return r.Start.AddDate(0, 0, 7*steps)
+	case FrequencyMonthly:
+		y, m, d := r.Start.Date()
+		// Clamp day to the last day of the month
+		if last := time.Date(y, m+time.Month(steps)+1, 0, 0, 0, 0, 0, r.Start.Location()).Day(); d > last {
+			d = last
+		}
+		return time.Date(y, m+time.Month(steps), d, r.Start.Hour(), r.Start.Minute(), r.Start.Second(), r.Start.Nanosecond(), r.Start.Location())
+	default:
+		return r.Start.AddDate(0, 0, steps)
+	}
+}
+
+// Between returns at most max occurrences of the rule between from and to ( inclusive )
+func (r RecurrenceRule) Between(from time.Time, to time.Time, max int) []time.Time {
+	res := []time.Time{}
+	for n := 0; len(res) < max; n++ {
+		t := r.Occurrence(n)
+		if t.After(to) || (!r.Until.IsZero() && t.After(r.Until)) {
+			break
+		}
+		if !t.Before(from) {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// Includes checks if the given time is an occurrence of the rule
+func (r RecurrenceRule) Includes(t time.Time) bool {
+	occ := r.Between(t, t, 1)
+	return len(occ) == 1
+}
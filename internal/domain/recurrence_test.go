@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceRuleValidate(t *testing.T) {
+	start := time.Date(2020, 1, 31, 9, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		rule        RecurrenceRule
+		expectedErr error
+	}{
+		"Correct":            {rule: RecurrenceRule{Freq: FrequencyWeekly, Interval: 1, Start: start}},
+		"Correct Until":      {rule: RecurrenceRule{Freq: FrequencyDaily, Interval: 2, Start: start, Until: start.AddDate(0, 1, 0)}},
+		"Unknown Freq":       {rule: RecurrenceRule{Freq: "hourly", Interval: 1, Start: start}, expectedErr: ErrRecurrenceFreq},
+		"Zero Interval":      {rule: RecurrenceRule{Freq: FrequencyDaily, Interval: 0, Start: start}, expectedErr: ErrRecurrenceInterval},
+		"Until Before Start": {rule: RecurrenceRule{Freq: FrequencyDaily, Interval: 1, Start: start, Until: start.AddDate(0, 0, -1)}, expectedErr: ErrRecurrenceUntil},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.rule.Validate(); err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleBetween(t *testing.T) {
+	start := time.Date(2020, 1, 31, 9, 0, 0, 0, time.UTC)
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		rule     RecurrenceRule
+		from     time.Time
+		max      int
+		expected string
+	}{
+		"Daily": {
+			rule: RecurrenceRule{Freq: FrequencyDaily, Interval: 10, Start: start}, from: from, max: 3,
+			expected: "2020-01-31 2020-02-10 2020-02-20",
+		},
+		"Weekly Until": {
+			rule: RecurrenceRule{Freq: FrequencyWeekly, Interval: 2, Start: start, Until: start.AddDate(0, 0, 30)}, from: from, max: 10,
+			expected: "2020-01-31 2020-02-14 2020-02-28",
+		},
+		"Monthly Last Day": {
+			rule: RecurrenceRule{Freq: FrequencyMonthly, Interval: 1, Start: start}, from: from, max: 10,
+			expected: "2020-01-31 2020-02-29 2020-03-31 2020-04-30",
+		},
+		"From After Start": {
+			rule: RecurrenceRule{Freq: FrequencyMonthly, Interval: 2, Start: start}, from: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), max: 10,
+			expected: "2020-03-31",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			days := []string{}
+			for _, occ := range test.rule.Between(test.from, to, test.max) {
+				if occ.Hour() != 9 {
+					t.Fatalf("\nExpected occurrence at 09:00\nReturned: %s", occ)
+				}
+				days = append(days, occ.Format("2006-01-02"))
+			}
+			if res := strings.Join(days, " "); res != test.expected {
+				t.Fatalf("\nExpected: %s\nReturned: %s", test.expected, res)
+			}
+		})
+	}
+	// Subcase: Includes
+	rule := RecurrenceRule{Freq: FrequencyWeekly, Interval: 1, Start: start}
+	if !rule.Includes(start.AddDate(0, 0, 14)) || rule.Includes(start.AddDate(0, 0, 13)) {
+		t.Fatal("\nExpected occurrences to be weekly")
+	}
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ExpenseTotal is a value-object representing the sum of
+// expense values sharing the same group and unit.
+// Values of different units are never summed together.
+type ExpenseTotal struct {
+	Group string // Period key, Tag name or Unit depending on grouping
+	Unit  Currency
+	Total Amount // In the minor unit of the currency
+	Count int
+}
+
+// String returns a one-line representation of an expense total
+func (et ExpenseTotal) String() string {
+	return fmt.Sprintf("[%s | %s %s (%d expenses)]", et.Group, et.Total.Format(et.Unit), et.Unit, et.Count)
+}
+
+// ActivityTotal is a value-object representing the total and
+// average duration of activities sharing the same group.
+type ActivityTotal struct {
+	Group   string // Tag name, Place, Weekday or Hour depending on grouping
+	Total   time.Duration
+	Average time.Duration
+	Count   int
+}
+
+// String returns a one-line representation of an activity total
+func (at ActivityTotal) String() string {
+	return fmt.Sprintf("[%s | %s (avg %s, %d activities)]", at.Group, at.Total, at.Average, at.Count)
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Scope is a value-object representing a permission granted to a token.
+// It is formatted as resource:action ( ex: expenses:read ) or is ScopeAll.
+type Scope string
+
+// ScopeAll grants every action on every resource
+const ScopeAll Scope = "*"
+
+// ScopeAction is an action of a scope.
+// Admin grants read & write, which do not grant each other.
+type ScopeAction string
+
+// Scope actions
+const (
+	ScopeRead  ScopeAction = "read"  // Fetch resources
+	ScopeWrite ScopeAction = "write" // Create & edit resources
+	ScopeAdmin ScopeAction = "admin" // Read, write & delete resources
+)
+
+// ScopeResources are the resources scopes can be granted on.
+// Scopes on keys allow to manage API keys, which can grant any API key scope.
+var ScopeResources = []string{"tags", "activities", "expenses", "budgets", "templates", "reports", "search", "rates", "transfers", "archive", "trash", "keys"}
+
+// Errors
+var (
+	ErrScopeInvalid error = &Error{
+		Kind:    KindInvalid,
+		Code:    "scope_invalid",
+		Message: fmt.Sprintf("Scope must be * or resource:action with a resource in %s and an action in read, write, admin", strings.Join(ScopeResources, ", ")),
+		Field:   "scopes",
+	}
+	ErrScopeInsufficient error = &Error{
+		Kind:    KindForbidden,
+		Code:    "insufficient_scope",
+		Message: "Token scopes do not allow this request",
+	}
+)
+
+// ************* Methods *************
+
+// NewScope returns the scope of given action on given resource
+func NewScope(resource string, action ScopeAction) Scope {
+	return Scope(resource + ":" + string(action))
+}
+
+// ParseScope parses and returns a scope
+// It returns ErrScopeInvalid if the resource or the action are unknown
+func ParseScope(str string) (Scope, error) {
+	str = strings.ToLower(strings.TrimSpace(str))
+	if Scope(str) == ScopeAll {
+		return ScopeAll, nil
+	}
+	parts := strings.SplitN(str, ":", 2)
+	if len(parts) != 2 {
+		return "", ErrScopeInvalid
+	}
+	switch ScopeAction(parts[1]) {
+	case ScopeRead, ScopeWrite, ScopeAdmin:
+	default:
+		return "", ErrScopeInvalid
+	}
+	for _, res := range ScopeResources {
+		if res == parts[0] {
+			return Scope(str), nil
+		}
+	}
+	return "", ErrScopeInvalid
+}
+
+// Scopes is a list of scopes granted to a token
+type Scopes []Scope
+
+// ParseScopes parses and returns a list of scopes.
+// An empty list grants everything.
+func ParseScopes(strs []string) (Scopes, error) {
+	if len(strs) == 0 {
+		return Scopes{ScopeAll}, nil
+	}
+	res := make(Scopes, len(strs))
+	for i, str := range strs {
+		s, err := ParseScope(str)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = s
+	}
+	return res, nil
+}
+
+// Allows returns whether the scopes grant the action on the resource
+func (scopes Scopes) Allows(resource string, action ScopeAction) bool {
+	for _, s := range scopes {
+		if s == ScopeAll || s == NewScope(resource, action) || s == NewScope(resource, ScopeAdmin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers returns whether the scopes grant everything the other scopes grant.
+// Only * covers *.
+func (scopes Scopes) Covers(other Scopes) bool {
+	for _, s := range other {
+		if s == ScopeAll {
+			if !scopes.hasAll() {
+				return false
+			}
+			continue
+		}
+		parts := strings.SplitN(string(s), ":", 2)
+		if len(parts) != 2 || !scopes.Allows(parts[0], ScopeAction(parts[1])) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasAll returns whether the scopes contain ScopeAll
+func (scopes Scopes) hasAll() bool {
+	for _, s := range scopes {
+		if s == ScopeAll {
+			return true
+		}
+	}
+	return false
+}
+
+// Strings returns the scopes as strings
+func (scopes Scopes) Strings() []string {
+	res := make([]string, len(scopes))
+	for i, s := range scopes {
+		res[i] = string(s)
+	}
+	return res
+}
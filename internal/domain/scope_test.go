@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestParseScope(t *testing.T) {
+	tests := map[string]struct {
+		str         string
+		expected    Scope
+		expectedErr error
+	}{
+		"All":              {str: "*", expected: ScopeAll},
+		"Read":             {str: "expenses:read", expected: "expenses:read"},
+		"Upper Case":       {str: " Tags:Admin ", expected: "tags:admin"},
+		"Unknown Resource": {str: "users:read", expectedErr: ErrScopeInvalid},
+		"Unknown Action":   {str: "tags:delete", expectedErr: ErrScopeInvalid},
+		"No Action":        {str: "tags", expectedErr: ErrScopeInvalid},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := ParseScope(test.str)
+			if err != test.expectedErr || s != test.expected {
+				t.Fatalf("\nExpected: %q, %v\nReturned: %q, %v", test.expected, test.expectedErr, s, err)
+			}
+		})
+	}
+	if scopes, err := ParseScopes(nil); err != nil || len(scopes) != 1 || scopes[0] != ScopeAll {
+		t.Fatalf("\nExpected empty scopes to grant everything\nReturned: %v, %v", scopes, err)
+	}
+	if _, err := ParseScopes([]string{"tags:read", "tags"}); err != ErrScopeInvalid {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", ErrScopeInvalid, err)
+	}
+}
+
+func TestScopesAllows(t *testing.T) {
+	scopes := Scopes{"expenses:read", "tags:admin", "activities:write"}
+	tests := map[string]struct {
+		resource string
+		action   ScopeAction
+		expected bool
+	}{
+		"Granted Read":         {resource: "expenses", action: ScopeRead, expected: true},
+		"Not Granted Write":    {resource: "expenses", action: ScopeWrite, expected: false},
+		"Admin Grants Read":    {resource: "tags", action: ScopeRead, expected: true},
+		"Admin Grants Delete":  {resource: "tags", action: ScopeAdmin, expected: true},
+		"Write Without Read":   {resource: "activities", action: ScopeRead, expected: false},
+		"Other Resource":       {resource: "budgets", action: ScopeRead, expected: false},
+		"Write Without Delete": {resource: "activities", action: ScopeAdmin, expected: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if res := scopes.Allows(test.resource, test.action); res != test.expected {
+				t.Fatalf("\nExpected: %v\nReturned: %v", test.expected, res)
+			}
+		})
+	}
+	if !(Scopes{ScopeAll}).Allows("trash", ScopeAdmin) {
+		t.Fatal("\nExpected * to grant everything")
+	}
+}
+
+func TestScopesCovers(t *testing.T) {
+	scopes := Scopes{"expenses:read", "tags:admin"}
+	tests := map[string]struct {
+		scopes   Scopes
+		other    Scopes
+		expected bool
+	}{
+		"Same Scopes":        {scopes: scopes, other: Scopes{"expenses:read", "tags:admin"}, expected: true},
+		"Admin Covers Write": {scopes: scopes, other: Scopes{"tags:write", "tags:read"}, expected: true},
+		"Not Granted Write":  {scopes: scopes, other: Scopes{"expenses:write"}, expected: false},
+		"Other Resource":     {scopes: scopes, other: Scopes{"expenses:read", "budgets:read"}, expected: false},
+		"All Not Covered":    {scopes: Scopes{"keys:write"}, other: Scopes{ScopeAll}, expected: false},
+		"All Covers All":     {scopes: Scopes{ScopeAll}, other: Scopes{ScopeAll}, expected: true},
+		"All Covers Any":     {scopes: Scopes{ScopeAll}, other: scopes, expected: true},
+		"Nothing":            {scopes: Scopes{}, other: Scopes{}, expected: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if res := test.scopes.Covers(test.other); res != test.expected {
+				t.Fatalf("\nExpected: %v\nReturned: %v", test.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// SearchResultKind specifies the kind of entity a search result refers to
+type SearchResultKind string
+
+// Search Result Kinds
+const (
+	SearchResultActivity SearchResultKind = "activity"
+	SearchResultExpense  SearchResultKind = "expense"
+)
+
+// SearchResult is a value-object representing an activity or an expense
+// matching a full-text search. Results with higher Rank match better.
+type SearchResult struct {
+	Kind  SearchResultKind
+	ID    uint // ActivityID or ExpenseID depending on Kind
+	Label string
+	Time  time.Time
+	Rank  float64
+}
+
+// String returns a one-line representation of a search result
+func (sr SearchResult) String() string {
+	return fmt.Sprintf("[%s %d | %s | %s (rank %.3f)]", sr.Kind, sr.ID, sr.Label, sr.Time.Format("2006-01-02 15:04"), sr.Rank)
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "strings"
+
+// Status is a value-object representing the state of an activity or an expense
+type Status string
+
+// Statuses
+const (
+	StatusPlanned   Status = "planned"   // Upcoming, may be in the future
+	StatusDone      Status = "done"      // Happened, can not be in the future
+	StatusCancelled Status = "cancelled" // Will not happen
+)
+
+// Errors
+var (
+	ErrStatusInvalid error = &Error{
+		Kind:    KindInvalid,
+		Code:    "status_invalid",
+		Message: "Status must be planned, done or cancelled",
+		Field:   "status",
+	}
+	ErrStatusNotPlanned error = &Error{
+		Kind:    KindConflict,
+		Code:    "status_not_planned",
+		Message: "Only planned items can be completed",
+		Field:   "status",
+	}
+)
+
+// ParseStatus returns the status with given name ( case insensitive ).
+// An empty name is parsed as StatusDone.
+// It returns ErrStatusInvalid if the name is not a known status.
+func ParseStatus(name string) (Status, error) {
+	st := Status(strings.ToLower(strings.TrimSpace(name)))
+	if st == "" {
+		return StatusDone, nil
+	}
+	if err := st.Validate(); err != nil {
+		return "", err
+	}
+	return st, nil
+}
+
+// Done checks if the status is done.
+// An empty status is done, as it is parsed as StatusDone.
+// Only done items are counted in reports and budgets.
+func (st Status) Done() bool {
+	return st == StatusDone || st == ""
+}
+
+// Validate checks the status is a known one
+func (st Status) Validate() error {
+	switch st {
+	case StatusPlanned, StatusDone, StatusCancelled:
+		return nil
+	}
+	return ErrStatusInvalid
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseStatus(t *testing.T) {
+	tests := map[string]struct {
+		name        string
+		expected    Status
+		expectedErr error
+	}{
+		"Empty":      {name: "", expected: StatusDone},
+		"Planned":    {name: "planned", expected: StatusPlanned},
+		"Upper Case": {name: " Cancelled ", expected: StatusCancelled},
+		"Unknown":    {name: "later", expectedErr: ErrStatusInvalid},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			st, err := ParseStatus(test.name)
+			if err != test.expectedErr || st != test.expected {
+				t.Fatalf("\nExpected: %q, %v\nReturned: %q, %v", test.expected, test.expectedErr, st, err)
+			}
+		})
+	}
+}
+
+func TestStatusDone(t *testing.T) {
+	tests := map[Status]bool{
+		StatusDone:      true,
+		"":              true,
+		StatusPlanned:   false,
+		StatusCancelled: false,
+	}
+	for st, expected := range tests {
+		if st.Done() != expected {
+			t.Fatalf("\nExpected %q done: %v\nReturned: %v", st, expected, st.Done())
+		}
+	}
+}
+
+func TestPlannedValidate(t *testing.T) {
+	future := time.Now().AddDate(0, 0, 7)
+	tests := map[string]struct {
+		status      Status
+		expectedErr error
+	}{
+		"Planned":   {status: StatusPlanned},
+		"Done":      {status: StatusDone, expectedErr: ErrExpenseTimeFuture},
+		"Empty":     {status: "", expectedErr: ErrExpenseTimeFuture},
+		"Cancelled": {status: StatusCancelled, expectedErr: ErrExpenseTimeFuture},
+		"Unknown":   {status: "later", expectedErr: ErrStatusInvalid},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			exp := Expense{Label: "Hotel booking", Time: future, Value: 100, Unit: "EUR", Status: test.status}
+			err := exp.Validate(DefaultPolicy())
+			if !errors.Is(err, test.expectedErr) || (test.expectedErr == nil && err != nil) {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+	now := time.Now()
+	act := Activity{Label: "Football", Time: now.Add(time.Hour), Duration: time.Hour, Status: StatusPlanned}
+	if err := act.Complete(now); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if act.Status != StatusDone || !act.Time.Add(act.Duration).Equal(now) {
+		t.Fatalf("\nExpected activity done ending now\nReturned: %+v", act)
+	}
+	if err := act.Complete(now); err != ErrStatusNotPlanned {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", ErrStatusNotPlanned, err)
+	}
+	past := now.AddDate(0, 0, -1)
+	exp := Expense{Label: "Ticket", Time: past, Status: StatusPlanned}
+	if err := exp.Complete(now); err != nil || exp.Status != StatusDone || !exp.Time.Equal(past) {
+		t.Fatalf("\nExpected expense done at its time\nReturned: %+v, %v", exp, err)
+	}
+}
@@ -35,3 +35,18 @@ func TestTagString(t *testing.T) {
 	tag := Tag{ID: 1, Name: "tag-1"}
 	log.Print(tag)
 }
+
+func TestBudgetString(t *testing.T) {
+	b := Budget{ID: 1, TagID: 2, Period: PeriodMonth, Amount: 30000, Currency: "EUR"}
+	log.Print(b)
+}
+
+func TestTemplateString(t *testing.T) {
+	tmpl := Template{
+		ID:      1,
+		Kind:    TemplateExpense,
+		Rule:    RecurrenceRule{Freq: FrequencyMonthly, Interval: 1, Start: time.Now()},
+		Expense: Expense{Label: "Rent", Value: 80000, Unit: "EUR"},
+	}
+	log.Print(tmpl)
+}
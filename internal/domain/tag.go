@@ -1,7 +1,6 @@
 package domain
 
 import (
-	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -31,11 +30,32 @@ const (
 
 // Errors
 var (
-	ErrTagNameLen               = fmt.Errorf("Tag name must be %d ~ %d characters long", TagNameMinLength, TagNameMaxLength)
-	ErrTagNameInvalidCharacters = errors.New("Tag name can only contain alphanumeric characters and dashes")
-	ErrTagNameDuplicate         = errors.New("Tag name duplicate")
+	ErrTagNameLen               error = tagNameLenError(TagNameMinLength, TagNameMaxLength)
+	ErrTagNameInvalidCharacters error = &Error{
+		Kind:    KindInvalid,
+		Code:    "tag_name_characters",
+		Message: "Tag name can only contain alphanumeric characters and dashes",
+		Field:   "name",
+	}
+	ErrTagNameDuplicate error = &Error{
+		Kind:    KindInvalid,
+		Code:    "tag_name_duplicate",
+		Message: "Tag name duplicate",
+		Field:   "name",
+	}
 )
 
+// tagNameLenError returns the error of a tag name out of given length limits
+func tagNameLenError(min int, max int) error {
+	return &Error{
+		Kind:    KindInvalid,
+		Code:    "tag_name_length",
+		Message: fmt.Sprintf("Tag name must be %d ~ %d characters long", min, max),
+		Field:   "name",
+		Details: map[string]interface{}{"min": min, "max": max},
+	}
+}
+
 // ************* Methods *************
 
 // String returns a one-line representation of a tag
@@ -44,18 +64,19 @@ func (t Tag) String() string {
 }
 
 // Validate checks primitive, non-db-related fields for validity
-func (t *Tag) Validate() error {
+// with the limits of given policy and returns all violations as ValidationErrors.
+func (t *Tag) Validate(p Policy) error {
+	errs := ValidationErrors{}
 	// Check tag name length
-	nameTooShort := len(t.Name) < TagNameMinLength
-	nameTooLong := len(t.Name) > TagNameMaxLength
+	nameTooShort := len(t.Name) < p.TagNameMinLen
+	nameTooLong := len(t.Name) > p.TagNameMaxLen
 	if nameTooShort || nameTooLong {
-		return ErrTagNameLen
+		errs.Add(tagNameLenError(p.TagNameMinLen, p.TagNameMaxLen))
 	}
 	t.Name = strings.ToLower(t.Name)
 	// Check Tag name characters
 	if match, _ := regexp.Match(TagNameValidChars, []byte(t.Name)); !match {
-		return ErrTagNameInvalidCharacters
+		errs.Add(ErrTagNameInvalidCharacters)
 	}
-	// Everything is good
-	return nil
+	return errs.Err()
 }
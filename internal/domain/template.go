@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TemplateID is a value-object representing Id of a template
+type TemplateID uint
+
+// String returns a string representation of the id
+func (id TemplateID) String() string {
+	return strconv.Itoa(int(id))
+}
+
+// TemplateKind is a value-object representing the kind of entity a template creates
+type TemplateKind string
+
+// Template Kinds
+const (
+	TemplateExpense  TemplateKind = "expense"
+	TemplateActivity TemplateKind = "activity"
+)
+
+// Template entity.
+// It creates an expense or an activity at each occurrence of its recurrence rule
+// ( ex: the rent each month or the gym session each week ).
+// The time of the Expense or Activity model is ignored.
+type Template struct {
+	ID       TemplateID
+	Kind     TemplateKind
+	Rule     RecurrenceRule
+	Expense  Expense  // Model of created expenses when Kind is expense
+	Activity Activity // Model of created activities when Kind is activity
+}
+
+// Occurrence is a value-object recording that an occurrence of a template
+// was materialized into an expense or an activity or was skipped.
+type Occurrence struct {
+	TemplateID TemplateID
+	Time       time.Time
+	Skipped    bool
+	ExpenseID  ExpenseID  // Created expense if any
+	ActivityID ActivityID // Created activity if any
+}
+
+// Errors
+var (
+	ErrTemplateKind error = &Error{
+		Kind:    KindInvalid,
+		Code:    "template_kind",
+		Message: "Template kind must be expense or activity",
+		Field:   "kind",
+	}
+)
+
+// ************* Methods *************
+
+// String returns a one-line representation of a template
+func (t Template) String() string {
+	label := t.Expense.Label
+	if t.Kind == TemplateActivity {
+		label = t.Activity.Label
+	}
+	return fmt.Sprintf("[%d | %s %s | every %d %s from %s]", t.ID, t.Kind, label, t.Rule.Interval, t.Rule.Freq, t.Rule.Start.Format("2006-01-02"))
+}
+
+// Validate checks the rule and the fields of the model entity for validity
+// with the limits of given policy ( without checking its time ).
+func (t *Template) Validate(p Policy) error {
+	if err := t.Rule.Validate(); err != nil {
+		return err
+	}
+	switch t.Kind {
+	case TemplateExpense:
+		exp := t.ExpenseAt(time.Time{})
+		if err := exp.Validate(p); err != nil {
+			return err
+		}
+		t.Expense.Unit = exp.Unit
+	case TemplateActivity:
+		act := t.ActivityAt(time.Time{})
+		if err := act.Validate(p); err != nil {
+			return err
+		}
+		t.Activity.Place = act.Place
+	default:
+		return ErrTemplateKind
+	}
+	return nil
+}
+
+// ExpenseAt returns the expense of the occurrence at the given time
+func (t Template) ExpenseAt(at time.Time) Expense {
+	exp := t.Expense
+	exp.ID = 0
+	exp.Time = at
+	return exp
+}
+
+// ActivityAt returns the activity of the occurrence at the given time
+func (t Template) ActivityAt(at time.Time) Activity {
+	act := t.Activity
+	act.ID = 0
+	act.Time = at
+	return act
+}
+
+// DueAt returns the time the occurrence at the given time is due
+// ( when it can be materialized ).
+// Activities are due once they are over.
+func (t Template) DueAt(at time.Time) time.Time {
+	if t.Kind == TemplateActivity {
+		return at.Add(t.Activity.Duration)
+	}
+	return at
+}
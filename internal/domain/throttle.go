@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Throttle is the state of the rate limiting of a key
+// ( ex: an IP address or an account ).
+// It counts the requests of the current window and the consecutive
+// failed logins which lock the key out.
+type Throttle struct {
+	Key         string
+	WindowStart time.Time // Start of the current rate limiting window
+	Hits        int       // Requests in the current window
+	Failures    int       // Consecutive failed logins
+	LastFailure time.Time
+	LockedUntil time.Time // Zero if the key is not locked out
+	LastSeen    time.Time // Time of the last request or failure
+}
+
+// String returns a one-line representation of a throttle
+func (th Throttle) String() string {
+	return fmt.Sprintf("[%s | %d hits since %s | %d failures | locked until %s]", th.Key, th.Hits, th.WindowStart.Format("15:04:05"), th.Failures, th.LockedUntil.Format("15:04:05"))
+}
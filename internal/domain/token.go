@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// RefreshToken Entity
+// It is the stored state of a refresh JWT identified by its ID ( jti claim ).
+// A token can be used once: using it issues a new token of the same family.
+type RefreshToken struct {
+	ID        string
+	Family    string // ID of the token issued at login, shared by its rotations
+	UserID    UserID
+	ExpiresAt time.Time
+	Used      bool // Set when the token is rotated
+	Revoked   bool // Set at logout or when the reuse of a used token of the family is detected
+}
+
+// String returns a one-line representation of a refresh token
+func (rt RefreshToken) String() string {
+	return fmt.Sprintf("[%s | family %s | user %d | expires %s | used: %t | revoked: %t]", rt.ID, rt.Family, rt.UserID, rt.ExpiresAt.Format("2006-01-02 15:04"), rt.Used, rt.Revoked)
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// TrashItemKind specifies the kind of entity a trashed item refers to
+type TrashItemKind string
+
+// Trash Item Kinds
+const (
+	TrashTag      TrashItemKind = "tag"
+	TrashActivity TrashItemKind = "activity"
+	TrashExpense  TrashItemKind = "expense"
+)
+
+// TrashItem is a value-object representing a soft-deleted tag, activity or expense.
+// It can be restored or purged until it is purged automatically.
+type TrashItem struct {
+	Kind      TrashItemKind
+	ID        uint   // TagID, ActivityID or ExpenseID depending on Kind
+	Label     string // Name for tags
+	DeletedAt time.Time
+}
+
+// String returns a one-line representation of a trashed item
+func (ti TrashItem) String() string {
+	return fmt.Sprintf("[%s %d | %s | deleted %s]", ti.Kind, ti.ID, ti.Label, ti.DeletedAt.Format("2006-01-02 15:04"))
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// UserID is a value-object representing Id of a User.
+type UserID uint
+
+// String returns a string representation of the id
+func (id UserID) String() string {
+	return strconv.Itoa(int(id))
+}
+
+// User Entity
+// Every tag, activity, expense, budget and template belongs to a user
+// and is only visible to that user.
+type User struct {
+	ID           UserID
+	Name         string
+	PasswordHash string // bcrypt hash of the password
+}
+
+// Constants for user name conditions
+const (
+	UserNameMinLength  int    = 3
+	UserNameMaxLength  int    = 30
+	UserNameValidChars string = `^[a-z0-9_.-]*$` // Lowercase alphanumeric characters, dots, dashes and underscores
+)
+
+// Errors
+var (
+	ErrUserNameLength error = &Error{
+		Kind:    KindInvalid,
+		Code:    "user_name_length",
+		Message: fmt.Sprintf("User name must be %d ~ %d characters long", UserNameMinLength, UserNameMaxLength),
+		Field:   "name",
+		Details: map[string]interface{}{"min": UserNameMinLength, "max": UserNameMaxLength},
+	}
+	ErrUserNameInvalidCharacters error = &Error{
+		Kind:    KindInvalid,
+		Code:    "user_name_characters",
+		Message: "User name can only contain alphanumeric characters, dots, dashes and underscores",
+		Field:   "name",
+	}
+	ErrUserNameDuplicate error = &Error{
+		Kind:    KindConflict,
+		Code:    "user_name_duplicate",
+		Message: "User name is already taken",
+		Field:   "name",
+	}
+)
+
+// ************* Methods *************
+
+// String returns a one-line representation of a user
+func (u User) String() string {
+	return fmt.Sprintf("[%d | %s ]", u.ID, u.Name)
+}
+
+// Validate checks the name of the user for validity.
+// The name is trimmed and transformed to lowercase.
+func (u *User) Validate() error {
+	errs := ValidationErrors{}
+	u.Name = strings.ToLower(strings.TrimSpace(u.Name))
+	if len(u.Name) < UserNameMinLength || len(u.Name) > UserNameMaxLength {
+		errs.Add(ErrUserNameLength)
+	}
+	if match, _ := regexp.Match(UserNameValidChars, []byte(u.Name)); !match {
+		errs.Add(ErrUserNameInvalidCharacters)
+	}
+	return errs.Err()
+}
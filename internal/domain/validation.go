@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ErrValidation is matched by all ValidationErrors with errors.Is.
+var ErrValidation error = &Error{
+	Kind:    KindInvalid,
+	Code:    "validation_failed",
+	Message: "Validation failed",
+}
+
+// ValidationErrors collects the violations of the validation rules
+// of an entity, keyed by the name of the invalid field.
+type ValidationErrors map[string][]error
+
+// Add adds a violation under the field of the error ( if it is an *Error ).
+func (ve ValidationErrors) Add(err error) {
+	var field string
+	var derr *Error
+	if errors.As(err, &derr) {
+		field = derr.Field
+	}
+	ve[field] = append(ve[field], err)
+}
+
+// AddField adds a violation under given field.
+// If err is an *Error, a copy with given field is added
+// which still matches err with errors.Is.
+func (ve ValidationErrors) AddField(field string, err error) {
+	if derr, ok := err.(*Error); ok {
+		cp := *derr
+		cp.Field = field
+		err = &cp
+	}
+	ve[field] = append(ve[field], err)
+}
+
+// Err returns nil if there are no violations, otherwise the violations.
+func (ve ValidationErrors) Err() error {
+	if len(ve) == 0 {
+		return nil
+	}
+	return ve
+}
+
+// Fields returns the names of invalid fields sorted alphabetically.
+func (ve ValidationErrors) Fields() []string {
+	fields := make([]string, 0, len(ve))
+	for field := range ve {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Errors returns all violations sorted by field.
+func (ve ValidationErrors) Errors() []error {
+	var errs []error
+	for _, field := range ve.Fields() {
+		errs = append(errs, ve[field]...)
+	}
+	return errs
+}
+
+// Error returns the messages of all violations.
+func (ve ValidationErrors) Error() string {
+	errs := ve.Errors()
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports whether target is ErrValidation or matches one of the violations,
+// so a violation can be checked with errors.Is.
+func (ve ValidationErrors) Is(target error) bool {
+	if target == ErrValidation {
+		return true
+	}
+	for _, errs := range ve {
+		for _, err := range errs {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidationErrors(t *testing.T) {
+	future := time.Now().AddDate(0, 0, 1)
+	tests := map[string]struct {
+		validate func(Policy) error
+		expected map[string]error
+	}{
+		"Valid Activity": {
+			validate: (&Activity{Label: "Football", Time: time.Now().AddDate(0, 0, -1)}).Validate,
+			expected: map[string]error{},
+		},
+		"Activity": {
+			validate: (&Activity{Label: "a", Place: strings.Repeat("p", ActivityPlaceMaxLen+1), Desc: strings.Repeat("d", ActivityDescMaxLen+1), Time: future}).Validate,
+			expected: map[string]error{
+				"label": ErrActivityLabelLength,
+				"place": ErrActivityPlaceLength,
+				"desc":  ErrActivityDescLength,
+				"time":  ErrActivityTimeFuture,
+			},
+		},
+		"Expense": {
+			validate: (&Expense{Label: "a", Time: future, Value: 0, Unit: "XX"}).Validate,
+			expected: map[string]error{
+				"label": ErrExpenseLabelLength,
+				"time":  ErrExpenseTimeFuture,
+				"value": ErrExpenseValue,
+				"unit":  ErrCurrencyInvalid,
+			},
+		},
+		"Tag": {
+			validate: (&Tag{Name: "$"}).Validate,
+			expected: map[string]error{"name": ErrTagNameLen},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.validate(DefaultPolicy())
+			if len(test.expected) == 0 {
+				if err != nil {
+					t.Fatalf("\nUnexpected Error: %v", err)
+				}
+				return
+			}
+			var ve ValidationErrors
+			if !errors.As(err, &ve) || !errors.Is(err, ErrValidation) {
+				t.Fatalf("\nExpected ValidationErrors\nReturned: %v", err)
+			}
+			fields := make([]string, 0, len(test.expected))
+			for field, expected := range test.expected {
+				fields = append(fields, field)
+				if !errors.Is(ValidationErrors{field: ve[field]}, expected) {
+					t.Fatalf("\nExpected %s Error: %v\nReturned Errors: %v", field, expected, ve[field])
+				}
+			}
+			returned := ve.Fields()
+			if len(returned) != len(fields) {
+				t.Fatalf("\nExpected Fields: %v\nReturned Fields: %v", fields, returned)
+			}
+			if field := ve[returned[0]][0].(*Error).Field; field != returned[0] {
+				t.Fatalf("\nExpected Field: %s\nReturned Field: %s", returned[0], field)
+			}
+		})
+	}
+}
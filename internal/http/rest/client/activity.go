@@ -0,0 +1,61 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+// ActivitiesPage is a page of activities.
+type ActivitiesPage struct {
+	Items []server.JSONRespListActivity `json:"items"`
+	Next  string                        `json:"next"`
+	Total int                           `json:"total"`
+}
+
+// Activities returns the requested page of activities in the given range.
+func (c *Client) Activities(r TimeRange, p PageOptions) (ActivitiesPage, error) {
+	q := url.Values{}
+	r.values(q)
+	p.values(q)
+	var page ActivitiesPage
+	err := c.do(http.MethodGet, "/activities", q, nil, &page)
+	return page, err
+}
+
+// Activity returns details of the activity with given ID.
+func (c *Client) Activity(id domain.ActivityID) (server.JSONRespDetailActivity, error) {
+	var act server.JSONRespDetailActivity
+	err := c.do(http.MethodGet, fmt.Sprintf("/activities/%d", id), nil, nil, &act)
+	return act, err
+}
+
+// AddActivity adds the given activity and returns it.
+func (c *Client) AddActivity(act server.JSONReqActivity) (domain.Activity, error) {
+	var created domain.Activity
+	err := c.do(http.MethodPost, "/activities", nil, act, &created)
+	return created, err
+}
+
+// EditActivity edits the activity with given ID and returns it.
+func (c *Client) EditActivity(id domain.ActivityID, act server.JSONReqActivity) (domain.Activity, error) {
+	act.ID = id
+	var edited domain.Activity
+	err := c.do(http.MethodPut, fmt.Sprintf("/activities/%d", id), nil, act, &edited)
+	return edited, err
+}
+
+// CompleteActivity marks the planned activity with given ID as done and returns it.
+func (c *Client) CompleteActivity(id domain.ActivityID) (server.JSONRespDetailActivity, error) {
+	var act server.JSONRespDetailActivity
+	err := c.do(http.MethodPost, fmt.Sprintf("/activities/%d/complete", id), nil, nil, &act)
+	return act, err
+}
+
+// DeleteActivity deletes the activity with given ID.
+func (c *Client) DeleteActivity(id domain.ActivityID) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/activities/%d", id), nil, nil, nil)
+}
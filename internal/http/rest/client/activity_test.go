@@ -0,0 +1,161 @@
+package client_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/client"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/usecase/deleting"
+	"github.com/elhamza90/lifelog/internal/usecase/listing"
+)
+
+func TestAddActivity(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "sport"},
+	}
+	repo.Activities = map[domain.ActivityID]domain.Activity{}
+	now := time.Now()
+	tests := map[string]struct {
+		act         server.JSONReqActivity
+		expectedErr error
+	}{
+		"Valid Activity": {
+			act:         server.JSONReqActivity{Label: "Football", Time: now.Add(-2 * time.Hour), Duration: time.Hour, TagIds: []domain.TagID{1}},
+			expectedErr: nil,
+		},
+		"Future Activity": {
+			act:         server.JSONReqActivity{Label: "Football", Time: now.Add(time.Hour), Duration: time.Hour},
+			expectedErr: domain.ErrActivityTimeFuture,
+		},
+		"Invalid Label & Future": {
+			act:         server.JSONReqActivity{Label: "Foot", Time: now.Add(time.Hour), Duration: time.Hour},
+			expectedErr: domain.ErrActivityLabelLength,
+		},
+		"Nonexistent Tag": {
+			act:         server.JSONReqActivity{Label: "Football", Time: now.Add(-2 * time.Hour), Duration: time.Hour, TagIds: []domain.TagID{99}},
+			expectedErr: store.ErrTagNotFound,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			created, err := cl.AddActivity(test.act)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+			if test.act.Time.After(now) && !errors.Is(err, domain.ErrActivityTimeFuture) {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", domain.ErrActivityTimeFuture, err)
+			}
+			if err != nil {
+				return
+			}
+			if created.ID == 0 || created.Label != test.act.Label || len(created.Tags) != 1 {
+				t.Fatalf("\nExpected created activity with its tag\nReturned: %+v", created)
+			}
+		})
+	}
+}
+
+func TestActivity(t *testing.T) {
+	actTime := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	repo.Tags = map[domain.TagID]domain.Tag{}
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Football", Place: "Stadium", Time: actTime, Duration: time.Hour},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Ticket", Time: actTime, Value: 1000, Unit: "EUR", ActivityID: 1},
+	}
+	act, err := cl.Activity(1)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if act.Label != "Football" || !act.Time.Equal(actTime) || len(act.Expenses) != 1 || act.Expenses[0].Value != "10.00" {
+		t.Fatalf("\nExpected activity with its expense\nReturned: %+v", act)
+	}
+	if _, err := cl.Activity(99); !errors.Is(err, store.ErrActivityNotFound) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", store.ErrActivityNotFound, err)
+	}
+	page, err := cl.Activities(client.TimeRange{From: actTime.Add(-time.Hour), To: actTime.Add(time.Hour)}, client.PageOptions{})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].ID != 1 {
+		t.Fatalf("\nExpected activity in range\nReturned: %+v", page)
+	}
+	if _, err := cl.Activities(client.TimeRange{From: actTime, To: actTime.Add(-time.Hour)}, client.PageOptions{}); !errors.Is(err, listing.ErrTimeRange) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", listing.ErrTimeRange, err)
+	}
+}
+
+func TestEditActivity(t *testing.T) {
+	actTime := time.Now().Add(-48 * time.Hour)
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Football", Time: actTime, Duration: time.Hour},
+	}
+	edited, err := cl.EditActivity(1, server.JSONReqActivity{Label: "Basketball", Time: actTime, Duration: time.Hour})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if edited.ID != 1 || edited.Label != "Basketball" {
+		t.Fatalf("\nExpected edited label Basketball\nReturned: %+v", edited)
+	}
+	if _, err := cl.EditActivity(99, server.JSONReqActivity{Label: "Basketball", Time: actTime}); !errors.Is(err, store.ErrActivityNotFound) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", store.ErrActivityNotFound, err)
+	}
+}
+
+func TestDeleteActivity(t *testing.T) {
+	defer func() { repo.Trash = map[string]time.Time{} }() // Empty trash of deleted items
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Football", Time: time.Now().Add(-time.Hour)},
+		2: {ID: 2, Label: "Concert", Time: time.Now().Add(-time.Hour)},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Ticket", Time: time.Now().Add(-time.Hour), Value: 1000, Unit: "EUR", ActivityID: 2},
+	}
+	tests := map[string]struct {
+		id          domain.ActivityID
+		expectedErr error
+	}{
+		"Activity Without Expenses": {id: 1, expectedErr: nil},
+		"Activity With Expenses":    {id: 2, expectedErr: deleting.ErrActivityHasExpenses},
+		"Nonexistent Activity":      {id: 99, expectedErr: store.ErrActivityNotFound},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := cl.DeleteActivity(test.id); !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestPlannedActivity(t *testing.T) {
+	repo.Activities = map[domain.ActivityID]domain.Activity{}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	now := time.Now()
+	created, err := cl.AddActivity(server.JSONReqActivity{Label: "Football", Time: now.Add(24 * time.Hour), Duration: time.Hour, Status: domain.StatusPlanned})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	page, err := cl.Activities(client.TimeRange{}, client.PageOptions{Status: domain.StatusPlanned})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if page.Total != 1 || page.Items[0].ID != created.ID {
+		t.Fatalf("\nExpected the planned activity\nReturned: %+v", page)
+	}
+	completed, err := cl.CompleteActivity(created.ID)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if completed.Status != domain.StatusDone || completed.Time.Add(completed.Duration).After(time.Now()) {
+		t.Fatalf("\nExpected activity done by now\nReturned: %+v", completed)
+	}
+	if _, err := cl.CompleteActivity(created.ID); !errors.Is(err, domain.ErrStatusNotPlanned) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", domain.ErrStatusNotPlanned, err)
+	}
+}
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+// APIKeys returns the API keys of the user.
+func (c *Client) APIKeys() ([]server.JSONRespAPIKey, error) {
+	keys := []server.JSONRespAPIKey{}
+	err := c.do(http.MethodGet, "/auth/keys", nil, nil, &keys)
+	return keys, err
+}
+
+// AddAPIKey creates the given API key and returns it with the key,
+// which can not be retrieved again.
+func (c *Client) AddAPIKey(k server.JSONReqAPIKey) (server.JSONRespNewAPIKey, error) {
+	var created server.JSONRespNewAPIKey
+	err := c.do(http.MethodPost, "/auth/keys", nil, k, &created)
+	return created, err
+}
+
+// DeleteAPIKey revokes the API key with given ID.
+func (c *Client) DeleteAPIKey(id domain.APIKeyID) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/auth/keys/%d", id), nil, nil, nil)
+}
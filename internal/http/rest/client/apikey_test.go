@@ -0,0 +1,46 @@
+package client_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/client"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/usecase/auth"
+)
+
+func TestAPIKeys(t *testing.T) {
+	defer func() { repo.APIKeys = map[domain.APIKeyID]domain.APIKey{} }()
+	if _, err := cl.AddAPIKey(server.JSONReqAPIKey{Name: "script", Scope: "admin"}); !errors.Is(err, domain.ErrAPIKeyScope) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", domain.ErrAPIKeyScope, err)
+	}
+	created, err := cl.AddAPIKey(server.JSONReqAPIKey{Name: "dashboard", Scope: domain.APIKeyScopeRead})
+	if err != nil || created.Key == "" {
+		t.Fatalf("\nExpected a created key\nReturned: %+v, %v", created, err)
+	}
+	keys, err := cl.APIKeys()
+	if err != nil || len(keys) != 1 || keys[0].ID != created.ID {
+		t.Fatalf("\nExpected key %d\nReturned: %+v, %v", created.ID, keys, err)
+	}
+	// Client with the key only
+	withKey := client.New(srv.URL, nil)
+	withKey.SetAPIKey(created.Key)
+	if _, err := withKey.Tags(client.PageOptions{}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if _, err := withKey.AddTag(server.JSONReqTag{Name: "sport"}); !errors.Is(err, auth.ErrAPIKeyScope) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", auth.ErrAPIKeyScope, err)
+	}
+	// Revoke
+	if err := cl.DeleteAPIKey(created.ID); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := cl.DeleteAPIKey(created.ID); !errors.Is(err, store.ErrAPIKeyNotFound) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", store.ErrAPIKeyNotFound, err)
+	}
+	if _, err := withKey.Tags(client.PageOptions{}); !errors.Is(err, auth.ErrAPIKeyInvalid) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", auth.ErrAPIKeyInvalid, err)
+	}
+}
@@ -0,0 +1,23 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+	"github.com/elhamza90/lifelog/internal/usecase/archiving"
+)
+
+// Backup returns an archive of all tags, activities and expenses.
+func (c *Client) Backup() (archiving.Archive, error) {
+	var archive archiving.Archive
+	err := c.do(http.MethodGet, "/backup", nil, nil, &archive)
+	return archive, err
+}
+
+// Restore restores the given archive into the empty store of the API
+// and returns the number of restored entities.
+func (c *Client) Restore(archive archiving.Archive) (server.JSONRespRestore, error) {
+	var resp server.JSONRespRestore
+	err := c.do(http.MethodPost, "/restore", nil, archive, &resp)
+	return resp, err
+}
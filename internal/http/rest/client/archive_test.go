@@ -0,0 +1,52 @@
+package client_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/archiving"
+)
+
+func TestBackupRestore(t *testing.T) {
+	actTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "sport"},
+	}
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Football", Time: actTime, Duration: time.Hour, Tags: []domain.Tag{{ID: 1, Name: "sport"}}},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Ticket", Time: actTime, Value: 1000, Unit: "EUR", ActivityID: 1},
+	}
+	archive, err := cl.Backup()
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(archive.Tags) != 1 || len(archive.Activities) != 1 || len(archive.Expenses) != 1 {
+		t.Fatalf("\nExpected archive of 1 tag, 1 activity and 1 expense\nReturned: %+v", archive)
+	}
+	if _, err := cl.Restore(archive); !errors.Is(err, archiving.ErrStoreNotEmpty) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", archiving.ErrStoreNotEmpty, err)
+	}
+	// Empty Store & Restore
+	repo.Tags = map[domain.TagID]domain.Tag{}
+	repo.Activities = map[domain.ActivityID]domain.Activity{}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	resp, err := cl.Restore(archive)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if resp.Tags != 1 || resp.Activities != 1 || resp.Expenses != 1 {
+		t.Fatalf("\nExpected 1 restored entity of each kind\nReturned: %+v", resp)
+	}
+	if len(repo.Expenses) != 1 {
+		t.Fatalf("\nExpected 1 restored expense\nReturned: %v", repo.Expenses)
+	}
+	for _, exp := range repo.Expenses {
+		if act, ok := repo.Activities[exp.ActivityID]; !ok || act.Label != "Football" || exp.Value != 1000 {
+			t.Fatalf("\nExpected restored expense linked to its activity\nReturned: %+v", exp)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+// history returns the changes of the resource at given path, oldest first.
+func (c *Client) history(path string) ([]server.JSONRespAuditEvent, error) {
+	events := []server.JSONRespAuditEvent{}
+	err := c.do(http.MethodGet, path+"/history", nil, nil, &events)
+	return events, err
+}
+
+// TagHistory returns the changes of the tag with given ID, oldest first.
+func (c *Client) TagHistory(id domain.TagID) ([]server.JSONRespAuditEvent, error) {
+	return c.history(fmt.Sprintf("/tags/%d", id))
+}
+
+// ActivityHistory returns the changes of the activity with given ID, oldest first.
+func (c *Client) ActivityHistory(id domain.ActivityID) ([]server.JSONRespAuditEvent, error) {
+	return c.history(fmt.Sprintf("/activities/%d", id))
+}
+
+// ExpenseHistory returns the changes of the expense with given ID, oldest first.
+func (c *Client) ExpenseHistory(id domain.ExpenseID) ([]server.JSONRespAuditEvent, error) {
+	return c.history(fmt.Sprintf("/expenses/%d", id))
+}
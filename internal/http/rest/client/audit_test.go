@@ -0,0 +1,35 @@
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+func TestTagHistory(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	}()
+	created, err := cl.AddTag(server.JSONReqTag{Name: "sport"})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	time.Sleep(time.Millisecond) // Keep events ordered
+	if _, err := cl.EditTag(created.ID, server.JSONReqTag{Name: "sports"}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	events, err := cl.TagHistory(created.ID)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(events) != 2 || events[0].Action != "create" || events[1].Action != "update" {
+		t.Fatalf("\nExpected creation then update\nReturned: %+v", events)
+	}
+	if events[1].Principal == "" || events[0].Before != nil || events[1].Before == nil {
+		t.Fatalf("\nExpected principal & snapshots\nReturned: %+v", events[1])
+	}
+}
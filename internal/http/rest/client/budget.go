@@ -0,0 +1,49 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+// Budgets returns all budgets.
+func (c *Client) Budgets() ([]server.JSONRespBudget, error) {
+	budgets := []server.JSONRespBudget{}
+	err := c.do(http.MethodGet, "/budgets", nil, nil, &budgets)
+	return budgets, err
+}
+
+// Budget returns the budget with given ID.
+func (c *Client) Budget(id domain.BudgetID) (server.JSONRespBudget, error) {
+	var b server.JSONRespBudget
+	err := c.do(http.MethodGet, fmt.Sprintf("/budgets/%d", id), nil, nil, &b)
+	return b, err
+}
+
+// BudgetStatus returns the amount spent on the budget with given ID in its current period.
+func (c *Client) BudgetStatus(id domain.BudgetID) (server.JSONRespBudgetStatus, error) {
+	var st server.JSONRespBudgetStatus
+	err := c.do(http.MethodGet, fmt.Sprintf("/budgets/%d/status", id), nil, nil, &st)
+	return st, err
+}
+
+// AddBudget adds the given budget and returns it.
+func (c *Client) AddBudget(b server.JSONReqBudget) (server.JSONRespBudget, error) {
+	var created server.JSONRespBudget
+	err := c.do(http.MethodPost, "/budgets", nil, b, &created)
+	return created, err
+}
+
+// EditBudget edits the budget with given ID and returns it.
+func (c *Client) EditBudget(id domain.BudgetID, b server.JSONReqBudget) (server.JSONRespBudget, error) {
+	var edited server.JSONRespBudget
+	err := c.do(http.MethodPut, fmt.Sprintf("/budgets/%d", id), nil, b, &edited)
+	return edited, err
+}
+
+// DeleteBudget deletes the budget with given ID.
+func (c *Client) DeleteBudget(id domain.BudgetID) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/budgets/%d", id), nil, nil, nil)
+}
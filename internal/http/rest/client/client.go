@@ -0,0 +1,309 @@
+// Package client is a typed Go client of the lifelog REST API.
+// It reuses the json types of the server package and maps error
+// responses back to the sentinel errors of the domain, store and usecase packages.
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// Tokens are the access & refresh tokens returned by the API at login.
+type Tokens struct {
+	Access  string `json:"at"`
+	Refresh string `json:"rt"`
+}
+
+// Client calls the lifelog REST API.
+// When the access token is rejected, it is refreshed with the refresh token
+// and the call is retried once.
+// Routes accepting API keys can also be called with an API key ( see SetAPIKey ).
+type Client struct {
+	baseURL string
+	http    *http.Client
+	tokens  Tokens
+	apiKey  string
+	// OnRefresh is called with the new tokens after a successful refresh.
+	// It can be used to cache tokens. It is optional.
+	OnRefresh func(Tokens)
+}
+
+// New constructs & returns a client of the API at baseURL.
+// If httpClient is nil, http.DefaultClient is used.
+func New(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{baseURL: strings.TrimRight(baseURL, "/"), http: httpClient}
+}
+
+// Tokens returns the current tokens of the client.
+func (c *Client) Tokens() Tokens {
+	return c.tokens
+}
+
+// SetTokens sets tokens obtained previously ( ex: from a cache ).
+func (c *Client) SetTokens(tk Tokens) {
+	c.tokens = tk
+}
+
+// SetAPIKey sets the API key sent with every request.
+// Routes which do not accept API keys still require tokens.
+func (c *Client) SetAPIKey(key string) {
+	c.apiKey = key
+}
+
+// HealthCheck checks the API is up and running.
+func (c *Client) HealthCheck() error {
+	status, content, err := c.send(http.MethodGet, "/health-check", nil, "", nil)
+	if err != nil {
+		return err
+	}
+	return responseError(status, content)
+}
+
+// Register creates a user account with the given name and password.
+func (c *Client) Register(name string, password string) (domain.User, error) {
+	body, err := json.Marshal(map[string]string{"name": name, "password": password})
+	if err != nil {
+		return domain.User{}, err
+	}
+	status, content, err := c.send(http.MethodPost, "/auth/register", nil, jsonContentType, body)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if err := responseError(status, content); err != nil {
+		return domain.User{}, err
+	}
+	var res struct {
+		ID   domain.UserID `json:"id"`
+		Name string        `json:"name"`
+	}
+	if err := json.Unmarshal(content, &res); err != nil {
+		return domain.User{}, err
+	}
+	return domain.User{ID: res.ID, Name: res.Name}, nil
+}
+
+// Login authenticates the user with the given name and password
+// and keeps the returned tokens.
+// Tokens are restricted to the given scopes ( if any ).
+func (c *Client) Login(name string, password string, scopes ...domain.Scope) (Tokens, error) {
+	req := map[string]interface{}{"name": name, "password": password}
+	if len(scopes) > 0 {
+		req["scopes"] = scopes
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return Tokens{}, err
+	}
+	status, content, err := c.send(http.MethodPost, "/auth/login", nil, jsonContentType, body)
+	if err != nil {
+		return Tokens{}, err
+	}
+	if err := responseError(status, content); err != nil {
+		return Tokens{}, err
+	}
+	var tk Tokens
+	if err := json.Unmarshal(content, &tk); err != nil {
+		return Tokens{}, err
+	}
+	c.tokens = tk
+	return tk, nil
+}
+
+// Refresh gets new access & refresh tokens with the refresh token.
+// The previous refresh token can not be used again.
+func (c *Client) Refresh() error {
+	if c.tokens.Refresh == "" {
+		return ErrUnauthorized
+	}
+	body, err := json.Marshal(map[string]string{"refresh": c.tokens.Refresh})
+	if err != nil {
+		return err
+	}
+	status, content, err := c.send(http.MethodPost, "/auth/refresh", nil, jsonContentType, body)
+	if err != nil {
+		return err
+	}
+	if err := responseError(status, content); err != nil {
+		return err
+	}
+	var tk Tokens
+	if err := json.Unmarshal(content, &tk); err != nil {
+		return err
+	}
+	c.tokens.Access = tk.Access
+	if tk.Refresh != "" {
+		c.tokens.Refresh = tk.Refresh
+	}
+	if c.OnRefresh != nil {
+		c.OnRefresh(c.tokens)
+	}
+	return nil
+}
+
+// Logout revokes the refresh token of this session on the server
+// and forgets the tokens.
+func (c *Client) Logout() error {
+	if c.tokens.Refresh == "" {
+		return ErrUnauthorized
+	}
+	body, err := json.Marshal(map[string]string{"refresh": c.tokens.Refresh})
+	if err != nil {
+		return err
+	}
+	status, content, err := c.send(http.MethodPost, "/auth/logout", nil, jsonContentType, body)
+	if err != nil {
+		return err
+	}
+	if err := responseError(status, content); err != nil {
+		return err
+	}
+	c.tokens = Tokens{}
+	return nil
+}
+
+// LogoutAll revokes all refresh tokens of the user on the server
+// and forgets the tokens.
+func (c *Client) LogoutAll() error {
+	if err := c.do(http.MethodPost, "/auth/logout-all", nil, nil, nil); err != nil {
+		return err
+	}
+	c.tokens = Tokens{}
+	return nil
+}
+
+// jsonContentType is the content type of json request bodies.
+const jsonContentType string = "application/json"
+
+// csvContentType is the content type of csv request bodies.
+const csvContentType string = "text/csv"
+
+// send sends a request with given body ( if not nil ) and access token.
+// It returns the response status and body.
+func (c *Client) send(method string, path string, query url.Values, contentType string, body []byte) (int, []byte, error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.tokens.Access != "" {
+		req.Header.Set("Authorization", "Bearer "+c.tokens.Access)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	content, err := ioutil.ReadAll(resp.Body)
+	return resp.StatusCode, content, err
+}
+
+// doRaw calls an authenticated route and returns the response body.
+// When the access token is rejected, it is refreshed and the call is retried once.
+func (c *Client) doRaw(method string, path string, query url.Values, contentType string, body []byte) ([]byte, error) {
+	status, content, err := c.send(method, path, query, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusUnauthorized && c.tokens.Refresh != "" {
+		if err := c.Refresh(); err != nil {
+			return nil, err
+		}
+		if status, content, err = c.send(method, path, query, contentType, body); err != nil {
+			return nil, err
+		}
+	}
+	if err := responseError(status, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// do calls an authenticated route with given json body ( if not nil )
+// and unmarshals the json response in out ( if not nil ).
+func (c *Client) do(method string, path string, query url.Values, in interface{}, out interface{}) error {
+	var (
+		body        []byte
+		contentType string
+		err         error
+	)
+	if in != nil {
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+		contentType = jsonContentType
+	}
+	content, err := c.doRaw(method, path, query, contentType, body)
+	if err != nil || out == nil {
+		return err
+	}
+	return json.Unmarshal(content, out)
+}
+
+// PageOptions specifies the page of a list to fetch.
+// Zero values are left to the defaults of the API.
+type PageOptions struct {
+	Limit  int
+	Cursor string
+	Sort   store.SortField
+	// Order is asc or desc
+	Order string
+	// Status filters activities & expenses ( empty for all )
+	Status domain.Status
+}
+
+// values adds the pagination query params to q.
+func (p PageOptions) values(q url.Values) {
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Cursor != "" {
+		q.Set("cursor", p.Cursor)
+	}
+	if p.Sort != "" {
+		q.Set("sort", string(p.Sort))
+	}
+	if p.Order != "" {
+		q.Set("order", p.Order)
+	}
+	if p.Status != "" {
+		q.Set("status", string(p.Status))
+	}
+}
+
+// TimeRange specifies the range of a list or a report.
+// Zero values are left to the defaults of the API.
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// values adds the from/to query params to q.
+func (r TimeRange) values(q url.Values) {
+	if !r.From.IsZero() {
+		q.Set("from", r.From.Format(time.RFC3339Nano))
+	}
+	if !r.To.IsZero() {
+		q.Set("to", r.To.Format(time.RFC3339Nano))
+	}
+}
@@ -0,0 +1,220 @@
+package client_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/client"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+	"github.com/elhamza90/lifelog/internal/store/memory"
+	"github.com/elhamza90/lifelog/internal/usecase/adding"
+	"github.com/elhamza90/lifelog/internal/usecase/archiving"
+	"github.com/elhamza90/lifelog/internal/usecase/auditing"
+	"github.com/elhamza90/lifelog/internal/usecase/auth"
+	"github.com/elhamza90/lifelog/internal/usecase/deleting"
+	"github.com/elhamza90/lifelog/internal/usecase/editing"
+	"github.com/elhamza90/lifelog/internal/usecase/exchanging"
+	"github.com/elhamza90/lifelog/internal/usecase/listing"
+	"github.com/elhamza90/lifelog/internal/usecase/reporting"
+	"github.com/elhamza90/lifelog/internal/usecase/scheduling"
+	"github.com/elhamza90/lifelog/internal/usecase/searching"
+	"github.com/elhamza90/lifelog/internal/usecase/throttling"
+	"github.com/elhamza90/lifelog/internal/usecase/transferring"
+	"github.com/elhamza90/lifelog/internal/usecase/trashing"
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	srv  *httptest.Server
+	repo memory.Repository
+	cl   *client.Client
+)
+
+// user & password are the credentials of the user of the testing server
+const (
+	user     string = "client"
+	password string = "client-password"
+)
+
+func TestMain(m *testing.M) {
+	log.SetLevel(log.ErrorLevel)
+	// Define JWT Secrets in Env Vars
+	os.Setenv("LFLG_JWT_ACCESS_SECRET", "test-access-secret")
+	os.Setenv("LFLG_JWT_REFRESH_SECRET", "test-refresh-secret")
+	// Init Interactors and Repository
+	repo = memory.NewRepository()
+	lister := listing.NewService(&repo)
+	adder := adding.NewService(&repo, domain.DefaultPolicy())
+	editor := editing.NewService(&repo, domain.DefaultPolicy())
+	deletor := deleting.NewService(&repo)
+	authenticator := auth.NewService(&repo)
+	reporter := reporting.NewService(&repo)
+	searcher := searching.NewService(&repo)
+	exchanger := exchanging.NewService(&repo)
+	scheduler := scheduling.NewService(&repo, domain.DefaultPolicy())
+	transferrer := transferring.NewService(&repo, domain.DefaultPolicy())
+	archiver := archiving.NewService(&repo, domain.DefaultPolicy())
+	trasher := trashing.NewService(&repo)
+	auditor := auditing.NewService(&repo)
+	throttlingConfig := throttling.DefaultConfig()
+	throttlingConfig.Requests = 0
+	throttler := throttling.NewService(memory.Throttles{}, nil, throttlingConfig)
+	hnd := server.NewHandler(&lister, &adder, &editor, &deletor, &authenticator, &reporter, &searcher, &exchanger, &scheduler, &transferrer, &archiver, &trasher, &auditor, &throttler)
+	// Tests seed the repository directly so all users share its data
+	forUser := func(domain.UserID) *server.Handler { return hnd }
+	// Init Router & Test Server
+	router := echo.New()
+	if err := server.RegisterRoutes(router, hnd, forUser); err != nil {
+		os.Exit(1)
+	}
+	srv = httptest.NewServer(router)
+	// Init Logged In Client
+	cl = client.New(srv.URL, nil)
+	if _, err := cl.Register(user, password); err != nil {
+		os.Exit(1)
+	}
+	if _, err := cl.Login(user, password); err != nil {
+		os.Exit(1)
+	}
+	code := m.Run()
+	srv.Close()
+	os.Exit(code)
+}
+
+func TestRegister(t *testing.T) {
+	tests := map[string]struct {
+		name        string
+		expectedErr error
+	}{
+		"Duplicate Name": {name: user, expectedErr: domain.ErrUserNameDuplicate},
+		"Invalid Name":   {name: "a b", expectedErr: domain.ErrUserNameInvalidCharacters},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := cl.Register(test.name, password); !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	tests := map[string]struct {
+		user        string
+		password    string
+		expectedErr error
+	}{
+		"Correct Password":   {user: user, password: password, expectedErr: nil},
+		"Incorrect Password": {user: user, password: "wrong-password", expectedErr: auth.ErrIncorrectCredentials},
+		"Unknown User":       {user: "someone", password: password, expectedErr: auth.ErrIncorrectCredentials},
+		"Short Password":     {user: user, password: "short", expectedErr: auth.ErrPasswordLength},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := client.New(srv.URL, nil)
+			tk, err := c.Login(test.user, test.password)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+			if err == nil && (tk.Access == "" || tk.Refresh == "") {
+				t.Fatalf("\nExpected access and refresh tokens\nReturned: %+v", tk)
+			}
+		})
+	}
+}
+
+func TestRefreshOnUnauthorized(t *testing.T) {
+	c := client.New(srv.URL, nil)
+	if _, err := c.Login(user, password); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	var refreshed client.Tokens
+	c.OnRefresh = func(tk client.Tokens) { refreshed = tk }
+	// Invalidate Access Token
+	tk := c.Tokens()
+	tk.Access = "expired"
+	c.SetTokens(tk)
+	if _, err := c.Tags(client.PageOptions{}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if refreshed.Access == "" || refreshed.Access == "expired" {
+		t.Fatalf("\nExpected a new access token\nReturned: %q", refreshed.Access)
+	}
+	// Invalidate Refresh Token too
+	c.SetTokens(client.Tokens{Access: "expired", Refresh: "expired"})
+	_, err := c.Tags(client.PageOptions{})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnprocessableEntity || apiErr.Code != "refresh_token_invalid" {
+		t.Fatalf("\nExpected refresh to be rejected\nReturned Err: %v", err)
+	}
+	// Without tokens
+	_, err = client.New(srv.URL, nil).Tags(client.PageOptions{})
+	if !errors.Is(err, client.ErrBadRequest) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", client.ErrBadRequest, err)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	// Another user, so that the sessions of the shared client stay valid
+	const other string = "logout"
+	if _, err := cl.Register(other, password); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	first, second := client.New(srv.URL, nil), client.New(srv.URL, nil)
+	for _, c := range []*client.Client{first, second} {
+		if _, err := c.Login(other, password); err != nil {
+			t.Fatalf("\nUnexpected Error: %v", err)
+		}
+	}
+	tk := first.Tokens()
+	if err := first.Logout(); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if first.Tokens() != (client.Tokens{}) {
+		t.Fatalf("\nExpected tokens to be forgotten\nReturned: %+v", first.Tokens())
+	}
+	// The revoked refresh token can not be used anymore
+	first.SetTokens(tk)
+	if err := first.Refresh(); !errors.Is(err, auth.ErrRefreshTokenRevoked) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", auth.ErrRefreshTokenRevoked, err)
+	}
+	// Other sessions are revoked by LogoutAll only
+	if err := second.Refresh(); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	tk = second.Tokens()
+	if err := second.LogoutAll(); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	second.SetTokens(tk)
+	if err := second.Refresh(); !errors.Is(err, auth.ErrRefreshTokenRevoked) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", auth.ErrRefreshTokenRevoked, err)
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	if err := client.New(srv.URL, nil).HealthCheck(); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+}
+
+func TestScopedLogin(t *testing.T) {
+	if _, err := client.New(srv.URL, nil).Login(user, password, "users:read"); !errors.Is(err, domain.ErrScopeInvalid) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", domain.ErrScopeInvalid, err)
+	}
+	c := client.New(srv.URL, nil)
+	if _, err := c.Login(user, password, "tags:read"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if _, err := c.Tags(client.PageOptions{}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if _, err := c.Budgets(); !errors.Is(err, domain.ErrScopeInsufficient) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", domain.ErrScopeInsufficient, err)
+	}
+}
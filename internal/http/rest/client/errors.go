@@ -0,0 +1,208 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/usecase/archiving"
+	"github.com/elhamza90/lifelog/internal/usecase/auth"
+	"github.com/elhamza90/lifelog/internal/usecase/deleting"
+	"github.com/elhamza90/lifelog/internal/usecase/exchanging"
+	"github.com/elhamza90/lifelog/internal/usecase/listing"
+	"github.com/elhamza90/lifelog/internal/usecase/reporting"
+	"github.com/elhamza90/lifelog/internal/usecase/scheduling"
+	"github.com/elhamza90/lifelog/internal/usecase/searching"
+	"github.com/elhamza90/lifelog/internal/usecase/throttling"
+	"github.com/elhamza90/lifelog/internal/usecase/transferring"
+)
+
+// Errors returned when the API responds with an error
+// that can not be mapped to a more specific one.
+var (
+	ErrBadRequest      error = errors.New("Request is invalid")
+	ErrUnauthorized    error = errors.New("Request is not authenticated")
+	ErrForbidden       error = errors.New("Request is not allowed")
+	ErrNotFound        error = errors.New("Resource not found")
+	ErrConflict        error = errors.New("Request conflicts with the current state")
+	ErrUnprocessable   error = errors.New("Request can not be processed")
+	ErrTooManyRequests error = errors.New("Too many requests")
+	ErrServer          error = errors.New("Internal server error")
+)
+
+// APIError is returned when the API responds with an error status.
+// Code & Field are the code of the error and the invalid input field ( if any ).
+// Err is the sentinel error the response was mapped to,
+// so errors.Is can be used to check it.
+// Errors are the violations of a failed validation.
+type APIError struct {
+	Status  int
+	Message string
+	Code    string
+	Field   string
+	Err     error
+	Errors  []*APIError
+}
+
+// Error returns the message of the API and the status.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s ( HTTP %d )", e.Message, e.Status)
+}
+
+// Unwrap returns the sentinel error of the response.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether one of the violations matches target,
+// so a violation can be checked with errors.Is.
+func (e *APIError) Is(target error) bool {
+	for _, violation := range e.Errors {
+		if errors.Is(violation, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// knownErrors are the errors the API identifies by their code in the response body.
+var knownErrors = []error{
+	// domain errors
+	domain.ErrValidation,
+	domain.ErrTagNameDuplicate,
+	domain.ErrTagNameLen,
+	domain.ErrTagNameInvalidCharacters,
+	domain.ErrActivityTimeFuture,
+	domain.ErrActivityLabelLength,
+	domain.ErrActivityPlaceLength,
+	domain.ErrActivityDescLength,
+	domain.ErrExpenseLabelLength,
+	domain.ErrExpenseValue,
+	domain.ErrExpenseTimeFuture,
+	domain.ErrCurrencyInvalid,
+	domain.ErrStatusInvalid,
+	domain.ErrStatusNotPlanned,
+	domain.ErrPeriodInvalid,
+	domain.ErrExchangeRateValue,
+	domain.ErrAmountFormat,
+	domain.ErrAmountPrecision,
+	domain.ErrBudgetAmount,
+	domain.ErrTemplateKind,
+	domain.ErrRecurrenceFreq,
+	domain.ErrRecurrenceInterval,
+	domain.ErrRecurrenceUntil,
+	domain.ErrUserNameLength,
+	domain.ErrUserNameInvalidCharacters,
+	domain.ErrUserNameDuplicate,
+	domain.ErrAPIKeyNameLength,
+	domain.ErrAPIKeyScope,
+	domain.ErrScopeInvalid,
+	domain.ErrScopeInsufficient,
+	// store errors
+	store.ErrCursorInvalid,
+	store.ErrTagNotFound,
+	store.ErrActivityNotFound,
+	store.ErrExpenseNotFound,
+	store.ErrBudgetNotFound,
+	store.ErrTemplateNotFound,
+	store.ErrRateNotFound,
+	store.ErrOccurrenceExists,
+	store.ErrTrashItemNotFound,
+	store.ErrUserNotFound,
+	store.ErrRefreshTokenNotFound,
+	store.ErrRefreshTokenUsed,
+	store.ErrAPIKeyNotFound,
+	// usecase errors
+	auth.ErrPasswordLength,
+	auth.ErrIncorrectCredentials,
+	auth.ErrRefreshTokenRevoked,
+	auth.ErrRefreshTokenReused,
+	auth.ErrAPIKeyInvalid,
+	auth.ErrAPIKeyScope,
+	auth.ErrAPIKeyExpiry,
+	deleting.ErrTagHasExpenses,
+	deleting.ErrTagHasActivities,
+	deleting.ErrTagHasBudgets,
+	deleting.ErrActivityHasExpenses,
+	listing.ErrTimeRange,
+	listing.ErrPageLimit,
+	listing.ErrSortField,
+	reporting.ErrReportRange,
+	searching.ErrQueryEmpty,
+	searching.ErrQueryLength,
+	searching.ErrSearchLimit,
+	exchanging.ErrRatesFormat,
+	exchanging.ErrRatesEmpty,
+	scheduling.ErrUpcomingLimit,
+	scheduling.ErrOccurrenceInvalid,
+	transferring.ErrCSVHeader,
+	archiving.ErrArchiveVersion,
+	archiving.ErrArchiveIDs,
+	archiving.ErrArchiveReference,
+	archiving.ErrStoreNotEmpty,
+	throttling.ErrRateLimited,
+	throttling.ErrLockedOut,
+}
+
+// statusErrors maps error statuses to the errors returned
+// when the response body does not specify a known error.
+var statusErrors = map[int]error{
+	http.StatusBadRequest:          ErrBadRequest,
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusForbidden:           ErrForbidden,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusConflict:            ErrConflict,
+	http.StatusUnprocessableEntity: ErrUnprocessable,
+	http.StatusTooManyRequests:     ErrTooManyRequests,
+}
+
+// responseError returns nil if the status is a success.
+// Otherwise it returns an *APIError wrapping:
+//	- the known error with the code of the response problem
+//	- the error of the status
+func responseError(status int, body []byte) error {
+	if status < 300 {
+		return nil
+	}
+	apiErr := &APIError{Status: status, Message: strings.TrimSpace(string(body))}
+	var problem server.JSONProblem
+	if err := json.Unmarshal(body, &problem); err == nil && problem.Code != "" {
+		apiErr.Message = problem.Detail
+		apiErr.Code = problem.Code
+		apiErr.Field = problem.Field
+		apiErr.Err = knownError(problem.Code)
+		for _, fieldErr := range problem.Errors {
+			apiErr.Errors = append(apiErr.Errors, &APIError{
+				Status:  status,
+				Message: fieldErr.Detail,
+				Code:    fieldErr.Code,
+				Field:   fieldErr.Field,
+				Err:     knownError(fieldErr.Code),
+			})
+		}
+	}
+	if apiErr.Err != nil {
+		return apiErr
+	}
+	if err, ok := statusErrors[status]; ok {
+		apiErr.Err = err
+	} else {
+		apiErr.Err = ErrServer
+	}
+	return apiErr
+}
+
+// knownError returns the known error with given code or nil.
+func knownError(code string) error {
+	for _, known := range knownErrors {
+		if errors.Is(known, &domain.Error{Code: code}) {
+			return known
+		}
+	}
+	return nil
+}
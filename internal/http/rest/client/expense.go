@@ -0,0 +1,72 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+// ExpensesPage is a page of expenses.
+type ExpensesPage struct {
+	Items []server.JSONRespListExpense `json:"items"`
+	Next  string                       `json:"next"`
+	Total int                          `json:"total"`
+}
+
+// currencyQuery returns the query params converting values to cur ( if not empty ).
+func currencyQuery(cur domain.Currency) url.Values {
+	q := url.Values{}
+	if cur != "" {
+		q.Set("currency", string(cur))
+	}
+	return q
+}
+
+// Expenses returns the requested page of expenses in the given range.
+// If cur is not empty, values are converted to this currency.
+func (c *Client) Expenses(r TimeRange, cur domain.Currency, p PageOptions) (ExpensesPage, error) {
+	q := currencyQuery(cur)
+	r.values(q)
+	p.values(q)
+	var page ExpensesPage
+	err := c.do(http.MethodGet, "/expenses", q, nil, &page)
+	return page, err
+}
+
+// Expense returns details of the expense with given ID.
+// If cur is not empty, the value is converted to this currency.
+func (c *Client) Expense(id domain.ExpenseID, cur domain.Currency) (server.JSONRespDetailExpense, error) {
+	var exp server.JSONRespDetailExpense
+	err := c.do(http.MethodGet, fmt.Sprintf("/expenses/%d", id), currencyQuery(cur), nil, &exp)
+	return exp, err
+}
+
+// AddExpense adds the given expense and returns it.
+func (c *Client) AddExpense(exp server.JSONReqExpense) (server.JSONRespDetailExpense, error) {
+	var created server.JSONRespDetailExpense
+	err := c.do(http.MethodPost, "/expenses", nil, exp, &created)
+	return created, err
+}
+
+// EditExpense edits the expense with given ID and returns it.
+func (c *Client) EditExpense(id domain.ExpenseID, exp server.JSONReqExpense) (server.JSONRespDetailExpense, error) {
+	exp.ID = id
+	var edited server.JSONRespDetailExpense
+	err := c.do(http.MethodPut, fmt.Sprintf("/expenses/%d", id), nil, exp, &edited)
+	return edited, err
+}
+
+// CompleteExpense marks the planned expense with given ID as done and returns it.
+func (c *Client) CompleteExpense(id domain.ExpenseID) (server.JSONRespDetailExpense, error) {
+	var exp server.JSONRespDetailExpense
+	err := c.do(http.MethodPost, fmt.Sprintf("/expenses/%d/complete", id), nil, nil, &exp)
+	return exp, err
+}
+
+// DeleteExpense deletes the expense with given ID.
+func (c *Client) DeleteExpense(id domain.ExpenseID) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/expenses/%d", id), nil, nil, nil)
+}
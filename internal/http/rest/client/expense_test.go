@@ -0,0 +1,104 @@
+package client_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/client"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+func TestAddExpense(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "food"},
+	}
+	repo.Activities = map[domain.ActivityID]domain.Activity{}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	expTime := time.Now().Add(-time.Hour)
+	tests := map[string]struct {
+		exp         server.JSONReqExpense
+		expectedErr error
+	}{
+		"Valid Expense": {
+			exp:         server.JSONReqExpense{Label: "Lunch", Time: expTime, Value: "12.50", Unit: "EUR", TagIds: []domain.TagID{1}},
+			expectedErr: nil,
+		},
+		"Invalid Amount": {
+			exp:         server.JSONReqExpense{Label: "Lunch", Time: expTime, Value: "12.505", Unit: "EUR"},
+			expectedErr: domain.ErrAmountPrecision,
+		},
+		"Invalid Currency": {
+			exp:         server.JSONReqExpense{Label: "Lunch", Time: expTime, Value: "12.50", Unit: "euro"},
+			expectedErr: domain.ErrCurrencyInvalid,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			created, err := cl.AddExpense(test.exp)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if created.ID == 0 || created.Value != "12.50" || created.Unit != "EUR" || len(created.Tags) != 1 {
+				t.Fatalf("\nExpected created expense with its tag\nReturned: %+v", created)
+			}
+		})
+	}
+}
+
+func TestExpenses(t *testing.T) {
+	expTime := time.Now().Add(-24 * time.Hour)
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "food"},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Lunch", Time: expTime, Value: 1250, Unit: "EUR", Tags: []domain.Tag{{ID: 1, Name: "food"}}},
+		2: {ID: 2, Label: "Dinner", Time: expTime.Add(time.Hour), Value: 3000, Unit: "EUR"},
+	}
+	page, err := cl.Expenses(client.TimeRange{From: expTime.Add(-time.Hour)}, "", client.PageOptions{Sort: store.SortByValue, Order: "asc"})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(page.Items) != 2 || page.Items[0].ID != 1 || page.Items[0].Value != "12.50" {
+		t.Fatalf("\nExpected expenses sorted by value\nReturned: %+v", page)
+	}
+	tagPage, err := cl.TagExpenses(1, "", client.PageOptions{})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(tagPage.Items) != 1 || tagPage.Items[0].ID != 1 {
+		t.Fatalf("\nExpected expense of tag\nReturned: %+v", tagPage)
+	}
+	if _, err := cl.Expenses(client.TimeRange{}, "euro", client.PageOptions{}); !errors.Is(err, domain.ErrCurrencyInvalid) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", domain.ErrCurrencyInvalid, err)
+	}
+}
+
+func TestEditExpense(t *testing.T) {
+	expTime := time.Now().Add(-time.Hour)
+	repo.Activities = map[domain.ActivityID]domain.Activity{}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Lunch", Time: expTime, Value: 1250, Unit: "EUR"},
+	}
+	edited, err := cl.EditExpense(1, server.JSONReqExpense{Label: "Brunch", Time: expTime, Value: "15", Unit: "EUR"})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if edited.Label != "Brunch" || edited.Value != "15.00" {
+		t.Fatalf("\nExpected edited expense\nReturned: %+v", edited)
+	}
+	if _, err := cl.Expense(99, ""); !errors.Is(err, store.ErrExpenseNotFound) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", store.ErrExpenseNotFound, err)
+	}
+	if err := cl.DeleteExpense(1); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := cl.DeleteExpense(1); !errors.Is(err, store.ErrExpenseNotFound) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", store.ErrExpenseNotFound, err)
+	}
+}
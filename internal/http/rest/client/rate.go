@@ -0,0 +1,44 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+// ExchangeRate returns the rate of base in quote at time t.
+// If t is zero, the current rate is returned.
+func (c *Client) ExchangeRate(base domain.Currency, quote domain.Currency, t time.Time) (server.JSONRespExchangeRate, error) {
+	q := url.Values{}
+	q.Set("base", string(base))
+	q.Set("quote", string(quote))
+	if !t.IsZero() {
+		q.Set("time", t.Format(time.RFC3339))
+	}
+	var rate server.JSONRespExchangeRate
+	err := c.do(http.MethodGet, "/rates", q, nil, &rate)
+	return rate, err
+}
+
+// ImportExchangeRates imports the exchange rates read from r
+// ( a CSV file with columns date,base,quote,rate or an ECB reference rates file )
+// and returns the number of imported rates.
+func (c *Client) ImportExchangeRates(r io.Reader) (int, error) {
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	content, err := c.doRaw(http.MethodPost, "/rates/import", nil, csvContentType, body)
+	if err != nil {
+		return 0, err
+	}
+	var resp server.JSONRespImportRates
+	err = json.Unmarshal(content, &resp)
+	return resp.Imported, err
+}
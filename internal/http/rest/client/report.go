@@ -0,0 +1,87 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+// ReportOptions specifies a report. Zero values are left to the defaults of the API.
+//	- Range: range of the report
+//	- Period: grouping period ( expenses by period only )
+//	- Tag: tag to filter expenses with ( expenses by period only )
+//	- Currency: currency to convert expenses to ( expenses reports only )
+type ReportOptions struct {
+	Range    TimeRange
+	Period   domain.Period
+	Tag      domain.TagID
+	Currency domain.Currency
+}
+
+// values returns the query params of the report.
+func (opts ReportOptions) values() url.Values {
+	q := url.Values{}
+	opts.Range.values(q)
+	if opts.Period != "" {
+		q.Set("period", string(opts.Period))
+	}
+	if opts.Tag != 0 {
+		q.Set("tag", strconv.Itoa(int(opts.Tag)))
+	}
+	if opts.Currency != "" {
+		q.Set("currency", string(opts.Currency))
+	}
+	return q
+}
+
+// expensesReport fetches the expenses report at given path.
+func (c *Client) expensesReport(path string, opts ReportOptions) ([]server.JSONRespExpenseTotal, error) {
+	totals := []server.JSONRespExpenseTotal{}
+	err := c.do(http.MethodGet, path, opts.values(), nil, &totals)
+	return totals, err
+}
+
+// activitiesReport fetches the activities report at given path.
+func (c *Client) activitiesReport(path string, opts ReportOptions) ([]server.JSONRespActivityTotal, error) {
+	totals := []server.JSONRespActivityTotal{}
+	err := c.do(http.MethodGet, path, opts.values(), nil, &totals)
+	return totals, err
+}
+
+// ExpensesReportByPeriod returns sums of expenses grouped by period and unit.
+func (c *Client) ExpensesReportByPeriod(opts ReportOptions) ([]server.JSONRespExpenseTotal, error) {
+	return c.expensesReport("/reports/expenses/by-period", opts)
+}
+
+// ExpensesReportByTag returns sums of expenses grouped by tag and unit.
+func (c *Client) ExpensesReportByTag(opts ReportOptions) ([]server.JSONRespExpenseTotal, error) {
+	return c.expensesReport("/reports/expenses/by-tag", opts)
+}
+
+// ExpensesReportByUnit returns sums of expenses grouped by unit.
+func (c *Client) ExpensesReportByUnit(opts ReportOptions) ([]server.JSONRespExpenseTotal, error) {
+	return c.expensesReport("/reports/expenses/by-unit", opts)
+}
+
+// ActivitiesReportByTag returns durations of activities grouped by tag.
+func (c *Client) ActivitiesReportByTag(opts ReportOptions) ([]server.JSONRespActivityTotal, error) {
+	return c.activitiesReport("/reports/activities/by-tag", opts)
+}
+
+// ActivitiesReportByPlace returns durations of activities grouped by place.
+func (c *Client) ActivitiesReportByPlace(opts ReportOptions) ([]server.JSONRespActivityTotal, error) {
+	return c.activitiesReport("/reports/activities/by-place", opts)
+}
+
+// ActivitiesReportByWeekday returns durations of activities grouped by weekday.
+func (c *Client) ActivitiesReportByWeekday(opts ReportOptions) ([]server.JSONRespActivityTotal, error) {
+	return c.activitiesReport("/reports/activities/by-weekday", opts)
+}
+
+// ActivitiesReportByHour returns durations of activities grouped by hour of the day.
+func (c *Client) ActivitiesReportByHour(opts ReportOptions) ([]server.JSONRespActivityTotal, error) {
+	return c.activitiesReport("/reports/activities/by-hour", opts)
+}
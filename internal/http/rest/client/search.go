@@ -0,0 +1,23 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+// Search returns activities and expenses matching the text query q
+// ordered from best to worst match.
+// If limit is zero, the default number of the API is returned.
+func (c *Client) Search(q string, limit int) ([]server.JSONRespSearchResult, error) {
+	query := url.Values{}
+	query.Set("q", q)
+	if limit != 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	results := []server.JSONRespSearchResult{}
+	err := c.do(http.MethodGet, "/search", query, nil, &results)
+	return results, err
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+// TagsPage is a page of tags.
+type TagsPage struct {
+	Items []server.JSONRespListTag `json:"items"`
+	Next  string                   `json:"next"`
+	Total int                      `json:"total"`
+}
+
+// Tags returns the requested page of tags.
+func (c *Client) Tags(p PageOptions) (TagsPage, error) {
+	q := url.Values{}
+	p.values(q)
+	var page TagsPage
+	err := c.do(http.MethodGet, "/tags", q, nil, &page)
+	return page, err
+}
+
+// TagExpenses returns the requested page of expenses of the tag with given ID.
+// If cur is not empty, values are converted to this currency.
+func (c *Client) TagExpenses(id domain.TagID, cur domain.Currency, p PageOptions) (ExpensesPage, error) {
+	q := url.Values{}
+	p.values(q)
+	if cur != "" {
+		q.Set("currency", string(cur))
+	}
+	var page ExpensesPage
+	err := c.do(http.MethodGet, fmt.Sprintf("/tags/%d/expenses", id), q, nil, &page)
+	return page, err
+}
+
+// TagActivities returns the requested page of activities of the tag with given ID.
+func (c *Client) TagActivities(id domain.TagID, p PageOptions) (ActivitiesPage, error) {
+	q := url.Values{}
+	p.values(q)
+	var page ActivitiesPage
+	err := c.do(http.MethodGet, fmt.Sprintf("/tags/%d/activities", id), q, nil, &page)
+	return page, err
+}
+
+// AddTag adds the given tag and returns it.
+func (c *Client) AddTag(t server.JSONReqTag) (domain.Tag, error) {
+	var created domain.Tag
+	err := c.do(http.MethodPost, "/tags", nil, t, &created)
+	return created, err
+}
+
+// EditTag edits the tag with given ID and returns it.
+func (c *Client) EditTag(id domain.TagID, t server.JSONReqTag) (domain.Tag, error) {
+	var edited domain.Tag
+	err := c.do(http.MethodPut, fmt.Sprintf("/tags/%d", id), nil, t, &edited)
+	return edited, err
+}
+
+// DeleteTag deletes the tag with given ID.
+func (c *Client) DeleteTag(id domain.TagID) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/tags/%d", id), nil, nil, nil)
+}
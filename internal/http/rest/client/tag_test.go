@@ -0,0 +1,106 @@
+package client_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/client"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/usecase/deleting"
+)
+
+func TestAddTag(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "existing"},
+	}
+	tests := map[string]struct {
+		name        string
+		expectedErr error
+	}{
+		"Valid Tag":         {name: "sport", expectedErr: nil},
+		"Duplicate Name":    {name: "existing", expectedErr: domain.ErrTagNameDuplicate},
+		"Invalid Character": {name: "sp*rt", expectedErr: domain.ErrTagNameInvalidCharacters},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			created, err := cl.AddTag(server.JSONReqTag{Name: test.name})
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+			if err == nil && (created.ID == 0 || created.Name != test.name) {
+				t.Fatalf("\nExpected created tag %q\nReturned: %+v", test.name, created)
+			}
+		})
+	}
+}
+
+func TestTags(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "a"},
+		2: {ID: 2, Name: "b"},
+		3: {ID: 3, Name: "c"},
+	}
+	page, err := cl.Tags(client.PageOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(page.Items) != 2 || page.Total != 3 || page.Next == "" {
+		t.Fatalf("\nExpected first page of 2 tags out of 3\nReturned: %+v", page)
+	}
+	page, err = cl.Tags(client.PageOptions{Limit: 2, Cursor: page.Next})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Name != "c" || page.Next != "" {
+		t.Fatalf("\nExpected last page with tag c\nReturned: %+v", page)
+	}
+	if _, err := cl.Tags(client.PageOptions{Cursor: "invalid"}); !errors.Is(err, store.ErrCursorInvalid) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", store.ErrCursorInvalid, err)
+	}
+}
+
+func TestEditTag(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "sport"},
+	}
+	edited, err := cl.EditTag(1, server.JSONReqTag{Name: "football"})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if edited.Name != "football" {
+		t.Fatalf("\nExpected edited name football\nReturned: %+v", edited)
+	}
+	if _, err := cl.EditTag(99, server.JSONReqTag{Name: "other"}); !errors.Is(err, store.ErrTagNotFound) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", store.ErrTagNotFound, err)
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	defer func() { repo.Trash = map[string]time.Time{} }() // Empty trash of deleted items
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "sport"},
+		2: {ID: 2, Name: "food"},
+	}
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Football", Tags: []domain.Tag{{ID: 1, Name: "sport"}}},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	tests := map[string]struct {
+		id          domain.TagID
+		expectedErr error
+	}{
+		"Unused Tag":      {id: 2, expectedErr: nil},
+		"Tag In Use":      {id: 1, expectedErr: deleting.ErrTagHasActivities},
+		"Nonexistent Tag": {id: 99, expectedErr: store.ErrTagNotFound},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := cl.DeleteTag(test.id); !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
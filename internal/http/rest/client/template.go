@@ -0,0 +1,55 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+// Templates returns all recurring templates.
+func (c *Client) Templates() ([]server.JSONRespTemplate, error) {
+	templates := []server.JSONRespTemplate{}
+	err := c.do(http.MethodGet, "/templates", nil, nil, &templates)
+	return templates, err
+}
+
+// Template returns the recurring template with given ID.
+func (c *Client) Template(id domain.TemplateID) (server.JSONRespTemplate, error) {
+	var tmpl server.JSONRespTemplate
+	err := c.do(http.MethodGet, fmt.Sprintf("/templates/%d", id), nil, nil, &tmpl)
+	return tmpl, err
+}
+
+// UpcomingOccurrences returns the next occurrences of the template with given ID.
+// If limit is zero, the default number of the API is returned.
+func (c *Client) UpcomingOccurrences(id domain.TemplateID, limit int) (server.JSONRespUpcoming, error) {
+	q := url.Values{}
+	if limit != 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	var upcoming server.JSONRespUpcoming
+	err := c.do(http.MethodGet, fmt.Sprintf("/templates/%d/upcoming", id), q, nil, &upcoming)
+	return upcoming, err
+}
+
+// AddTemplate adds the given recurring template and returns it.
+func (c *Client) AddTemplate(tmpl server.JSONReqTemplate) (server.JSONRespTemplate, error) {
+	var created server.JSONRespTemplate
+	err := c.do(http.MethodPost, "/templates", nil, tmpl, &created)
+	return created, err
+}
+
+// SkipOccurrence skips the occurrence at time t of the template with given ID.
+func (c *Client) SkipOccurrence(id domain.TemplateID, t time.Time) error {
+	return c.do(http.MethodPost, fmt.Sprintf("/templates/%d/skip", id), nil, server.JSONReqSkip{Time: t}, nil)
+}
+
+// DeleteTemplate deletes the recurring template with given ID.
+func (c *Client) DeleteTemplate(id domain.TemplateID) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/templates/%d", id), nil, nil, nil)
+}
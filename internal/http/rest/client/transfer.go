@@ -0,0 +1,36 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+// Export writes the exported CSV file ( tags.csv, activities.csv or expenses.csv ) to w.
+func (c *Client) Export(file string, w io.Writer) error {
+	content, err := c.doRaw(http.MethodGet, "/export/"+file, nil, "", nil)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(content)
+	return err
+}
+
+// Import imports the CSV file read from r as entities ( tags, activities or expenses ).
+// Valid rows are imported and invalid ones are reported.
+func (c *Client) Import(entity string, r io.Reader) (server.JSONRespImport, error) {
+	var resp server.JSONRespImport
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return resp, err
+	}
+	content, err := c.doRaw(http.MethodPost, "/import/"+entity, nil, csvContentType, body)
+	if err != nil {
+		return resp, err
+	}
+	err = json.Unmarshal(content, &resp)
+	return resp, err
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+// Trash returns the deleted tags, activities and expenses, most recently deleted first.
+func (c *Client) Trash() ([]server.JSONRespTrashItem, error) {
+	items := []server.JSONRespTrashItem{}
+	err := c.do(http.MethodGet, "/trash", nil, nil, &items)
+	return items, err
+}
+
+// RestoreTrashItem takes an item of given kind ( tags, activities or expenses ) out of the trash.
+func (c *Client) RestoreTrashItem(kind string, id uint) error {
+	return c.do(http.MethodPost, fmt.Sprintf("/trash/%s/%d/restore", kind, id), nil, nil, nil)
+}
+
+// PurgeTrashItem permanently deletes an item of given kind ( tags, activities or expenses ) from the trash.
+func (c *Client) PurgeTrashItem(kind string, id uint) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/trash/%s/%d", kind, id), nil, nil, nil)
+}
+
+// EmptyTrash permanently deletes all items in the trash and returns how many were purged.
+func (c *Client) EmptyTrash() (int, error) {
+	var resp server.JSONRespPurge
+	err := c.do(http.MethodDelete, "/trash", nil, nil, &resp)
+	return resp.Purged, err
+}
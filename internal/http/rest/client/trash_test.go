@@ -0,0 +1,48 @@
+package client_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+func TestTrash(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "sport"},
+		2: {ID: 2, Name: "food"},
+	}
+	repo.Activities = map[domain.ActivityID]domain.Activity{}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Trash = map[string]time.Time{}
+	}()
+	if err := cl.DeleteTag(1); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := cl.DeleteTag(2); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	items, err := cl.Trash()
+	if err != nil || len(items) != 2 {
+		t.Fatalf("\nExpected 2 trashed items\nReturned: %v, %v", items, err)
+	}
+	if err := cl.RestoreTrashItem("tags", 1); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := cl.RestoreTrashItem("tags", 1); !errors.Is(err, store.ErrTrashItemNotFound) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", store.ErrTrashItemNotFound, err)
+	}
+	if _, err := repo.FindTagByID(1); err != nil {
+		t.Fatalf("\nExpected restored tag\nReturned Err: %v", err)
+	}
+	if purged, err := cl.EmptyTrash(); err != nil || purged != 1 {
+		t.Fatalf("\nExpected 1 purged item\nReturned: %d, %v", purged, err)
+	}
+	if err := cl.PurgeTrashItem("tags", 2); !errors.Is(err, store.ErrTrashItemNotFound) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", store.ErrTrashItemNotFound, err)
+	}
+}
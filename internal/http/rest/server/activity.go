@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 )
@@ -17,33 +18,45 @@ func defaultActivitiesDateFilter() time.Time {
 	return time.Now().AddDate(0, -3, 0)
 }
 
-// ActivitiesByDate handler returns a list of all activities from a specific date up to now.
-// It has an optional query parameter "from" specifying the date as mm-dd-yyyy
+// ActivitiesByDate handler returns a list of all activities in a date range.
+// It has optional query parameters "from" and "to" specifying the range
+// as mm-dd-yyyy or as ISO-8601 dates/times.
 // If "from" parameter is missing, a default value is used.
+// If "to" parameter is missing, the range goes up to now
+// ( or 3 months ahead when listing planned activities ).
+// If "status" parameter is provided, only activities with this status are listed.
+// The list is paginated ( see pageRequest for pagination params )
+// and sorted by time descendent by default.
 func (h *Handler) ActivitiesByDate(c echo.Context) error {
-	dateStr := c.QueryParam("from")
-	logrus.Debugf("Extracted query param from: %s", dateStr)
-	var date time.Time
-	if len(dateStr) == 0 {
-		date = defaultActivitiesDateFilter()
-	} else {
-		var err error
-		date, err = time.Parse(dateFilterFormat, dateStr)
-		if err != nil {
-			msg := "Error parsing from param to valid date"
-			details := err.Error()
-			logrus.Error(msg + ": " + details)
-			return c.String(http.StatusBadRequest, msg)
-		}
+	from, to, err := dateRangeFilter(c, defaultActivitiesDateFilter())
+	if err != nil {
+		msg := "Error parsing from/to params to valid dates"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
+	p, err := pageRequest(c, store.SortByTime, true)
+	if err != nil {
+		msg := "Error parsing pagination params"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
+	if p.Status, err = statusFilter(c); err != nil {
+		msg := "Error parsing status param"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
+	// Planned items are listed ahead of now by default
+	if p.Status == domain.StatusPlanned && len(c.QueryParam("to")) == 0 {
+		to = defaultPlannedDateFilter()
 	}
 	// Fetch Activities
-	activities, err := h.lister.ActivitiesByTime(date)
+	activities, page, err := h.lister.ActivitiesByTimeRangePage(from, to, p)
 	if err != nil {
-		msg := fmt.Sprintf("Internal Server Error while fetching activities since %s", date.Format("2006-01-02"))
+		msg := fmt.Sprintf("Internal Server Error while fetching activities from %s to %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "activities"), msg)
+		return err
 	}
-	logrus.Infof("Fetched activities since %s successfully", date.Format("2006-01-02"))
+	logrus.Infof("Fetched activities from %s to %s successfully", from.Format("2006-01-02"), to.Format("2006-01-02"))
 	// Construct respActivities from fetched activities
 	respActivities := make([]JSONRespListActivity, len(activities))
 	var respAct JSONRespListActivity
@@ -51,7 +64,9 @@ func (h *Handler) ActivitiesByDate(c echo.Context) error {
 		respAct.From(act)
 		respActivities[i] = respAct
 	}
-	return c.JSON(http.StatusOK, respActivities)
+	var resp JSONRespPage
+	resp.From(respActivities, page)
+	return c.JSON(http.StatusOK, resp)
 }
 
 // ActivityDetails handler returns details of activity with given ID.
@@ -64,7 +79,7 @@ func (h *Handler) ActivityDetails(c echo.Context) error {
 		msg := fmt.Sprintf("Error while converting path param Activity ID with value %s to int", idStr)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(http.StatusBadRequest, msg)
+		return errIDParam
 	}
 	actID := domain.ActivityID(id)
 	// Get Activity
@@ -72,7 +87,7 @@ func (h *Handler) ActivityDetails(c echo.Context) error {
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while fetching activity %s", actID)
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "activities"), msg)
+		return err
 	}
 	logrus.Infof("Fetched activity %s successfully", actID)
 	// Fetch Expenses
@@ -80,7 +95,7 @@ func (h *Handler) ActivityDetails(c echo.Context) error {
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while fetching expenses of activity %s", actID)
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "activities"), err.Error())
+		return err
 	}
 	logrus.Infof("Fetched expenses of activity %s successfully", actID)
 	var actResp JSONRespDetailActivity
@@ -96,17 +111,16 @@ func (h *Handler) AddActivity(c echo.Context) error {
 		var (
 			msg     string = errInvalidJSON.Error()
 			details string = httpErrorMsg(err)
-			code    int    = errToHTTPCode(errInvalidJSON, "activities")
 		)
 		logrus.Error(msg + " : " + details)
-		return c.String(code, msg)
+		return errInvalidJSON
 	}
 	act := jsAct.ToDomain()
-	id, err := h.adder.NewActivity(act)
+	id, err := h.adder.NewActivity(act, h.principal(c))
 	if err != nil {
 		msg := "Internal Server Error while adding activity"
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "activities"), msg)
+		return err
 	}
 	logrus.Infof("Created activity %s successfully", id)
 	// Retrieve created activity
@@ -114,7 +128,7 @@ func (h *Handler) AddActivity(c echo.Context) error {
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while fetching activity %s", id)
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "activities"), msg)
+		return err
 	}
 	return c.JSON(http.StatusCreated, created)
 }
@@ -129,7 +143,7 @@ func (h *Handler) EditActivity(c echo.Context) error {
 		msg := fmt.Sprintf("Error while converting path param Activity ID with value %s to int", idStr)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(http.StatusBadRequest, msg)
+		return errIDParam
 	}
 	actID := domain.ActivityID(id)
 	// Get Activity
@@ -137,7 +151,7 @@ func (h *Handler) EditActivity(c echo.Context) error {
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while fetching activity %s", actID)
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "activities"), msg)
+		return err
 	}
 	logrus.Infof("Fetched activity %s successfully", actID)
 	// Json unmarshall
@@ -146,17 +160,21 @@ func (h *Handler) EditActivity(c echo.Context) error {
 		var (
 			msg     string = errInvalidJSON.Error()
 			details string = httpErrorMsg(err)
-			code    int    = errToHTTPCode(errInvalidJSON, "activities")
 		)
 		logrus.Error(msg + " | " + details)
-		return c.String(code, msg)
+		return errInvalidJSON
 	}
 	updated := jsAct.ToDomain()
-	err = h.editor.EditActivity(updated)
+	updated.ID = actID
+	// Keep status when not provided
+	if len(updated.Status) == 0 {
+		updated.Status = act.Status
+	}
+	err = h.editor.EditActivity(updated, h.principal(c))
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while updating activity %s", actID)
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "activities"), msg)
+		return err
 	}
 	logrus.Infof("Updated activity %s successfully", actID)
 	// Retrieve edited activity
@@ -164,12 +182,45 @@ func (h *Handler) EditActivity(c echo.Context) error {
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while fetching updated activity %s", act.ID)
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "activities"), msg)
+		return err
 	}
 	logrus.Infof("Fetched activity %s successfully", act.ID)
 	return c.JSON(http.StatusOK, edited)
 }
 
+// CompleteActivity handler marks the planned activity with given ID as done and returns it.
+// It requires a path parameter :id
+func (h *Handler) CompleteActivity(c echo.Context) error {
+	// Get ID from Path param
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		msg := fmt.Sprintf("Error while converting path param Activity ID with value %s to int", idStr)
+		details := err.Error()
+		logrus.Error(msg + " | " + details)
+		return errIDParam
+	}
+	actID := domain.ActivityID(id)
+	// Complete Activity
+	act, err := h.editor.CompleteActivity(actID, h.principal(c))
+	if err != nil {
+		msg := fmt.Sprintf("Error while completing activity %s", actID)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Completed activity %s successfully", actID)
+	// Fetch Expenses
+	expenses, err := h.lister.ExpensesByActivity(act.ID)
+	if err != nil {
+		msg := fmt.Sprintf("Internal Server Error while fetching expenses of activity %s", actID)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	var actResp JSONRespDetailActivity
+	actResp.From(act, expenses)
+	return c.JSON(http.StatusOK, actResp)
+}
+
 // DeleteActivity handler deletes an activity with given ID.
 // It requires a path parameter :id
 func (h *Handler) DeleteActivity(c echo.Context) error {
@@ -180,15 +231,15 @@ func (h *Handler) DeleteActivity(c echo.Context) error {
 		msg := fmt.Sprintf("Error while converting path param Activity ID with value %s to int", idStr)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(http.StatusBadRequest, msg)
+		return errIDParam
 	}
 	actID := domain.ActivityID(id)
 	// Delete Activity
-	err = h.deleter.Activity(actID)
+	err = h.deleter.Activity(actID, h.principal(c))
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while deleting activity %s", actID)
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "activities"), msg)
+		return err
 	}
 	logrus.Infof("Deleted activity %s successfully", actID)
 	return c.JSON(http.StatusNoContent, "Activity Deleted Successfully")
@@ -14,6 +14,7 @@ type JSONReqActivity struct {
 	Place    string            `json:"place"`
 	Time     time.Time         `json:"time"`
 	Duration time.Duration     `json:"duration"`
+	Status   domain.Status     `json:"status"` // Empty for done
 	TagIds   []domain.TagID    `json:"tagIds"`
 }
 
@@ -32,6 +33,7 @@ func (reqAct JSONReqActivity) ToDomain() domain.Activity {
 		Place:    reqAct.Place,
 		Time:     reqAct.Time,
 		Duration: reqAct.Duration,
+		Status:   reqAct.Status,
 		Tags:     tags,
 	}
 }
@@ -44,6 +46,7 @@ type JSONRespDetailActivity struct {
 	Place    string                `json:"place"`
 	Time     time.Time             `json:"time"`
 	Duration time.Duration         `json:"duration"`
+	Status   domain.Status         `json:"status"`
 	Expenses []JSONRespListExpense `json:"expenses"`
 	Tags     []domain.Tag          `json:"tags"`
 }
@@ -56,6 +59,7 @@ func (respAct *JSONRespDetailActivity) From(act domain.Activity, expenses []doma
 	(*respAct).Desc = act.Desc
 	(*respAct).Time = act.Time
 	(*respAct).Duration = act.Duration
+	(*respAct).Status = act.Status
 	respExpenses := make([]JSONRespListExpense, len(expenses))
 	var respExp JSONRespListExpense
 	for i, exp := range expenses {
@@ -74,6 +78,7 @@ type JSONRespListActivity struct {
 	Place    string            `json:"place"`
 	Time     time.Time         `json:"time"`
 	Duration time.Duration     `json:"duration"`
+	Status   domain.Status     `json:"status"`
 }
 
 // From constructs a JSONRespListActivity object from a domain.Activity object
@@ -84,4 +89,5 @@ func (respAct *JSONRespListActivity) From(act domain.Activity) {
 	(*respAct).Desc = act.Desc
 	(*respAct).Time = act.Time
 	(*respAct).Duration = act.Duration
+	(*respAct).Status = act.Status
 }
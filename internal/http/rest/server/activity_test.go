@@ -36,7 +36,63 @@ func TestActivitiesByDate(t *testing.T) {
 			expectedCode: http.StatusBadRequest,
 		},
 		"Wrong Date Format": {
-			filter:       fmt.Sprintf("?%s=2020-01-31", param),
+			filter:       fmt.Sprintf("?%s=31/01/2020", param),
+			expectedCode: http.StatusBadRequest,
+		},
+		"ISO-8601 Date Range": {
+			filter:       "?from=2020-01-01&to=2020-01-31",
+			expectedCode: http.StatusOK,
+		},
+		"ISO-8601 Time Range": {
+			filter:       "?from=2020-01-01T10:00:00Z&to=2020-01-31T18:30:00%2B01:00",
+			expectedCode: http.StatusOK,
+		},
+		"Legacy Date Range": {
+			filter:       fmt.Sprintf("?from=%s&to=%s", now.AddDate(0, -1, 0).Format(frmt), now.AddDate(0, 0, -2).Format(frmt)),
+			expectedCode: http.StatusOK,
+		},
+		"From after To": {
+			filter:       "?from=2020-01-31&to=2020-01-01",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Wrong To Format": {
+			filter:       "?to=31/01/2020",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Paginated Sorted By Label": {
+			filter:       "?limit=10&sort=label&order=asc",
+			expectedCode: http.StatusOK,
+		},
+		"Wrong Limit": {
+			filter:       "?limit=ten",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Limit Too High": {
+			filter:       "?limit=100000",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Wrong Order": {
+			filter:       "?order=up",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Wrong Sort Field": {
+			filter:       "?sort=value",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Invalid Cursor": {
+			filter:       "?cursor=not-a-cursor",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Planned From Future": {
+			filter:       fmt.Sprintf("?status=planned&%s=%s", param, now.AddDate(0, 0, 1).Format(frmt)),
+			expectedCode: http.StatusOK,
+		},
+		"Done From Future": {
+			filter:       fmt.Sprintf("?status=done&%s=%s&to=%s", param, now.AddDate(0, 0, 1).Format(frmt), now.AddDate(0, 0, 2).Format(frmt)),
+			expectedCode: http.StatusBadRequest,
+		},
+		"Wrong Status": {
+			filter:       "?status=later",
 			expectedCode: http.StatusBadRequest,
 		},
 	}
@@ -52,7 +108,7 @@ func TestActivitiesByDate(t *testing.T) {
 			rec = httptest.NewRecorder()
 			ctx = router.NewContext(req, rec)
 			ctx.SetPath(path)
-			hnd.ActivitiesByDate(ctx)
+			handle(hnd.ActivitiesByDate, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %v\nReturned Body: %s", test.expectedCode, rec.Code, body)
@@ -110,7 +166,7 @@ func TestActivityDetails(t *testing.T) {
 			ctx.SetPath(path)
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(test.idStr)
-			hnd.ActivityDetails(ctx)
+			handle(hnd.ActivityDetails, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
@@ -187,7 +243,7 @@ func TestEditActivity(t *testing.T) {
 			ctx.SetPath(path)
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(test.idStr)
-			hnd.EditActivity(ctx)
+			handle(hnd.EditActivity, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
@@ -197,6 +253,7 @@ func TestEditActivity(t *testing.T) {
 }
 
 func TestDeleteActivity(t *testing.T) {
+	defer func() { repo.Trash = map[string]time.Time{} }() // Empty trash of deleted items
 	// Init Repo with two test activities: one with and one without expense
 	actWithoutExpense := domain.Activity{
 		ID:       1,
@@ -269,7 +326,7 @@ func TestDeleteActivity(t *testing.T) {
 			ctx.SetPath(path)
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(test.idStr)
-			hnd.DeleteActivity(ctx)
+			handle(hnd.DeleteActivity, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
@@ -278,6 +335,45 @@ func TestDeleteActivity(t *testing.T) {
 	}
 }
 
+func TestCompleteActivity(t *testing.T) {
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Planned Activity", Time: time.Now().AddDate(0, 0, 1), Duration: time.Hour, Status: domain.StatusPlanned},
+		2: {ID: 2, Label: "Done Activity", Time: time.Now().AddDate(0, 0, -1), Duration: time.Hour, Status: domain.StatusDone},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	// Sub-tests definitions
+	tests := map[string]struct {
+		idStr        string
+		expectedCode int
+	}{
+		"Planned Activity":      {idStr: "1", expectedCode: http.StatusOK},
+		"Done Activity":         {idStr: "2", expectedCode: http.StatusConflict},
+		"Non-Existing Activity": {idStr: "234234", expectedCode: http.StatusNotFound},
+		"Wrong Id format":       {idStr: "blabls", expectedCode: http.StatusBadRequest},
+	}
+	// Sub-tests Execution
+	const path string = "/activities/:id/complete"
+	const url string = "/activities/%s/complete"
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf(url, test.idStr), nil)
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath(path)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(test.idStr)
+			handle(hnd.CompleteActivity, ctx)
+			if rec.Code != test.expectedCode {
+				body := rec.Body.String()
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
+			}
+			if rec.Code == http.StatusOK && !strings.Contains(rec.Body.String(), `"status":"done"`) {
+				t.Fatalf("\nExpected done activity\nReturned Body: %s", rec.Body.String())
+			}
+		})
+	}
+}
+
 func TestAddActivity(t *testing.T) {
 	// Init Repo with some tags
 	repo.Tags = map[domain.TagID]domain.Tag{
@@ -321,7 +417,7 @@ func TestAddActivity(t *testing.T) {
 			rec = httptest.NewRecorder()
 			ctx = router.NewContext(req, rec)
 			ctx.SetPath(path)
-			hnd.AddActivity(ctx)
+			handle(hnd.AddActivity, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
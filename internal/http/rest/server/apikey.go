@@ -0,0 +1,84 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// apiKeyIDParam extracts the API key ID from the path parameter :id
+func apiKeyIDParam(c echo.Context) (domain.APIKeyID, error) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		msg := fmt.Sprintf("Error while converting path param API Key ID with value %s to int", idStr)
+		logrus.Error(msg + " | " + err.Error())
+		return 0, errIDParam
+	}
+	return domain.APIKeyID(id), nil
+}
+
+// GetAllAPIKeys handler returns the API keys of the authenticated user.
+func (h *Handler) GetAllAPIKeys(c echo.Context) error {
+	keys, err := h.authenticator.APIKeys(userID(c))
+	if err != nil {
+		msg := "Internal Server Error while fetching API keys"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Info("All API keys fetched successfully")
+	respKeys := make([]JSONRespAPIKey, len(keys))
+	var respKey JSONRespAPIKey
+	for i, k := range keys {
+		respKey.From(k)
+		respKeys[i] = respKey
+	}
+	return c.JSON(http.StatusOK, respKeys)
+}
+
+// AddAPIKey handler creates an API key for the authenticated user
+// and returns it with the key, which is only shown once.
+func (h *Handler) AddAPIKey(c echo.Context) error {
+	// Json unmarshall
+	var jsKey JSONReqAPIKey
+	if err := c.Bind(&jsKey); err != nil {
+		var (
+			msg     string = errInvalidJSON.Error()
+			details string = httpErrorMsg(err)
+		)
+		logrus.Error(msg + " : " + details)
+		return errInvalidJSON
+	}
+	// Call auth service
+	k, key, err := h.authenticator.NewAPIKey(userID(c), jsKey.ToDomain(), grantedScopes(c))
+	if err != nil {
+		msg := "Error while creating API key"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Created API key %s successfully", k.ID)
+	var respKey JSONRespNewAPIKey
+	respKey.From(k)
+	respKey.Key = key
+	return c.JSON(http.StatusCreated, respKey)
+}
+
+// DeleteAPIKey handler revokes the API key with given ID of the authenticated user.
+// It required a path parameter :id
+func (h *Handler) DeleteAPIKey(c echo.Context) error {
+	id, err := apiKeyIDParam(c)
+	if err != nil {
+		return err
+	}
+	if err := h.authenticator.RevokeAPIKey(userID(c), id); err != nil {
+		msg := fmt.Sprintf("Error while revoking API key %s", id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Revoked API key %s successfully", id)
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// JSONReqAPIKey is used to unmarshal a json API key.
+// A key without expiry never expires.
+type JSONReqAPIKey struct {
+	Name      string             `json:"name"`
+	Scope     domain.APIKeyScope `json:"scope"`
+	ExpiresAt *time.Time         `json:"expiresAt,omitempty"`
+}
+
+// ToDomain constructs and returns a domain.APIKey from a JSONReqAPIKey
+func (reqKey JSONReqAPIKey) ToDomain() domain.APIKey {
+	k := domain.APIKey{Name: reqKey.Name, Scope: reqKey.Scope}
+	if reqKey.ExpiresAt != nil {
+		k.ExpiresAt = *reqKey.ExpiresAt
+	}
+	return k
+}
+
+// JSONRespAPIKey is used to marshal an API key to json.
+// The key itself is never returned after its creation.
+type JSONRespAPIKey struct {
+	ID        domain.APIKeyID    `json:"id"`
+	Name      string             `json:"name"`
+	Prefix    string             `json:"prefix"`
+	Scope     domain.APIKeyScope `json:"scope"`
+	ExpiresAt *time.Time         `json:"expiresAt,omitempty"`
+	CreatedAt time.Time          `json:"createdAt"`
+}
+
+// From constructs a JSONRespAPIKey object from a domain.APIKey object.
+func (respKey *JSONRespAPIKey) From(k domain.APIKey) {
+	(*respKey).ID = k.ID
+	(*respKey).Name = k.Name
+	(*respKey).Prefix = k.Prefix
+	(*respKey).Scope = k.Scope
+	(*respKey).ExpiresAt = nil
+	if !k.ExpiresAt.IsZero() {
+		exp := k.ExpiresAt
+		(*respKey).ExpiresAt = &exp
+	}
+	(*respKey).CreatedAt = k.CreatedAt
+}
+
+// JSONRespNewAPIKey is used to marshal a created API key to json
+// with the key, which can not be retrieved again.
+type JSONRespNewAPIKey struct {
+	JSONRespAPIKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,106 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// serveAPIKey sends a request with given JSON body through the router
+// with given API key and returns the recorded response.
+func serveAPIKey(method string, path string, body string, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-type", "application/json")
+	req.Header.Set("X-API-Key", key)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAPIKeys(t *testing.T) {
+	defer func() {
+		repo.Users = map[domain.UserID]domain.User{}
+		repo.RefreshTokens = map[string]domain.RefreshToken{}
+		repo.APIKeys = map[domain.APIKeyID]domain.APIKey{}
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Owners = map[string]domain.UserID{}
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	}()
+	const creds string = `{"name":"hamza","password":"test_pass"}`
+	if rec := serveJSON(http.MethodPost, "/auth/register", creds, ""); rec.Code != http.StatusCreated {
+		t.Fatalf("\nUnexpected register response: %d %s", rec.Code, rec.Body.String())
+	}
+	access := readTokens(t, serveJSON(http.MethodPost, "/auth/login", creds, "")).Access
+	// newKey creates an API key and returns its ID & the key
+	newKey := func(body string) (domain.APIKeyID, string) {
+		rec := serveJSON(http.MethodPost, "/auth/keys", body, access)
+		var created struct {
+			ID  domain.APIKeyID `json:"id"`
+			Key string          `json:"key"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil || rec.Code != http.StatusCreated || created.Key == "" {
+			t.Fatalf("\nUnexpected create key response: %d %s", rec.Code, rec.Body.String())
+		}
+		return created.ID, created.Key
+	}
+	_, readKey := newKey(`{"name":"dashboard","scope":"read"}`)
+	writeID, writeKey := newKey(`{"name":"scanner","scope":"read-write","expiresAt":"2999-01-01T00:00:00Z"}`)
+	// Invalid keys
+	invalid := map[string]struct {
+		json         string
+		expectedCode int
+	}{
+		"Invalid Scope": {json: `{"name":"script","scope":"admin"}`, expectedCode: http.StatusBadRequest},
+		"Past Expiry":   {json: `{"name":"script","scope":"read","expiresAt":"2000-01-01T00:00:00Z"}`, expectedCode: http.StatusBadRequest},
+		"No Name":       {json: `{"scope":"read"}`, expectedCode: http.StatusBadRequest},
+	}
+	for name, test := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if rec := serveJSON(http.MethodPost, "/auth/keys", test.json, access); rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+		})
+	}
+	// List keys without the keys themselves
+	rec := serveJSON(http.MethodGet, "/auth/keys", "", access)
+	if body := rec.Body.String(); rec.Code != http.StatusOK || !strings.Contains(body, "dashboard") || strings.Contains(body, readKey) {
+		t.Fatalf("\nUnexpected list keys response: %d %s", rec.Code, body)
+	}
+	// Requests with keys
+	tests := map[string]struct {
+		method       string
+		path         string
+		json         string
+		key          string
+		expectedCode int
+	}{
+		"Read with Read Key":        {method: http.MethodGet, path: "/tags", key: readKey, expectedCode: http.StatusOK},
+		"Write with Read Key":       {method: http.MethodPost, path: "/tags", json: `{"name":"sport"}`, key: readKey, expectedCode: http.StatusForbidden},
+		"Write with Read-Write Key": {method: http.MethodPost, path: "/activities", json: `{"label":"Football","time":"2020-01-01T10:00:00Z","duration":3600}`, key: writeKey, expectedCode: http.StatusCreated},
+		"Read Expenses":             {method: http.MethodGet, path: "/expenses", key: readKey, expectedCode: http.StatusOK},
+		"Unknown Key":               {method: http.MethodGet, path: "/tags", key: "lflg_unknown", expectedCode: http.StatusUnauthorized},
+		"Route without Keys":        {method: http.MethodGet, path: "/budgets", key: readKey, expectedCode: http.StatusBadRequest},
+		"Manage Keys with Key":      {method: http.MethodGet, path: "/auth/keys", key: writeKey, expectedCode: http.StatusBadRequest},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if rec := serveAPIKey(test.method, test.path, test.json, test.key); rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+		})
+	}
+	// Revoke
+	if rec := serveJSON(http.MethodDelete, "/auth/keys/"+writeID.String(), "", access); rec.Code != http.StatusNoContent {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+	}
+	if rec := serveAPIKey(http.MethodGet, "/tags", "", writeKey); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+	if rec := serveJSON(http.MethodDelete, "/auth/keys/"+writeID.String(), "", access); rec.Code != http.StatusNotFound {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+}
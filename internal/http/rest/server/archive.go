@@ -0,0 +1,50 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/usecase/archiving"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// Backup handler returns an archive of all tags, activities and expenses
+// as a json file attachment.
+func (h *Handler) Backup(c echo.Context) error {
+	now := time.Now()
+	archive, err := h.archiver.Backup(now)
+	if err != nil {
+		msg := "Internal Server Error while backing up"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Info("Backed up all entities successfully")
+	filename := "lifelog-backup-" + now.Format("20060102-150405") + ".json"
+	c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=\""+filename+"\"")
+	return c.JSON(http.StatusOK, archive)
+}
+
+// Restore handler restores the archive in the request body
+// into the store which must be empty, trash included. Relations are kept
+// but restored entities are given new IDs.
+func (h *Handler) Restore(c echo.Context) error {
+	var archive archiving.Archive
+	if err := c.Bind(&archive); err != nil {
+		var (
+			msg     string = errInvalidJSON.Error()
+			details string = httpErrorMsg(err)
+		)
+		logrus.Error(msg + " : " + details)
+		return errInvalidJSON
+	}
+	if err := h.archiver.Restore(archive, h.principal(c)); err != nil {
+		msg := "Error while restoring archive"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	var resp JSONRespRestore
+	resp.From(archive)
+	logrus.Infof("Restored %d tags, %d activities and %d expenses successfully", resp.Tags, resp.Activities, resp.Expenses)
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,17 @@
+package server
+
+import "github.com/elhamza90/lifelog/internal/usecase/archiving"
+
+// JSONRespRestore is used to marshal the number of restored entities to json.
+type JSONRespRestore struct {
+	Tags       int `json:"tags"`
+	Activities int `json:"activities"`
+	Expenses   int `json:"expenses"`
+}
+
+// From constructs a JSONRespRestore object from a restored archive.
+func (resp *JSONRespRestore) From(archive archiving.Archive) {
+	(*resp).Tags = len(archive.Tags)
+	(*resp).Activities = len(archive.Activities)
+	(*resp).Expenses = len(archive.Expenses)
+}
@@ -0,0 +1,91 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+func TestBackup(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "food"},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		2: {ID: 2, Label: "Lunch", Time: time.Date(2020, 10, 5, 12, 0, 0, 0, time.UTC), Value: 1250, Unit: "EUR", Tags: []domain.Tag{repo.Tags[1]}},
+	}
+	repo.Activities = map[domain.ActivityID]domain.Activity{}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	}()
+	req := httptest.NewRequest(http.MethodGet, "/backup", nil)
+	rec := httptest.NewRecorder()
+	ctx := router.NewContext(req, rec)
+	handle(hnd.Backup, ctx)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	const expectedBody string = `"tags":[{"id":1,"name":"food"}],"activities":[],"expenses":[{"id":2,"label":"Lunch","time":"2020-10-05T12:00:00Z","value":1250,"unit":"EUR","activityId":0,"tagIds":[1]}]}`
+	if body := rec.Body.String(); !strings.HasPrefix(body, `{"version":1,`) || !strings.Contains(body, expectedBody) {
+		t.Fatalf("\nExpected Body to contain: %s\nReturned Body: %s", expectedBody, body)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); !strings.HasPrefix(cd, "attachment") {
+		t.Fatalf("\nExpected attachment\nReturned Content-Disposition: %s", cd)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	// Restore requires an empty store
+	repo.Tags = map[domain.TagID]domain.Tag{}
+	repo.Activities = map[domain.ActivityID]domain.Activity{}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Activities = map[domain.ActivityID]domain.Activity{}
+		repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	}()
+	const archive string = `{"version":1,"tags":[{"id":3,"name":"sport"}],` +
+		`"activities":[{"id":4,"label":"Football","time":"2020-10-05T18:00:00Z","duration":3600000000000,"tagIds":[3]}],` +
+		`"expenses":[{"id":5,"label":"Drinks","time":"2020-10-05T19:00:00Z","value":350,"unit":"EUR","activityId":4,"tagIds":[3]}]}`
+	tests := []struct {
+		name         string
+		json         string
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "Wrong Version", json: `{"version":9}`, expectedCode: http.StatusBadRequest},
+		{name: "Unknown Reference", json: `{"version":1,"expenses":[{"id":5,"label":"Drinks","time":"2020-10-05T19:00:00Z","value":350,"unit":"EUR","activityId":4}]}`, expectedCode: http.StatusBadRequest},
+		{name: "Wrong Json", json: `{"version":1`, expectedCode: http.StatusBadRequest},
+		{name: "Correct", json: archive, expectedCode: http.StatusOK, expectedBody: `{"tags":1,"activities":1,"expenses":1}`},
+		{name: "Store Not Empty", json: archive, expectedCode: http.StatusConflict},
+	}
+	// Sub-tests are run in order since the store is not empty after a restore
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/restore", strings.NewReader(test.json))
+			req.Header.Set("Content-type", "application/json")
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			handle(hnd.Restore, ctx)
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+			if body := strings.TrimSpace(rec.Body.String()); test.expectedBody != "" && body != test.expectedBody {
+				t.Fatalf("\nExpected Body: %s\nReturned Body: %s", test.expectedBody, body)
+			}
+		})
+	}
+	if len(repo.Expenses) != 1 {
+		t.Fatalf("\nExpected 1 restored expense\nReturned: %v", repo.Expenses)
+	}
+	// Restored entities are given new IDs
+	for _, exp := range repo.Expenses {
+		if act, ok := repo.Activities[exp.ActivityID]; exp.ID == 5 || !ok || act.Label != "Football" || len(exp.Tags) != 1 {
+			t.Fatalf("\nExpected expense restored with new ID, its activity & tags\nReturned: %v", exp)
+		}
+	}
+}
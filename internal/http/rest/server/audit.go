@@ -0,0 +1,82 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// principal returns the name of the authenticated user
+// from the access token set in the context by the JWT middleware.
+// Requests authenticated with an API key are made by apikey:<key name>
+// followed by the name of the owner of the key in parentheses.
+// It returns an empty string if there is none.
+func (h *Handler) principal(c echo.Context) string {
+	if k, ok := c.Get(apiKeyContextKey).(domain.APIKey); ok {
+		name := "apikey:" + k.Name
+		owner, err := h.authenticator.APIKeyOwner(k)
+		if err != nil {
+			msg := fmt.Sprintf("Error while fetching owner of API Key %s", k.ID)
+			logrus.Error(msg + " : " + err.Error())
+			return name
+		}
+		return name + " (" + owner.Name + ")"
+	}
+	token, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return ""
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	name, _ := claims["name"].(string)
+	return name
+}
+
+// history returns the changes of the entity of given kind
+// with the ID in the path parameter :id
+func (h *Handler) history(c echo.Context, entity domain.AuditEntity) error {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		msg := fmt.Sprintf("Error while converting path param ID with value %s to int", idStr)
+		logrus.Error(msg + " | " + err.Error())
+		return errIDParam
+	}
+	events, err := h.auditor.History(entity, uint(id))
+	if err != nil {
+		msg := fmt.Sprintf("Error while fetching history of %s %d", entity, id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	resp := make([]JSONRespAuditEvent, len(events))
+	for i, ev := range events {
+		resp[i].From(ev)
+	}
+	logrus.Infof("Fetched %d changes of %s %d successfully", len(resp), entity, id)
+	return c.JSON(http.StatusOK, resp)
+}
+
+// TagHistory handler returns the changes of tag with given ID, oldest first.
+// It requires a path parameter :id
+func (h *Handler) TagHistory(c echo.Context) error {
+	return h.history(c, domain.AuditTag)
+}
+
+// ActivityHistory handler returns the changes of activity with given ID, oldest first.
+// It requires a path parameter :id
+func (h *Handler) ActivityHistory(c echo.Context) error {
+	return h.history(c, domain.AuditActivity)
+}
+
+// ExpenseHistory handler returns the changes of expense with given ID, oldest first.
+// It requires a path parameter :id
+func (h *Handler) ExpenseHistory(c echo.Context) error {
+	return h.history(c, domain.AuditExpense)
+}
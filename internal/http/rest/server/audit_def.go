@@ -0,0 +1,29 @@
+package server
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// JSONRespAuditEvent is used to marshal a change of an entity to json.
+// Before & After are the snapshots of the entity around the change.
+type JSONRespAuditEvent struct {
+	ID        domain.AuditEventID `json:"id"`
+	Action    string              `json:"action"`
+	Principal string              `json:"principal"`
+	Time      time.Time           `json:"time"`
+	Before    json.RawMessage     `json:"before,omitempty"`
+	After     json.RawMessage     `json:"after,omitempty"`
+}
+
+// From constructs a JSONRespAuditEvent object from a domain.AuditEvent object.
+func (resp *JSONRespAuditEvent) From(ev domain.AuditEvent) {
+	(*resp).ID = ev.ID
+	(*resp).Action = string(ev.Action)
+	(*resp).Principal = ev.Principal
+	(*resp).Time = ev.Time
+	(*resp).Before = ev.Before
+	(*resp).After = ev.After
+}
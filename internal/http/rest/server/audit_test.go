@@ -0,0 +1,202 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+	"github.com/labstack/echo/v4"
+)
+
+func TestTagHistory(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		8987: {ID: 8987, Name: "sport"},
+	}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Trash = map[string]time.Time{}
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	}()
+	// Token set by the JWT middleware
+	user := &jwt.Token{Claims: jwt.MapClaims{"name": "El Hamza"}}
+	// Edit then delete tag
+	const path string = "/tags/:id"
+	req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(`{"name":"sports"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ctx := router.NewContext(req, rec)
+	ctx.SetPath(path)
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("8987")
+	ctx.Set("user", user)
+	handle(hnd.EditTag, ctx)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	time.Sleep(time.Millisecond) // Keep events ordered
+	req = httptest.NewRequest(http.MethodDelete, path, nil)
+	rec = httptest.NewRecorder()
+	ctx = router.NewContext(req, rec)
+	ctx.SetPath(path)
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("8987")
+	ctx.Set("user", user)
+	handle(hnd.DeleteTag, ctx)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+	}
+	// History
+	tests := map[string]struct {
+		idStr        string
+		expectedCode int
+		expectedLen  int
+	}{
+		"Edited Tag": {idStr: "8987", expectedCode: http.StatusOK, expectedLen: 2},
+		"Other Tag":  {idStr: "1", expectedCode: http.StatusOK, expectedLen: 0},
+		"Invalid ID": {idStr: "one", expectedCode: http.StatusBadRequest},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/tags/:id/history", nil)
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath("/tags/:id/history")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(test.idStr)
+			handle(hnd.TagHistory, ctx)
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+			if test.expectedCode != http.StatusOK {
+				return
+			}
+			var events []server.JSONRespAuditEvent
+			if err := json.Unmarshal(rec.Body.Bytes(), &events); err != nil {
+				t.Fatalf("\nUnexpected Error: %v", err)
+			}
+			if len(events) != test.expectedLen {
+				t.Fatalf("\nExpected %d events\nReturned Body: %s", test.expectedLen, rec.Body.String())
+			}
+		})
+	}
+	events, _ := repo.FindAuditEvents(domain.AuditTag, 8987)
+	updated, deleted := events[0], events[1]
+	if updated.Action != domain.AuditUpdate || updated.Principal != "El Hamza" || !strings.Contains(string(updated.Before), `"sport"`) || !strings.Contains(string(updated.After), `"sports"`) {
+		t.Fatalf("\nExpected update from sport to sports by El Hamza\nReturned: %v | %s | %s", updated, updated.Before, updated.After)
+	}
+	if deleted.Action != domain.AuditDelete || deleted.After != nil || !strings.Contains(string(deleted.Before), `"sports"`) {
+		t.Fatalf("\nExpected deletion of sports\nReturned: %v | %s | %s", deleted, deleted.Before, deleted.After)
+	}
+}
+
+func TestEditBodyID(t *testing.T) {
+	actTime := time.Now().AddDate(0, 0, -2)
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Run", Time: actTime, Duration: time.Hour},
+		2: {ID: 2, Label: "Swim", Time: actTime, Duration: time.Hour},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Shoes", Time: actTime, Value: 5000, Unit: "EUR"},
+		2: {ID: 2, Label: "Drinks", Time: actTime, Value: 350, Unit: "EUR"},
+	}
+	repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	defer func() {
+		repo.Activities = map[domain.ActivityID]domain.Activity{}
+		repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	}()
+	// The ID in the body is ignored: the path ID is edited
+	tests := map[string]struct {
+		path    string
+		json    string
+		handler echo.HandlerFunc
+		entity  domain.AuditEntity
+		label   func() (string, string) // Labels of entities 1 & 2
+	}{
+		"Activity": {
+			path:    "/activities/:id",
+			json:    `{"id":2,"label":"Cycling","time":"2020-04-01T18:00:00Z","duration":3600000000000}`,
+			handler: hnd.EditActivity,
+			entity:  domain.AuditActivity,
+			label: func() (string, string) {
+				return repo.Activities[1].Label, repo.Activities[2].Label
+			},
+		},
+		"Expense": {
+			path:    "/expenses/:id",
+			json:    `{"id":2,"label":"Cycling","value":12.5,"unit":"EUR","time":"2020-04-01T18:00:00Z"}`,
+			handler: hnd.EditExpense,
+			entity:  domain.AuditExpense,
+			label: func() (string, string) {
+				return repo.Expenses[1].Label, repo.Expenses[2].Label
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, test.path, strings.NewReader(test.json))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath(test.path)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues("1")
+			handle(test.handler, ctx)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+			if first, second := test.label(); first != "Cycling" || second == "Cycling" {
+				t.Fatalf("\nExpected only %s 1 edited\nReturned labels: %s, %s", name, first, second)
+			}
+			if events, _ := repo.FindAuditEvents(test.entity, 2); len(events) != 0 {
+				t.Fatalf("\nExpected no change of %s 2\nReturned: %v", name, events)
+			}
+			events, _ := repo.FindAuditEvents(test.entity, 1)
+			if len(events) != 1 || !strings.Contains(string(events[0].After), `"Cycling"`) {
+				t.Fatalf("\nExpected update of %s 1 to Cycling\nReturned: %v", name, events)
+			}
+		})
+	}
+}
+
+func TestAPIKeyPrincipal(t *testing.T) {
+	defer func() {
+		repo.Users = map[domain.UserID]domain.User{}
+		repo.RefreshTokens = map[string]domain.RefreshToken{}
+		repo.APIKeys = map[domain.APIKeyID]domain.APIKey{}
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Owners = map[string]domain.UserID{}
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	}()
+	repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	const creds string = `{"name":"hamza","password":"test_pass"}`
+	if rec := serveJSON(http.MethodPost, "/auth/register", creds, ""); rec.Code != http.StatusCreated {
+		t.Fatalf("\nUnexpected register response: %d %s", rec.Code, rec.Body.String())
+	}
+	access := readTokens(t, serveJSON(http.MethodPost, "/auth/login", creds, "")).Access
+	rec := serveJSON(http.MethodPost, "/auth/keys", `{"name":"scanner","scope":"read-write"}`, access)
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil || rec.Code != http.StatusCreated {
+		t.Fatalf("\nUnexpected create key response: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := serveAPIKey(http.MethodPost, "/tags", `{"name":"sport"}`, created.Key); rec.Code != http.StatusCreated {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	if len(repo.AuditEvents) != 1 {
+		t.Fatalf("\nExpected 1 event\nReturned: %v", repo.AuditEvents)
+	}
+	const expected string = "apikey:scanner (hamza)"
+	for _, ev := range repo.AuditEvents {
+		if ev.Action != domain.AuditCreate || ev.Principal != expected {
+			t.Fatalf("\nExpected creation by %s\nReturned: %v", expected, ev)
+		}
+	}
+}
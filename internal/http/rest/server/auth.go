@@ -2,37 +2,51 @@ package server
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
+	"time"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/auth"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 )
 
 // loginRequest specifies the structure of json in an authentication request.
+// Scopes restrict the permissions of the tokens ( all if empty ).
 type loginRequest struct {
+	Name     string   `json:"name"`
+	Password string   `json:"password"`
+	Scopes   []string `json:"scopes,omitempty"`
+}
+
+// registerRequest specifies the structure of json in a registration request.
+type registerRequest struct {
+	Name     string `json:"name"`
 	Password string `json:"password"`
 }
 
+// registerResponse specifies the structure of json in a registration response.
+type registerResponse struct {
+	ID   domain.UserID `json:"id"`
+	Name string        `json:"name"`
+}
+
 // refreshRequest specifies the structure of json in a refresh-token request.
 type refreshRequest struct {
 	RefreshToken string `json:"refresh"`
 }
 
-// errSigningJwt represents the error returned when Token can not be signed.
-var errSigningJwt error = errors.New("Could not sign JWT Token")
+// tokensResponse specifies the structure of json in an authentication response.
+type tokensResponse struct {
+	Access  string `json:"at"`
+	Refresh string `json:"rt,omitempty"`
+}
 
-// jwtSignErrHandler takes the error returned when signing a JWT Token
-// and returns:
-//	- Http Code to be returned to the client
-//	- Msg body to be returned to the client
-//	- Log message to be logged
-func jwtSignErrHandler(err error) (code int, respMsg string, logMsg string) {
-	code = errToHTTPCode(errSigningJwt, "auth")
-	respMsg = errSigningJwt.Error()
-	logMsg = respMsg + " : " + err.Error()
-	return code, respMsg, logMsg
+// jwtSignErr logs the error returned when signing a JWT Token
+// and returns the error to be sent to the client.
+func jwtSignErr(err error) error {
+	logrus.Error(errSigningJwt.Error() + " : " + err.Error())
+	return errSigningJwt
 }
 
 // Login handler authenticates user and returns a JWT Token if authentication is successful.
@@ -43,87 +57,157 @@ func (h *Handler) Login(c echo.Context) error {
 		var (
 			msg     string = errInvalidJSON.Error()
 			details string = httpErrorMsg(err)
-			code    int    = errToHTTPCode(errInvalidJSON, "auth")
 		)
 		logrus.Error(msg + " | " + details)
-		return c.String(code, msg)
+		return errInvalidJSON
+	}
+	scopes, err := domain.ParseScopes(req.Scopes)
+	if err != nil {
+		logrus.Error(err.Error())
+		return err
+	}
+	// Reject locked out accounts & addresses before checking the password
+	keys := loginKeys(c, req.Name)
+	for _, k := range keys {
+		if wait, err := h.throttler.Locked(k); err != nil {
+			logrus.Error("Login of " + k + " : " + err.Error())
+			retryAfter(c, wait)
+			return err
+		}
 	}
 	// Authenticate
-	if err := h.authenticator.Authenticate(req.Password); err != nil {
-		msg := err.Error()
-		logrus.Error(msg)
-		code := errToHTTPCode(err, "auth")
-		return c.String(code, msg)
+	usr, err := h.authenticator.Authenticate(req.Name, req.Password)
+	if errors.Is(err, auth.ErrIncorrectCredentials) {
+		for _, k := range keys {
+			if _, err := h.throttler.Fail(k); err != nil {
+				logrus.Error("Error while recording failed login of " + k + " : " + err.Error())
+			}
+		}
+	}
+	if err != nil {
+		logrus.Error(err.Error())
+		return err
+	}
+	// Failures of the address are not forgotten so that logging into
+	// an account does not lift the lockout of attempts on others
+	if err := h.throttler.Succeed(keys[0]); err != nil {
+		logrus.Error("Error while recording login of " + keys[0] + " : " + err.Error())
 	}
 	logrus.Info("Authentication successful")
 	// Generate and return Access/Refresh Tokens
-	access, err := generateAccessToken()
+	access, err := generateAccessToken(usr, scopes)
 	if err != nil {
-		code, msg, logMsg := jwtSignErrHandler(err)
-		logrus.Error(logMsg)
-		return c.String(code, msg)
+		return jwtSignErr(err)
 	}
 	logrus.Info("Generated Access Token")
-	refresh, err := generateRefreshToken()
+	rt, err := h.authenticator.NewRefreshToken(usr.ID, time.Now().Add(refreshTokenExpDuration))
 	if err != nil {
-		code, msg, logMsg := jwtSignErrHandler(err)
-		logrus.Error(logMsg)
-		return c.String(code, msg)
+		logrus.Error(err.Error())
+		return err
 	}
-	logrus.Info("Generated Refresh Token")
-	body := map[string]string{
-		"at": access,
-		"rt": refresh,
+	refresh, err := generateRefreshToken(usr, rt, scopes)
+	if err != nil {
+		return jwtSignErr(err)
 	}
-	return c.JSON(http.StatusOK, body)
+	logrus.Info("Generated Refresh Token")
+	return c.JSON(http.StatusOK, tokensResponse{Access: access, Refresh: refresh})
 }
 
-// RefreshToken handler requires a refresh token in JSON
-// and returns a new access/refresh token pair.
-func (h *Handler) RefreshToken(c echo.Context) error {
+// bindRefreshToken extracts the refresh token from JSON
+// and returns its validated claims & user.
+func bindRefreshToken(c echo.Context) (refreshTokenClaims, domain.User, error) {
 	// Unmarshal JSON
 	var req refreshRequest
 	if err := c.Bind(&req); err != nil {
 		var (
 			msg     string = errInvalidJSON.Error()
 			details string = httpErrorMsg(err)
-			code    int    = errToHTTPCode(errInvalidJSON, "auth")
 		)
 		logrus.Error(msg + " | " + details)
-		return c.String(code, msg)
+		return refreshTokenClaims{}, domain.User{}, errInvalidJSON
 	}
 	if req.RefreshToken == "" {
-		code := errToHTTPCode(errInvalidJSON, "auth")
-		msg := "No Refresh Token Provided"
-		logrus.Error(msg)
-		return c.String(code, msg)
+		logrus.Error(errRefreshMissing.Error())
+		return refreshTokenClaims{}, domain.User{}, errRefreshMissing
 	}
 	logrus.Info("Extracted refresh token successfully")
-	// Parse Token
-	_, err := jwt.Parse(req.RefreshToken, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			msg := fmt.Sprintf("Unexpected signing method: %v", token.Header["alg"])
-			logrus.Error(msg)
-			return nil, errors.New(msg)
-		}
-		return jwtRefreshSecret(), nil
-	})
+	return parseRefreshToken(req.RefreshToken)
+}
+
+// RefreshToken handler requires a refresh token in JSON
+// and returns a new access/refresh token pair with the scopes of the given token.
+// The given refresh token can not be used again: using it twice
+// revokes all refresh tokens issued since login.
+func (h *Handler) RefreshToken(c echo.Context) error {
+	claims, usr, err := bindRefreshToken(c)
 	if err != nil {
-		msg := "Refresh Token is Invalid"
-		logrus.Error(msg + " = " + err.Error())
-		return c.String(http.StatusUnprocessableEntity, msg)
+		return err
 	}
 	logrus.Info("Refresh Token validation successful")
-	// Generate and return new Access Token
-	access, err := generateAccessToken()
+	// Rotate Refresh Token
+	rt, err := h.authenticator.RotateRefreshToken(claims.Id, usr.ID, time.Now().Add(refreshTokenExpDuration))
 	if err != nil {
-		code, msg, logMsg := jwtSignErrHandler(err)
-		logrus.Error(logMsg)
-		return c.String(code, msg)
+		logrus.Error(err.Error())
+		return err
+	}
+	// Generate and return new Access/Refresh Tokens
+	access, err := generateAccessToken(usr, claims.Scopes)
+	if err != nil {
+		return jwtSignErr(err)
 	}
 	logrus.Info("Generated Access Token")
-	body := map[string]string{
-		"at": access,
+	refresh, err := generateRefreshToken(usr, rt, claims.Scopes)
+	if err != nil {
+		return jwtSignErr(err)
+	}
+	logrus.Info("Generated Refresh Token")
+	return c.JSON(http.StatusOK, tokensResponse{Access: access, Refresh: refresh})
+}
+
+// Logout handler requires a refresh token in JSON and revokes it
+// along with the refresh tokens issued since the same login.
+func (h *Handler) Logout(c echo.Context) error {
+	claims, usr, err := bindRefreshToken(c)
+	if err != nil {
+		return err
+	}
+	if err := h.authenticator.Logout(claims.Id, usr.ID); err != nil {
+		logrus.Error(err.Error())
+		return err
+	}
+	logrus.Info("Logged out user " + usr.ID.String())
+	return c.NoContent(http.StatusNoContent)
+}
+
+// LogoutAll handler revokes all refresh tokens of the authenticated user.
+// Access tokens stay valid until they expire.
+func (h *Handler) LogoutAll(c echo.Context) error {
+	uid := userID(c)
+	if err := h.authenticator.LogoutAll(uid); err != nil {
+		logrus.Error(err.Error())
+		return err
+	}
+	logrus.Info("Logged out all sessions of user " + uid.String())
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Register handler creates a new user with the name & password in JSON.
+func (h *Handler) Register(c echo.Context) error {
+	// Unmarshal JSON
+	var req registerRequest
+	if err := c.Bind(&req); err != nil {
+		var (
+			msg     string = errInvalidJSON.Error()
+			details string = httpErrorMsg(err)
+		)
+		logrus.Error(msg + " | " + details)
+		return errInvalidJSON
+	}
+	usr, err := h.authenticator.Register(req.Name, req.Password)
+	if err != nil {
+		logrus.Error(err.Error())
+		return err
 	}
-	return c.JSON(http.StatusOK, body)
+	logrus.Info("Registered user " + usr.ID.String())
+	return c.JSON(http.StatusCreated, registerResponse{ID: usr.ID, Name: usr.Name})
 }
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// apiKeyHeader is the header of requests authenticated with an API key.
+const apiKeyHeader string = "X-API-Key"
+
+// apiKeyContextKey is the key of the authenticated API key in the context.
+const apiKeyContextKey string = "apiKey"
+
+// APIKeyAuth middleware authenticates requests with an API key in the X-API-Key header
+// and rejects requests the scope of the key does not allow.
+// Requests without the header are left to the JWT middleware.
+func (h *Handler) APIKeyAuth(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := c.Request().Header.Get(apiKeyHeader)
+		if key == "" {
+			return next(c)
+		}
+		k, err := h.authenticator.AuthenticateAPIKey(key)
+		if err != nil {
+			logrus.Error("API Key authentication failed : " + err.Error())
+			return err
+		}
+		if !k.Allows(c.Request().Method) {
+			logrus.Errorf("API Key %s of scope %s used for %s %s", k.ID, k.Scope, c.Request().Method, c.Path())
+			return auth.ErrAPIKeyScope
+		}
+		c.Set(apiKeyContextKey, k)
+		return next(c)
+	}
+}
+
+// apiKeyAuthenticated returns whether the request was authenticated with an API key.
+// It is used to skip the JWT middleware.
+func apiKeyAuthenticated(c echo.Context) bool {
+	_, ok := c.Get(apiKeyContextKey).(domain.APIKey)
+	return ok
+}
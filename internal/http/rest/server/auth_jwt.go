@@ -1,10 +1,17 @@
 package server
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
 )
 
 // jwtAccessSecret returns Jwt Signing Key for Access Tokens as a bytes slice.
@@ -27,18 +34,37 @@ const (
 )
 
 // accessTokenClaims represents claims used in Access Token.
+// The subject is the ID of the user. Scopes are the permissions of the token.
 type accessTokenClaims struct {
-	Name string `json:"name"`
+	Name   string        `json:"name"`
+	Scopes domain.Scopes `json:"scopes"`
 	jwt.StandardClaims
 }
 
-// generateAccessToken generates & returns a signed access token.
-func generateAccessToken() (string, error) {
+// refreshTokenClaims represents claims used in Refresh Token.
+// The ID is the ID of the stored refresh token and the subject is the ID of the user.
+// Scopes are given to the access tokens it refreshes.
+type refreshTokenClaims struct {
+	Name   string        `json:"name"`
+	Scopes domain.Scopes `json:"scopes"`
+	jwt.StandardClaims
+}
+
+// generateAccessToken generates & returns a signed access token for given user
+// with given scopes.
+func generateAccessToken(usr domain.User, scopes domain.Scopes) (string, error) {
 	secret := jwtAccessSecret()
+	id, err := auth.NewTokenID()
+	if err != nil {
+		return "", err
+	}
 	now := time.Now()
 	claims := &accessTokenClaims{
-		"El Hamza",
+		usr.Name,
+		scopes,
 		jwt.StandardClaims{
+			Id:        id,
+			Subject:   usr.ID.String(),
 			ExpiresAt: now.Add(accessTokenExpDuration).Unix(),
 		},
 	}
@@ -50,16 +76,75 @@ func generateAccessToken() (string, error) {
 	return signed, nil
 }
 
-// generateTokenPair generates & returns a signed refresh token.
-func generateRefreshToken() (string, error) {
+// generateRefreshToken generates & returns a signed refresh token
+// for given user & scopes with the ID & expiry of the given stored token.
+func generateRefreshToken(usr domain.User, rt domain.RefreshToken, scopes domain.Scopes) (string, error) {
 	secret := jwtRefreshSecret()
-	now := time.Now()
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
-	claims["exp"] = now.Add(refreshTokenExpDuration).Unix()
+	claims := &refreshTokenClaims{
+		usr.Name,
+		scopes,
+		jwt.StandardClaims{
+			Id:        rt.ID,
+			Subject:   usr.ID.String(),
+			ExpiresAt: rt.ExpiresAt.Unix(),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	signed, err := token.SignedString(secret)
 	if err != nil {
 		return "", err
 	}
 	return signed, nil
 }
+
+// parseRefreshToken validates the signature & expiry of the given refresh token
+// and returns its claims. It returns errRefreshInvalid if the token
+// is invalid or has no ID or subject.
+// Tokens issued without scopes grant everything.
+func parseRefreshToken(signed string) (refreshTokenClaims, domain.User, error) {
+	var claims refreshTokenClaims
+	_, err := jwt.ParseWithClaims(signed, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			msg := fmt.Sprintf("Unexpected signing method: %v", token.Header["alg"])
+			logrus.Error(msg)
+			return nil, errors.New(msg)
+		}
+		return jwtRefreshSecret(), nil
+	})
+	if err != nil {
+		logrus.Error(errRefreshInvalid.Error() + " = " + err.Error())
+		return claims, domain.User{}, errRefreshInvalid
+	}
+	uid, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if claims.Id == "" || err != nil || uid == 0 {
+		logrus.Error(errRefreshInvalid.Error() + " = no token ID or user in claims")
+		return claims, domain.User{}, errRefreshInvalid
+	}
+	if len(claims.Scopes) == 0 {
+		claims.Scopes = domain.Scopes{domain.ScopeAll}
+	}
+	return claims, domain.User{ID: domain.UserID(uid), Name: claims.Name}, nil
+}
+
+// userID returns the ID of the authenticated user: the owner of the API key
+// or the subject of the access token set in the context by the JWT middleware.
+// It returns 0 if there is none.
+func userID(c echo.Context) domain.UserID {
+	if k, ok := c.Get(apiKeyContextKey).(domain.APIKey); ok {
+		return k.UserID
+	}
+	token, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return 0
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0
+	}
+	sub, _ := claims["sub"].(string)
+	uid, err := strconv.ParseUint(sub, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return domain.UserID(uid)
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// scopeAction returns the action of requests with given http method:
+// read for safe methods, admin for deletions and write otherwise.
+func scopeAction(method string) domain.ScopeAction {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return domain.ScopeRead
+	case http.MethodDelete:
+		return domain.ScopeAdmin
+	}
+	return domain.ScopeWrite
+}
+
+// grantedScopes returns the scopes of the API key or of the access token
+// set in the context by the authentication middlewares.
+// Access tokens issued without scopes grant everything.
+func grantedScopes(c echo.Context) domain.Scopes {
+	if k, ok := c.Get(apiKeyContextKey).(domain.APIKey); ok {
+		return k.Scopes()
+	}
+	token, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return domain.Scopes{}
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return domain.Scopes{}
+	}
+	raw, ok := claims["scopes"].([]interface{})
+	if !ok {
+		return domain.Scopes{domain.ScopeAll}
+	}
+	scopes := make(domain.Scopes, 0, len(raw))
+	for _, s := range raw {
+		if str, ok := s.(string); ok {
+			scopes = append(scopes, domain.Scope(str))
+		}
+	}
+	return scopes
+}
+
+// requireScope returns a middleware rejecting requests whose scopes
+// do not grant the action of their method ( see scopeAction ) on resource.
+// It must run after the authentication middlewares.
+func requireScope(resource string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			action := scopeAction(c.Request().Method)
+			if !grantedScopes(c).Allows(resource, action) {
+				logrus.Errorf("Scope %s is required for %s %s", domain.NewScope(resource, action), c.Request().Method, c.Path())
+				return domain.ErrScopeInsufficient
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+func TestScopes(t *testing.T) {
+	defer func() {
+		repo.Users = map[domain.UserID]domain.User{}
+		repo.RefreshTokens = map[string]domain.RefreshToken{}
+		repo.APIKeys = map[domain.APIKeyID]domain.APIKey{}
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+		repo.Owners = map[string]domain.UserID{}
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	}()
+	if rec := serveJSON(http.MethodPost, "/auth/register", `{"name":"hamza","password":"test_pass"}`, ""); rec.Code != http.StatusCreated {
+		t.Fatalf("\nUnexpected register response: %d %s", rec.Code, rec.Body.String())
+	}
+	// login returns tokens with given scopes
+	login := func(scopes ...string) tokens {
+		body := `{"name":"hamza","password":"test_pass"}`
+		if len(scopes) > 0 {
+			body = fmt.Sprintf(`{"name":"hamza","password":"test_pass","scopes":["%s"]}`, strings.Join(scopes, `","`))
+		}
+		return readTokens(t, serveJSON(http.MethodPost, "/auth/login", body, ""))
+	}
+	if rec := serveJSON(http.MethodPost, "/auth/login", `{"name":"hamza","password":"test_pass","scopes":["users:read"]}`, ""); rec.Code != http.StatusBadRequest {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+	full := login()
+	dashboard := login("expenses:read", "tags:read")
+	scanner := login("expenses:write")
+	tagsAdmin := login("tags:admin")
+	keysWriter := login("keys:write")
+	// Refreshed tokens keep their scopes
+	refreshed := readTokens(t, serveJSON(http.MethodPost, "/auth/refresh", refreshJSON(dashboard.Refresh), ""))
+	const expense string = `{"label":"Lunch","time":"2020-01-01T12:00:00Z","value":12.5,"unit":"EUR"}`
+	const readWriteKey string = `{"name":"scanner","scope":"read-write"}`
+	tests := map[string]struct {
+		method       string
+		path         string
+		json         string
+		access       string
+		expectedCode int
+	}{
+		"Full Access":                        {method: http.MethodGet, path: "/budgets", access: full.Access, expectedCode: http.StatusOK},
+		"Dashboard Reads Expenses":           {method: http.MethodGet, path: "/expenses", access: dashboard.Access, expectedCode: http.StatusOK},
+		"Dashboard Adds Expense":             {method: http.MethodPost, path: "/expenses", json: expense, access: dashboard.Access, expectedCode: http.StatusForbidden},
+		"Dashboard Reads Budgets":            {method: http.MethodGet, path: "/budgets", access: dashboard.Access, expectedCode: http.StatusForbidden},
+		"Refreshed Dashboard":                {method: http.MethodPost, path: "/tags", json: `{"name":"food"}`, access: refreshed.Access, expectedCode: http.StatusForbidden},
+		"Scanner Adds Expense":               {method: http.MethodPost, path: "/expenses", json: expense, access: scanner.Access, expectedCode: http.StatusCreated},
+		"Scanner Reads Expenses":             {method: http.MethodGet, path: "/expenses", access: scanner.Access, expectedCode: http.StatusForbidden},
+		"Tags Admin Adds Tag":                {method: http.MethodPost, path: "/tags", json: `{"name":"sport"}`, access: tagsAdmin.Access, expectedCode: http.StatusCreated},
+		"Tags Admin Lists Tags":              {method: http.MethodGet, path: "/tags", access: tagsAdmin.Access, expectedCode: http.StatusOK},
+		"Tags Admin Manages Keys":            {method: http.MethodGet, path: "/auth/keys", access: tagsAdmin.Access, expectedCode: http.StatusForbidden},
+		"Keys Writer Creates Read-Write Key": {method: http.MethodPost, path: "/auth/keys", json: readWriteKey, access: keysWriter.Access, expectedCode: http.StatusForbidden},
+		"Keys Writer Creates Read Key":       {method: http.MethodPost, path: "/auth/keys", json: `{"name":"dashboard","scope":"read"}`, access: keysWriter.Access, expectedCode: http.StatusForbidden},
+		"Full Access Creates Read-Write Key": {method: http.MethodPost, path: "/auth/keys", json: readWriteKey, access: full.Access, expectedCode: http.StatusCreated},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if rec := serveJSON(test.method, test.path, test.json, test.access); rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
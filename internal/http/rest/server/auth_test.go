@@ -1,6 +1,7 @@
 package server_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -11,55 +12,96 @@ import (
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/elhamza90/lifelog/internal/domain"
 	"github.com/labstack/echo/v4"
 	"golang.org/x/crypto/bcrypt"
 )
 
+func TestRegister(t *testing.T) {
+	defer func() { repo.Users = map[domain.UserID]domain.User{} }()
+	const path string = "/auth/register"
+	tests := map[string]struct {
+		json         string
+		expectedCode int
+	}{
+		"Correct": {
+			json:         `{"name":"Hamza","password":"test_pass"}`,
+			expectedCode: http.StatusCreated,
+		},
+		"Duplicate Name": {
+			json:         `{"name":"hamza","password":"test_pass"}`,
+			expectedCode: http.StatusConflict,
+		},
+		"Invalid Name": {
+			json:         `{"name":"ha mza","password":"test_pass"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Short Password": {
+			json:         `{"name":"other","password":"pswd"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Invalid JSON": {
+			json:         `{"name":}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	// The correct user must be registered before the duplicate one
+	for _, name := range []string{"Correct", "Duplicate Name", "Invalid Name", "Short Password", "Invalid JSON"} {
+		test := tests[name]
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(test.json))
+			req.Header.Set("Content-type", "application/json")
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath(path)
+			handle(hnd.Register, ctx)
+			body := rec.Body.String()
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
+			}
+			if rec.Code == http.StatusCreated {
+				pat := `^{"id":[1-9][0-9]*,"name":"hamza"}`
+				if match, err := regexp.Match(pat, []byte(body)); !match {
+					t.Fatal(body, err)
+				}
+			}
+		})
+	}
+}
+
 func TestLogin(t *testing.T) {
+	defer func() { repo.Users = map[domain.UserID]domain.User{} }()
 	const path string = "/auth/login"
-	// Sub-test No original password is set
-	t.Run("Password not found in system", func(t *testing.T) {
-		os.Setenv(hashEnvVarName, "")
-		json := `{"password":"somepassword"}`
-		expectedCode := http.StatusInternalServerError
-		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(json))
-		req.Header.Set("Content-type", "application/json")
-		rec := httptest.NewRecorder()
-		ctx := router.NewContext(req, rec)
-		ctx.SetPath(path)
-		hnd.Login(ctx)
-		if rec.Code != expectedCode {
-			body := rec.Body.String()
-			t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", expectedCode, rec.Code, body)
-		}
-	})
-	// Generate Password hash and save it in env variable
+	// Generate Password hash and save user
 	const testPass string = "test_pass"
 	hash, err := bcrypt.GenerateFromPassword([]byte(testPass), 10)
 	if err != nil {
 		t.Fatalf("Error generating bcrypt hash: %s", err)
 	}
-	os.Setenv(hashEnvVarName, string(hash))
-	defer os.Setenv(hashEnvVarName, "")
+	repo.SaveUser(domain.User{Name: "hamza", PasswordHash: string(hash)})
 	// Subtests Definition
 	tests := map[string]struct {
 		json         string
 		expectedCode int
 	}{
 		"Correct Credentials": {
-			json:         fmt.Sprintf("{\"password\":\"%s\"}", testPass),
+			json:         fmt.Sprintf("{\"name\":\"hamza\",\"password\":\"%s\"}", testPass),
 			expectedCode: http.StatusOK,
 		},
 		"Incorrect Credentials": {
-			json:         `{"password": "mywrongtestpass"}`,
+			json:         `{"name":"hamza","password": "mywrongtestpass"}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		"Unknown User": {
+			json:         fmt.Sprintf("{\"name\":\"someone\",\"password\":\"%s\"}", testPass),
 			expectedCode: http.StatusUnauthorized,
 		},
 		"Short Password": {
-			json:         `{"password": "pswd"}`,
+			json:         `{"name":"hamza","password": "pswd"}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		"Long Password": {
-			json:         fmt.Sprintf("{\"password\": \"%s\"}", strings.Repeat("abc", 100)),
+			json:         fmt.Sprintf("{\"name\":\"hamza\",\"password\": \"%s\"}", strings.Repeat("abc", 100)),
 			expectedCode: http.StatusBadRequest,
 		},
 		"Invalid JSON": {
@@ -80,7 +122,7 @@ func TestLogin(t *testing.T) {
 			rec = httptest.NewRecorder()
 			ctx = router.NewContext(req, rec)
 			ctx.SetPath(path)
-			hnd.Login(ctx)
+			handle(hnd.Login, ctx)
 			body := rec.Body.String()
 			if rec.Code != test.expectedCode {
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
@@ -96,12 +138,21 @@ func TestLogin(t *testing.T) {
 }
 
 func TestRefreshToken(t *testing.T) {
+	defer func() { repo.RefreshTokens = map[string]domain.RefreshToken{} }()
 	correctSecret := os.Getenv("LFLG_JWT_REFRESH_SECRET")
+	// Store a refresh token
+	const storedID string = "stored"
+	repo.SaveRefreshToken(domain.RefreshToken{ID: storedID, Family: storedID, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)})
 	// Create a new token
-	genToken := func(secret string, exp time.Time) string {
+	genToken := func(secret string, exp time.Time, uid domain.UserID, id string) string {
 		token := jwt.New(jwt.SigningMethodHS256)
 		claims := token.Claims.(jwt.MapClaims)
 		claims["exp"] = exp.Unix()
+		claims["jti"] = id
+		if uid > 0 {
+			claims["sub"] = uid.String()
+			claims["name"] = "hamza"
+		}
 		signed, err := token.SignedString([]byte(secret))
 		if err != nil {
 			t.Log(err)
@@ -117,17 +168,33 @@ func TestRefreshToken(t *testing.T) {
 		expectedCode int
 	}{
 		"Correct": {
-			json:         fmt.Sprintf("{\"refresh\":\"%s\"}", genToken(correctSecret, now.Add(time.Duration(time.Hour)))),
+			json:         fmt.Sprintf("{\"refresh\":\"%s\"}", genToken(correctSecret, now.Add(time.Duration(time.Hour)), 1, storedID)),
 			expectedCode: http.StatusOK,
 		},
 		"Expired Token": {
-			json:         fmt.Sprintf("{\"refresh\":\"%s\"}", genToken(correctSecret, now.Add(time.Duration(-1*time.Hour)))),
+			json:         fmt.Sprintf("{\"refresh\":\"%s\"}", genToken(correctSecret, now.Add(time.Duration(-1*time.Hour)), 1, storedID)),
 			expectedCode: http.StatusUnprocessableEntity,
 		},
 		"Token Signed with wrong secret": {
-			json:         fmt.Sprintf("{\"refresh\":\"%s\"}", genToken(os.Getenv("LFLG_JWT_ACCESS_SECRET"), now.Add(time.Duration(time.Hour)))),
+			json:         fmt.Sprintf("{\"refresh\":\"%s\"}", genToken(os.Getenv("LFLG_JWT_ACCESS_SECRET"), now.Add(time.Duration(time.Hour)), 1, storedID)),
 			expectedCode: http.StatusUnprocessableEntity,
 		},
+		"No User in Token": {
+			json:         fmt.Sprintf("{\"refresh\":\"%s\"}", genToken(correctSecret, now.Add(time.Duration(time.Hour)), 0, storedID)),
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		"No ID in Token": {
+			json:         fmt.Sprintf("{\"refresh\":\"%s\"}", genToken(correctSecret, now.Add(time.Duration(time.Hour)), 1, "")),
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		"Unknown Token": {
+			json:         fmt.Sprintf("{\"refresh\":\"%s\"}", genToken(correctSecret, now.Add(time.Duration(time.Hour)), 1, "unknown")),
+			expectedCode: http.StatusUnauthorized,
+		},
+		"Token of another User": {
+			json:         fmt.Sprintf("{\"refresh\":\"%s\"}", genToken(correctSecret, now.Add(time.Duration(time.Hour)), 2, storedID)),
+			expectedCode: http.StatusUnauthorized,
+		},
 		"No Token in JSON": {
 			json:         `{"what":"is this?"}`,
 			expectedCode: http.StatusBadRequest,
@@ -147,13 +214,13 @@ func TestRefreshToken(t *testing.T) {
 			rec = httptest.NewRecorder()
 			ctx = router.NewContext(req, rec)
 			ctx.SetPath(path)
-			hnd.RefreshToken(ctx)
+			handle(hnd.RefreshToken, ctx)
 			body := rec.Body.String()
 			if rec.Code != test.expectedCode {
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
 			}
 			if rec.Code == http.StatusOK {
-				pat := `^{"at":".*"}`
+				pat := `^{"at":".*","rt":".*"}`
 				if match, err := regexp.Match(pat, []byte(body)); !match {
 					t.Fatal(body, err)
 				}
@@ -161,3 +228,53 @@ func TestRefreshToken(t *testing.T) {
 		})
 	}
 }
+
+func TestUserIsolation(t *testing.T) {
+	defer func() {
+		repo.Users = map[domain.UserID]domain.User{}
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Owners = map[string]domain.UserID{}
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+		repo.RefreshTokens = map[string]domain.RefreshToken{}
+	}()
+	// serve sends a request through the router with given access token
+	serve := func(method string, path string, json string, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(json))
+		req.Header.Set("Content-type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	// login registers a user and returns its access token
+	login := func(name string) string {
+		creds := fmt.Sprintf(`{"name":"%s","password":"test_pass"}`, name)
+		if rec := serve(http.MethodPost, "/auth/register", creds, ""); rec.Code != http.StatusCreated {
+			t.Fatalf("\nUnexpected register response: %d %s", rec.Code, rec.Body.String())
+		}
+		rec := serve(http.MethodPost, "/auth/login", creds, "")
+		var tk struct {
+			Access string `json:"at"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &tk); err != nil || tk.Access == "" {
+			t.Fatalf("\nUnexpected login response: %d %s", rec.Code, rec.Body.String())
+		}
+		return tk.Access
+	}
+	alice, bob := login("alice"), login("bob")
+	if rec := serve(http.MethodPost, "/tags", `{"name":"sport"}`, alice); rec.Code != http.StatusCreated {
+		t.Fatalf("\nUnexpected add tag response: %d %s", rec.Code, rec.Body.String())
+	}
+	if body := serve(http.MethodGet, "/tags", "", alice).Body.String(); !strings.Contains(body, "sport") {
+		t.Fatalf("\nExpected tag of alice in her tags\nReturned Body: %s", body)
+	}
+	if body := serve(http.MethodGet, "/tags", "", bob).Body.String(); strings.Contains(body, "sport") {
+		t.Fatalf("\nExpected tag of alice not to be in tags of bob\nReturned Body: %s", body)
+	}
+	// Bob can use the same tag name
+	if rec := serve(http.MethodPost, "/tags", `{"name":"sport"}`, bob); rec.Code != http.StatusCreated {
+		t.Fatalf("\nUnexpected add tag response: %d %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// budgetIDParam extracts the budget ID from the path parameter :id
+func budgetIDParam(c echo.Context) (domain.BudgetID, error) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		msg := fmt.Sprintf("Error while converting path param Budget ID with value %s to int", idStr)
+		logrus.Error(msg + " | " + err.Error())
+		return 0, errIDParam
+	}
+	return domain.BudgetID(id), nil
+}
+
+// GetAllBudgets handler returns a list of all budgets.
+func (h *Handler) GetAllBudgets(c echo.Context) error {
+	budgets, err := h.lister.AllBudgets()
+	if err != nil {
+		msg := "Internal Server Error while fetching budgets"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Info("All budgets fetched successfully")
+	respBudgets := make([]JSONRespBudget, len(budgets))
+	var respBudget JSONRespBudget
+	for i, b := range budgets {
+		respBudget.From(b)
+		respBudgets[i] = respBudget
+	}
+	return c.JSON(http.StatusOK, respBudgets)
+}
+
+// BudgetDetails handler returns details of budget with given ID.
+// It required a path parameter :id
+func (h *Handler) BudgetDetails(c echo.Context) error {
+	id, err := budgetIDParam(c)
+	if err != nil {
+		return err
+	}
+	b, err := h.lister.Budget(id)
+	if err != nil {
+		msg := fmt.Sprintf("Internal Server Error while fetching budget %s", id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Fetched budget %s successfully", id)
+	var respBudget JSONRespBudget
+	respBudget.From(b)
+	return c.JSON(http.StatusOK, respBudget)
+}
+
+// AddBudget handler adds given budget and returns it.
+func (h *Handler) AddBudget(c echo.Context) error {
+	// Json unmarshall
+	var jsBudget JSONReqBudget
+	if err := c.Bind(&jsBudget); err != nil {
+		var (
+			msg     string = errInvalidJSON.Error()
+			details string = httpErrorMsg(err)
+		)
+		logrus.Error(msg + " : " + details)
+		return errInvalidJSON
+	}
+	b, err := jsBudget.ToDomain()
+	if err != nil {
+		msg := "Error while converting budget amount to its currency"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	// Call adding service
+	id, err := h.adder.NewBudget(b)
+	if err != nil {
+		msg := "Internal Server Error while adding budget"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Created budget %s successfully", id)
+	// Retrieve created budget
+	created, err := h.lister.Budget(id)
+	if err != nil {
+		msg := fmt.Sprintf("Internal Server Error while fetching budget %s", id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	var respBudget JSONRespBudget
+	respBudget.From(created)
+	return c.JSON(http.StatusCreated, respBudget)
+}
+
+// EditBudget handler edits a budget with given ID and returns it.
+// It required a path parameter :id
+func (h *Handler) EditBudget(c echo.Context) error {
+	id, err := budgetIDParam(c)
+	if err != nil {
+		return err
+	}
+	// Json unmarshall
+	var jsBudget JSONReqBudget
+	if err := c.Bind(&jsBudget); err != nil {
+		var (
+			msg     string = errInvalidJSON.Error()
+			details string = httpErrorMsg(err)
+		)
+		logrus.Error(msg + " | " + details)
+		return errInvalidJSON
+	}
+	b, err := jsBudget.ToDomain()
+	if err != nil {
+		msg := "Error while converting budget amount to its currency"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	b.ID = id
+	// Update
+	if err := h.editor.EditBudget(b); err != nil {
+		msg := fmt.Sprintf("Internal Server Error while updating budget %s", id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Updated budget %s successfully", id)
+	// Retrieve edited budget
+	edited, err := h.lister.Budget(id)
+	if err != nil {
+		msg := fmt.Sprintf("Internal Server Error while fetching updated budget %s", id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	var respBudget JSONRespBudget
+	respBudget.From(edited)
+	return c.JSON(http.StatusOK, respBudget)
+}
+
+// DeleteBudget handler deletes a budget with given ID.
+// It required a path parameter :id
+func (h *Handler) DeleteBudget(c echo.Context) error {
+	id, err := budgetIDParam(c)
+	if err != nil {
+		return err
+	}
+	if err := h.deleter.Budget(id); err != nil {
+		msg := fmt.Sprintf("Internal Server Error while deleting budget %s", id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Deleted budget %s successfully", id)
+	return c.String(http.StatusNoContent, "Budget Deleted Successfully")
+}
+
+// BudgetStatus handler returns the amount spent on the budget with given ID
+// in its current period compared to its limit, flagging over-budget
+// and projected overspend at the current pace.
+// It required a path parameter :id
+func (h *Handler) BudgetStatus(c echo.Context) error {
+	id, err := budgetIDParam(c)
+	if err != nil {
+		return err
+	}
+	st, err := h.reporter.BudgetStatus(id, time.Now())
+	if err != nil {
+		msg := fmt.Sprintf("Internal Server Error while computing status of budget %s", id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Computed status of budget %s successfully", id)
+	var respStatus JSONRespBudgetStatus
+	respStatus.From(st)
+	return c.JSON(http.StatusOK, respStatus)
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// JSONReqBudget is used to unmarshal a json budget.
+// A budget without tag limits all expenses.
+// Amount is a decimal number in the currency ( ex: 300.00 ).
+type JSONReqBudget struct {
+	ID       domain.BudgetID `json:"id"`
+	TagID    domain.TagID    `json:"tagId"`
+	Period   domain.Period   `json:"period"`
+	Amount   json.Number     `json:"amount"`
+	Currency string          `json:"currency"`
+}
+
+// ToDomain constructs and returns a domain.Budget from a JSONReqBudget.
+// It returns an error if the currency or the amount are invalid
+// because the amount is converted to the minor unit of the currency.
+func (reqBudget JSONReqBudget) ToDomain() (domain.Budget, error) {
+	cur, err := domain.ParseCurrency(reqBudget.Currency)
+	if err != nil {
+		return domain.Budget{}, err
+	}
+	amount, err := domain.ParseAmount(reqBudget.Amount.String(), cur)
+	if err != nil {
+		return domain.Budget{}, err
+	}
+	return domain.Budget{
+		ID:       reqBudget.ID,
+		TagID:    reqBudget.TagID,
+		Period:   reqBudget.Period,
+		Amount:   amount,
+		Currency: cur,
+	}, nil
+}
+
+// JSONRespBudget is used to marshal a budget to json.
+// Amount is a decimal number with the decimals of the currency.
+type JSONRespBudget struct {
+	ID       domain.BudgetID `json:"id"`
+	TagID    domain.TagID    `json:"tagId"`
+	Period   domain.Period   `json:"period"`
+	Amount   json.Number     `json:"amount"`
+	Currency domain.Currency `json:"currency"`
+}
+
+// From constructs a JSONRespBudget object from a domain.Budget object.
+func (respBudget *JSONRespBudget) From(b domain.Budget) {
+	(*respBudget).ID = b.ID
+	(*respBudget).TagID = b.TagID
+	(*respBudget).Period = b.Period
+	(*respBudget).Amount = json.Number(b.Amount.Format(b.Currency))
+	(*respBudget).Currency = b.Currency
+}
+
+// JSONRespBudgetStatus is used to marshal the status of a budget
+// in its current period to json.
+// Amounts are in the currency of the budget.
+type JSONRespBudgetStatus struct {
+	Budget             JSONRespBudget `json:"budget"`
+	Start              time.Time      `json:"from"`
+	End                time.Time      `json:"to"`
+	Spent              json.Number    `json:"spent"`
+	Remaining          json.Number    `json:"remaining"`
+	Projected          json.Number    `json:"projected"`
+	OverBudget         bool           `json:"overBudget"`
+	ProjectedOverspend bool           `json:"projectedOverspend"`
+}
+
+// From constructs a JSONRespBudgetStatus object from a domain.BudgetStatus object.
+func (respStatus *JSONRespBudgetStatus) From(st domain.BudgetStatus) {
+	cur := st.Budget.Currency
+	(*respStatus).Budget.From(st.Budget)
+	(*respStatus).Start = st.From
+	(*respStatus).End = st.To
+	(*respStatus).Spent = json.Number(st.Spent.Format(cur))
+	(*respStatus).Remaining = json.Number(st.Remaining.Format(cur))
+	(*respStatus).Projected = json.Number(st.Projected.Format(cur))
+	(*respStatus).OverBudget = st.OverBudget()
+	(*respStatus).ProjectedOverspend = st.ProjectedOverspend()
+}
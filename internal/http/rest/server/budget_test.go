@@ -0,0 +1,203 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+func TestAddBudget(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "groceries"},
+	}
+	defer func() { repo.Budgets = map[domain.BudgetID]domain.Budget{} }()
+	// Sub-tests definitions
+	tests := map[string]struct {
+		json         string
+		expectedCode int
+		expectedBody string
+	}{
+		"Correct": {
+			json:         `{"tagId":1,"period":"month","amount":300,"currency":"eur"}`,
+			expectedCode: http.StatusCreated,
+			expectedBody: `"tagId":1,"period":"month","amount":300.00,"currency":"EUR"`,
+		},
+		"All Expenses": {
+			json:         `{"period":"week","amount":"150.5","currency":"USD"}`,
+			expectedCode: http.StatusCreated,
+			expectedBody: `"tagId":0,"period":"week","amount":150.50,"currency":"USD"`,
+		},
+		"Non-Existing Tag": {
+			json:         `{"tagId":33,"period":"month","amount":300,"currency":"EUR"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		"Invalid Period": {
+			json:         `{"period":"quarter","amount":300,"currency":"EUR"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Zero Amount": {
+			json:         `{"period":"month","amount":0,"currency":"EUR"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Amount Too Precise": {
+			json:         `{"period":"month","amount":10.555,"currency":"EUR"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Unknown Currency": {
+			json:         `{"period":"month","amount":300,"currency":"euro"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Wrong Json": {
+			json:         `{"period":"month","amount":300,"currency":"EUR"`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	// Sub-tests Execution
+	const path string = "/budgets"
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(test.json))
+			req.Header.Set("Content-type", "application/json")
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath(path)
+			handle(hnd.AddBudget, ctx)
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), test.expectedBody) {
+				t.Fatalf("\nExpected Body to contain: %s\nReturned Body: %s", test.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestEditAndDeleteBudget(t *testing.T) {
+	repo.Budgets = map[domain.BudgetID]domain.Budget{
+		1: {ID: 1, Period: domain.PeriodMonth, Amount: 30000, Currency: "EUR"},
+	}
+	defer func() { repo.Budgets = map[domain.BudgetID]domain.Budget{} }()
+	// Sub-tests definitions
+	tests := map[string]struct {
+		method       string
+		handler      echo.HandlerFunc
+		idStr        string
+		json         string
+		expectedCode int
+	}{
+		"Details": {
+			method: http.MethodGet, handler: hnd.BudgetDetails, idStr: "1",
+			expectedCode: http.StatusOK,
+		},
+		"Edit": {
+			method: http.MethodPut, handler: hnd.EditBudget, idStr: "1",
+			json:         `{"period":"week","amount":75,"currency":"EUR"}`,
+			expectedCode: http.StatusOK,
+		},
+		"Edit Non-Existing": {
+			method: http.MethodPut, handler: hnd.EditBudget, idStr: "2",
+			json:         `{"period":"week","amount":75,"currency":"EUR"}`,
+			expectedCode: http.StatusNotFound,
+		},
+		"Delete Wrong Id Format": {
+			method: http.MethodDelete, handler: hnd.DeleteBudget, idStr: "one",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Delete": {
+			method: http.MethodDelete, handler: hnd.DeleteBudget, idStr: "1",
+			expectedCode: http.StatusNoContent,
+		},
+		"Details Deleted": {
+			method: http.MethodGet, handler: hnd.BudgetDetails, idStr: "1",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	// Sub-tests Execution ( in order )
+	const path string = "/budgets/:id"
+	for _, name := range []string{"Details", "Edit", "Edit Non-Existing", "Delete Wrong Id Format", "Delete", "Details Deleted"} {
+		test := tests[name]
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, fmt.Sprintf("/budgets/%s", test.idStr), strings.NewReader(test.json))
+			req.Header.Set("Content-type", "application/json")
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath(path)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(test.idStr)
+			handle(test.handler, ctx)
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestBudgetStatus(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "groceries"},
+	}
+	// Expenses at the start of the current month
+	start := domain.PeriodMonth.Start(time.Now())
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Exp A", Time: start, Value: 20000, Unit: "EUR", Tags: []domain.Tag{repo.Tags[1]}},
+		2: {ID: 2, Label: "Exp B", Time: start, Value: 1000, Unit: "EUR"},
+		3: {ID: 3, Label: "Exp C", Time: start.Add(-time.Hour), Value: 50000, Unit: "EUR", Tags: []domain.Tag{repo.Tags[1]}},
+	}
+	repo.Budgets = map[domain.BudgetID]domain.Budget{
+		1: {ID: 1, TagID: 1, Period: domain.PeriodMonth, Amount: 10000, Currency: "EUR"},
+		2: {ID: 2, Period: domain.PeriodYear, Amount: 100000000, Currency: "EUR"},
+	}
+	defer func() {
+		repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+		repo.Budgets = map[domain.BudgetID]domain.Budget{}
+	}()
+	// Sub-tests definitions
+	tests := map[string]struct {
+		idStr        string
+		expectedCode int
+		expectedBody string
+	}{
+		"Over Budget": {
+			idStr:        "1",
+			expectedCode: http.StatusOK,
+			expectedBody: `"spent":200.00,"remaining":-100.00,`,
+		},
+		"Under Budget": {
+			idStr:        "2",
+			expectedCode: http.StatusOK,
+			expectedBody: `"overBudget":false`,
+		},
+		"Non-Existing Budget": {
+			idStr:        "3",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	// Sub-tests Execution
+	const path string = "/budgets/:id/status"
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/budgets/%s/status", test.idStr), nil)
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath(path)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(test.idStr)
+			handle(hnd.BudgetStatus, ctx)
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), test.expectedBody) {
+				t.Fatalf("\nExpected Body to contain: %s\nReturned Body: %s", test.expectedBody, rec.Body.String())
+			}
+			if test.idStr == "1" && !strings.Contains(rec.Body.String(), `"overBudget":true,"projectedOverspend":true`) {
+				t.Fatalf("\nExpected over budget flags\nReturned Body: %s", rec.Body.String())
+			}
+		})
+	}
+}
@@ -1,22 +1,25 @@
 package server
 
 import (
-	"errors"
-	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/elhamza90/lifelog/internal/domain"
 	"github.com/elhamza90/lifelog/internal/store"
-	"github.com/elhamza90/lifelog/internal/usecase/auth"
-	"github.com/elhamza90/lifelog/internal/usecase/deleting"
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
 )
 
-// dateFilterFormat specifies the format of date in a query filter.
+// dateFilterFormat specifies the legacy format of date in a query filter.
 const dateFilterFormat string = "01-02-2006"
 
-// errInvalidJSON represents an error that occured while binding
-// (unmarshaling) a json request to struct type.
-var errInvalidJSON error = errors.New("Invalid JSON")
+// dateFilterFormats specifies the formats accepted for a date in a query filter:
+// the legacy format and ISO-8601 dates & date-times.
+// The first ones specify a day without time.
+var dateFilterFormats = []string{dateFilterFormat, "2006-01-02", time.RFC3339, "2006-01-02T15:04:05"}
+
+// nbrDayFilterFormats specifies how many of dateFilterFormats specify a day without time.
+const nbrDayFilterFormats int = 2
 
 // httpErrorMsg extracts & returns the error message from echo.HTTPError struct.
 func httpErrorMsg(err error) string {
@@ -24,73 +27,106 @@ func httpErrorMsg(err error) string {
 	return he.Message.(string)
 }
 
-// errToHTTPCode returns the http code that should be sent for an error.
-// The grp parameter specifies which handler group called the function
-// because some errors will get treated differently depending on the handler
-// in which they were received.
-// Ex: a Tag not found will raise a StatusNotFound in a tag handler,
-//     but will raise a StatusUnprocessableEntity in an expense handler.
-func errToHTTPCode(err error, grp string) int {
-	switch err {
-	// rest errors
-	case errInvalidJSON:
-		return http.StatusBadRequest
-	case errSigningJwt:
-		return http.StatusInternalServerError
-	// auth errors
-	case auth.ErrPasswordLength:
-		return http.StatusBadRequest
-	case auth.ErrIncorrectCredentials:
-		return http.StatusUnauthorized
-	case auth.ErrHashNotFound:
-		return http.StatusInternalServerError
-	// domain errors
-	case domain.ErrTagNameDuplicate:
-		fallthrough
-	case domain.ErrTagNameLen:
-		fallthrough
-	case domain.ErrTagNameInvalidCharacters:
-		return http.StatusBadRequest
-	case domain.ErrActivityTimeFuture:
-		fallthrough
-	case domain.ErrActivityLabelLength:
-		fallthrough
-	case domain.ErrActivityPlaceLength:
-		fallthrough
-	case domain.ErrActivityDescLength:
-		fallthrough
-	case domain.ErrExpenseLabelLength:
-		fallthrough
-	case domain.ErrExpenseValue:
-		fallthrough
-	case domain.ErrExpenseUnitLength:
-		fallthrough
-	case domain.ErrExpenseTimeFuture:
-		return http.StatusBadRequest
-	// usecase errors
-	case deleting.ErrTagHasExpenses:
-		fallthrough
-	case deleting.ErrTagHasActivities:
-		fallthrough
-	case deleting.ErrActivityHasExpenses:
-		return http.StatusUnprocessableEntity
-	// store errors
-	case store.ErrTagNotFound:
-		if grp == "tags" {
-			return http.StatusNotFound
+// parseDateFilter parses a date in a query filter using one of the accepted formats.
+// When the date specifies a day without time and endOfDay is true,
+// the last instant of that day is returned instead of its start.
+func parseDateFilter(str string, endOfDay bool) (time.Time, error) {
+	for i, frmt := range dateFilterFormats {
+		date, err := time.Parse(frmt, str)
+		if err != nil {
+			continue
 		}
-		return http.StatusUnprocessableEntity
-	case store.ErrActivityNotFound:
-		if grp == "activities" {
-			return http.StatusNotFound
+		if endOfDay && i < nbrDayFilterFormats {
+			date = date.AddDate(0, 0, 1).Add(-time.Nanosecond)
 		}
-		return http.StatusUnprocessableEntity
-	case store.ErrExpenseNotFound:
-		if grp == "expenses" {
-			return http.StatusNotFound
+		return date, nil
+	}
+	return time.Time{}, errDateFilterFormat
+}
+
+// dateRangeFilter extracts the optional query parameters "from" and "to"
+// specifying a date range ( see parseDateFilter for accepted formats ).
+// If "from" is missing, the given default value is used.
+// If "to" is missing, the range goes up to now.
+// When "to" specifies a day, the whole day is included in the range.
+func dateRangeFilter(c echo.Context, defaultFrom time.Time) (from time.Time, to time.Time, err error) {
+	fromStr := c.QueryParam("from")
+	toStr := c.QueryParam("to")
+	logrus.Debugf("Extracted query params from: %s, to: %s", fromStr, toStr)
+	if len(fromStr) == 0 {
+		from = defaultFrom
+	} else if from, err = parseDateFilter(fromStr, false); err != nil {
+		return from, to, err
+	}
+	if len(toStr) == 0 {
+		to = time.Now()
+	} else if to, err = parseDateFilter(toStr, true); err != nil {
+		return from, to, err
+	}
+	return from, to, nil
+}
+
+// pageRequest extracts the optional pagination query parameters:
+//	- "limit": maximum number of items in the page
+//	- "cursor": cursor returned with the previous page
+//	- "sort": field the list is sorted by ( time, label or value )
+//	- "order": asc or desc
+// If "sort" or "order" are missing, the given default values are used.
+func pageRequest(c echo.Context, defaultSort store.SortField, defaultDesc bool) (store.PageRequest, error) {
+	p := store.PageRequest{
+		Cursor: c.QueryParam("cursor"),
+		Sort:   store.SortField(c.QueryParam("sort")),
+		Desc:   defaultDesc,
+	}
+	logrus.Debugf("Extracted query params limit: %s, cursor: %s, sort: %s, order: %s", c.QueryParam("limit"), p.Cursor, p.Sort, c.QueryParam("order"))
+	if limitStr := c.QueryParam("limit"); len(limitStr) > 0 {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return p, errPageParams
 		}
-		return http.StatusInternalServerError
+		p.Limit = limit
+	}
+	if len(p.Sort) == 0 {
+		p.Sort = defaultSort
+	}
+	switch c.QueryParam("order") {
+	case "":
+	case "asc":
+		p.Desc = false
+	case "desc":
+		p.Desc = true
 	default:
-		return http.StatusInternalServerError
+		return p, errPageParams
+	}
+	return p, nil
+}
+
+// statusFilter extracts the optional query parameter "status"
+// ( planned, done or cancelled ) filtering listed activities & expenses.
+// It returns an empty status if the parameter is missing.
+func statusFilter(c echo.Context) (domain.Status, error) {
+	stStr := c.QueryParam("status")
+	logrus.Debugf("Extracted query param status: %s", stStr)
+	if len(stStr) == 0 {
+		return "", nil
+	}
+	return domain.ParseStatus(stStr)
+}
+
+// defaultPlannedDateFilter returns the default end of the date range
+// when listing planned activities & expenses ( default is 3 months ahead ).
+func defaultPlannedDateFilter() time.Time {
+	return time.Now().AddDate(0, 3, 0)
+}
+
+// currencyFilter extracts the optional query parameter "currency" specifying
+// the ISO-4217 currency expenses must be converted to.
+// It returns an empty currency if the parameter is missing.
+func currencyFilter(c echo.Context) (domain.Currency, error) {
+	curStr := c.QueryParam("currency")
+	logrus.Debugf("Extracted query param currency: %s", curStr)
+	if len(curStr) == 0 {
+		return "", nil
 	}
+	return domain.ParseCurrency(curStr)
 }
@@ -0,0 +1,252 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// Errors of the rest layer
+var (
+	// errInvalidJSON represents an error that occured while binding
+	// (unmarshaling) a json request to struct type.
+	errInvalidJSON error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "invalid_json",
+		Message: "Invalid JSON",
+	}
+	// errIDParam represents an error that occured while parsing the :id path parameter.
+	errIDParam error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "id_format",
+		Message: "ID must be an integer",
+		Field:   "id",
+	}
+	// errDateFilterFormat represents an error that occured while parsing a date in a query filter.
+	errDateFilterFormat error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "date_format",
+		Message: "Date must be formatted as mm-dd-yyyy, yyyy-mm-dd or yyyy-mm-ddThh:mm:ssZ",
+	}
+	// errPageParams represents an error that occured while parsing pagination query params.
+	errPageParams error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "page_params",
+		Message: "Page limit must be an integer and order must be asc or desc",
+	}
+	// errTagFilterFormat represents an error that occured while parsing the tag query filter.
+	errTagFilterFormat error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "tag_filter_format",
+		Message: "Tag ID must be an integer",
+		Field:   "tag",
+	}
+	// errSearchLimitFormat represents an error that occured while parsing the search limit.
+	errSearchLimitFormat error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "search_limit_format",
+		Message: "Search limit must be an integer",
+		Field:   "limit",
+	}
+	// errUpcomingLimitFormat represents an error that occured while parsing the number of upcoming occurrences.
+	errUpcomingLimitFormat error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "upcoming_limit_format",
+		Message: "Upcoming occurrences limit must be an integer",
+		Field:   "limit",
+	}
+	// errTransferNotFound represents the error returned when a CSV export or import does not exist.
+	errTransferNotFound error = &domain.Error{
+		Kind:    domain.KindNotFound,
+		Code:    "transfer_not_found",
+		Message: "No such CSV export or import",
+	}
+	// errTrashKind represents an error that occured while parsing the :kind path parameter of the trash.
+	errTrashKind error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "trash_kind",
+		Message: "Kind must be one of tags, activities or expenses",
+		Field:   "kind",
+	}
+	// errSigningJwt represents the error returned when Token can not be signed.
+	errSigningJwt error = &domain.Error{
+		Kind:    domain.KindInternal,
+		Code:    "jwt_signing",
+		Message: "Could not sign JWT Token",
+	}
+	// errRefreshMissing represents the error returned when no refresh token is provided.
+	errRefreshMissing error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "refresh_token_missing",
+		Message: "No Refresh Token Provided",
+		Field:   "refresh",
+	}
+	// errRefreshInvalid represents the error returned when the refresh token can not be validated.
+	errRefreshInvalid error = &domain.Error{
+		Kind:    domain.KindUnprocessable,
+		Code:    "refresh_token_invalid",
+		Message: "Refresh Token is Invalid",
+		Field:   "refresh",
+	}
+	// errInternal is rendered in place of errors which are not domain errors
+	// so their details are not leaked to the client.
+	errInternal *domain.Error = &domain.Error{
+		Kind:    domain.KindInternal,
+		Code:    "internal",
+		Message: "Internal Server Error",
+	}
+)
+
+// notFoundGroups maps the codes of "not found" errors to the route group
+// of the resource. Such an error raises a StatusNotFound in its group
+// but a StatusUnprocessableEntity in others.
+// Ex: a Tag not found will raise a StatusNotFound in a tag handler,
+//     but will raise a StatusUnprocessableEntity in an expense handler.
+var notFoundGroups = map[string]string{
+	"tag_not_found":        "tags",
+	"activity_not_found":   "activities",
+	"expense_not_found":    "expenses",
+	"budget_not_found":     "budgets",
+	"template_not_found":   "templates",
+	"rate_not_found":       "rates",
+	"trash_item_not_found": "trash",
+}
+
+// kindStatus maps the kinds of domain errors to http codes.
+var kindStatus = map[domain.ErrorKind]int{
+	domain.KindInternal:        http.StatusInternalServerError,
+	domain.KindInvalid:         http.StatusBadRequest,
+	domain.KindUnauthorized:    http.StatusUnauthorized,
+	domain.KindNotFound:        http.StatusNotFound,
+	domain.KindConflict:        http.StatusConflict,
+	domain.KindUnprocessable:   http.StatusUnprocessableEntity,
+	domain.KindForbidden:       http.StatusForbidden,
+	domain.KindTooManyRequests: http.StatusTooManyRequests,
+}
+
+// routeGroup returns the first segment of the route path of the request.
+// Ex: "tags" for /tags/:id/expenses
+func routeGroup(c echo.Context) string {
+	return strings.SplitN(strings.TrimPrefix(c.Path(), "/"), "/", 2)[0]
+}
+
+// errToHTTPCode returns the http code that should be sent for a domain error.
+// The grp parameter specifies which route group the error was returned in
+// ( see notFoundGroups ).
+func errToHTTPCode(err *domain.Error, grp string) int {
+	if err.Kind == domain.KindNotFound {
+		if resGrp, ok := notFoundGroups[err.Code]; ok && resGrp != grp {
+			return http.StatusUnprocessableEntity
+		}
+	}
+	if code, ok := kindStatus[err.Kind]; ok {
+		return code
+	}
+	return http.StatusInternalServerError
+}
+
+// errorCode returns the code of a domain error, the code of the first violation
+// of validation errors, or the code of internal errors otherwise.
+func errorCode(err error) string {
+	var (
+		ve   domain.ValidationErrors
+		derr *domain.Error
+	)
+	if errors.As(err, &ve) {
+		err = ve.Errors()[0]
+	}
+	if errors.As(err, &derr) {
+		return derr.Code
+	}
+	return errInternal.Code
+}
+
+// httpErrorProblem constructs the problem of an echo error
+// ( ex: missing JWT, unknown route ). Its code is derived from the status text.
+func httpErrorProblem(he *echo.HTTPError) JSONProblem {
+	title := http.StatusText(he.Code)
+	msg, ok := he.Message.(string)
+	if !ok {
+		msg = fmt.Sprint(he.Message)
+	}
+	return JSONProblem{
+		Type:   "about:blank",
+		Title:  title,
+		Status: he.Code,
+		Detail: msg,
+		Code:   strings.ReplaceAll(strings.ToLower(title), " ", "_"),
+	}
+}
+
+// validationProblem constructs the problem of validation errors listing all violations.
+// Its status is the one of the violations if they share it, StatusBadRequest otherwise.
+func validationProblem(ve domain.ValidationErrors, grp string) JSONProblem {
+	var resp JSONProblem
+	resp.From(domain.ErrValidation.(*domain.Error), http.StatusBadRequest)
+	resp.Detail = ve.Error()
+	statuses := map[int]bool{}
+	for _, err := range ve.Errors() {
+		derr := errInternal
+		errors.As(err, &derr)
+		var fieldErr JSONFieldError
+		fieldErr.From(derr)
+		resp.Errors = append(resp.Errors, fieldErr)
+		statuses[errToHTTPCode(derr, grp)] = true
+	}
+	if len(statuses) == 1 {
+		for status := range statuses {
+			resp.Status = status
+			resp.Title = http.StatusText(status)
+		}
+	}
+	return resp
+}
+
+// HTTPErrorHandler renders errors returned by handlers & middlewares
+// as RFC 7807 problems:
+//	- validation errors are rendered with all their violations
+//	- domain errors are rendered with their code, field & details
+//	- echo errors are rendered with their status & message
+//	- other errors are logged and rendered as internal errors
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+	var (
+		ve   domain.ValidationErrors
+		derr *domain.Error
+		he   *echo.HTTPError
+		resp JSONProblem
+	)
+	switch {
+	case errors.As(err, &ve):
+		resp = validationProblem(ve, routeGroup(c))
+	case errors.As(err, &derr):
+		resp.From(derr, errToHTTPCode(derr, routeGroup(c)))
+	case errors.As(err, &he):
+		resp = httpErrorProblem(he)
+	default:
+		logrus.Error("Unexpected error : " + err.Error())
+		resp.From(errInternal, http.StatusInternalServerError)
+	}
+	if c.Request().Method == http.MethodHead {
+		if err := c.NoContent(resp.Status); err != nil {
+			logrus.Error("Error while sending error response : " + err.Error())
+		}
+		return
+	}
+	body, err := json.Marshal(resp)
+	if err != nil {
+		logrus.Error("Error while marshaling error response : " + err.Error())
+		return
+	}
+	if err := c.Blob(resp.Status, problemContentType, body); err != nil {
+		logrus.Error("Error while sending error response : " + err.Error())
+	}
+}
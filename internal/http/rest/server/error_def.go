@@ -0,0 +1,51 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// problemContentType is the media type of error responses ( RFC 7807 )
+const problemContentType string = "application/problem+json"
+
+// JSONProblem is used to marshal an error to json as a RFC 7807 problem.
+// Code identifies the error and Field the invalid input field ( if any ).
+// Errors lists all violations of a failed validation.
+type JSONProblem struct {
+	Type    string                 `json:"type"`
+	Title   string                 `json:"title"`
+	Status  int                    `json:"status"`
+	Detail  string                 `json:"detail"`
+	Code    string                 `json:"code"`
+	Field   string                 `json:"field,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	Errors  []JSONFieldError       `json:"errors,omitempty"`
+}
+
+// JSONFieldError is used to marshal a violation of a validation rule to json.
+type JSONFieldError struct {
+	Field   string                 `json:"field"`
+	Code    string                 `json:"code"`
+	Detail  string                 `json:"detail"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// From constructs a JSONFieldError object from a domain error.
+func (resp *JSONFieldError) From(err *domain.Error) {
+	(*resp).Field = err.Field
+	(*resp).Code = err.Code
+	(*resp).Detail = err.Message
+	(*resp).Details = err.Details
+}
+
+// From constructs a JSONProblem object from a domain error and its http status.
+func (resp *JSONProblem) From(err *domain.Error, status int) {
+	(*resp).Type = "about:blank"
+	(*resp).Title = http.StatusText(status)
+	(*resp).Status = status
+	(*resp).Detail = err.Message
+	(*resp).Code = err.Code
+	(*resp).Field = err.Field
+	(*resp).Details = err.Details
+}
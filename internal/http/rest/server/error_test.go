@@ -0,0 +1,136 @@
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/labstack/echo/v4"
+)
+
+func TestHTTPErrorHandler(t *testing.T) {
+	// Sub-tests definition
+	tests := map[string]struct {
+		path     string
+		err      error
+		expected server.JSONProblem
+	}{
+		"Invalid Field": {
+			path: "/tags",
+			err:  domain.ErrTagNameDuplicate,
+			expected: server.JSONProblem{
+				Status: http.StatusBadRequest,
+				Code:   "tag_name_duplicate",
+				Field:  "name",
+			},
+		},
+		"Not Found In Own Group": {
+			path:     "/tags/:id",
+			err:      store.ErrTagNotFound,
+			expected: server.JSONProblem{Status: http.StatusNotFound, Code: "tag_not_found"},
+		},
+		"Not Found In Other Group": {
+			path:     "/expenses",
+			err:      store.ErrTagNotFound,
+			expected: server.JSONProblem{Status: http.StatusUnprocessableEntity, Code: "tag_not_found"},
+		},
+		"Echo Error": {
+			path:     "/tags",
+			err:      echo.ErrUnauthorized,
+			expected: server.JSONProblem{Status: http.StatusUnauthorized, Code: "unauthorized"},
+		},
+		"Untyped Error": {
+			path:     "/tags",
+			err:      errors.New("connection refused"),
+			expected: server.JSONProblem{Status: http.StatusInternalServerError, Code: "internal"},
+		},
+	}
+	// Sub-tests execution
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath(test.path)
+			router.HTTPErrorHandler(test.err, ctx)
+			if rec.Code != test.expected.Status {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d", test.expected.Status, rec.Code)
+			}
+			if ct := rec.Header().Get(echo.HeaderContentType); ct != "application/problem+json" {
+				t.Fatalf("\nExpected Content Type: application/problem+json\nReturned Content Type: %s", ct)
+			}
+			var resp server.JSONProblem
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("\nUnexpected Error: %v", err)
+			}
+			if resp.Status != test.expected.Status || resp.Code != test.expected.Code || resp.Field != test.expected.Field {
+				t.Fatalf("\nExpected Problem: %+v\nReturned Problem: %+v", test.expected, resp)
+			}
+			if strings.Contains(resp.Detail, "connection refused") {
+				t.Fatalf("\nUnexpected Detail: %s", resp.Detail)
+			}
+		})
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	// Sub-tests definition
+	tests := map[string]struct {
+		path     string
+		handler  echo.HandlerFunc
+		json     string
+		expected map[string]string // codes by field
+	}{
+		"Tag": {
+			path:     "/tags",
+			handler:  hnd.AddTag,
+			json:     `{"name":"x"}`,
+			expected: map[string]string{"name": "tag_name_length"},
+		},
+		"Activity": {
+			path:     "/activities",
+			handler:  hnd.AddActivity,
+			json:     `{"label":"a","place":"` + strings.Repeat("p", domain.ActivityPlaceMaxLen+1) + `","time":"` + time.Now().AddDate(0, 0, 1).Format(time.RFC3339) + `"}`,
+			expected: map[string]string{"label": "activity_label_length", "place": "activity_place_length", "time": "activity_time_future"},
+		},
+		"Expense": {
+			path:     "/expenses",
+			handler:  hnd.AddExpense,
+			json:     `{"label":"ex","value":1,"unit":"XX","time":"` + time.Now().AddDate(0, 0, -1).Format(time.RFC3339) + `"}`,
+			expected: map[string]string{"label": "expense_label_length", "unit": "currency_invalid"},
+		},
+	}
+	// Sub-tests execution
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, test.path, strings.NewReader(test.json))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath(test.path)
+			handle(test.handler, ctx)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+			}
+			var resp server.JSONProblem
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("\nUnexpected Error: %v", err)
+			}
+			returned := map[string]string{}
+			for _, fieldErr := range resp.Errors {
+				returned[fieldErr.Field] = fieldErr.Code
+			}
+			if resp.Code != "validation_failed" || !reflect.DeepEqual(returned, test.expected) {
+				t.Fatalf("\nExpected Errors: %v\nReturned Problem: %+v", test.expected, resp)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 )
@@ -17,27 +18,57 @@ func defaultExpensesDateFilter() time.Time {
 	return time.Now().AddDate(0, -3, 0)
 }
 
-// ExpensesByDate handler returns a list of all expenses from a specific date up to now.
-// It has an optional query parameter "from" specifying the date as mm-dd-yyyy
+// ExpensesByDate handler returns a list of all expenses in a date range.
+// It has optional query parameters "from" and "to" specifying the range
+// as mm-dd-yyyy or as ISO-8601 dates/times.
 // If "from" parameter is missing, a default value is used.
+// If "to" parameter is missing, the range goes up to now
+// ( or 3 months ahead when listing planned expenses ).
+// If "status" parameter is provided, only expenses with this status are listed.
+// If "currency" parameter is provided, values are converted to this currency.
+// The list is paginated ( see pageRequest for pagination params )
+// and sorted by time descendent by default.
 func (h *Handler) ExpensesByDate(c echo.Context) error {
-	dateStr := c.QueryParam("from")
-	var date time.Time
-	if len(dateStr) == 0 {
-		date = defaultExpensesDateFilter()
-	} else {
-		var err error
-		if date, err = time.Parse(dateFilterFormat, dateStr); err != nil {
-			return c.String(http.StatusBadRequest, err.Error())
-		}
+	from, to, err := dateRangeFilter(c, defaultExpensesDateFilter())
+	if err != nil {
+		msg := "Error parsing from/to params to valid dates"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
+	p, err := pageRequest(c, store.SortByTime, true)
+	if err != nil {
+		msg := "Error parsing pagination params"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
+	if p.Status, err = statusFilter(c); err != nil {
+		msg := "Error parsing status param"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
+	// Planned items are listed ahead of now by default
+	if p.Status == domain.StatusPlanned && len(c.QueryParam("to")) == 0 {
+		to = defaultPlannedDateFilter()
 	}
-	expenses, err := h.lister.ExpensesByTime(date)
+	expenses, page, err := h.lister.ExpensesByTimeRangePage(from, to, p)
 	if err != nil {
-		msg := fmt.Sprintf("Internal Server Error while fetching expenses since %s", date.Format("2006-01-02"))
+		msg := fmt.Sprintf("Internal Server Error while fetching expenses from %s to %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "expenses"), msg)
+		return err
+	}
+	logrus.Infof("Fetched expenses from %s to %s successfully", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	// Convert expenses if a currency was provided
+	if cur, err := currencyFilter(c); err != nil {
+		msg := "Error parsing currency param"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	} else if len(cur) > 0 {
+		if expenses, err = h.exchanger.ConvertExpenses(expenses, cur); err != nil {
+			msg := "Error while converting expenses to " + string(cur)
+			logrus.Error(msg + " : " + err.Error())
+			return err
+		}
 	}
-	logrus.Infof("Fetched expenses since %s successfully", date.Format("2006-01-02"))
 	// Construct response expenses from fetched expenses
 	respExpenses := make([]JSONRespListExpense, len(expenses))
 	var respExp JSONRespListExpense
@@ -45,11 +76,14 @@ func (h *Handler) ExpensesByDate(c echo.Context) error {
 		respExp.From(exp)
 		respExpenses[i] = respExp
 	}
-	return c.JSON(http.StatusOK, respExpenses)
+	var resp JSONRespPage
+	resp.From(respExpenses, page)
+	return c.JSON(http.StatusOK, resp)
 }
 
 // ExpenseDetails handler returns details of expense with given ID.
-// It required a path parameter :id
+// It required a path parameter :id and has an optional query parameter
+// "currency" specifying a currency to convert the value to.
 func (h *Handler) ExpenseDetails(c echo.Context) error {
 	// Get ID from Path param
 	idStr := c.Param("id")
@@ -58,7 +92,7 @@ func (h *Handler) ExpenseDetails(c echo.Context) error {
 		msg := fmt.Sprintf("Error while converting path param Expense ID with value %s to int", idStr)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(http.StatusBadRequest, msg)
+		return errIDParam
 	}
 	expID := domain.ExpenseID(id)
 	// Get Expense
@@ -66,7 +100,7 @@ func (h *Handler) ExpenseDetails(c echo.Context) error {
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while fetching expense %s", expID)
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "expenses"), msg)
+		return err
 	}
 	logrus.Infof("Fetched expense %s successfully", expID)
 	// Get Expense Activity if exists
@@ -76,10 +110,23 @@ func (h *Handler) ExpenseDetails(c echo.Context) error {
 		if err != nil {
 			msg := fmt.Sprintf("Internal Server Error while fetching expense %s's activity %s", expID, exp.ActivityID)
 			logrus.Error(msg + " : " + err.Error())
-			return c.String(errToHTTPCode(err, "expenses"), msg)
+			return err
 		}
 		logrus.Infof("Fetched expense %s's activity %s successfully", expID, exp.ActivityID)
 	}
+	// Convert expense if a currency was provided
+	if cur, err := currencyFilter(c); err != nil {
+		msg := "Error parsing currency param"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	} else if len(cur) > 0 {
+		if exp.Value, err = h.exchanger.Convert(exp.Value, exp.Unit, cur, exp.Time); err != nil {
+			msg := "Error while converting expense to " + string(cur)
+			logrus.Error(msg + " : " + err.Error())
+			return err
+		}
+		exp.Unit = cur
+	}
 	var respExp JSONRespDetailExpense
 	respExp.From(exp, act)
 	return c.JSON(http.StatusOK, respExp)
@@ -93,18 +140,22 @@ func (h *Handler) AddExpense(c echo.Context) error {
 		var (
 			msg     string = errInvalidJSON.Error()
 			details string = httpErrorMsg(err)
-			code    int    = errToHTTPCode(errInvalidJSON, "expenses")
 		)
 		logrus.Error(msg + " : " + details)
-		return c.String(code, msg)
+		return errInvalidJSON
+	}
+	exp, err := jsExp.ToDomain(h.adder.Policy())
+	if err != nil {
+		msg := "Error while converting expense value to its currency"
+		logrus.Error(msg + " : " + err.Error())
+		return err
 	}
 	// Call adding service
-	exp := jsExp.ToDomain()
-	id, err := h.adder.NewExpense(exp)
+	id, err := h.adder.NewExpense(exp, h.principal(c))
 	if err != nil {
 		msg := "Internal Server Error while adding expense"
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "expenses"), msg)
+		return err
 	}
 	logrus.Infof("Created expense %s successfully", id)
 	// Retrieve created expense
@@ -112,7 +163,7 @@ func (h *Handler) AddExpense(c echo.Context) error {
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while fetching expense %s", id)
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "expenses"), msg)
+		return err
 	}
 	logrus.Infof("Fetched expense %s successfully", id)
 	// Get Expense Activity if exists
@@ -122,7 +173,7 @@ func (h *Handler) AddExpense(c echo.Context) error {
 		if err != nil {
 			msg := fmt.Sprintf("Internal Server Error while fetching expense %s's activity %s", id, exp.ActivityID)
 			logrus.Error(msg + " : " + err.Error())
-			return c.String(errToHTTPCode(err, "expenses"), msg)
+			return err
 		}
 		logrus.Infof("Fetched expense %s's activity %s successfully", id, exp.ActivityID)
 	}
@@ -141,15 +192,15 @@ func (h *Handler) EditExpense(c echo.Context) error {
 		msg := fmt.Sprintf("Error while converting path param Expense ID with value %s to int", idStr)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(http.StatusBadRequest, msg)
+		return errIDParam
 	}
 	expID := domain.ExpenseID(id)
 	// Check Expense with given ID exists
-	_, err = h.lister.Expense(expID)
+	old, err := h.lister.Expense(expID)
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while fetching expense %s", expID)
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "expenses"), msg)
+		return err
 	}
 	logrus.Infof("Fetched expense %s successfully", expID)
 	// Json unmarshall
@@ -158,18 +209,27 @@ func (h *Handler) EditExpense(c echo.Context) error {
 		var (
 			msg     string = errInvalidJSON.Error()
 			details string = httpErrorMsg(err)
-			code    int    = errToHTTPCode(errInvalidJSON, "activities")
 		)
 		logrus.Error(msg + " | " + details)
-		return c.String(code, msg)
+		return errInvalidJSON
 	}
+	// Keep status when not provided
+	if len(jsExp.Status) == 0 {
+		jsExp.Status = old.Status
+	}
+	exp, err := jsExp.ToDomain(h.editor.Policy())
+	if err != nil {
+		msg := "Error while converting expense value to its currency"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	exp.ID = expID
 	// Update
-	exp := jsExp.ToDomain()
-	err = h.editor.EditExpense(exp)
+	err = h.editor.EditExpense(exp, h.principal(c))
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while updating expense %s", expID)
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "expenses"), msg)
+		return err
 	}
 	logrus.Infof("Updated expense %s successfully", exp.ID)
 	// Retrieve edited expense
@@ -177,7 +237,7 @@ func (h *Handler) EditExpense(c echo.Context) error {
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while fetching updated expense %s", exp.ID)
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "expenses"), msg)
+		return err
 	}
 	logrus.Infof("Fetched expense %s successfully", exp.ID)
 	// Get Expense Activity if exists
@@ -187,7 +247,7 @@ func (h *Handler) EditExpense(c echo.Context) error {
 		if err != nil {
 			msg := fmt.Sprintf("Internal Server Error while fetching expense %s's activity %s", exp.ID, exp.ActivityID)
 			logrus.Error(msg + " : " + err.Error())
-			return c.String(errToHTTPCode(err, "expenses"), msg)
+			return err
 		}
 	}
 	var respExp JSONRespDetailExpense
@@ -195,6 +255,42 @@ func (h *Handler) EditExpense(c echo.Context) error {
 	return c.JSON(http.StatusOK, respExp)
 }
 
+// CompleteExpense handler marks the planned expense with given ID as done and returns it.
+// It requires a path parameter :id
+func (h *Handler) CompleteExpense(c echo.Context) error {
+	// Get ID from Path param
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		msg := fmt.Sprintf("Error while converting path param Expense ID with value %s to int", idStr)
+		details := err.Error()
+		logrus.Error(msg + " | " + details)
+		return errIDParam
+	}
+	expID := domain.ExpenseID(id)
+	// Complete Expense
+	exp, err := h.editor.CompleteExpense(expID, h.principal(c))
+	if err != nil {
+		msg := fmt.Sprintf("Error while completing expense %s", expID)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Completed expense %s successfully", expID)
+	// Get Expense Activity if exists
+	act := domain.Activity{Label: "No Activity"}
+	if exp.ActivityID > 0 {
+		act, err = h.lister.Activity(exp.ActivityID)
+		if err != nil {
+			msg := fmt.Sprintf("Internal Server Error while fetching expense %s's activity %s", exp.ID, exp.ActivityID)
+			logrus.Error(msg + " : " + err.Error())
+			return err
+		}
+	}
+	var respExp JSONRespDetailExpense
+	respExp.From(exp, act)
+	return c.JSON(http.StatusOK, respExp)
+}
+
 // DeleteExpense handler deletes an expense with given ID.
 // It required a path parameter :id
 func (h *Handler) DeleteExpense(c echo.Context) error {
@@ -205,15 +301,15 @@ func (h *Handler) DeleteExpense(c echo.Context) error {
 		msg := fmt.Sprintf("Error while converting path param Expense ID with value %s to int", idStr)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(http.StatusBadRequest, msg)
+		return errIDParam
 	}
 	expID := domain.ExpenseID(id)
 	// Delete Expense
-	err = h.deleter.Expense(expID)
+	err = h.deleter.Expense(expID, h.principal(c))
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while deleting expense %s", expID)
 		logrus.Error(msg + " : " + err.Error())
-		return c.String(errToHTTPCode(err, "expenses"), msg)
+		return err
 	}
 	logrus.Infof("Deleted expense %s successfully", expID)
 	return c.JSON(http.StatusNoContent, "Expense Deleted Successfully")
@@ -1,49 +1,81 @@
 package server
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/elhamza90/lifelog/internal/domain"
 )
 
 // JSONReqExpense is used to unmarshal a json expense.
+// Value is a decimal number in the currency ( ex: 12.50 ).
 type JSONReqExpense struct {
 	ID         domain.ExpenseID  `json:"id"`
 	Label      string            `json:"label"`
 	Time       time.Time         `json:"time"`
-	Value      float32           `json:"value"`
-	Unit       string            `json:"unit"`
+	Value      json.Number       `json:"value"`
+	Unit       domain.Currency   `json:"unit"`
 	ActivityID domain.ActivityID `json:"activityId"`
+	Status     domain.Status     `json:"status"` // Empty for done
 	TagIds     []domain.TagID    `json:"tagIds"`
 }
 
 // ToDomain constructs and returns a domain.Expense from a JSONReqExpense.
-func (reqExp JSONReqExpense) ToDomain() domain.Expense {
+// It returns validation errors if the currency or the value are invalid
+// because the value is converted to the minor unit of the currency.
+// The violations of the other fields ( see policy ) are returned with them.
+func (reqExp JSONReqExpense) ToDomain(p domain.Policy) (domain.Expense, error) {
+	errs := domain.ValidationErrors{}
+	cur, err := domain.ParseCurrency(string(reqExp.Unit))
+	if err != nil {
+		errs.AddField("unit", err)
+	}
+	var val domain.Amount
+	if err == nil {
+		if val, err = domain.ParseAmount(reqExp.Value.String(), cur); err != nil {
+			errs.AddField("value", err)
+		}
+	}
 	// Construct Tags slice from ids ( don't fetch anything )
 	tags := []domain.Tag{}
 	for _, id := range reqExp.TagIds {
 		tags = append(tags, domain.Tag{ID: id})
 	}
-	return domain.Expense{
+	exp := domain.Expense{
 		ID:         reqExp.ID,
 		Label:      reqExp.Label,
 		Time:       reqExp.Time,
-		Value:      reqExp.Value,
-		Unit:       reqExp.Unit,
+		Value:      val,
+		Unit:       cur,
 		ActivityID: reqExp.ActivityID,
+		Status:     reqExp.Status,
 		Tags:       tags,
 	}
+	if len(errs) == 0 {
+		return exp, nil
+	}
+	// Value & unit could not be parsed so only other fields are validated
+	if verrs, ok := exp.Validate(p).(domain.ValidationErrors); ok {
+		for _, field := range verrs.Fields() {
+			if field != "unit" && field != "value" {
+				errs[field] = verrs[field]
+			}
+		}
+	}
+	return exp, errs
 }
 
 // JSONRespDetailExpense is used to marshal an expense to json.
+// Value is a decimal number with the decimals of the currency.
 type JSONRespDetailExpense struct {
 	ID            domain.ExpenseID  `json:"id"`
 	Label         string            `json:"label"`
 	Time          time.Time         `json:"time"`
-	Value         float32           `json:"value"`
-	Unit          string            `json:"unit"`
+	Value         json.Number       `json:"value"`
+	Unit          domain.Currency   `json:"unit"`
 	ActivityID    domain.ActivityID `json:"activityId"`
 	ActivityLabel string            `json:"activityLabel"`
+	Status        domain.Status     `json:"status"`
 	Tags          []domain.Tag      `json:"tags"`
 }
 
@@ -52,21 +84,24 @@ func (respExp *JSONRespDetailExpense) From(exp domain.Expense, act domain.Activi
 	(*respExp).ID = exp.ID
 	(*respExp).Label = exp.Label
 	(*respExp).Time = exp.Time
-	(*respExp).Value = exp.Value
+	(*respExp).Value = json.Number(exp.Value.Format(exp.Unit))
 	(*respExp).Unit = exp.Unit
 	(*respExp).ActivityID = exp.ActivityID
+	(*respExp).Status = exp.Status
 	(*respExp).ActivityLabel = act.Label
 	(*respExp).Tags = exp.Tags
 }
 
 // JSONRespListExpense is used to marshal an expense in a list to json.
+// Value is a decimal number with the decimals of the currency.
 type JSONRespListExpense struct {
 	ID         domain.ExpenseID  `json:"id"`
 	Label      string            `json:"label"`
 	Time       time.Time         `json:"time"`
-	Value      float32           `json:"value"`
-	Unit       string            `json:"unit"`
+	Value      json.Number       `json:"value"`
+	Unit       domain.Currency   `json:"unit"`
 	ActivityID domain.ActivityID `json:"activityId"`
+	Status     domain.Status     `json:"status"`
 }
 
 // From constructs a JSONRespListExpense object from a domain.Expense object.
@@ -74,7 +109,8 @@ func (respExp *JSONRespListExpense) From(exp domain.Expense) {
 	(*respExp).ID = exp.ID
 	(*respExp).Label = exp.Label
 	(*respExp).Time = exp.Time
-	(*respExp).Value = exp.Value
+	(*respExp).Value = json.Number(exp.Value.Format(exp.Unit))
 	(*respExp).Unit = exp.Unit
 	(*respExp).ActivityID = exp.ActivityID
+	(*respExp).Status = exp.Status
 }
@@ -1,6 +1,7 @@
 package server_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
 	"github.com/labstack/echo/v4"
 )
 
@@ -36,7 +38,51 @@ func TestExpensesByDate(t *testing.T) {
 			expectedCode: http.StatusBadRequest,
 		},
 		"Wrong Date Format": {
-			filter:       fmt.Sprintf("?%s=2020-01-31", param),
+			filter:       fmt.Sprintf("?%s=31/01/2020", param),
+			expectedCode: http.StatusBadRequest,
+		},
+		"ISO-8601 Date Range": {
+			filter:       "?from=2020-01-01&to=2020-01-31",
+			expectedCode: http.StatusOK,
+		},
+		"ISO-8601 Time Range": {
+			filter:       "?from=2020-01-01T10:00:00Z&to=2020-01-31T18:30:00%2B01:00",
+			expectedCode: http.StatusOK,
+		},
+		"Legacy Date Range": {
+			filter:       fmt.Sprintf("?from=%s&to=%s", now.AddDate(0, -1, 0).Format(frmt), now.AddDate(0, 0, -2).Format(frmt)),
+			expectedCode: http.StatusOK,
+		},
+		"From after To": {
+			filter:       "?from=2020-01-31&to=2020-01-01",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Wrong To Format": {
+			filter:       "?to=31/01/2020",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Paginated Sorted By Label": {
+			filter:       "?limit=10&sort=label&order=asc",
+			expectedCode: http.StatusOK,
+		},
+		"Wrong Limit": {
+			filter:       "?limit=ten",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Limit Too High": {
+			filter:       "?limit=100000",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Wrong Order": {
+			filter:       "?order=up",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Wrong Sort Field": {
+			filter:       "?sort=place",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Invalid Cursor": {
+			filter:       "?cursor=not-a-cursor",
 			expectedCode: http.StatusBadRequest,
 		},
 	}
@@ -52,7 +98,7 @@ func TestExpensesByDate(t *testing.T) {
 			rec = httptest.NewRecorder()
 			ctx = router.NewContext(req, rec)
 			ctx.SetPath(path)
-			hnd.ExpensesByDate(ctx)
+			handle(hnd.ExpensesByDate, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %v\nReturned Body: %s", test.expectedCode, rec.Code, body)
@@ -61,12 +107,118 @@ func TestExpensesByDate(t *testing.T) {
 	}
 }
 
+func TestExpensesByDatePages(t *testing.T) {
+	now := time.Now()
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Exp A", Time: now.AddDate(0, 0, -1), Value: 10, Unit: "eu"},
+		2: {ID: 2, Label: "Exp B", Time: now.AddDate(0, 0, -2), Value: 5, Unit: "eu"},
+		3: {ID: 3, Label: "Exp C", Time: now.AddDate(0, 0, -3), Value: 7, Unit: "eu"},
+	}
+	defer func() { repo.Expenses = map[domain.ExpenseID]domain.Expense{} }()
+	// Walk through pages of 2 expenses sorted by value ascendent
+	const path string = "/expenses?limit=2&sort=value&order=asc%s"
+	var (
+		labels []string
+		cursor string
+	)
+	for i := 0; i < 3; i++ {
+		query := ""
+		if cursor != "" {
+			query = "&cursor=" + cursor
+		}
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf(path, query), nil)
+		rec := httptest.NewRecorder()
+		ctx := router.NewContext(req, rec)
+		handle(hnd.ExpensesByDate, ctx)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("\nExpected Code: %d\nReturned Code: %v\nReturned Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var resp struct {
+			Items []server.JSONRespListExpense `json:"items"`
+			Next  string                       `json:"next"`
+			Total int                          `json:"total"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("\nUnexpected Error while unmarshaling response: %v", err)
+		}
+		if resp.Total != 3 {
+			t.Fatalf("\nExpected Total: %d\nReturned Total: %d", 3, resp.Total)
+		}
+		for _, exp := range resp.Items {
+			labels = append(labels, exp.Label)
+		}
+		if resp.Next == "" {
+			break
+		}
+		cursor = resp.Next
+	}
+	expected := []string{"Exp B", "Exp C", "Exp A"}
+	if strings.Join(labels, ",") != strings.Join(expected, ",") {
+		t.Fatalf("\nExpected: %v\nReturned: %v", expected, labels)
+	}
+}
+
+func TestExpensesInCurrency(t *testing.T) {
+	now := time.Now()
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Exp A", Time: now.AddDate(0, 0, -1), Value: 1000, Unit: "EUR"},
+	}
+	repo.Rates = map[string]domain.ExchangeRate{}
+	repo.SaveExchangeRates([]domain.ExchangeRate{
+		{Base: "EUR", Quote: "USD", Date: now.AddDate(0, 0, -10), Rate: 1.25},
+	})
+	defer func() {
+		repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+		repo.Rates = map[string]domain.ExchangeRate{}
+	}()
+	tests := map[string]struct {
+		handler      func(echo.Context) error
+		url          string
+		id           string
+		expectedCode int
+		expectedBody string
+	}{
+		"List": {
+			handler: hnd.ExpensesByDate, url: "/expenses?currency=usd", expectedCode: http.StatusOK,
+			expectedBody: `"value":12.50,"unit":"USD"`,
+		},
+		"Details": {
+			handler: hnd.ExpenseDetails, url: "/expenses/1?currency=USD", id: "1", expectedCode: http.StatusOK,
+			expectedBody: `"value":12.50,"unit":"USD"`,
+		},
+		"Unknown Currency": {
+			handler: hnd.ExpensesByDate, url: "/expenses?currency=dollar", expectedCode: http.StatusBadRequest,
+		},
+		"Missing Rate": {
+			handler: hnd.ExpenseDetails, url: "/expenses/1?currency=JPY", id: "1", expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, test.url, nil)
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			if test.id != "" {
+				ctx.SetParamNames("id")
+				ctx.SetParamValues(test.id)
+			}
+			handle(test.handler, ctx)
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), test.expectedBody) {
+				t.Fatalf("\nExpected Body to contain: %s\nReturned Body: %s", test.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
 func TestExpenseDetails(t *testing.T) {
 	// Init Repo with one test activity
 	exp := domain.Expense{
 		ID:         1,
 		Label:      "Test Expense",
-		Value:      15.5,
+		Value:      1550,
 		Unit:       "eu",
 		Time:       time.Now().AddDate(0, 0, -2),
 		ActivityID: 0,
@@ -112,7 +264,7 @@ func TestExpenseDetails(t *testing.T) {
 			ctx.SetPath(path)
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(test.idStr)
-			hnd.ExpenseDetails(ctx)
+			handle(hnd.ExpenseDetails, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
@@ -134,23 +286,39 @@ func TestAddExpense(t *testing.T) {
 		expectedCode int
 	}{
 		"Correct": {
-			json:         `{"label":"New Expense","value":9.5,"unit":"eu","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
+			json:         `{"label":"New Expense","value":9.5,"unit":"eur","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
 			expectedCode: http.StatusCreated,
 		},
 		"Non-Existing Tag": {
-			json:         `{"label":"New Expense","value":9.5,"unit":"eu","time":"2020-04-01T18:00:00Z","tagIds":[1,33]}`,
+			json:         `{"label":"New Expense","value":9.5,"unit":"eur","time":"2020-04-01T18:00:00Z","tagIds":[1,33]}`,
 			expectedCode: http.StatusUnprocessableEntity,
 		},
 		"Time Future": {
-			json:         `{"label":"New Expense","value":9.5,"unit":"eu",` + fmt.Sprintf("\"time\":\"%s\"", time.Now().AddDate(0, 0, 1).Format("2006-01-02")) + `,"tagIds":[1,3]}`,
+			json:         `{"label":"New Expense","value":9.5,"unit":"eur",` + fmt.Sprintf("\"time\":\"%s\"", time.Now().AddDate(0, 0, 1).Format("2006-01-02")) + `,"tagIds":[1,3]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Planned In Future": {
+			json:         `{"label":"New Expense","value":9.5,"unit":"eur","status":"planned",` + fmt.Sprintf("\"time\":\"%s\"", time.Now().AddDate(0, 0, 7).Format(time.RFC3339)) + `,"tagIds":[1,3]}`,
+			expectedCode: http.StatusCreated,
+		},
+		"Unknown Status": {
+			json:         `{"label":"New Expense","value":9.5,"unit":"eur","status":"later","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		"Value zero": {
-			json:         `{"label":"New Expense","value":0,"unit":"eu","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
+			json:         `{"label":"New Expense","value":0,"unit":"eur","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Unknown Currency": {
+			json:         `{"label":"New Expense","value":9.5,"unit":"euro","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Value Too Precise": {
+			json:         `{"label":"New Expense","value":9.505,"unit":"eur","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		"Wrong Json": {
-			json:         `{"label":"New Expense","value":9.5,"unit":"eu","time":"2020-04-01T18:00:00Z""tagIds":[1,3]}`,
+			json:         `{"label":"New Expense","value":9.5,"unit":"eur","time":"2020-04-01T18:00:00Z""tagIds":[1,3]}`,
 			expectedCode: http.StatusBadRequest,
 		},
 	}
@@ -168,11 +336,15 @@ func TestAddExpense(t *testing.T) {
 			rec = httptest.NewRecorder()
 			ctx = router.NewContext(req, rec)
 			ctx.SetPath(path)
-			hnd.AddExpense(ctx)
+			handle(hnd.AddExpense, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
 			}
+			// Value is returned exactly with the decimals of the currency
+			if rec.Code == http.StatusCreated && !strings.Contains(rec.Body.String(), `"value":9.50,"unit":"EUR"`) {
+				t.Fatalf("\nExpected exact value 9.50 EUR\nReturned Body: %s", rec.Body.String())
+			}
 		})
 	}
 }
@@ -187,7 +359,7 @@ func TestEditExpense(t *testing.T) {
 	exp := domain.Expense{
 		ID:    1,
 		Label: "Existing Expense",
-		Value: 13.4,
+		Value: 1340,
 		Unit:  "Eu",
 		Time:  time.Now().AddDate(0, 0, -2),
 		Tags:  []domain.Tag{},
@@ -203,37 +375,37 @@ func TestEditExpense(t *testing.T) {
 	}{
 		"Correct": {
 			idStr:        strconv.Itoa(int(exp.ID)),
-			json:         `{"label":"New Expense","value":9.5,"unit":"eu","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
+			json:         `{"label":"New Expense","value":9.5,"unit":"eur","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
 			expectedCode: http.StatusOK,
 		},
 		"Non-Existing Expense": {
 			idStr:        "32543454",
-			json:         `{"label":"New Expense","value":9.5,"unit":"eu","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
+			json:         `{"label":"New Expense","value":9.5,"unit":"eur","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
 			expectedCode: http.StatusNotFound,
 		},
 		"Non-Existing Tag": {
 			idStr:        strconv.Itoa(int(exp.ID)),
-			json:         `{"label":"New Expense","value":9.5,"unit":"eu","time":"2020-04-01T18:00:00Z","tagIds":[1,33]}`,
+			json:         `{"label":"New Expense","value":9.5,"unit":"eur","time":"2020-04-01T18:00:00Z","tagIds":[1,33]}`,
 			expectedCode: http.StatusUnprocessableEntity,
 		},
 		"Time Future": {
 			idStr:        strconv.Itoa(int(exp.ID)),
-			json:         `{"label":"New Expense","value":9.5,"unit":"eu",` + fmt.Sprintf("\"time\":\"%s\"", time.Now().AddDate(0, 0, 1).Format("2006-01-02")) + `,"tagIds":[1,3]}`,
+			json:         `{"label":"New Expense","value":9.5,"unit":"eur",` + fmt.Sprintf("\"time\":\"%s\"", time.Now().AddDate(0, 0, 1).Format("2006-01-02")) + `,"tagIds":[1,3]}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		"Value zero": {
 			idStr:        strconv.Itoa(int(exp.ID)),
-			json:         `{"label":"New Expense","value":0,"unit":"eu","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
+			json:         `{"label":"New Expense","value":0,"unit":"eur","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		"Wrong ID": {
 			idStr:        "sdf",
-			json:         `{"label":"New Expense","value":9.5,"unit":"eu","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
+			json:         `{"label":"New Expense","value":9.5,"unit":"eur","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		"Wrong Json": {
 			idStr:        strconv.Itoa(int(exp.ID)),
-			json:         `{"label":"New Expense,"value":9.5,"unit":"eu","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
+			json:         `{"label":"New Expense,"value":9.5,"unit":"eur","time":"2020-04-01T18:00:00Z","tagIds":[1,3]}`,
 			expectedCode: http.StatusBadRequest,
 		},
 	}
@@ -254,7 +426,7 @@ func TestEditExpense(t *testing.T) {
 			ctx.SetPath(path)
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(test.idStr)
-			hnd.EditExpense(ctx)
+			handle(hnd.EditExpense, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
@@ -264,6 +436,7 @@ func TestEditExpense(t *testing.T) {
 }
 
 func TestDeleteExpense(t *testing.T) {
+	defer func() { repo.Trash = map[string]time.Time{} }() // Empty trash of deleted items
 	// Init Repo with two test activities: one with and one without expense
 	exp := domain.Expense{
 		ID:    1,
@@ -309,7 +482,7 @@ func TestDeleteExpense(t *testing.T) {
 			ctx.SetPath(path)
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(test.idStr)
-			hnd.DeleteExpense(ctx)
+			handle(hnd.DeleteExpense, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
@@ -0,0 +1,450 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/archiving"
+	"github.com/labstack/echo/v4"
+)
+
+// openAPIVersion is the version of the OpenAPI specification the document follows.
+const openAPIVersion string = "3.0.3"
+
+// apiVersion is the version of the API described by the document.
+const apiVersion string = "1.0.0"
+
+// apiParam describes a parameter of an operation.
+// In is "query" or "path". Path parameters that are not described
+// are documented as integers if named id, as strings otherwise.
+type apiParam struct {
+	Name        string
+	In          string
+	Description string
+	Type        string
+	Enum        []string
+	Required    bool
+}
+
+// apiPage specifies a page of items ( see JSONRespPage ) as body of a response.
+type apiPage struct {
+	item interface{}
+}
+
+// apiOperation describes an operation of the API.
+// Request and Response are values of the types of the bodies ( nil if none ).
+// A string body is documented with the content type given by
+// RequestType/ResponseType, other bodies are json.
+type apiOperation struct {
+	Method       string
+	Path         string
+	Tag          string
+	Summary      string
+	Public       bool
+	Params       []apiParam
+	Request      interface{}
+	RequestType  string
+	Status       int
+	Response     interface{}
+	ResponseType string
+}
+
+// Parameters shared by several operations
+var (
+	pageParams = []apiParam{
+		{Name: "limit", In: "query", Type: "integer", Description: "Maximum number of items in the page"},
+		{Name: "cursor", In: "query", Type: "string", Description: "Cursor returned with the previous page"},
+		{Name: "sort", In: "query", Type: "string", Enum: []string{"time", "label", "value"}, Description: "Field the list is sorted by"},
+		{Name: "order", In: "query", Type: "string", Enum: []string{"asc", "desc"}},
+	}
+	rangeParams = []apiParam{
+		{Name: "from", In: "query", Type: "string", Description: "Start of the range ( mm-dd-yyyy or ISO-8601 )"},
+		{Name: "to", In: "query", Type: "string", Description: "End of the range ( mm-dd-yyyy or ISO-8601 )"},
+	}
+	statusParam    = apiParam{Name: "status", In: "query", Type: "string", Enum: []string{"planned", "done", "cancelled"}, Description: "Status of listed items"}
+	currencyParam  = apiParam{Name: "currency", In: "query", Type: "string", Description: "ISO-4217 currency to convert values to"}
+	limitParam     = apiParam{Name: "limit", In: "query", Type: "integer", Description: "Maximum number of results"}
+	periodParam    = apiParam{Name: "period", In: "query", Type: "string", Enum: []string{"day", "week", "month", "year"}}
+	tagParam       = apiParam{Name: "tag", In: "query", Type: "integer", Description: "ID of a tag to filter expenses with"}
+	trashKindParam = apiParam{Name: "kind", In: "path", Type: "string", Required: true, Enum: sortedKeys(restorers)}
+)
+
+// params concatenates lists of parameters
+func params(lists ...[]apiParam) []apiParam {
+	all := []apiParam{}
+	for _, l := range lists {
+		all = append(all, l...)
+	}
+	return all
+}
+
+// sortedKeys returns the sorted names of the exporters or importers.
+func sortedKeys(m interface{}) []string {
+	keys := []string{}
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// apiKeyTags are the tags of the operations which also accept an API key.
+var apiKeyTags = map[string]bool{"tags": true, "activities": true, "expenses": true}
+
+// apiOperations describes every route registered by RegisterRoutes.
+var apiOperations = []apiOperation{
+	// Health & Documentation
+	{Method: http.MethodGet, Path: "/health-check", Tag: "health", Summary: "Check the API is up and running", Public: true, Status: http.StatusOK, Response: "", ResponseType: echo.MIMETextPlain},
+	{Method: http.MethodGet, Path: "/openapi.json", Tag: "health", Summary: "OpenAPI document of the API", Public: true, Status: http.StatusOK, Response: map[string]interface{}{}},
+	// Auth
+	{Method: http.MethodPost, Path: "/auth/register", Tag: "auth", Summary: "Create a user account", Public: true, Request: registerRequest{}, Status: http.StatusCreated, Response: registerResponse{}},
+	{Method: http.MethodPost, Path: "/auth/login", Tag: "auth", Summary: "Authenticate and get access & refresh tokens restricted to the given scopes ( all by default ). Repeated failures lock the account & address out", Public: true, Request: loginRequest{}, Status: http.StatusOK, Response: tokensResponse{}},
+	{Method: http.MethodPost, Path: "/auth/refresh", Tag: "auth", Summary: "Get new access & refresh tokens with a refresh token", Public: true, Request: refreshRequest{}, Status: http.StatusOK, Response: tokensResponse{}},
+	{Method: http.MethodPost, Path: "/auth/logout", Tag: "auth", Summary: "Revoke a refresh token and the ones issued since the same login", Public: true, Request: refreshRequest{}, Status: http.StatusNoContent},
+	{Method: http.MethodPost, Path: "/auth/logout-all", Tag: "auth", Summary: "Revoke all refresh tokens of the user", Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/auth/keys", Tag: "auth", Summary: "List the API keys of the user", Status: http.StatusOK, Response: []JSONRespAPIKey{}},
+	{Method: http.MethodPost, Path: "/auth/keys", Tag: "auth", Summary: "Create an API key ( the key is only returned once )", Request: JSONReqAPIKey{}, Status: http.StatusCreated, Response: JSONRespNewAPIKey{}},
+	{Method: http.MethodDelete, Path: "/auth/keys/:id", Tag: "auth", Summary: "Revoke an API key", Status: http.StatusNoContent},
+	// Tags
+	{Method: http.MethodGet, Path: "/tags", Tag: "tags", Summary: "List tags", Params: pageParams, Status: http.StatusOK, Response: apiPage{JSONRespListTag{}}},
+	{Method: http.MethodGet, Path: "/tags/:id/expenses", Tag: "tags", Summary: "List expenses of a tag", Params: params(pageParams, []apiParam{statusParam, currencyParam}), Status: http.StatusOK, Response: apiPage{JSONRespListExpense{}}},
+	{Method: http.MethodGet, Path: "/tags/:id/activities", Tag: "tags", Summary: "List activities of a tag", Params: params(pageParams, []apiParam{statusParam}), Status: http.StatusOK, Response: apiPage{JSONRespListActivity{}}},
+	{Method: http.MethodPost, Path: "/tags", Tag: "tags", Summary: "Add a tag", Request: JSONReqTag{}, Status: http.StatusCreated, Response: domain.Tag{}},
+	{Method: http.MethodPut, Path: "/tags/:id", Tag: "tags", Summary: "Edit a tag", Request: JSONReqTag{}, Status: http.StatusOK, Response: domain.Tag{}},
+	{Method: http.MethodDelete, Path: "/tags/:id", Tag: "tags", Summary: "Delete a tag", Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/tags/:id/history", Tag: "tags", Summary: "List changes of a tag, oldest first", Status: http.StatusOK, Response: []JSONRespAuditEvent{}},
+	// Activities
+	{Method: http.MethodGet, Path: "/activities", Tag: "activities", Summary: "List activities in a date range", Params: params(rangeParams, pageParams, []apiParam{statusParam}), Status: http.StatusOK, Response: apiPage{JSONRespListActivity{}}},
+	{Method: http.MethodGet, Path: "/activities/:id", Tag: "activities", Summary: "Get details of an activity", Status: http.StatusOK, Response: JSONRespDetailActivity{}},
+	{Method: http.MethodPost, Path: "/activities", Tag: "activities", Summary: "Add an activity", Request: JSONReqActivity{}, Status: http.StatusCreated, Response: domain.Activity{}},
+	{Method: http.MethodPut, Path: "/activities/:id", Tag: "activities", Summary: "Edit an activity", Request: JSONReqActivity{}, Status: http.StatusOK, Response: domain.Activity{}},
+	{Method: http.MethodPost, Path: "/activities/:id/complete", Tag: "activities", Summary: "Mark a planned activity as done", Status: http.StatusOK, Response: JSONRespDetailActivity{}},
+	{Method: http.MethodDelete, Path: "/activities/:id", Tag: "activities", Summary: "Delete an activity", Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/activities/:id/history", Tag: "activities", Summary: "List changes of an activity, oldest first", Status: http.StatusOK, Response: []JSONRespAuditEvent{}},
+	// Expenses
+	{Method: http.MethodGet, Path: "/expenses", Tag: "expenses", Summary: "List expenses in a date range", Params: params(rangeParams, pageParams, []apiParam{statusParam, currencyParam}), Status: http.StatusOK, Response: apiPage{JSONRespListExpense{}}},
+	{Method: http.MethodGet, Path: "/expenses/:id", Tag: "expenses", Summary: "Get details of an expense", Params: []apiParam{currencyParam}, Status: http.StatusOK, Response: JSONRespDetailExpense{}},
+	{Method: http.MethodPost, Path: "/expenses", Tag: "expenses", Summary: "Add an expense", Request: JSONReqExpense{}, Status: http.StatusCreated, Response: JSONRespDetailExpense{}},
+	{Method: http.MethodPut, Path: "/expenses/:id", Tag: "expenses", Summary: "Edit an expense", Request: JSONReqExpense{}, Status: http.StatusOK, Response: JSONRespDetailExpense{}},
+	{Method: http.MethodPost, Path: "/expenses/:id/complete", Tag: "expenses", Summary: "Mark a planned expense as done", Status: http.StatusOK, Response: JSONRespDetailExpense{}},
+	{Method: http.MethodDelete, Path: "/expenses/:id", Tag: "expenses", Summary: "Delete an expense", Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/expenses/:id/history", Tag: "expenses", Summary: "List changes of an expense, oldest first", Status: http.StatusOK, Response: []JSONRespAuditEvent{}},
+	// Budgets
+	{Method: http.MethodGet, Path: "/budgets", Tag: "budgets", Summary: "List budgets", Status: http.StatusOK, Response: []JSONRespBudget{}},
+	{Method: http.MethodGet, Path: "/budgets/:id", Tag: "budgets", Summary: "Get a budget", Status: http.StatusOK, Response: JSONRespBudget{}},
+	{Method: http.MethodGet, Path: "/budgets/:id/status", Tag: "budgets", Summary: "Get the amount spent on a budget in its current period", Status: http.StatusOK, Response: JSONRespBudgetStatus{}},
+	{Method: http.MethodPost, Path: "/budgets", Tag: "budgets", Summary: "Add a budget", Request: JSONReqBudget{}, Status: http.StatusCreated, Response: JSONRespBudget{}},
+	{Method: http.MethodPut, Path: "/budgets/:id", Tag: "budgets", Summary: "Edit a budget", Request: JSONReqBudget{}, Status: http.StatusOK, Response: JSONRespBudget{}},
+	{Method: http.MethodDelete, Path: "/budgets/:id", Tag: "budgets", Summary: "Delete a budget", Status: http.StatusNoContent},
+	// Recurring Templates
+	{Method: http.MethodGet, Path: "/templates", Tag: "templates", Summary: "List recurring templates", Status: http.StatusOK, Response: []JSONRespTemplate{}},
+	{Method: http.MethodGet, Path: "/templates/:id", Tag: "templates", Summary: "Get a recurring template", Status: http.StatusOK, Response: JSONRespTemplate{}},
+	{Method: http.MethodGet, Path: "/templates/:id/upcoming", Tag: "templates", Summary: "List next occurrences of a template", Params: []apiParam{limitParam}, Status: http.StatusOK, Response: JSONRespUpcoming{}},
+	{Method: http.MethodPost, Path: "/templates", Tag: "templates", Summary: "Add a recurring template", Request: JSONReqTemplate{}, Status: http.StatusCreated, Response: JSONRespTemplate{}},
+	{Method: http.MethodPost, Path: "/templates/:id/skip", Tag: "templates", Summary: "Skip an occurrence of a template", Request: JSONReqSkip{}, Status: http.StatusNoContent},
+	{Method: http.MethodDelete, Path: "/templates/:id", Tag: "templates", Summary: "Delete a recurring template", Status: http.StatusNoContent},
+	// Reports
+	{Method: http.MethodGet, Path: "/reports/expenses/by-period", Tag: "reports", Summary: "Sum expenses by period and unit", Params: params(rangeParams, []apiParam{periodParam, tagParam, currencyParam}), Status: http.StatusOK, Response: []JSONRespExpenseTotal{}},
+	{Method: http.MethodGet, Path: "/reports/expenses/by-tag", Tag: "reports", Summary: "Sum expenses by tag and unit", Params: params(rangeParams, []apiParam{currencyParam}), Status: http.StatusOK, Response: []JSONRespExpenseTotal{}},
+	{Method: http.MethodGet, Path: "/reports/expenses/by-unit", Tag: "reports", Summary: "Sum expenses by unit", Params: params(rangeParams, []apiParam{currencyParam}), Status: http.StatusOK, Response: []JSONRespExpenseTotal{}},
+	{Method: http.MethodGet, Path: "/reports/activities/by-tag", Tag: "reports", Summary: "Sum durations of activities by tag", Params: rangeParams, Status: http.StatusOK, Response: []JSONRespActivityTotal{}},
+	{Method: http.MethodGet, Path: "/reports/activities/by-place", Tag: "reports", Summary: "Sum durations of activities by place", Params: rangeParams, Status: http.StatusOK, Response: []JSONRespActivityTotal{}},
+	{Method: http.MethodGet, Path: "/reports/activities/by-weekday", Tag: "reports", Summary: "Sum durations of activities by weekday", Params: rangeParams, Status: http.StatusOK, Response: []JSONRespActivityTotal{}},
+	{Method: http.MethodGet, Path: "/reports/activities/by-hour", Tag: "reports", Summary: "Sum durations of activities by hour of the day", Params: rangeParams, Status: http.StatusOK, Response: []JSONRespActivityTotal{}},
+	// Search
+	{Method: http.MethodGet, Path: "/search", Tag: "search", Summary: "Search activities and expenses", Params: []apiParam{{Name: "q", In: "query", Type: "string", Required: true, Description: "Text query"}, limitParam}, Status: http.StatusOK, Response: []JSONRespSearchResult{}},
+	// Exchange Rates
+	{Method: http.MethodGet, Path: "/rates", Tag: "rates", Summary: "Get the rate of a currency in another one", Params: []apiParam{
+		{Name: "base", In: "query", Type: "string", Required: true, Description: "ISO-4217 currency"},
+		{Name: "quote", In: "query", Type: "string", Required: true, Description: "ISO-4217 currency"},
+		{Name: "time", In: "query", Type: "string", Description: "Date of the rate ( default is now )"},
+	}, Status: http.StatusOK, Response: JSONRespExchangeRate{}},
+	{Method: http.MethodPost, Path: "/rates/import", Tag: "rates", Summary: "Import exchange rates from a CSV or an ECB file", Request: "", RequestType: "text/csv", Status: http.StatusOK, Response: JSONRespImportRates{}},
+	// CSV Export & Import
+	{Method: http.MethodGet, Path: "/export/:file", Tag: "transfer", Summary: "Export entities as a CSV file", Params: []apiParam{{Name: "file", In: "path", Type: "string", Required: true, Enum: sortedKeys(exporters)}}, Status: http.StatusOK, Response: "", ResponseType: "text/csv"},
+	{Method: http.MethodPost, Path: "/import/:entity", Tag: "transfer", Summary: "Import entities from a CSV file", Params: []apiParam{{Name: "entity", In: "path", Type: "string", Required: true, Enum: sortedKeys(importers)}}, Request: "", RequestType: "text/csv", Status: http.StatusOK, Response: JSONRespImport{}},
+	// Backup & Restore
+	{Method: http.MethodGet, Path: "/backup", Tag: "archive", Summary: "Back up all tags, activities and expenses", Status: http.StatusOK, Response: archiving.Archive{}},
+	{Method: http.MethodPost, Path: "/restore", Tag: "archive", Summary: "Restore a backup into an empty store, giving entities new IDs", Request: archiving.Archive{}, Status: http.StatusOK, Response: JSONRespRestore{}},
+	// Trash
+	{Method: http.MethodGet, Path: "/trash", Tag: "trash", Summary: "List deleted tags, activities and expenses", Status: http.StatusOK, Response: []JSONRespTrashItem{}},
+	{Method: http.MethodPost, Path: "/trash/:kind/:id/restore", Tag: "trash", Summary: "Restore an item from the trash", Params: []apiParam{trashKindParam}, Status: http.StatusNoContent},
+	{Method: http.MethodDelete, Path: "/trash/:kind/:id", Tag: "trash", Summary: "Permanently delete an item from the trash", Params: []apiParam{trashKindParam}, Status: http.StatusNoContent},
+	{Method: http.MethodDelete, Path: "/trash", Tag: "trash", Summary: "Permanently delete all items in the trash", Status: http.StatusOK, Response: JSONRespPurge{}},
+}
+
+// openAPISchemas holds the schemas of the named types used in bodies.
+type openAPISchemas map[string]interface{}
+
+// schemaName returns the name of the schema of a named type.
+func schemaName(t reflect.Type) string {
+	name := t.Name()
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// ref returns a reference to the schema with given name.
+func ref(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+// of returns the schema of the json encoding of type t.
+// Schemas of structs are added to the components and referenced.
+func (schemas openAPISchemas) of(t reflect.Type) map[string]interface{} {
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case reflect.TypeOf(time.Duration(0)):
+		return map[string]interface{}{"type": "integer", "format": "int64", "description": "Duration in nanoseconds"}
+	case reflect.TypeOf(json.Number("")):
+		return map[string]interface{}{"type": "number"}
+	case reflect.TypeOf(json.RawMessage{}):
+		return map[string]interface{}{"description": "Any JSON value"}
+	case reflect.TypeOf(domain.Status("")):
+		return map[string]interface{}{"type": "string", "enum": statusParam.Enum}
+	case reflect.TypeOf(domain.Period("")):
+		return map[string]interface{}{"type": "string", "enum": periodParam.Enum}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemas.of(t.Elem())
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemas.of(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemas.of(t.Elem())}
+	case reflect.Struct:
+		name := schemaName(t)
+		if _, ok := schemas[name]; !ok {
+			schemas[name] = nil // Registered before its fields in case it references itself
+			schemas[name] = schemas.object(t)
+		}
+		return ref(name)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// jsonFields calls fn with the json name, the options and the field
+// of each field encoded in json of struct type t, including embedded ones.
+func jsonFields(t reflect.Type, fn func(name string, opts string, field reflect.StructField)) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			jsonFields(field.Type, fn)
+			continue
+		}
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+		name, opts := tag, ""
+		if comma := strings.Index(tag, ","); comma >= 0 {
+			name, opts = tag[:comma], tag[comma:]
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fn(name, opts, field)
+	}
+}
+
+// object returns the schema of a struct from its fields & json tags.
+func (schemas openAPISchemas) object(t reflect.Type) map[string]interface{} {
+	props := map[string]interface{}{}
+	jsonFields(t, func(name string, _ string, field reflect.StructField) {
+		props[name] = schemas.of(field.Type)
+	})
+	return map[string]interface{}{"type": "object", "properties": props}
+}
+
+// require lists as required in the schemas of the structs of the json encoding
+// of type t the properties they always encode: neither omitempty nor pointers.
+// It is only called for responses as requests may leave properties out.
+func (schemas openAPISchemas) require(t reflect.Type, seen map[reflect.Type]bool) {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		schemas.require(t.Elem(), seen)
+	case reflect.Struct:
+		schema, ok := schemas[schemaName(t)].(map[string]interface{})
+		if !ok || seen[t] {
+			return
+		}
+		seen[t] = true
+		required := []string{}
+		jsonFields(t, func(name string, opts string, field reflect.StructField) {
+			if !strings.Contains(opts, ",omitempty") && field.Type.Kind() != reflect.Ptr {
+				required = append(required, name)
+			}
+			schemas.require(field.Type, seen)
+		})
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+	}
+}
+
+// page returns the schema of a page of items of type t.
+func (schemas openAPISchemas) page(t reflect.Type) map[string]interface{} {
+	name := schemaName(t) + "Page"
+	if _, ok := schemas[name]; !ok {
+		schemas[name] = map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"items": map[string]interface{}{"type": "array", "items": schemas.of(t)},
+				"next":  map[string]interface{}{"type": "string", "description": "Cursor of the next page ( empty if none )"},
+				"total": map[string]interface{}{"type": "integer"},
+			},
+			"required": []string{"items", "next", "total"},
+		}
+	}
+	return ref(name)
+}
+
+// content returns the content of a body with given value and content type.
+// Properties always encoded are required in the schemas of responses.
+func (schemas openAPISchemas) content(body interface{}, contentType string, response bool) map[string]interface{} {
+	var schema map[string]interface{}
+	t := reflect.TypeOf(body)
+	if p, ok := body.(apiPage); ok {
+		t = reflect.TypeOf(p.item)
+		schema = schemas.page(t)
+	} else {
+		schema = schemas.of(t)
+	}
+	if response {
+		schemas.require(t, map[reflect.Type]bool{})
+	}
+	if contentType == "" {
+		contentType = echo.MIMEApplicationJSON
+	}
+	return map[string]interface{}{contentType: map[string]interface{}{"schema": schema}}
+}
+
+// openAPIPath converts an echo path ( /tags/:id ) to an OpenAPI path ( /tags/{id} )
+// and returns the names of its parameters.
+func openAPIPath(path string) (string, []string) {
+	names := []string{}
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") {
+			names = append(names, s[1:])
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), names
+}
+
+// document returns the OpenAPI operation object of op.
+func (op apiOperation) document(schemas openAPISchemas, pathParams []string) map[string]interface{} {
+	described := map[string]bool{}
+	parameters := []interface{}{}
+	addParam := func(p apiParam) {
+		schema := map[string]interface{}{"type": p.Type}
+		if len(p.Enum) > 0 {
+			schema["enum"] = p.Enum
+		}
+		param := map[string]interface{}{"name": p.Name, "in": p.In, "required": p.Required || p.In == "path", "schema": schema}
+		if p.Description != "" {
+			param["description"] = p.Description
+		}
+		parameters = append(parameters, param)
+	}
+	for _, p := range op.Params {
+		if p.In == "path" {
+			described[p.Name] = true
+		}
+	}
+	for _, name := range pathParams {
+		if described[name] {
+			continue
+		}
+		typ := "string"
+		if name == "id" {
+			typ = "integer"
+		}
+		addParam(apiParam{Name: name, In: "path", Type: typ})
+	}
+	for _, p := range op.Params {
+		addParam(p)
+	}
+	success := map[string]interface{}{"description": http.StatusText(op.Status)}
+	if op.Response != nil {
+		success["content"] = schemas.content(op.Response, op.ResponseType, true)
+	}
+	doc := map[string]interface{}{
+		"summary": op.Summary,
+		"tags":    []string{op.Tag},
+		"responses": map[string]interface{}{
+			strconv.Itoa(op.Status): success,
+			"default": map[string]interface{}{
+				"description": "Error problem ( RFC 7807 )",
+				"content":     schemas.content(JSONProblem{}, problemContentType, true),
+			},
+		},
+	}
+	if len(parameters) > 0 {
+		doc["parameters"] = parameters
+	}
+	if op.Request != nil {
+		doc["requestBody"] = map[string]interface{}{"required": true, "content": schemas.content(op.Request, op.RequestType, false)}
+	}
+	if !op.Public {
+		security := []interface{}{map[string]interface{}{"bearerAuth": []string{}}}
+		if apiKeyTags[op.Tag] {
+			security = append(security, map[string]interface{}{"apiKeyAuth": []string{}})
+		}
+		doc["security"] = security
+	}
+	return doc
+}
+
+// openAPIDocument returns the OpenAPI 3 document describing apiOperations.
+func openAPIDocument() map[string]interface{} {
+	schemas := openAPISchemas{}
+	paths := map[string]map[string]interface{}{}
+	for _, op := range apiOperations {
+		path, pathParams := openAPIPath(op.Path)
+		if paths[path] == nil {
+			paths[path] = map[string]interface{}{}
+		}
+		paths[path][strings.ToLower(op.Method)] = op.document(schemas, pathParams)
+	}
+	return map[string]interface{}{
+		"openapi": openAPIVersion,
+		"info": map[string]interface{}{
+			"title":   "Lifelog API",
+			"version": apiVersion,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+				"apiKeyAuth": map[string]interface{}{"type": "apiKey", "in": "header", "name": apiKeyHeader},
+			},
+		},
+	}
+}
+
+// OpenAPI handler returns the OpenAPI 3 document describing the API.
+func OpenAPI(c echo.Context) error {
+	return c.JSON(http.StatusOK, openAPIDocument())
+}
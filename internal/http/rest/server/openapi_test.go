@@ -0,0 +1,138 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+// openAPIDoc is the part of the OpenAPI document checked by tests
+type openAPIDoc struct {
+	OpenAPI    string                                `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]json.RawMessage `json:"schemas"`
+	} `json:"components"`
+}
+
+// fetchOpenAPI calls the OpenAPI handler and returns the decoded document
+func fetchOpenAPI(t *testing.T) openAPIDoc {
+	path := "/openapi.json"
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	c := router.NewContext(req, rec)
+	c.SetPath(path)
+	if err := server.OpenAPI(c); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d", http.StatusOK, rec.Code)
+	}
+	var doc openAPIDoc
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("\nUnexpected Error decoding document: %v", err)
+	}
+	return doc
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	doc := fetchOpenAPI(t)
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Fatalf("\nExpected OpenAPI 3 document\nReturned version: %s", doc.OpenAPI)
+	}
+	for _, r := range router.Routes() {
+		// Skip routes registered by echo for group middlewares
+		if strings.HasPrefix(r.Name, "github.com/labstack/echo") {
+			continue
+		}
+		segments := strings.Split(r.Path, "/")
+		for i, s := range segments {
+			if strings.HasPrefix(s, ":") {
+				segments[i] = "{" + s[1:] + "}"
+			}
+		}
+		path := strings.Join(segments, "/")
+		if _, ok := doc.Paths[path][strings.ToLower(r.Method)]; !ok {
+			t.Errorf("\nRoute %s %s is missing from the OpenAPI document", r.Method, path)
+		}
+	}
+}
+
+func TestOpenAPISchemas(t *testing.T) {
+	doc := fetchOpenAPI(t)
+	// Expected schemas are written by hand, not derived from the types
+	tests := map[string]string{
+		"JSONRespBudget": `{
+			"type": "object",
+			"properties": {
+				"id": {"type": "integer", "minimum": 0},
+				"tagId": {"type": "integer", "minimum": 0},
+				"period": {"type": "string", "enum": ["day", "week", "month", "year"]},
+				"amount": {"type": "number"},
+				"currency": {"type": "string"}
+			},
+			"required": ["id", "tagId", "period", "amount", "currency"]
+		}`,
+		"JSONRespListExpense": `{
+			"type": "object",
+			"properties": {
+				"id": {"type": "integer", "minimum": 0},
+				"label": {"type": "string"},
+				"time": {"type": "string", "format": "date-time"},
+				"value": {"type": "number"},
+				"unit": {"type": "string"},
+				"activityId": {"type": "integer", "minimum": 0},
+				"status": {"type": "string", "enum": ["planned", "done", "cancelled"]}
+			},
+			"required": ["id", "label", "time", "value", "unit", "activityId", "status"]
+		}`,
+		"JSONRespAPIKey": `{
+			"type": "object",
+			"properties": {
+				"id": {"type": "integer", "minimum": 0},
+				"name": {"type": "string"},
+				"prefix": {"type": "string"},
+				"scope": {"type": "string"},
+				"createdAt": {"type": "string", "format": "date-time"},
+				"expiresAt": {"type": "string", "format": "date-time"}
+			},
+			"required": ["id", "name", "prefix", "scope", "createdAt"]
+		}`,
+		"JSONReqActivity": `{
+			"type": "object",
+			"properties": {
+				"id": {"type": "integer", "minimum": 0},
+				"label": {"type": "string"},
+				"desc": {"type": "string"},
+				"place": {"type": "string"},
+				"time": {"type": "string", "format": "date-time"},
+				"duration": {"type": "integer", "format": "int64", "description": "Duration in nanoseconds"},
+				"status": {"type": "string", "enum": ["planned", "done", "cancelled"]},
+				"tagIds": {"type": "array", "items": {"type": "integer", "minimum": 0}}
+			}
+		}`,
+	}
+	for name, expected := range tests {
+		t.Run(name, func(t *testing.T) {
+			raw, ok := doc.Components.Schemas[name]
+			if !ok {
+				t.Fatalf("\nSchema %s is missing from the OpenAPI document", name)
+			}
+			var expectedSchema, schema interface{}
+			if err := json.Unmarshal([]byte(expected), &expectedSchema); err != nil {
+				t.Fatalf("\nUnexpected Error decoding expected schema: %v", err)
+			}
+			if err := json.Unmarshal(raw, &schema); err != nil {
+				t.Fatalf("\nUnexpected Error decoding schema: %v", err)
+			}
+			if !reflect.DeepEqual(schema, expectedSchema) {
+				t.Fatalf("\nExpected Schema: %s\nReturned Schema: %s", expected, raw)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package server
+
+import "github.com/elhamza90/lifelog/internal/store"
+
+// JSONRespPage is the envelope of a page of a list.
+// Next is the cursor to send to fetch the next page.
+// It is empty when there are no more items.
+type JSONRespPage struct {
+	Items interface{} `json:"items"`
+	Next  string      `json:"next"`
+	Total int         `json:"total"`
+}
+
+// From constructs a JSONRespPage object from given items and page information
+func (resp *JSONRespPage) From(items interface{}, p store.Page) {
+	resp.Items = items
+	resp.Next = p.NextCursor
+	resp.Total = p.Total
+}
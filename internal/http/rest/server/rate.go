@@ -0,0 +1,60 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// ExchangeRate handler returns the rate of a currency in another one.
+// It requires query parameters "base" and "quote" ( ISO-4217 codes )
+// and has an optional query parameter "time" specifying the date
+// of the rate ( see parseDateFilter for accepted formats ). Default is now.
+func (h *Handler) ExchangeRate(c echo.Context) error {
+	base, err := domain.ParseCurrency(c.QueryParam("base"))
+	if err != nil {
+		msg := "Error parsing base currency"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	quote, err := domain.ParseCurrency(c.QueryParam("quote"))
+	if err != nil {
+		msg := "Error parsing quote currency"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	t := time.Now()
+	if timeStr := c.QueryParam("time"); len(timeStr) > 0 {
+		if t, err = parseDateFilter(timeStr, true); err != nil {
+			msg := "Error parsing time param to valid date"
+			logrus.Error(msg + " : " + err.Error())
+			return err
+		}
+	}
+	rate, err := h.exchanger.Rate(base, quote, t)
+	if err != nil {
+		msg := "Error while fetching exchange rate of " + string(base) + " in " + string(quote)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Fetched exchange rate of %s in %s successfully", base, quote)
+	return c.JSON(http.StatusOK, JSONRespExchangeRate{Base: base, Quote: quote, Time: t, Rate: rate})
+}
+
+// ImportExchangeRates handler imports exchange rates from the request body.
+// The body is a CSV file with columns date,base,quote,rate
+// or an ECB reference rates file ( XML or CSV ).
+func (h *Handler) ImportExchangeRates(c echo.Context) error {
+	defer c.Request().Body.Close()
+	imported, err := h.exchanger.ImportRates(c.Request().Body)
+	if err != nil {
+		msg := "Error while importing exchange rates"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Imported %d exchange rates successfully", imported)
+	return c.JSON(http.StatusOK, JSONRespImportRates{Imported: imported})
+}
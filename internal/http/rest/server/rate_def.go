@@ -0,0 +1,20 @@
+package server
+
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// JSONRespExchangeRate is used to marshal an exchange rate to json.
+type JSONRespExchangeRate struct {
+	Base  domain.Currency `json:"base"`
+	Quote domain.Currency `json:"quote"`
+	Time  time.Time       `json:"time"`
+	Rate  float64         `json:"rate"`
+}
+
+// JSONRespImportRates is used to marshal the result of an exchange rates import to json.
+type JSONRespImportRates struct {
+	Imported int `json:"imported"`
+}
@@ -0,0 +1,88 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+func TestImportExchangeRates(t *testing.T) {
+	defer func() { repo.Rates = map[string]domain.ExchangeRate{} }()
+	tests := map[string]struct {
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		"CSV": {
+			body:         "date,base,quote,rate\n2020-11-20,EUR,USD,1.25\n2020-11-20,EUR,MAD,10.5\n",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"imported":2}`,
+		},
+		"ECB XML": {
+			body:         `<Envelope><Cube><Cube time="2020-11-20"><Cube currency="USD" rate="1.1861"/></Cube></Cube></Envelope>`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"imported":1}`,
+		},
+		"Wrong Format": {
+			body:         "2020-11-20;EUR;USD;1.25\n",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Unknown Currency": {
+			body:         "2020-11-20,EUR,DOLLAR,1.25\n",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	const path string = "/rates/import"
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(test.body))
+			req.Header.Set("Content-type", "text/csv")
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath(path)
+			handle(hnd.ImportExchangeRates, ctx)
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+			if body := strings.TrimSpace(rec.Body.String()); test.expectedBody != "" && body != test.expectedBody {
+				t.Fatalf("\nExpected Body: %s\nReturned Body: %s", test.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestExchangeRate(t *testing.T) {
+	repo.Rates = map[string]domain.ExchangeRate{}
+	repo.SaveExchangeRates([]domain.ExchangeRate{
+		{Base: "EUR", Quote: "USD", Date: time.Date(2020, 11, 20, 0, 0, 0, 0, time.UTC), Rate: 1.25},
+	})
+	defer func() { repo.Rates = map[string]domain.ExchangeRate{} }()
+	tests := map[string]struct {
+		query        string
+		expectedCode int
+	}{
+		"Direct":           {query: "?base=EUR&quote=USD&time=2020-11-21", expectedCode: http.StatusOK},
+		"Inverse":          {query: "?base=usd&quote=eur", expectedCode: http.StatusOK},
+		"Before Rates":     {query: "?base=EUR&quote=USD&time=2020-11-19", expectedCode: http.StatusNotFound},
+		"Unknown Currency": {query: "?base=EURO&quote=USD", expectedCode: http.StatusBadRequest},
+		"Missing Quote":    {query: "?base=EUR", expectedCode: http.StatusBadRequest},
+		"Wrong Time":       {query: "?base=EUR&quote=USD&time=20/11/2020", expectedCode: http.StatusBadRequest},
+	}
+	const path string = "/rates"
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, path+test.query, nil)
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath(path)
+			handle(hnd.ExchangeRate, ctx)
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultReportPeriod specifies the period used to group
+// a report when no period was provided.
+const defaultReportPeriod domain.Period = domain.PeriodMonth
+
+// reportRange extracts the range of a report from query parameters.
+// If "from" is missing, the last 3 months are reported.
+func reportRange(c echo.Context) (from time.Time, to time.Time, err error) {
+	return dateRangeFilter(c, defaultExpensesDateFilter())
+}
+
+// writeExpensesReport writes the given totals as json response.
+func writeExpensesReport(c echo.Context, totals []domain.ExpenseTotal) error {
+	respTotals := make([]JSONRespExpenseTotal, len(totals))
+	var respTotal JSONRespExpenseTotal
+	for i, total := range totals {
+		respTotal.From(total)
+		respTotals[i] = respTotal
+	}
+	return c.JSON(http.StatusOK, respTotals)
+}
+
+// ExpensesReportByPeriod handler returns sums of expenses grouped by period and unit.
+// It has optional query parameters:
+//	- "period": one of day, week, month, year ( default is month )
+//	- "from" & "to": range of the report
+//	- "tag": ID of a tag to filter expenses with
+//	- "currency": currency to convert expenses to before summing them
+func (h *Handler) ExpensesReportByPeriod(c echo.Context) error {
+	from, to, err := reportRange(c)
+	if err != nil {
+		msg := "Error parsing from/to params to valid dates"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
+	period := domain.Period(c.QueryParam("period"))
+	if len(period) == 0 {
+		period = defaultReportPeriod
+	}
+	var tagID domain.TagID
+	if tagStr := c.QueryParam("tag"); len(tagStr) > 0 {
+		id, err := strconv.Atoi(tagStr)
+		if err != nil {
+			msg := fmt.Sprintf("Error while converting query param Tag ID with value %s to int", tagStr)
+			logrus.Error(msg + " | " + err.Error())
+			return errTagFilterFormat
+		}
+		tagID = domain.TagID(id)
+	}
+	cur, err := currencyFilter(c)
+	if err != nil {
+		msg := "Error parsing currency param"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	// Fetch Totals
+	var totals []domain.ExpenseTotal
+	if len(cur) > 0 {
+		totals, err = h.reporter.ExpensesByPeriodIn(cur, period, from, to, tagID)
+	} else {
+		totals, err = h.reporter.ExpensesByPeriod(period, from, to, tagID)
+	}
+	if err != nil {
+		msg := fmt.Sprintf("Error while reporting expenses by %s", period)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Reported expenses by %s successfully", period)
+	return writeExpensesReport(c, totals)
+}
+
+// ExpensesReportByTag handler returns sums of expenses grouped by tag and unit.
+// It has optional query parameters "from" & "to" specifying the range of the report
+// and "currency" specifying a currency to convert expenses to before summing them.
+func (h *Handler) ExpensesReportByTag(c echo.Context) error {
+	from, to, err := reportRange(c)
+	if err != nil {
+		msg := "Error parsing from/to params to valid dates"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
+	cur, err := currencyFilter(c)
+	if err != nil {
+		msg := "Error parsing currency param"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	// Fetch Totals
+	var totals []domain.ExpenseTotal
+	if len(cur) > 0 {
+		totals, err = h.reporter.ExpensesByTagIn(cur, from, to)
+	} else {
+		totals, err = h.reporter.ExpensesByTag(from, to)
+	}
+	if err != nil {
+		msg := "Error while reporting expenses by tag"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Info("Reported expenses by tag successfully")
+	return writeExpensesReport(c, totals)
+}
+
+// ExpensesReportByUnit handler returns sums of expenses grouped by unit.
+// It has optional query parameters "from" & "to" specifying the range of the report
+// and "currency" specifying a currency to convert all expenses to.
+// When a currency is provided, a single total is returned.
+func (h *Handler) ExpensesReportByUnit(c echo.Context) error {
+	from, to, err := reportRange(c)
+	if err != nil {
+		msg := "Error parsing from/to params to valid dates"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
+	cur, err := currencyFilter(c)
+	if err != nil {
+		msg := "Error parsing currency param"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	// Fetch Totals
+	var totals []domain.ExpenseTotal
+	if len(cur) > 0 {
+		totals, err = h.reporter.ExpensesTotalIn(cur, from, to)
+	} else {
+		totals, err = h.reporter.ExpensesByUnit(from, to)
+	}
+	if err != nil {
+		msg := "Error while reporting expenses by unit"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Info("Reported expenses by unit successfully")
+	return writeExpensesReport(c, totals)
+}
+
+// activitiesReport extracts the range of the report from query params,
+// calls the given reporting function and writes the returned totals as json response.
+// The by parameter specifies the grouping criteria of the report ( used in messages ).
+func activitiesReport(c echo.Context, by string, report func(time.Time, time.Time) ([]domain.ActivityTotal, error)) error {
+	from, to, err := reportRange(c)
+	if err != nil {
+		msg := "Error parsing from/to params to valid dates"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
+	// Fetch Totals
+	totals, err := report(from, to)
+	if err != nil {
+		msg := fmt.Sprintf("Error while reporting activities by %s", by)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Reported activities by %s successfully", by)
+	respTotals := make([]JSONRespActivityTotal, len(totals))
+	var respTotal JSONRespActivityTotal
+	for i, total := range totals {
+		respTotal.From(total)
+		respTotals[i] = respTotal
+	}
+	return c.JSON(http.StatusOK, respTotals)
+}
+
+// ActivitiesReportByTag handler returns total & average durations of activities grouped by tag.
+// It has optional query parameters "from" & "to" specifying the range of the report
+func (h *Handler) ActivitiesReportByTag(c echo.Context) error {
+	return activitiesReport(c, "tag", h.reporter.ActivitiesByTag)
+}
+
+// ActivitiesReportByPlace handler returns total & average durations of activities grouped by place.
+// It has optional query parameters "from" & "to" specifying the range of the report
+func (h *Handler) ActivitiesReportByPlace(c echo.Context) error {
+	return activitiesReport(c, "place", h.reporter.ActivitiesByPlace)
+}
+
+// ActivitiesReportByWeekday handler returns total & average durations of activities grouped by weekday.
+// It has optional query parameters "from" & "to" specifying the range of the report
+func (h *Handler) ActivitiesReportByWeekday(c echo.Context) error {
+	return activitiesReport(c, "weekday", h.reporter.ActivitiesByWeekday)
+}
+
+// ActivitiesReportByHour handler returns total & average durations of activities grouped by hour of day.
+// It has optional query parameters "from" & "to" specifying the range of the report
+func (h *Handler) ActivitiesReportByHour(c echo.Context) error {
+	return activitiesReport(c, "hour", h.reporter.ActivitiesByHour)
+}
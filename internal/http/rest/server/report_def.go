@@ -0,0 +1,41 @@
+package server
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// JSONRespExpenseTotal is used to marshal an expense total in a report to json.
+// Total is a decimal number with the decimals of the currency.
+type JSONRespExpenseTotal struct {
+	Group string          `json:"group"`
+	Unit  domain.Currency `json:"unit"`
+	Total json.Number     `json:"total"`
+	Count int             `json:"count"`
+}
+
+// From constructs a JSONRespExpenseTotal object from a domain.ExpenseTotal object.
+func (respTotal *JSONRespExpenseTotal) From(total domain.ExpenseTotal) {
+	(*respTotal).Group = total.Group
+	(*respTotal).Unit = total.Unit
+	(*respTotal).Total = json.Number(total.Total.Format(total.Unit))
+	(*respTotal).Count = total.Count
+}
+
+// JSONRespActivityTotal is used to marshal an activity total in a report to json.
+type JSONRespActivityTotal struct {
+	Group   string        `json:"group"`
+	Total   time.Duration `json:"total"`
+	Average time.Duration `json:"average"`
+	Count   int           `json:"count"`
+}
+
+// From constructs a JSONRespActivityTotal object from a domain.ActivityTotal object.
+func (respTotal *JSONRespActivityTotal) From(total domain.ActivityTotal) {
+	(*respTotal).Group = total.Group
+	(*respTotal).Total = total.Total
+	(*respTotal).Average = total.Average
+	(*respTotal).Count = total.Count
+}
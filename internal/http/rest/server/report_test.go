@@ -0,0 +1,237 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+func TestExpensesReportByPeriod(t *testing.T) {
+	// Init Repo with some tags & expenses
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "tag1"},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Expense 1", Value: 10, Unit: "eu", Time: time.Now().AddDate(0, 0, -2), Tags: []domain.Tag{repo.Tags[1]}},
+		2: {ID: 2, Label: "Expense 2", Value: 5, Unit: "eu", Time: time.Now().AddDate(0, 0, -1)},
+	}
+	const frmt string = "01-02-2006" // time format in query param
+	now := time.Now()
+	// Sub-tests definition
+	tests := map[string]struct {
+		filter       string
+		expectedCode int
+	}{
+		"No Params": {
+			filter:       "",
+			expectedCode: http.StatusOK,
+		},
+		"Week with range and tag": {
+			filter:       fmt.Sprintf("?period=week&from=%s&to=%s&tag=1", now.AddDate(0, -1, 0).Format(frmt), now.Format(frmt)),
+			expectedCode: http.StatusOK,
+		},
+		"Invalid Period": {
+			filter:       "?period=decade",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Invalid Range": {
+			filter:       fmt.Sprintf("?from=%s&to=%s", now.Format(frmt), now.AddDate(0, -1, 0).Format(frmt)),
+			expectedCode: http.StatusBadRequest,
+		},
+		"Wrong Date Format": {
+			filter:       "?from=31/01/2020",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Wrong Tag Format": {
+			filter:       "?tag=abc",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Non-Existing Tag": {
+			filter:       "?tag=9898",
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+	// Sub-tests Execution
+	const path string = "/reports/expenses/by-period%s"
+	var (
+		req *http.Request
+		rec *httptest.ResponseRecorder
+		ctx echo.Context
+	)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req = httptest.NewRequest(http.MethodGet, fmt.Sprintf(path, test.filter), nil)
+			rec = httptest.NewRecorder()
+			ctx = router.NewContext(req, rec)
+			ctx.SetPath(path)
+			handle(hnd.ExpensesReportByPeriod, ctx)
+			if rec.Code != test.expectedCode {
+				body := rec.Body.String()
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
+			}
+		})
+	}
+}
+
+func TestExpensesReportByTagAndUnit(t *testing.T) {
+	// Init Repo with some tags & expenses
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "tag1"},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Expense 1", Value: 1000, Unit: "EUR", Time: time.Now().AddDate(0, 0, -2), Tags: []domain.Tag{repo.Tags[1]}},
+		2: {ID: 2, Label: "Expense 2", Value: 500, Unit: "MAD", Time: time.Now().AddDate(0, 0, -1)},
+	}
+	repo.Rates = map[string]domain.ExchangeRate{}
+	repo.SaveExchangeRates([]domain.ExchangeRate{
+		{Base: "EUR", Quote: "MAD", Date: time.Now().AddDate(0, 0, -10), Rate: 10},
+	})
+	defer func() { repo.Rates = map[string]domain.ExchangeRate{} }()
+	// Sub-tests definition
+	tests := map[string]struct {
+		path         string
+		handler      echo.HandlerFunc
+		expectedCode int
+		expectedBody string
+	}{
+		"By Tag": {
+			path:         "/reports/expenses/by-tag",
+			handler:      hnd.ExpensesReportByTag,
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"group":"tag1","unit":"EUR","total":10.00,"count":1}]`,
+		},
+		"By Tag In Currency": {
+			path:         "/reports/expenses/by-tag?currency=mad",
+			handler:      hnd.ExpensesReportByTag,
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"group":"tag1","unit":"MAD","total":100.00,"count":1}]`,
+		},
+		"By Tag Unknown Currency": {
+			path:         "/reports/expenses/by-tag?currency=dirham",
+			handler:      hnd.ExpensesReportByTag,
+			expectedCode: http.StatusBadRequest,
+		},
+		"By Unit": {
+			path:         "/reports/expenses/by-unit",
+			handler:      hnd.ExpensesReportByUnit,
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"group":"EUR","unit":"EUR","total":10.00,"count":1},{"group":"MAD","unit":"MAD","total":5.00,"count":1}]`,
+		},
+		"By Unit In Currency": {
+			path:         "/reports/expenses/by-unit?currency=EUR",
+			handler:      hnd.ExpensesReportByUnit,
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"group":"EUR","unit":"EUR","total":10.50,"count":2}]`,
+		},
+		"By Unit Missing Rate": {
+			path:         "/reports/expenses/by-unit?currency=USD",
+			handler:      hnd.ExpensesReportByUnit,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		"By Unit Wrong Date Format": {
+			path:         "/reports/expenses/by-unit?to=31/01/2020",
+			handler:      hnd.ExpensesReportByUnit,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	// Sub-tests Execution
+	var (
+		req *http.Request
+		rec *httptest.ResponseRecorder
+		ctx echo.Context
+	)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req = httptest.NewRequest(http.MethodGet, test.path, nil)
+			rec = httptest.NewRecorder()
+			ctx = router.NewContext(req, rec)
+			ctx.SetPath(test.path)
+			handle(test.handler, ctx)
+			body := rec.Body.String()
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
+			}
+			if test.expectedBody != "" && body != test.expectedBody+"\n" {
+				t.Fatalf("\nExpected Body: %s\nReturned Body: %s", test.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestActivitiesReports(t *testing.T) {
+	// Init Repo with some activities
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Activity 1", Place: "gym", Time: time.Date(2020, 10, 5, 10, 0, 0, 0, time.UTC), Duration: time.Hour},
+		2: {ID: 2, Label: "Activity 2", Place: "gym", Time: time.Date(2020, 10, 12, 10, 30, 0, 0, time.UTC), Duration: 2 * time.Hour},
+	}
+	const rangeFilter string = "?from=01-01-2020&to=12-31-2020"
+	// Sub-tests definition
+	tests := map[string]struct {
+		path         string
+		handler      echo.HandlerFunc
+		expectedCode int
+		expectedBody string
+	}{
+		"By Tag": {
+			path:         "/reports/activities/by-tag" + rangeFilter,
+			handler:      hnd.ActivitiesReportByTag,
+			expectedCode: http.StatusOK,
+			expectedBody: `[]`,
+		},
+		"By Place": {
+			path:         "/reports/activities/by-place" + rangeFilter,
+			handler:      hnd.ActivitiesReportByPlace,
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"group":"gym","total":10800000000000,"average":5400000000000,"count":2}]`,
+		},
+		"By Weekday": {
+			path:         "/reports/activities/by-weekday" + rangeFilter,
+			handler:      hnd.ActivitiesReportByWeekday,
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"group":"Monday","total":10800000000000,"average":5400000000000,"count":2}]`,
+		},
+		"By Hour": {
+			path:         "/reports/activities/by-hour" + rangeFilter,
+			handler:      hnd.ActivitiesReportByHour,
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"group":"10","total":10800000000000,"average":5400000000000,"count":2}]`,
+		},
+		"Invalid Range": {
+			path:         "/reports/activities/by-hour?from=12-31-2020&to=01-01-2020",
+			handler:      hnd.ActivitiesReportByHour,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Wrong Date Format": {
+			path:         "/reports/activities/by-place?from=31/01/2020",
+			handler:      hnd.ActivitiesReportByPlace,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	// Sub-tests Execution
+	var (
+		req *http.Request
+		rec *httptest.ResponseRecorder
+		ctx echo.Context
+	)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req = httptest.NewRequest(http.MethodGet, test.path, nil)
+			rec = httptest.NewRecorder()
+			ctx = router.NewContext(req, rec)
+			ctx.SetPath(test.path)
+			handle(test.handler, ctx)
+			body := rec.Body.String()
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
+			}
+			if test.expectedBody != "" && body != test.expectedBody+"\n" {
+				t.Fatalf("\nExpected Body: %s\nReturned Body: %s", test.expectedBody, body)
+			}
+		})
+	}
+}
@@ -6,13 +6,23 @@ import (
 	"os"
 	"testing"
 
+	"github.com/elhamza90/lifelog/internal/domain"
 	"github.com/elhamza90/lifelog/internal/http/rest/server"
 	"github.com/elhamza90/lifelog/internal/store/memory"
 	"github.com/elhamza90/lifelog/internal/usecase/adding"
+	"github.com/elhamza90/lifelog/internal/usecase/archiving"
+	"github.com/elhamza90/lifelog/internal/usecase/auditing"
 	"github.com/elhamza90/lifelog/internal/usecase/auth"
 	"github.com/elhamza90/lifelog/internal/usecase/deleting"
 	"github.com/elhamza90/lifelog/internal/usecase/editing"
+	"github.com/elhamza90/lifelog/internal/usecase/exchanging"
 	"github.com/elhamza90/lifelog/internal/usecase/listing"
+	"github.com/elhamza90/lifelog/internal/usecase/reporting"
+	"github.com/elhamza90/lifelog/internal/usecase/scheduling"
+	"github.com/elhamza90/lifelog/internal/usecase/searching"
+	"github.com/elhamza90/lifelog/internal/usecase/throttling"
+	"github.com/elhamza90/lifelog/internal/usecase/transferring"
+	"github.com/elhamza90/lifelog/internal/usecase/trashing"
 	"github.com/labstack/echo/v4"
 	log "github.com/sirupsen/logrus"
 )
@@ -21,34 +31,63 @@ var (
 	router *echo.Echo
 	hnd    *server.Handler
 	repo   memory.Repository
+	// throttler is shared by the handlers of all users.
+	// Rate limiting is disabled as tests send many requests from the same address.
+	throttler throttling.Service
 )
 
-// hashEnvVarName specifies the name of the environment variable
-// where the testing password hash should be stored
-const hashEnvVarName string = "LFLG_TEST_PASS_HASH"
+// newHandler constructs the services using given repository & throttler and returns their handler
+func newHandler(r *memory.Repository, t *throttling.Service) *server.Handler {
+	lister := listing.NewService(r)
+	adder := adding.NewService(r, domain.DefaultPolicy())
+	editor := editing.NewService(r, domain.DefaultPolicy())
+	deletor := deleting.NewService(r)
+	authenticator := auth.NewService(r)
+	reporter := reporting.NewService(r)
+	searcher := searching.NewService(r)
+	exchanger := exchanging.NewService(r)
+	scheduler := scheduling.NewService(r, domain.DefaultPolicy())
+	transferrer := transferring.NewService(r, domain.DefaultPolicy())
+	archiver := archiving.NewService(r, domain.DefaultPolicy())
+	trasher := trashing.NewService(r)
+	auditor := auditing.NewService(r)
+	return server.NewHandler(&lister, &adder, &editor, &deletor, &authenticator, &reporter, &searcher, &exchanger, &scheduler, &transferrer, &archiver, &trasher, &auditor, t)
+}
+
+// forUser returns the handler of the user with given ID
+func forUser(id domain.UserID) *server.Handler {
+	userRepo := repo.ForUser(id)
+	return newHandler(&userRepo, &throttler)
+}
 
 func TestMain(m *testing.M) {
 	log.SetLevel(log.DebugLevel)
 	log.Debug("Setting Up Test router")
 	// Init Interactors and Repository
 	repo = memory.NewRepository()
-	lister := listing.NewService(&repo)
-	adder := adding.NewService(&repo)
-	editor := editing.NewService(&repo)
-	deletor := deleting.NewService(&repo)
-	authenticator := auth.NewService(hashEnvVarName)
-	hnd = server.NewHandler(&lister, &adder, &editor, &deletor, &authenticator)
+	config := throttling.DefaultConfig()
+	config.Requests = 0
+	throttler = throttling.NewService(memory.Throttles{}, nil, config)
+	hnd = newHandler(&repo, &throttler) // Passing by reference to change db when testing
 	// Define and Save JWT Secrets in Env Vars
 	os.Setenv("LFLG_JWT_ACCESS_SECRET", "test-access-secret")
 	os.Setenv("LFLG_JWT_REFRESH_SECRET", "test-refresh-secret")
 	// Init Router
 	router = echo.New()
-	if err := server.RegisterRoutes(router, hnd); err != nil {
+	if err := server.RegisterRoutes(router, hnd, forUser); err != nil {
 		os.Exit(1)
 	}
 	os.Exit(m.Run())
 }
 
+// handle calls a handler and renders its error
+// like the router does ( see server.HTTPErrorHandler ).
+func handle(h echo.HandlerFunc, c echo.Context) {
+	if err := h(c); err != nil {
+		router.HTTPErrorHandler(err, c)
+	}
+}
+
 func TestHealthCheck(t *testing.T) {
 	path := "/health-check"
 	req := httptest.NewRequest(http.MethodGet, path, nil)
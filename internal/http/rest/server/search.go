@@ -0,0 +1,42 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// Search handler returns activities and expenses matching a text query
+// ordered from best to worst match.
+// It requires a query parameter "q" and has an optional
+// query parameter "limit" specifying the maximum number of results.
+func (h *Handler) Search(c echo.Context) error {
+	q := c.QueryParam("q")
+	limitStr := c.QueryParam("limit")
+	logrus.Debugf("Extracted query params q: %s, limit: %s", q, limitStr)
+	var limit int
+	if len(limitStr) > 0 {
+		var err error
+		if limit, err = strconv.Atoi(limitStr); err != nil {
+			msg := "Error parsing search limit"
+			logrus.Error(msg + " : " + err.Error())
+			return errSearchLimitFormat
+		}
+	}
+	results, err := h.searcher.Search(q, limit)
+	if err != nil {
+		msg := "Error while searching activities and expenses"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Found %d results for search %q", len(results), q)
+	respResults := make([]JSONRespSearchResult, len(results))
+	var respRes JSONRespSearchResult
+	for i, res := range results {
+		respRes.From(res)
+		respResults[i] = respRes
+	}
+	return c.JSON(http.StatusOK, respResults)
+}
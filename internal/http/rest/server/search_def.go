@@ -0,0 +1,25 @@
+package server
+
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// JSONRespSearchResult is used to marshal a search result to json.
+type JSONRespSearchResult struct {
+	Kind  domain.SearchResultKind `json:"kind"`
+	ID    uint                    `json:"id"`
+	Label string                  `json:"label"`
+	Time  time.Time               `json:"time"`
+	Rank  float64                 `json:"rank"`
+}
+
+// From constructs a JSONRespSearchResult object from a domain.SearchResult object.
+func (respRes *JSONRespSearchResult) From(res domain.SearchResult) {
+	(*respRes).Kind = res.Kind
+	(*respRes).ID = res.ID
+	(*respRes).Label = res.Label
+	(*respRes).Time = res.Time
+	(*respRes).Rank = res.Rank
+}
@@ -0,0 +1,60 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+)
+
+func TestSearch(t *testing.T) {
+	now := time.Now()
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Dinner with friends", Place: "Le Petit Bistrot", Time: now.AddDate(0, 0, -3)},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Dinner", Value: 45, Unit: "eu", Time: now.AddDate(0, 0, -4)},
+	}
+	defer func() {
+		repo.Activities = map[domain.ActivityID]domain.Activity{}
+		repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	}()
+	tests := map[string]struct {
+		query         string
+		expectedCode  int
+		expectedCount int
+	}{
+		"Mixed Results":  {query: "?q=dinner", expectedCode: http.StatusOK, expectedCount: 2},
+		"Limit":          {query: "?q=dinner&limit=1", expectedCode: http.StatusOK, expectedCount: 1},
+		"No Result":      {query: "?q=cinema", expectedCode: http.StatusOK, expectedCount: 0},
+		"Missing Query":  {query: "", expectedCode: http.StatusBadRequest},
+		"Wrong Limit":    {query: "?q=dinner&limit=two", expectedCode: http.StatusBadRequest},
+		"Limit Too High": {query: "?q=dinner&limit=1000", expectedCode: http.StatusBadRequest},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/search"+test.query, nil)
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath("/search")
+			handle(hnd.Search, ctx)
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %v\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			var results []server.JSONRespSearchResult
+			if err := json.Unmarshal(rec.Body.Bytes(), &results); err != nil {
+				t.Fatalf("\nUnexpected Error while unmarshaling response: %v", err)
+			}
+			if len(results) != test.expectedCount {
+				t.Fatalf("\nExpected %d results\nReturned: %v", test.expectedCount, results)
+			}
+		})
+	}
+}
@@ -4,11 +4,21 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/elhamza90/lifelog/internal/domain"
 	"github.com/elhamza90/lifelog/internal/usecase/adding"
+	"github.com/elhamza90/lifelog/internal/usecase/archiving"
+	"github.com/elhamza90/lifelog/internal/usecase/auditing"
 	"github.com/elhamza90/lifelog/internal/usecase/auth"
 	"github.com/elhamza90/lifelog/internal/usecase/deleting"
 	"github.com/elhamza90/lifelog/internal/usecase/editing"
+	"github.com/elhamza90/lifelog/internal/usecase/exchanging"
 	"github.com/elhamza90/lifelog/internal/usecase/listing"
+	"github.com/elhamza90/lifelog/internal/usecase/reporting"
+	"github.com/elhamza90/lifelog/internal/usecase/scheduling"
+	"github.com/elhamza90/lifelog/internal/usecase/searching"
+	"github.com/elhamza90/lifelog/internal/usecase/throttling"
+	"github.com/elhamza90/lifelog/internal/usecase/transferring"
+	"github.com/elhamza90/lifelog/internal/usecase/trashing"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	log "github.com/sirupsen/logrus"
@@ -22,54 +32,160 @@ type Handler struct {
 	editor        editing.Service
 	deleter       deleting.Service
 	authenticator auth.Service
+	reporter      reporting.Service
+	searcher      searching.Service
+	exchanger     exchanging.Service
+	scheduler     scheduling.Service
+	transferrer   transferring.Service
+	archiver      archiving.Service
+	trasher       trashing.Service
+	auditor       auditing.Service
+	throttler     throttling.Service
 }
 
 // NewHandler constructs & returns a new handler with provided services.
-func NewHandler(lister *listing.Service, adder *adding.Service, editor *editing.Service, deleter *deleting.Service, authenticator *auth.Service) *Handler {
+func NewHandler(lister *listing.Service, adder *adding.Service, editor *editing.Service, deleter *deleting.Service, authenticator *auth.Service, reporter *reporting.Service, searcher *searching.Service, exchanger *exchanging.Service, scheduler *scheduling.Service, transferrer *transferring.Service, archiver *archiving.Service, trasher *trashing.Service, auditor *auditing.Service, throttler *throttling.Service) *Handler {
 	return &Handler{
 		lister:        *lister,
 		adder:         *adder,
 		editor:        *editor,
 		deleter:       *deleter,
 		authenticator: *authenticator,
+		reporter:      *reporter,
+		searcher:      *searcher,
+		exchanger:     *exchanger,
+		scheduler:     *scheduler,
+		transferrer:   *transferrer,
+		archiver:      *archiver,
+		trasher:       *trasher,
+		auditor:       *auditor,
+		throttler:     *throttler,
 	}
 }
 
+// HandlerFactory returns a handler with services which only access
+// the data of the user with given ID.
+type HandlerFactory func(domain.UserID) *Handler
+
 // RegisterRoutes registers routes with handlers.
-func RegisterRoutes(r *echo.Echo, hnd *Handler) error {
+// Authentication routes use hnd. Other routes require an access token
+// and use the handler returned by forUser for the authenticated user.
+// Tags, activities & expenses routes also accept an API key instead.
+// Each route requires a scope on its resource ( see requireScope ).
+// Requests are rate limited by IP address and by account.
+func RegisterRoutes(r *echo.Echo, hnd *Handler, forUser HandlerFactory) error {
 	secret := jwtAccessSecret()
 	if len(secret) == 0 {
 		msg := "No JWT Secret was found in system"
 		log.Fatal(msg)
 		return errors.New(msg)
 	}
+	r.HTTPErrorHandler = HTTPErrorHandler
+	// protected returns the middlewares of routes requiring an access token
+	// and a scope on resource. Requests are rate limited by account.
+	protected := func(resource string) []echo.MiddlewareFunc {
+		return []echo.MiddlewareFunc{middleware.JWT(secret), hnd.RateLimit(accountKey), requireScope(resource)}
+	}
+	// protectedOrAPIKey returns the middlewares of protected routes
+	// which also accept an API key
+	protectedOrAPIKey := func(resource string) []echo.MiddlewareFunc {
+		return []echo.MiddlewareFunc{
+			hnd.APIKeyAuth,
+			middleware.JWTWithConfig(middleware.JWTConfig{SigningKey: secret, Skipper: apiKeyAuthenticated}),
+			hnd.RateLimit(accountKey),
+			requireScope(resource),
+		}
+	}
+	scoped := func(method func(*Handler, echo.Context) error) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return method(forUser(userID(c)), c)
+		}
+	}
+	r.Use(hnd.RateLimit(ipKey))
 	r.GET("/health-check", HealthCheck)
+	r.GET("/openapi.json", OpenAPI)
 	// Group Auth
 	auth := r.Group("/auth")
+	auth.POST("/register", hnd.Register)
 	auth.POST("/login", hnd.Login)
 	auth.POST("/refresh", hnd.RefreshToken)
+	auth.POST("/logout", hnd.Logout)
+	auth.POST("/logout-all", hnd.LogoutAll, middleware.JWT(secret), hnd.RateLimit(accountKey))
+	// API Keys are managed with an access token only
+	keys := auth.Group("/keys", protected("keys")...)
+	keys.GET("", hnd.GetAllAPIKeys)
+	keys.POST("", hnd.AddAPIKey)
+	keys.DELETE("/:id", hnd.DeleteAPIKey)
 	// Group Tags
-	tags := r.Group("/tags", middleware.JWT(secret))
-	tags.GET("", hnd.GetAllTags)
-	tags.GET("/:id/expenses", hnd.GetTagExpenses)
-	tags.GET("/:id/activities", hnd.GetTagActivities)
-	tags.POST("", hnd.AddTag)
-	tags.PUT("/:id", hnd.EditTag)
-	tags.DELETE("/:id", hnd.DeleteTag)
+	tags := r.Group("/tags", protectedOrAPIKey("tags")...)
+	tags.GET("", scoped((*Handler).GetAllTags))
+	tags.GET("/:id/expenses", scoped((*Handler).GetTagExpenses))
+	tags.GET("/:id/activities", scoped((*Handler).GetTagActivities))
+	tags.POST("", scoped((*Handler).AddTag))
+	tags.PUT("/:id", scoped((*Handler).EditTag))
+	tags.DELETE("/:id", scoped((*Handler).DeleteTag))
+	tags.GET("/:id/history", scoped((*Handler).TagHistory))
 	// Group Activities
-	activities := r.Group("/activities", middleware.JWT(secret))
-	activities.GET("", hnd.ActivitiesByDate)
-	activities.GET("/:id", hnd.ActivityDetails)
-	activities.POST("", hnd.AddActivity)
-	activities.PUT("/:id", hnd.EditActivity)
-	activities.DELETE("/:id", hnd.DeleteActivity)
+	activities := r.Group("/activities", protectedOrAPIKey("activities")...)
+	activities.GET("", scoped((*Handler).ActivitiesByDate))
+	activities.GET("/:id", scoped((*Handler).ActivityDetails))
+	activities.POST("", scoped((*Handler).AddActivity))
+	activities.PUT("/:id", scoped((*Handler).EditActivity))
+	activities.POST("/:id/complete", scoped((*Handler).CompleteActivity))
+	activities.DELETE("/:id", scoped((*Handler).DeleteActivity))
+	activities.GET("/:id/history", scoped((*Handler).ActivityHistory))
 	// Group Expenses
-	expenses := r.Group("/expenses", middleware.JWT(secret))
-	expenses.GET("", hnd.ExpensesByDate)
-	expenses.GET("/:id", hnd.ExpenseDetails)
-	expenses.POST("", hnd.AddExpense)
-	expenses.PUT("/:id", hnd.EditExpense)
-	expenses.DELETE("/:id", hnd.DeleteExpense)
+	expenses := r.Group("/expenses", protectedOrAPIKey("expenses")...)
+	expenses.GET("", scoped((*Handler).ExpensesByDate))
+	expenses.GET("/:id", scoped((*Handler).ExpenseDetails))
+	expenses.POST("", scoped((*Handler).AddExpense))
+	expenses.PUT("/:id", scoped((*Handler).EditExpense))
+	expenses.POST("/:id/complete", scoped((*Handler).CompleteExpense))
+	expenses.DELETE("/:id", scoped((*Handler).DeleteExpense))
+	expenses.GET("/:id/history", scoped((*Handler).ExpenseHistory))
+	// Group Budgets
+	budgets := r.Group("/budgets", protected("budgets")...)
+	budgets.GET("", scoped((*Handler).GetAllBudgets))
+	budgets.GET("/:id", scoped((*Handler).BudgetDetails))
+	budgets.GET("/:id/status", scoped((*Handler).BudgetStatus))
+	budgets.POST("", scoped((*Handler).AddBudget))
+	budgets.PUT("/:id", scoped((*Handler).EditBudget))
+	budgets.DELETE("/:id", scoped((*Handler).DeleteBudget))
+	// Group Recurring Templates
+	templates := r.Group("/templates", protected("templates")...)
+	templates.GET("", scoped((*Handler).GetAllTemplates))
+	templates.GET("/:id", scoped((*Handler).TemplateDetails))
+	templates.GET("/:id/upcoming", scoped((*Handler).UpcomingOccurrences))
+	templates.POST("", scoped((*Handler).AddTemplate))
+	templates.POST("/:id/skip", scoped((*Handler).SkipOccurrence))
+	templates.DELETE("/:id", scoped((*Handler).DeleteTemplate))
+	// Group Reports
+	reports := r.Group("/reports", protected("reports")...)
+	reports.GET("/expenses/by-period", scoped((*Handler).ExpensesReportByPeriod))
+	reports.GET("/expenses/by-tag", scoped((*Handler).ExpensesReportByTag))
+	reports.GET("/expenses/by-unit", scoped((*Handler).ExpensesReportByUnit))
+	reports.GET("/activities/by-tag", scoped((*Handler).ActivitiesReportByTag))
+	reports.GET("/activities/by-place", scoped((*Handler).ActivitiesReportByPlace))
+	reports.GET("/activities/by-weekday", scoped((*Handler).ActivitiesReportByWeekday))
+	reports.GET("/activities/by-hour", scoped((*Handler).ActivitiesReportByHour))
+	// Search
+	r.GET("/search", scoped((*Handler).Search), protected("search")...)
+	// Group Exchange Rates
+	rates := r.Group("/rates", protected("rates")...)
+	rates.GET("", scoped((*Handler).ExchangeRate))
+	rates.POST("/import", scoped((*Handler).ImportExchangeRates))
+	// CSV Export & Import
+	r.GET("/export/:file", scoped((*Handler).Export), protected("transfers")...)
+	r.POST("/import/:entity", scoped((*Handler).Import), protected("transfers")...)
+	// Backup & Restore
+	r.GET("/backup", scoped((*Handler).Backup), protected("archive")...)
+	r.POST("/restore", scoped((*Handler).Restore), protected("archive")...)
+	// Group Trash
+	trash := r.Group("/trash", protected("trash")...)
+	trash.GET("", scoped((*Handler).GetTrash))
+	trash.POST("/:kind/:id/restore", scoped((*Handler).RestoreTrashItem))
+	trash.DELETE("/:kind/:id", scoped((*Handler).PurgeTrashItem))
+	trash.DELETE("", scoped((*Handler).EmptyTrash))
 	return nil
 }
 
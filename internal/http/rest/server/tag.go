@@ -6,18 +6,27 @@ import (
 	"strconv"
 
 	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 )
 
 // GetAllTags handler returns a list of all tags.
+// The list is paginated ( see pageRequest for pagination params )
+// and sorted by name ( label ) ascendent by default.
 func (h *Handler) GetAllTags(c echo.Context) error {
-	tags, err := h.lister.AllTags()
+	p, err := pageRequest(c, store.SortByLabel, false)
+	if err != nil {
+		msg := "Error parsing pagination params"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
+	tags, page, err := h.lister.TagsPage(p)
 	if err != nil {
 		msg := "Internal Server Error while fetching tags"
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(http.StatusInternalServerError, msg)
+		return err
 	}
 	logrus.Info("All tags fetched successfully")
 	respTags := make([]JSONRespListTag, len(tags))
@@ -26,10 +35,16 @@ func (h *Handler) GetAllTags(c echo.Context) error {
 		respTag.From(t)
 		respTags[i] = respTag
 	}
-	return c.JSON(http.StatusOK, tags)
+	var resp JSONRespPage
+	resp.From(respTags, page)
+	return c.JSON(http.StatusOK, resp)
 }
 
 // GetTagExpenses handler returns expenses of a given tag.
+// If "status" query parameter is provided, only expenses with this status are listed.
+// If "currency" query parameter is provided, values are converted to this currency.
+// The list is paginated ( see pageRequest for pagination params )
+// and sorted by time descendent by default.
 func (h *Handler) GetTagExpenses(c echo.Context) error {
 	// Get Tag ID from path
 	idStr := c.Param("id")
@@ -38,19 +53,42 @@ func (h *Handler) GetTagExpenses(c echo.Context) error {
 		msg := fmt.Sprintf("Error while converting path param Tag ID with value %s to int", idStr)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(http.StatusBadRequest, msg)
+		return errIDParam
 	}
 	tagID := domain.TagID(id)
 	logrus.Debugf("Extracted tag id from path param: %s", tagID)
+	p, err := pageRequest(c, store.SortByTime, true)
+	if err != nil {
+		msg := "Error parsing pagination params"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
+	if p.Status, err = statusFilter(c); err != nil {
+		msg := "Error parsing status param"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
 	// Get Expenses
-	expenses, err := h.lister.ExpensesByTag(tagID)
+	expenses, page, err := h.lister.ExpensesByTagPage(tagID, p)
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while fetching expenses of tag %s", tagID)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(errToHTTPCode(err, "tags"), msg)
+		return err
 	}
 	logrus.Infof("Expenses of tag with ID %s fetched successfully", tagID)
+	// Convert expenses if a currency was provided
+	if cur, err := currencyFilter(c); err != nil {
+		msg := "Error parsing currency param"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	} else if len(cur) > 0 {
+		if expenses, err = h.exchanger.ConvertExpenses(expenses, cur); err != nil {
+			msg := "Error while converting expenses to " + string(cur)
+			logrus.Error(msg + " : " + err.Error())
+			return err
+		}
+	}
 	// Construct response expenses from fetched expenses
 	respExpenses := make([]JSONRespListExpense, len(expenses))
 	var respExp JSONRespListExpense
@@ -58,10 +96,15 @@ func (h *Handler) GetTagExpenses(c echo.Context) error {
 		respExp.From(exp)
 		respExpenses[i] = respExp
 	}
-	return c.JSON(http.StatusOK, respExpenses)
+	var resp JSONRespPage
+	resp.From(respExpenses, page)
+	return c.JSON(http.StatusOK, resp)
 }
 
 // GetTagActivities handler returns activities of a given tag.
+// If "status" query parameter is provided, only activities with this status are listed.
+// The list is paginated ( see pageRequest for pagination params )
+// and sorted by time descendent by default.
 func (h *Handler) GetTagActivities(c echo.Context) error {
 	// Get Tag ID from path
 	idStr := c.Param("id")
@@ -70,17 +113,28 @@ func (h *Handler) GetTagActivities(c echo.Context) error {
 		msg := fmt.Sprintf("Error while converting path param Tag ID with value %s to int", idStr)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(http.StatusBadRequest, msg)
+		return errIDParam
 	}
 	tagID := domain.TagID(id)
 	logrus.Debugf("Extracted tag id from path param: %s", tagID)
+	p, err := pageRequest(c, store.SortByTime, true)
+	if err != nil {
+		msg := "Error parsing pagination params"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
+	if p.Status, err = statusFilter(c); err != nil {
+		msg := "Error parsing status param"
+		logrus.Error(msg + ": " + err.Error())
+		return err
+	}
 	// Get Activities
-	activities, err := h.lister.ActivitiesByTag(domain.TagID(id))
+	activities, page, err := h.lister.ActivitiesByTagPage(tagID, p)
 	if err != nil {
 		msg := fmt.Sprintf("Internal Server Error while fetching activities of tag %s", tagID)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(errToHTTPCode(err, "tags"), msg)
+		return err
 	}
 	logrus.Infof("Activities of tag with ID %s fetched successfully", tagID)
 	// Construct respActivities from fetched activities
@@ -90,7 +144,9 @@ func (h *Handler) GetTagActivities(c echo.Context) error {
 		respAct.From(act)
 		respActivities[i] = respAct
 	}
-	return c.JSON(http.StatusOK, respActivities)
+	var resp JSONRespPage
+	resp.From(respActivities, page)
+	return c.JSON(http.StatusOK, resp)
 }
 
 // AddTag handler adds a given tag and returns it.
@@ -101,18 +157,17 @@ func (h *Handler) AddTag(c echo.Context) error {
 		var (
 			msg     string = errInvalidJSON.Error()
 			details string = httpErrorMsg(err)
-			code    int    = errToHTTPCode(errInvalidJSON, "tags")
 		)
 		logrus.Error(msg + " | " + details)
-		return c.String(code, msg)
+		return errInvalidJSON
 	}
 	// Create Tag
 	tag := jsTag.ToDomain()
-	id, err := h.adder.NewTag(tag)
+	id, err := h.adder.NewTag(tag, h.principal(c))
 	if err != nil {
 		msg := err.Error()
 		logrus.Error(msg)
-		return c.String(errToHTTPCode(err, "tags"), msg)
+		return err
 	}
 	logrus.Infof("Created Tag %s successfully", id)
 	// Get created Tag
@@ -130,7 +185,7 @@ func (h *Handler) EditTag(c echo.Context) error {
 		msg := fmt.Sprintf("Error while converting path param Tag ID with value %s to int", idStr)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(http.StatusBadRequest, msg)
+		return errIDParam
 	}
 	tagID := domain.TagID(id)
 	logrus.Debugf("Extracted tag id from path param: %s", tagID)
@@ -140,20 +195,19 @@ func (h *Handler) EditTag(c echo.Context) error {
 		var (
 			msg     string = errInvalidJSON.Error()
 			details string = httpErrorMsg(err)
-			code    int    = errToHTTPCode(errInvalidJSON, "tags")
 		)
 		logrus.Error(msg + " | " + details)
-		return c.String(code, msg)
+		return errInvalidJSON
 	}
 	logrus.Debug("Unmarshalled JSON successfully")
 	// Edit Tag
 	tag := jsTag.ToDomain()
 	tag.ID = tagID
-	if err := h.editor.EditTag(tag); err != nil {
+	if err := h.editor.EditTag(tag, h.principal(c)); err != nil {
 		msg := fmt.Sprintf("error while updating tag %s", tagID)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(errToHTTPCode(err, "tags"), msg)
+		return err
 	}
 	logrus.Infof("Updated Tag %s successfully", tagID)
 	// Retrieve edited Tag
@@ -162,7 +216,7 @@ func (h *Handler) EditTag(c echo.Context) error {
 		msg := fmt.Sprintf("error while retrieving updated tag %s", tagID)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(errToHTTPCode(err, "tags"), msg)
+		return err
 	}
 	logrus.Infof("Retrieved Tag %s successfully", tagID)
 	return c.JSON(http.StatusOK, edited)
@@ -177,17 +231,17 @@ func (h *Handler) DeleteTag(c echo.Context) error {
 		msg := fmt.Sprintf("Error while converting path param Tag ID with value %s to int", idStr)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(http.StatusBadRequest, msg)
+		return errIDParam
 	}
 	tagID := domain.TagID(id)
 	logrus.Debugf("Extracted tag id from path param: %s", tagID)
 	// Delete Tag
-	err = h.deleter.Tag(domain.TagID(id))
+	err = h.deleter.Tag(domain.TagID(id), h.principal(c))
 	if err != nil {
 		msg := fmt.Sprintf("error while deleting tag with ID: %s", tagID)
 		details := err.Error()
 		logrus.Error(msg + " | " + details)
-		return c.String(errToHTTPCode(err, "tags"), msg)
+		return err
 	}
 	logrus.Infof("Deleted tag %s successfully", tagID)
 	return c.String(http.StatusNoContent, "Tag Deleted Successfully")
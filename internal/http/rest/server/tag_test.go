@@ -18,7 +18,7 @@ func TestGetAllTags(t *testing.T) {
 	rec := httptest.NewRecorder()
 	ctx := router.NewContext(req, rec)
 	ctx.SetPath(path)
-	hnd.GetAllTags(ctx)
+	handle(hnd.GetAllTags, ctx)
 	if rec.Code != expectedCode {
 		t.Fatalf("\nExpected Code: %d\nReturned Code: %d", expectedCode, rec.Code)
 	}
@@ -76,7 +76,7 @@ func TestGetTagExpenses(t *testing.T) {
 			ctx.SetPath(path)
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(test.idStr)
-			hnd.GetTagExpenses(ctx)
+			handle(hnd.GetTagExpenses, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %v\nReturned Body: %s", test.expectedCode, rec.Code, body)
@@ -136,7 +136,7 @@ func TestGetTagActivities(t *testing.T) {
 			ctx.SetPath(path)
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(test.idStr)
-			hnd.GetTagActivities(ctx)
+			handle(hnd.GetTagActivities, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %v\nReturned Body: %s", test.expectedCode, rec.Code, body)
@@ -186,7 +186,7 @@ func TestAddTag(t *testing.T) {
 			rec = httptest.NewRecorder()
 			ctx = router.NewContext(req, rec)
 			ctx.SetPath(path)
-			hnd.AddTag(ctx)
+			handle(hnd.AddTag, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
@@ -254,7 +254,7 @@ func TestEditTag(t *testing.T) {
 			ctx.SetPath(path)
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(test.idStr)
-			hnd.EditTag(ctx)
+			handle(hnd.EditTag, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %v\nReturned Body: %s", test.expectedCode, rec.Code, body)
@@ -264,6 +264,7 @@ func TestEditTag(t *testing.T) {
 }
 
 func TestDeleteTag(t *testing.T) {
+	defer func() { repo.Trash = map[string]time.Time{} }() // Empty trash of deleted items
 	// Init repo with a tag to test return code when deleting it
 	repo.Tags = map[domain.TagID]domain.Tag{
 		8987: {ID: 8987, Name: "tag-with-nothing"},
@@ -331,7 +332,7 @@ func TestDeleteTag(t *testing.T) {
 			ctx.SetPath(path)
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(test.idStr)
-			hnd.DeleteTag(ctx)
+			handle(hnd.DeleteTag, ctx)
 			if rec.Code != test.expectedCode {
 				body := rec.Body.String()
 				t.Fatalf("\nExpected Code: %d\nReturned Code: %v\nReturned Body: %s", test.expectedCode, rec.Code, body)
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// templateIDParam extracts the template ID from the path parameter :id
+func templateIDParam(c echo.Context) (domain.TemplateID, error) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		msg := fmt.Sprintf("Error while converting path param Template ID with value %s to int", idStr)
+		logrus.Error(msg + " | " + err.Error())
+		return 0, errIDParam
+	}
+	return domain.TemplateID(id), nil
+}
+
+// GetAllTemplates handler returns a list of all recurring templates.
+func (h *Handler) GetAllTemplates(c echo.Context) error {
+	templates, err := h.lister.AllTemplates()
+	if err != nil {
+		msg := "Internal Server Error while fetching templates"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Info("All templates fetched successfully")
+	respTemplates := make([]JSONRespTemplate, len(templates))
+	var respTmpl JSONRespTemplate
+	for i, tmpl := range templates {
+		respTmpl.From(tmpl)
+		respTemplates[i] = respTmpl
+	}
+	return c.JSON(http.StatusOK, respTemplates)
+}
+
+// TemplateDetails handler returns details of template with given ID.
+// It required a path parameter :id
+func (h *Handler) TemplateDetails(c echo.Context) error {
+	id, err := templateIDParam(c)
+	if err != nil {
+		return err
+	}
+	tmpl, err := h.lister.Template(id)
+	if err != nil {
+		msg := fmt.Sprintf("Internal Server Error while fetching template %s", id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Fetched template %s successfully", id)
+	var respTmpl JSONRespTemplate
+	respTmpl.From(tmpl)
+	return c.JSON(http.StatusOK, respTmpl)
+}
+
+// AddTemplate handler adds given recurring template and returns it.
+func (h *Handler) AddTemplate(c echo.Context) error {
+	// Json unmarshall
+	var jsTmpl JSONReqTemplate
+	if err := c.Bind(&jsTmpl); err != nil {
+		var (
+			msg     string = errInvalidJSON.Error()
+			details string = httpErrorMsg(err)
+		)
+		logrus.Error(msg + " : " + details)
+		return errInvalidJSON
+	}
+	tmpl, err := jsTmpl.ToDomain()
+	if err != nil {
+		msg := "Error while converting template expense value to its currency"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	// Call adding service
+	id, err := h.adder.NewTemplate(tmpl)
+	if err != nil {
+		msg := "Internal Server Error while adding template"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Created template %s successfully", id)
+	// Retrieve created template
+	created, err := h.lister.Template(id)
+	if err != nil {
+		msg := fmt.Sprintf("Internal Server Error while fetching template %s", id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	var respTmpl JSONRespTemplate
+	respTmpl.From(created)
+	return c.JSON(http.StatusCreated, respTmpl)
+}
+
+// DeleteTemplate handler deletes a template with given ID.
+// Expenses and activities it created are kept.
+// It required a path parameter :id
+func (h *Handler) DeleteTemplate(c echo.Context) error {
+	id, err := templateIDParam(c)
+	if err != nil {
+		return err
+	}
+	if err := h.deleter.Template(id); err != nil {
+		msg := fmt.Sprintf("Internal Server Error while deleting template %s", id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Deleted template %s successfully", id)
+	return c.String(http.StatusNoContent, "Template Deleted Successfully")
+}
+
+// UpcomingOccurrences handler returns the next occurrences of a template
+// which were not materialized or skipped yet.
+// It required a path parameter :id and has an optional query parameter
+// "limit" specifying the number of occurrences.
+func (h *Handler) UpcomingOccurrences(c echo.Context) error {
+	id, err := templateIDParam(c)
+	if err != nil {
+		return err
+	}
+	limit := 0
+	if limitStr := c.QueryParam("limit"); len(limitStr) > 0 {
+		if limit, err = strconv.Atoi(limitStr); err != nil {
+			logrus.Error(errUpcomingLimitFormat.Error() + " : " + err.Error())
+			return errUpcomingLimitFormat
+		}
+	}
+	occurrences, err := h.scheduler.Upcoming(id, time.Now(), limit)
+	if err != nil {
+		msg := fmt.Sprintf("Error while listing upcoming occurrences of template %s", id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Listed upcoming occurrences of template %s successfully", id)
+	return c.JSON(http.StatusOK, JSONRespUpcoming{TemplateID: id, Occurrences: occurrences})
+}
+
+// SkipOccurrence handler skips the occurrence of a template at the given time
+// so it is never materialized.
+// It required a path parameter :id
+func (h *Handler) SkipOccurrence(c echo.Context) error {
+	id, err := templateIDParam(c)
+	if err != nil {
+		return err
+	}
+	var jsSkip JSONReqSkip
+	if err := c.Bind(&jsSkip); err != nil {
+		var (
+			msg     string = errInvalidJSON.Error()
+			details string = httpErrorMsg(err)
+		)
+		logrus.Error(msg + " : " + details)
+		return errInvalidJSON
+	}
+	if err := h.scheduler.Skip(id, jsSkip.Time); err != nil {
+		msg := fmt.Sprintf("Error while skipping occurrence of template %s", id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Skipped occurrence %s of template %s successfully", jsSkip.Time, id)
+	return c.String(http.StatusNoContent, "Occurrence Skipped Successfully")
+}
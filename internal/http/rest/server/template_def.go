@@ -0,0 +1,173 @@
+package server
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// JSONRecurrenceRule is used to marshal/unmarshal a recurrence rule.
+// Until is omitted when the rule recurs forever.
+type JSONRecurrenceRule struct {
+	Freq     domain.Frequency `json:"freq"`
+	Interval int              `json:"interval"`
+	Start    time.Time        `json:"start"`
+	Until    *time.Time       `json:"until,omitempty"`
+}
+
+// ToDomain constructs and returns a domain.RecurrenceRule from a JSONRecurrenceRule.
+func (rule JSONRecurrenceRule) ToDomain() domain.RecurrenceRule {
+	res := domain.RecurrenceRule{
+		Freq:     rule.Freq,
+		Interval: rule.Interval,
+		Start:    rule.Start,
+	}
+	if rule.Until != nil {
+		res.Until = *rule.Until
+	}
+	return res
+}
+
+// From constructs a JSONRecurrenceRule object from a domain.RecurrenceRule object.
+func (rule *JSONRecurrenceRule) From(r domain.RecurrenceRule) {
+	(*rule).Freq = r.Freq
+	(*rule).Interval = r.Interval
+	(*rule).Start = r.Start
+	(*rule).Until = nil
+	if !r.Until.IsZero() {
+		until := r.Until
+		(*rule).Until = &until
+	}
+}
+
+// JSONTemplateExpense is used to marshal/unmarshal the model expense of a template.
+// Value is a decimal number in the currency ( ex: 12.50 ).
+type JSONTemplateExpense struct {
+	Label      string            `json:"label"`
+	Value      json.Number       `json:"value"`
+	Unit       string            `json:"unit"`
+	ActivityID domain.ActivityID `json:"activityId"`
+	TagIds     []domain.TagID    `json:"tagIds"`
+}
+
+// JSONTemplateActivity is used to marshal/unmarshal the model activity of a template.
+type JSONTemplateActivity struct {
+	Label    string         `json:"label"`
+	Desc     string         `json:"desc"`
+	Place    string         `json:"place"`
+	Duration time.Duration  `json:"duration"`
+	TagIds   []domain.TagID `json:"tagIds"`
+}
+
+// JSONReqTemplate is used to unmarshal a json template.
+// Only the model entity matching the kind is used.
+type JSONReqTemplate struct {
+	Kind     domain.TemplateKind   `json:"kind"`
+	Rule     JSONRecurrenceRule    `json:"rule"`
+	Expense  *JSONTemplateExpense  `json:"expense"`
+	Activity *JSONTemplateActivity `json:"activity"`
+}
+
+// tagsFromIds constructs Tags slice from ids ( don't fetch anything )
+func tagsFromIds(ids []domain.TagID) []domain.Tag {
+	tags := []domain.Tag{}
+	for _, id := range ids {
+		tags = append(tags, domain.Tag{ID: id})
+	}
+	return tags
+}
+
+// ToDomain constructs and returns a domain.Template from a JSONReqTemplate.
+// It returns an error if the currency or the value of a model expense are invalid
+// because the value is converted to the minor unit of the currency.
+func (reqTmpl JSONReqTemplate) ToDomain() (domain.Template, error) {
+	tmpl := domain.Template{
+		Kind: reqTmpl.Kind,
+		Rule: reqTmpl.Rule.ToDomain(),
+	}
+	if exp := reqTmpl.Expense; exp != nil && tmpl.Kind == domain.TemplateExpense {
+		cur, err := domain.ParseCurrency(exp.Unit)
+		if err != nil {
+			return domain.Template{}, err
+		}
+		val, err := domain.ParseAmount(exp.Value.String(), cur)
+		if err != nil {
+			return domain.Template{}, err
+		}
+		tmpl.Expense = domain.Expense{
+			Label:      exp.Label,
+			Value:      val,
+			Unit:       cur,
+			ActivityID: exp.ActivityID,
+			Tags:       tagsFromIds(exp.TagIds),
+		}
+	}
+	if act := reqTmpl.Activity; act != nil && tmpl.Kind == domain.TemplateActivity {
+		tmpl.Activity = domain.Activity{
+			Label:    act.Label,
+			Desc:     act.Desc,
+			Place:    act.Place,
+			Duration: act.Duration,
+			Tags:     tagsFromIds(act.TagIds),
+		}
+	}
+	return tmpl, nil
+}
+
+// JSONRespTemplate is used to marshal a template to json.
+// Only the model entity matching the kind is present.
+type JSONRespTemplate struct {
+	ID       domain.TemplateID     `json:"id"`
+	Kind     domain.TemplateKind   `json:"kind"`
+	Rule     JSONRecurrenceRule    `json:"rule"`
+	Expense  *JSONTemplateExpense  `json:"expense,omitempty"`
+	Activity *JSONTemplateActivity `json:"activity,omitempty"`
+}
+
+// tagIds returns the ids of the given tags
+func tagIds(tags []domain.Tag) []domain.TagID {
+	ids := make([]domain.TagID, len(tags))
+	for i, t := range tags {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+// From constructs a JSONRespTemplate object from a domain.Template object.
+func (respTmpl *JSONRespTemplate) From(tmpl domain.Template) {
+	(*respTmpl).ID = tmpl.ID
+	(*respTmpl).Kind = tmpl.Kind
+	(*respTmpl).Rule.From(tmpl.Rule)
+	(*respTmpl).Expense = nil
+	(*respTmpl).Activity = nil
+	switch tmpl.Kind {
+	case domain.TemplateActivity:
+		(*respTmpl).Activity = &JSONTemplateActivity{
+			Label:    tmpl.Activity.Label,
+			Desc:     tmpl.Activity.Desc,
+			Place:    tmpl.Activity.Place,
+			Duration: tmpl.Activity.Duration,
+			TagIds:   tagIds(tmpl.Activity.Tags),
+		}
+	default:
+		(*respTmpl).Expense = &JSONTemplateExpense{
+			Label:      tmpl.Expense.Label,
+			Value:      json.Number(tmpl.Expense.Value.Format(tmpl.Expense.Unit)),
+			Unit:       string(tmpl.Expense.Unit),
+			ActivityID: tmpl.Expense.ActivityID,
+			TagIds:     tagIds(tmpl.Expense.Tags),
+		}
+	}
+}
+
+// JSONReqSkip is used to unmarshal the occurrence of a template to skip.
+type JSONReqSkip struct {
+	Time time.Time `json:"time"`
+}
+
+// JSONRespUpcoming is used to marshal upcoming occurrences of a template to json.
+type JSONRespUpcoming struct {
+	TemplateID  domain.TemplateID `json:"templateId"`
+	Occurrences []time.Time       `json:"occurrences"`
+}
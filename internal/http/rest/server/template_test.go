@@ -0,0 +1,217 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+func TestAddTemplate(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "bills"},
+	}
+	defer func() { repo.Templates = map[domain.TemplateID]domain.Template{} }()
+	// Sub-tests definitions
+	tests := map[string]struct {
+		json         string
+		expectedCode int
+		expectedBody string
+	}{
+		"Correct Expense": {
+			json:         `{"kind":"expense","rule":{"freq":"monthly","interval":1,"start":"2020-01-31T10:00:00Z"},"expense":{"label":"Rent","value":"750.5","unit":"eur","tagIds":[1]}}`,
+			expectedCode: http.StatusCreated,
+			expectedBody: `"expense":{"label":"Rent","value":750.50,"unit":"EUR","activityId":0,"tagIds":[1]}`,
+		},
+		"Correct Activity": {
+			json:         `{"kind":"activity","rule":{"freq":"weekly","interval":2,"start":"2020-01-06T18:00:00Z","until":"2020-12-31T00:00:00Z"},"activity":{"label":"Football","place":"Stadium","duration":3600000000000}}`,
+			expectedCode: http.StatusCreated,
+			expectedBody: `"until":"2020-12-31T00:00:00Z"},"activity":{"label":"Football","desc":"","place":"stadium"`,
+		},
+		"Invalid Kind": {
+			json:         `{"kind":"task","rule":{"freq":"daily","interval":1,"start":"2020-01-01T10:00:00Z"}}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Invalid Frequency": {
+			json:         `{"kind":"expense","rule":{"freq":"yearly","interval":1,"start":"2020-01-01T10:00:00Z"},"expense":{"label":"Rent","value":750,"unit":"EUR"}}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Invalid Interval": {
+			json:         `{"kind":"expense","rule":{"freq":"daily","interval":0,"start":"2020-01-01T10:00:00Z"},"expense":{"label":"Rent","value":750,"unit":"EUR"}}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Until Before Start": {
+			json:         `{"kind":"expense","rule":{"freq":"daily","interval":1,"start":"2020-01-01T10:00:00Z","until":"2019-01-01T00:00:00Z"},"expense":{"label":"Rent","value":750,"unit":"EUR"}}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Unknown Currency": {
+			json:         `{"kind":"expense","rule":{"freq":"daily","interval":1,"start":"2020-01-01T10:00:00Z"},"expense":{"label":"Rent","value":750,"unit":"euro"}}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Non-Existing Tag": {
+			json:         `{"kind":"expense","rule":{"freq":"daily","interval":1,"start":"2020-01-01T10:00:00Z"},"expense":{"label":"Rent","value":750,"unit":"EUR","tagIds":[9]}}`,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		"Wrong Json": {
+			json:         `{"kind":"expense"`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	// Sub-tests Execution
+	const path string = "/templates"
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(test.json))
+			req.Header.Set("Content-type", "application/json")
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath(path)
+			handle(hnd.AddTemplate, ctx)
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), test.expectedBody) {
+				t.Fatalf("\nExpected Body to contain: %s\nReturned Body: %s", test.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTemplateOccurrences(t *testing.T) {
+	start := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	repo.Templates = map[domain.TemplateID]domain.Template{
+		1: {
+			ID:      1,
+			Kind:    domain.TemplateExpense,
+			Rule:    domain.RecurrenceRule{Freq: domain.FrequencyDaily, Interval: 1, Start: start},
+			Expense: domain.Expense{Label: "Coffee", Value: 250, Unit: "EUR"},
+		},
+	}
+	defer func() {
+		repo.Templates = map[domain.TemplateID]domain.Template{}
+		repo.Occurrences = map[string]domain.Occurrence{}
+	}()
+	// Sub-tests definitions
+	tests := map[string]struct {
+		method       string
+		path         string
+		idStr        string
+		json         string
+		handler      echo.HandlerFunc
+		expectedCode int
+		expectedBody string
+	}{
+		"Upcoming": {
+			method:       http.MethodGet,
+			path:         "/templates/:id/upcoming?limit=2",
+			idStr:        "1",
+			handler:      hnd.UpcomingOccurrences,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"templateId":1,"occurrences":["` + start.Format(time.RFC3339) + `","` + start.AddDate(0, 0, 1).Format(time.RFC3339) + `"]}`,
+		},
+		"Upcoming Wrong Limit Format": {
+			method:       http.MethodGet,
+			path:         "/templates/:id/upcoming?limit=ten",
+			idStr:        "1",
+			handler:      hnd.UpcomingOccurrences,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Upcoming Limit Too High": {
+			method:       http.MethodGet,
+			path:         "/templates/:id/upcoming?limit=1000",
+			idStr:        "1",
+			handler:      hnd.UpcomingOccurrences,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Upcoming Non-Existing Template": {
+			method:       http.MethodGet,
+			path:         "/templates/:id/upcoming",
+			idStr:        "8",
+			handler:      hnd.UpcomingOccurrences,
+			expectedCode: http.StatusNotFound,
+		},
+		"Skip Invalid Occurrence": {
+			method:       http.MethodPost,
+			path:         "/templates/:id/skip",
+			idStr:        "1",
+			json:         `{"time":"` + start.Add(time.Minute).Format(time.RFC3339) + `"}`,
+			handler:      hnd.SkipOccurrence,
+			expectedCode: http.StatusBadRequest,
+		},
+		"Skip Non-Existing Template": {
+			method:       http.MethodPost,
+			path:         "/templates/:id/skip",
+			idStr:        "8",
+			json:         `{"time":"` + start.Format(time.RFC3339) + `"}`,
+			handler:      hnd.SkipOccurrence,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	// Sub-tests Execution
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.json))
+			req.Header.Set("Content-type", "application/json")
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath(test.path)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(test.idStr)
+			handle(test.handler, ctx)
+			body := rec.Body.String()
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, body)
+			}
+			if test.expectedBody != "" && body != test.expectedBody+"\n" {
+				t.Fatalf("\nExpected Body: %s\nReturned Body: %s", test.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestSkipOccurrence(t *testing.T) {
+	start := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	repo.Templates = map[domain.TemplateID]domain.Template{
+		1: {
+			ID:      1,
+			Kind:    domain.TemplateExpense,
+			Rule:    domain.RecurrenceRule{Freq: domain.FrequencyWeekly, Interval: 1, Start: start},
+			Expense: domain.Expense{Label: "Groceries", Value: 5000, Unit: "EUR"},
+		},
+	}
+	defer func() {
+		repo.Templates = map[domain.TemplateID]domain.Template{}
+		repo.Occurrences = map[string]domain.Occurrence{}
+	}()
+	skip := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/templates/:id/skip", strings.NewReader(`{"time":"`+start.Format(time.RFC3339)+`"}`))
+		req.Header.Set("Content-type", "application/json")
+		rec := httptest.NewRecorder()
+		ctx := router.NewContext(req, rec)
+		ctx.SetParamNames("id")
+		ctx.SetParamValues("1")
+		handle(hnd.SkipOccurrence, ctx)
+		return rec.Code
+	}
+	if code := skip(); code != http.StatusNoContent {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d", http.StatusNoContent, code)
+	}
+	if code := skip(); code != http.StatusConflict {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d", http.StatusConflict, code)
+	}
+	// Skipped occurrence must not be listed as upcoming
+	req := httptest.NewRequest(http.MethodGet, "/templates/:id/upcoming?limit=1", nil)
+	rec := httptest.NewRecorder()
+	ctx := router.NewContext(req, rec)
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("1")
+	handle(hnd.UpcomingOccurrences, ctx)
+	expected := `{"templateId":1,"occurrences":["` + start.AddDate(0, 0, 7).Format(time.RFC3339) + `"]}` + "\n"
+	if body := rec.Body.String(); body != expected {
+		t.Fatalf("\nExpected Body: %s\nReturned Body: %s", expected, body)
+	}
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// retryAfterHeader is the header telling rate limited clients when to retry.
+const retryAfterHeader string = "Retry-After"
+
+// retryAfter sets the Retry-After header to the given duration in seconds, rounded up.
+func retryAfter(c echo.Context, d time.Duration) {
+	secs := int((d + time.Second - 1) / time.Second)
+	if secs < 1 {
+		secs = 1
+	}
+	c.Response().Header().Set(retryAfterHeader, strconv.Itoa(secs))
+}
+
+// ipKey returns the rate limiting key of the IP address of the request
+func ipKey(c echo.Context) string {
+	return "ip:" + c.RealIP()
+}
+
+// accountKey returns the rate limiting key of the authenticated user
+func accountKey(c echo.Context) string {
+	return "user:" + userID(c).String()
+}
+
+// loginKeys returns the lockout keys of a login with given name:
+// the account first and the IP address of the request
+func loginKeys(c echo.Context, name string) []string {
+	return []string{
+		"login:user:" + strings.ToLower(strings.TrimSpace(name)),
+		"login:ip:" + c.RealIP(),
+	}
+}
+
+// RateLimit returns a middleware which rejects requests
+// once the key returned by key made too many requests.
+func (h *Handler) RateLimit(key func(echo.Context) string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			k := key(c)
+			wait, err := h.throttler.Hit(k)
+			if err != nil {
+				logrus.Error("Rate limit of " + k + " : " + err.Error())
+				retryAfter(c, wait)
+				return err
+			}
+			return next(c)
+		}
+	}
+}
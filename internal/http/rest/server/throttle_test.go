@@ -0,0 +1,104 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/http/rest/server"
+	"github.com/elhamza90/lifelog/internal/store/memory"
+	"github.com/elhamza90/lifelog/internal/usecase/throttling"
+	"github.com/labstack/echo/v4"
+)
+
+// newThrottledRouter returns a router rate limited with given limits
+// and the clock of its throttler, which tests can move forward.
+func newThrottledRouter(t *testing.T, config throttling.Config) (*echo.Echo, *time.Time) {
+	now := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	th := throttling.NewService(memory.Throttles{}, func() time.Time { return now }, config)
+	h := newHandler(&repo, &th)
+	r := echo.New()
+	if err := server.RegisterRoutes(r, h, func(domain.UserID) *server.Handler { return h }); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	return r, &now
+}
+
+// serveFrom sends a request with given JSON body through given router
+// from given IP address and returns the recorded response.
+func serveFrom(r *echo.Echo, ip string, method string, path string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-type", "application/json")
+	req.RemoteAddr = ip + ":1234"
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit(t *testing.T) {
+	r, now := newThrottledRouter(t, throttling.Config{Requests: 2, Window: time.Minute})
+	for i := 0; i < 2; i++ {
+		if rec := serveFrom(r, "10.0.0.1", http.MethodGet, "/health-check", ""); rec.Code != http.StatusOK {
+			t.Fatalf("\nExpected Code: %d\nReturned Code: %d", http.StatusOK, rec.Code)
+		}
+	}
+	*now = now.Add(15 * time.Second)
+	rec := serveFrom(r, "10.0.0.1", http.MethodGet, "/health-check", "")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "45" {
+		t.Fatalf("\nExpected Code: %d with Retry-After 45\nReturned Code: %d with Retry-After %q", http.StatusTooManyRequests, rec.Code, rec.Header().Get("Retry-After"))
+	}
+	// Other addresses are not limited
+	if rec := serveFrom(r, "10.0.0.2", http.MethodGet, "/health-check", ""); rec.Code != http.StatusOK {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d", http.StatusOK, rec.Code)
+	}
+	// Next window
+	*now = now.Add(45 * time.Second)
+	if rec := serveFrom(r, "10.0.0.1", http.MethodGet, "/health-check", ""); rec.Code != http.StatusOK {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	defer func() {
+		repo.Users = map[domain.UserID]domain.User{}
+		repo.RefreshTokens = map[string]domain.RefreshToken{}
+	}()
+	r, now := newThrottledRouter(t, throttling.Config{MaxFailures: 2, Lockout: time.Minute, MaxLockout: time.Hour})
+	const (
+		creds string = `{"name":"hamza","password":"test_pass"}`
+		wrong string = `{"name":"hamza","password":"wrong_pass"}`
+	)
+	if rec := serveFrom(r, "10.0.0.1", http.MethodPost, "/auth/register", creds); rec.Code != http.StatusCreated {
+		t.Fatalf("\nUnexpected register response: %d %s", rec.Code, rec.Body.String())
+	}
+	// A success forgets the failures of the account
+	serveFrom(r, "10.0.0.1", http.MethodPost, "/auth/login", wrong)
+	readTokens(t, serveFrom(r, "10.0.0.2", http.MethodPost, "/auth/login", creds))
+	for _, ip := range []string{"10.0.0.3", "10.0.0.4"} {
+		if rec := serveFrom(r, ip, http.MethodPost, "/auth/login", wrong); rec.Code != http.StatusUnauthorized {
+			t.Fatalf("\nExpected Code: %d\nReturned Code: %d", http.StatusUnauthorized, rec.Code)
+		}
+	}
+	// Locked out account, even with the right password
+	rec := serveFrom(r, "10.0.0.5", http.MethodPost, "/auth/login", creds)
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "60" {
+		t.Fatalf("\nExpected Code: %d with Retry-After 60\nReturned Code: %d with Retry-After %q", http.StatusTooManyRequests, rec.Code, rec.Header().Get("Retry-After"))
+	}
+	// Each further failure doubles the lockout
+	*now = now.Add(time.Minute)
+	serveFrom(r, "10.0.0.5", http.MethodPost, "/auth/login", wrong)
+	if rec := serveFrom(r, "10.0.0.5", http.MethodPost, "/auth/login", creds); rec.Header().Get("Retry-After") != "120" {
+		t.Fatalf("\nExpected Retry-After 120\nReturned Code: %d with Retry-After %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	*now = now.Add(2 * time.Minute)
+	readTokens(t, serveFrom(r, "10.0.0.6", http.MethodPost, "/auth/login", creds))
+	// Locked out address, whatever the account
+	serveFrom(r, "10.0.0.7", http.MethodPost, "/auth/login", `{"name":"unknown","password":"wrong_pass"}`)
+	serveFrom(r, "10.0.0.7", http.MethodPost, "/auth/login", `{"name":"other","password":"wrong_pass"}`)
+	if rec := serveFrom(r, "10.0.0.7", http.MethodPost, "/auth/login", creds); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d", http.StatusTooManyRequests, rec.Code)
+	}
+}
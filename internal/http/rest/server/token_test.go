@@ -0,0 +1,144 @@
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// serveJSON sends a request with given JSON body through the router
+// with given access token and returns the recorded response.
+func serveJSON(method string, path string, body string, access string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-type", "application/json")
+	if access != "" {
+		req.Header.Set("Authorization", "Bearer "+access)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// tokens represents the access & refresh tokens in an authentication response.
+type tokens struct {
+	Access  string `json:"at"`
+	Refresh string `json:"rt"`
+}
+
+// readTokens decodes the tokens in given response.
+func readTokens(t *testing.T, rec *httptest.ResponseRecorder) tokens {
+	var tk tokens
+	if err := json.Unmarshal(rec.Body.Bytes(), &tk); err != nil || tk.Access == "" || tk.Refresh == "" {
+		t.Fatalf("\nUnexpected tokens response: %d %s", rec.Code, rec.Body.String())
+	}
+	return tk
+}
+
+// refreshJSON returns the JSON body of a request with given refresh token.
+func refreshJSON(refresh string) string {
+	return fmt.Sprintf("{\"refresh\":\"%s\"}", refresh)
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	defer func() {
+		repo.Users = map[domain.UserID]domain.User{}
+		repo.RefreshTokens = map[string]domain.RefreshToken{}
+	}()
+	const creds string = `{"name":"hamza","password":"test_pass"}`
+	if rec := serveJSON(http.MethodPost, "/auth/register", creds, ""); rec.Code != http.StatusCreated {
+		t.Fatalf("\nUnexpected register response: %d %s", rec.Code, rec.Body.String())
+	}
+	login := readTokens(t, serveJSON(http.MethodPost, "/auth/login", creds, ""))
+	// Rotate
+	rec := serveJSON(http.MethodPost, "/auth/refresh", refreshJSON(login.Refresh), "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	rotated := readTokens(t, rec)
+	if rotated.Refresh == login.Refresh {
+		t.Fatal("\nExpected a new refresh token")
+	}
+	// Reuse the old token
+	if rec := serveJSON(http.MethodPost, "/auth/refresh", refreshJSON(login.Refresh), ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+	// Reuse revoked the rotated token too
+	if rec := serveJSON(http.MethodPost, "/auth/refresh", refreshJSON(rotated.Refresh), ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+}
+
+func TestLogout(t *testing.T) {
+	defer func() {
+		repo.Users = map[domain.UserID]domain.User{}
+		repo.RefreshTokens = map[string]domain.RefreshToken{}
+	}()
+	const creds string = `{"name":"hamza","password":"test_pass"}`
+	if rec := serveJSON(http.MethodPost, "/auth/register", creds, ""); rec.Code != http.StatusCreated {
+		t.Fatalf("\nUnexpected register response: %d %s", rec.Code, rec.Body.String())
+	}
+	first := readTokens(t, serveJSON(http.MethodPost, "/auth/login", creds, ""))
+	second := readTokens(t, serveJSON(http.MethodPost, "/auth/login", creds, ""))
+	// Subtests Definition
+	tests := map[string]struct {
+		json         string
+		expectedCode int
+	}{
+		"Invalid Token": {
+			json:         refreshJSON("invalid"),
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		"No Token in JSON": {
+			json:         `{"what":"is this?"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := serveJSON(http.MethodPost, "/auth/logout", test.json, "")
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+		})
+	}
+	// Logout first session
+	if rec := serveJSON(http.MethodPost, "/auth/logout", refreshJSON(first.Refresh), ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+	}
+	if rec := serveJSON(http.MethodPost, "/auth/refresh", refreshJSON(first.Refresh), ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+	// Second session is still valid
+	if rec := serveJSON(http.MethodPost, "/auth/refresh", refreshJSON(second.Refresh), ""); rec.Code != http.StatusOK {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+}
+
+func TestLogoutAll(t *testing.T) {
+	defer func() {
+		repo.Users = map[domain.UserID]domain.User{}
+		repo.RefreshTokens = map[string]domain.RefreshToken{}
+	}()
+	const creds string = `{"name":"hamza","password":"test_pass"}`
+	if rec := serveJSON(http.MethodPost, "/auth/register", creds, ""); rec.Code != http.StatusCreated {
+		t.Fatalf("\nUnexpected register response: %d %s", rec.Code, rec.Body.String())
+	}
+	first := readTokens(t, serveJSON(http.MethodPost, "/auth/login", creds, ""))
+	second := readTokens(t, serveJSON(http.MethodPost, "/auth/login", creds, ""))
+	if rec := serveJSON(http.MethodPost, "/auth/logout-all", "", ""); rec.Code != http.StatusUnauthorized && rec.Code != http.StatusBadRequest {
+		t.Fatalf("\nExpected logout-all to require an access token\nReturned Code: %d", rec.Code)
+	}
+	if rec := serveJSON(http.MethodPost, "/auth/logout-all", "", first.Access); rec.Code != http.StatusNoContent {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+	}
+	for _, tk := range []tokens{first, second} {
+		if rec := serveJSON(http.MethodPost, "/auth/refresh", refreshJSON(tk.Refresh), ""); rec.Code != http.StatusUnauthorized {
+			t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/elhamza90/lifelog/internal/usecase/transferring"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// exporters maps the names of exported files to the service methods writing them
+var exporters = map[string]func(transferring.Service, io.Writer) error{
+	"tags.csv":       transferring.Service.ExportTags,
+	"activities.csv": transferring.Service.ExportActivities,
+	"expenses.csv":   transferring.Service.ExportExpenses,
+}
+
+// importers maps the names of imported entities to the service methods reading them
+var importers = map[string]func(transferring.Service, io.Reader, string) (transferring.Report, error){
+	"tags":       transferring.Service.ImportTags,
+	"activities": transferring.Service.ImportActivities,
+	"expenses":   transferring.Service.ImportExpenses,
+}
+
+// Export handler streams all entities of a kind as a CSV file.
+// It requires a path parameter :file which is one of
+// tags.csv, activities.csv or expenses.csv
+func (h *Handler) Export(c echo.Context) error {
+	file := c.Param("file")
+	export, ok := exporters[file]
+	if !ok {
+		msg := "No export named " + file
+		logrus.Error(msg)
+		return errTransferNotFound
+	}
+	resp := c.Response()
+	resp.Header().Set(echo.HeaderContentType, "text/csv; charset=UTF-8")
+	resp.Header().Set(echo.HeaderContentDisposition, "attachment; filename=\""+file+"\"")
+	resp.WriteHeader(http.StatusOK)
+	// Status is already sent so errors can only be logged
+	if err := export(h.transferrer, resp); err != nil {
+		logrus.Error("Error while exporting " + file + " : " + err.Error())
+		return nil
+	}
+	logrus.Infof("Exported %s successfully", file)
+	return nil
+}
+
+// Import handler imports entities of a kind from the CSV file in the request body.
+// It requires a path parameter :entity which is one of tags, activities or expenses.
+// Valid rows are imported and the errors of invalid rows are returned.
+// Imported entities are recorded in their history.
+func (h *Handler) Import(c echo.Context) error {
+	entity := c.Param("entity")
+	imp, ok := importers[entity]
+	if !ok {
+		msg := "No import of " + entity
+		logrus.Error(msg)
+		return errTransferNotFound
+	}
+	defer c.Request().Body.Close()
+	report, err := imp(h.transferrer, c.Request().Body, h.principal(c))
+	if err != nil {
+		msg := "Error while importing " + entity
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Imported %d %s successfully ( %d rows rejected )", report.Imported, entity, len(report.Errors))
+	var resp JSONRespImport
+	resp.From(report)
+	return c.JSON(http.StatusOK, resp)
+}
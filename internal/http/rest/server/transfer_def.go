@@ -0,0 +1,25 @@
+package server
+
+import "github.com/elhamza90/lifelog/internal/usecase/transferring"
+
+// JSONRowError is used to marshal an error of an imported CSV row to json.
+type JSONRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+// JSONRespImport is used to marshal the report of a CSV import to json.
+type JSONRespImport struct {
+	Imported int            `json:"imported"`
+	Errors   []JSONRowError `json:"errors"`
+}
+
+// From constructs a JSONRespImport object from a transferring.Report object.
+func (resp *JSONRespImport) From(report transferring.Report) {
+	(*resp).Imported = report.Imported
+	(*resp).Errors = make([]JSONRowError, len(report.Errors))
+	for i, e := range report.Errors {
+		(*resp).Errors[i] = JSONRowError{Row: e.Row, Error: e.Err.Error(), Code: errorCode(e.Err)}
+	}
+}
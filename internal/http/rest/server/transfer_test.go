@@ -0,0 +1,125 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+func TestExport(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "food"},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Lunch", Time: time.Date(2020, 10, 5, 12, 0, 0, 0, time.UTC), Value: 1250, Unit: "EUR", Tags: []domain.Tag{repo.Tags[1]}},
+	}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	}()
+	tests := map[string]struct {
+		file         string
+		expectedCode int
+		expectedBody string
+	}{
+		"Tags": {
+			file:         "tags.csv",
+			expectedCode: http.StatusOK,
+			expectedBody: "id,name\n1,food\n",
+		},
+		"Expenses": {
+			file:         "expenses.csv",
+			expectedCode: http.StatusOK,
+			expectedBody: "id,label,time,value,unit,activity_id,activity_label,activity_time,tags\n1,Lunch,2020-10-05T12:00:00Z,12.50,EUR,,,,food\n",
+		},
+		"Unknown File": {
+			file:         "budgets.csv",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	const path string = "/export/:file"
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/export/"+test.file, nil)
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath(path)
+			ctx.SetParamNames("file")
+			ctx.SetParamValues(test.file)
+			handle(hnd.Export, ctx)
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+			if test.expectedBody == "" {
+				return
+			}
+			if body := rec.Body.String(); body != test.expectedBody {
+				t.Fatalf("\nExpected Body: %s\nReturned Body: %s", test.expectedBody, body)
+			}
+			if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+				t.Fatalf("\nExpected CSV Content-Type\nReturned: %s", ct)
+			}
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Activities = map[domain.ActivityID]domain.Activity{}
+	}()
+	tests := map[string]struct {
+		entity       string
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		"Tags": {
+			entity:       "tags",
+			body:         "name\nsport\nx\n",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"imported":1,"errors":[{"row":3,"error":"` + domain.ErrTagNameLen.Error() + `","code":"tag_name_length"}]}`,
+		},
+		"Activities": {
+			entity:       "activities",
+			body:         "label,time,duration,tags\nFootball,2020-10-05 18:00,1h30m,sport\n",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"imported":1,"errors":[]}`,
+		},
+		"Missing Columns": {
+			entity:       "expenses",
+			body:         "label,time\nLunch,2020-10-05\n",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Unknown Entity": {
+			entity:       "budgets",
+			body:         "name\n",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	const path string = "/import/:entity"
+	// Tests are run in order since activities use imported tags
+	for _, name := range []string{"Tags", "Activities", "Missing Columns", "Unknown Entity"} {
+		test := tests[name]
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/import/"+test.entity, strings.NewReader(test.body))
+			req.Header.Set("Content-type", "text/csv")
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath(path)
+			ctx.SetParamNames("entity")
+			ctx.SetParamValues(test.entity)
+			handle(hnd.Import, ctx)
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+			if body := strings.TrimSpace(rec.Body.String()); test.expectedBody != "" && body != test.expectedBody {
+				t.Fatalf("\nExpected Body: %s\nReturned Body: %s", test.expectedBody, body)
+			}
+		})
+	}
+}
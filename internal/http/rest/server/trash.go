@@ -0,0 +1,112 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/trashing"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// trashItemFunc restores or purges the trashed item with given ID on behalf of a principal
+type trashItemFunc func(s trashing.Service, id uint, principal string) error
+
+// restorers maps the :kind path parameter of the trash to the service methods restoring items
+var restorers = map[string]trashItemFunc{
+	"tags":       func(s trashing.Service, id uint, p string) error { return s.RestoreTag(domain.TagID(id), p) },
+	"activities": func(s trashing.Service, id uint, p string) error { return s.RestoreActivity(domain.ActivityID(id), p) },
+	"expenses":   func(s trashing.Service, id uint, p string) error { return s.RestoreExpense(domain.ExpenseID(id), p) },
+}
+
+// purgers maps the :kind path parameter of the trash to the service methods purging items
+var purgers = map[string]trashItemFunc{
+	"tags":       func(s trashing.Service, id uint, p string) error { return s.PurgeTag(domain.TagID(id), p) },
+	"activities": func(s trashing.Service, id uint, p string) error { return s.PurgeActivity(domain.ActivityID(id), p) },
+	"expenses":   func(s trashing.Service, id uint, p string) error { return s.PurgeExpense(domain.ExpenseID(id), p) },
+}
+
+// trashItemParams extracts the :kind and :id path parameters of a trash item
+// and returns the function of given map handling that kind.
+func trashItemParams(c echo.Context, funcs map[string]trashItemFunc) (trashItemFunc, uint, error) {
+	kind := c.Param("kind")
+	f, ok := funcs[kind]
+	if !ok {
+		logrus.Error("No trash item kind named " + kind)
+		return nil, 0, errTrashKind
+	}
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		msg := fmt.Sprintf("Error while converting path param ID with value %s to int", idStr)
+		logrus.Error(msg + " | " + err.Error())
+		return nil, 0, errIDParam
+	}
+	return f, uint(id), nil
+}
+
+// GetTrash handler returns the items in the trash, most recently deleted first.
+func (h *Handler) GetTrash(c echo.Context) error {
+	items, err := h.trasher.Trash()
+	if err != nil {
+		msg := "Error while getting trash"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	resp := make([]JSONRespTrashItem, len(items))
+	for i, it := range items {
+		resp[i].From(it)
+	}
+	logrus.Infof("Retrieved %d trashed items successfully", len(resp))
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RestoreTrashItem handler takes an item out of the trash
+// and records it in the history of the item.
+// It requires path parameters :kind ( tags, activities or expenses ) and :id
+func (h *Handler) RestoreTrashItem(c echo.Context) error {
+	restore, id, err := trashItemParams(c, restorers)
+	if err != nil {
+		return err
+	}
+	if err := restore(h.trasher, id, h.principal(c)); err != nil {
+		msg := fmt.Sprintf("Error while restoring %s with ID %d", c.Param("kind"), id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Restored %s with ID %d successfully", c.Param("kind"), id)
+	return c.String(http.StatusNoContent, "Item Restored Successfully")
+}
+
+// PurgeTrashItem handler permanently deletes an item from the trash
+// and records it in the history of the item.
+// It requires path parameters :kind ( tags, activities or expenses ) and :id
+func (h *Handler) PurgeTrashItem(c echo.Context) error {
+	purge, id, err := trashItemParams(c, purgers)
+	if err != nil {
+		return err
+	}
+	if err := purge(h.trasher, id, h.principal(c)); err != nil {
+		msg := fmt.Sprintf("Error while purging %s with ID %d", c.Param("kind"), id)
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Purged %s with ID %d successfully", c.Param("kind"), id)
+	return c.String(http.StatusNoContent, "Item Purged Successfully")
+}
+
+// EmptyTrash handler permanently deletes all items in the trash
+// and returns how many were purged.
+func (h *Handler) EmptyTrash(c echo.Context) error {
+	purged, err := h.trasher.Purge(time.Now(), h.principal(c))
+	if err != nil {
+		msg := "Error while emptying trash"
+		logrus.Error(msg + " : " + err.Error())
+		return err
+	}
+	logrus.Infof("Purged %d trashed items successfully", purged)
+	return c.JSON(http.StatusOK, JSONRespPurge{Purged: purged})
+}
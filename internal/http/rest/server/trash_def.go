@@ -0,0 +1,28 @@
+package server
+
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// JSONRespTrashItem is used to marshal an item of the trash to json.
+type JSONRespTrashItem struct {
+	Kind      string    `json:"kind"`
+	ID        uint      `json:"id"`
+	Label     string    `json:"label"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+// From constructs a JSONRespTrashItem object from a domain.TrashItem object.
+func (resp *JSONRespTrashItem) From(it domain.TrashItem) {
+	(*resp).Kind = string(it.Kind)
+	(*resp).ID = it.ID
+	(*resp).Label = it.Label
+	(*resp).DeletedAt = it.DeletedAt
+}
+
+// JSONRespPurge is used to marshal the result of emptying the trash to json.
+type JSONRespPurge struct {
+	Purged int `json:"purged"`
+}
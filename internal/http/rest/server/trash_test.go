@@ -0,0 +1,130 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+func TestGetTrash(t *testing.T) {
+	deleted := time.Date(2020, 10, 5, 12, 0, 0, 0, time.UTC)
+	repo.Tags = map[domain.TagID]domain.Tag{1: {ID: 1, Name: "food"}}
+	repo.Trash = map[string]time.Time{"tag/1": deleted}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Trash = map[string]time.Time{}
+	}()
+	req := httptest.NewRequest(http.MethodGet, "/trash", nil)
+	rec := httptest.NewRecorder()
+	ctx := router.NewContext(req, rec)
+	handle(hnd.GetTrash, ctx)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	const expectedBody string = `[{"kind":"tag","id":1,"label":"food","deletedAt":"2020-10-05T12:00:00Z"}]`
+	if body := strings.TrimSpace(rec.Body.String()); body != expectedBody {
+		t.Fatalf("\nExpected Body: %s\nReturned Body: %s", expectedBody, body)
+	}
+}
+
+func TestRestoreTrashItem(t *testing.T) {
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Football", Time: time.Now().AddDate(0, 0, -1), Duration: time.Hour},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Ticket", Value: 10, Unit: "EUR", ActivityID: 1},
+	}
+	repo.Trash = map[string]time.Time{"activity/1": time.Now(), "expense/1": time.Now()}
+	repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	defer func() {
+		repo.Activities = map[domain.ActivityID]domain.Activity{}
+		repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+		repo.Trash = map[string]time.Time{}
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	}()
+	// Subtests run in order: the expense can be restored once its activity is
+	tests := []struct {
+		name         string
+		kind         string
+		id           string
+		expectedCode int
+	}{
+		{name: "Unknown Kind", kind: "budgets", id: "1", expectedCode: http.StatusBadRequest},
+		{name: "Invalid ID", kind: "expenses", id: "one", expectedCode: http.StatusBadRequest},
+		{name: "Activity In Trash", kind: "expenses", id: "1", expectedCode: http.StatusUnprocessableEntity},
+		{name: "Restore Activity", kind: "activities", id: "1", expectedCode: http.StatusNoContent},
+		{name: "Not In Trash", kind: "activities", id: "1", expectedCode: http.StatusNotFound},
+		{name: "Restore Expense", kind: "expenses", id: "1", expectedCode: http.StatusNoContent},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			rec := httptest.NewRecorder()
+			ctx := router.NewContext(req, rec)
+			ctx.SetPath("/trash/:kind/:id/restore")
+			ctx.SetParamNames("kind", "id")
+			ctx.SetParamValues(test.kind, test.id)
+			ctx.Set("user", &jwt.Token{Claims: jwt.MapClaims{"name": "hamza"}})
+			handle(hnd.RestoreTrashItem, ctx)
+			if rec.Code != test.expectedCode {
+				t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", test.expectedCode, rec.Code, rec.Body.String())
+			}
+		})
+	}
+	// Restores are recorded in the history of the items
+	events, _ := repo.FindAuditEvents(domain.AuditExpense, 1)
+	if len(events) != 1 || events[0].Action != domain.AuditRestore || events[0].Principal != "hamza" {
+		t.Fatalf("\nExpected restore of expense 1 by hamza\nReturned: %v", events)
+	}
+}
+
+func TestPurgeTrashItem(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{1: {ID: 1, Name: "food"}}
+	repo.Trash = map[string]time.Time{"tag/1": time.Now()}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Trash = map[string]time.Time{}
+	}()
+	for _, expectedCode := range []int{http.StatusNoContent, http.StatusNotFound} {
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		rec := httptest.NewRecorder()
+		ctx := router.NewContext(req, rec)
+		ctx.SetPath("/trash/:kind/:id")
+		ctx.SetParamNames("kind", "id")
+		ctx.SetParamValues("tags", "1")
+		handle(hnd.PurgeTrashItem, ctx)
+		if rec.Code != expectedCode {
+			t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", expectedCode, rec.Code, rec.Body.String())
+		}
+	}
+	if len(repo.Tags) != 0 {
+		t.Fatalf("\nExpected purged tag\nReturned: %v", repo.Tags)
+	}
+}
+
+func TestEmptyTrash(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{1: {ID: 1, Name: "food"}, 2: {ID: 2, Name: "sport"}}
+	repo.Trash = map[string]time.Time{"tag/1": time.Now().Add(-time.Minute)}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Trash = map[string]time.Time{}
+	}()
+	req := httptest.NewRequest(http.MethodDelete, "/trash", nil)
+	rec := httptest.NewRecorder()
+	ctx := router.NewContext(req, rec)
+	handle(hnd.EmptyTrash, ctx)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("\nExpected Code: %d\nReturned Code: %d\nReturned Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if body := strings.TrimSpace(rec.Body.String()); body != `{"purged":1}` {
+		t.Fatalf("\nExpected Body: %s\nReturned Body: %s", `{"purged":1}`, body)
+	}
+	if _, ok := repo.Tags[2]; !ok || len(repo.Tags) != 1 {
+		t.Fatalf("\nExpected only live tag kept\nReturned: %v", repo.Tags)
+	}
+}
@@ -14,7 +14,7 @@ import (
 // If none is found, returns error
 func (repo Repository) FindActivityByID(id domain.ActivityID) (domain.Activity, error) {
 	var act Activity
-	err := repo.db.Preload("Tags").First(&act, id).Error
+	err := repo.owned().Preload("Tags").First(&act, id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		err = store.ErrActivityNotFound
 	}
@@ -34,7 +34,9 @@ func (repo Repository) SaveActivity(act domain.Activity) (domain.ActivityID, err
 		Desc:     act.Desc,
 		Time:     act.Time,
 		Duration: act.Duration,
+		Status:   act.Status,
 		Tags:     tags,
+		UserID:   repo.owner,
 	}
 	res := repo.db.Create(&dbAct)
 	return domain.ActivityID(dbAct.ID), res.Error
@@ -44,7 +46,7 @@ func (repo Repository) SaveActivity(act domain.Activity) (domain.ActivityID, err
 // with Time field greater than or equal to the given time
 func (repo Repository) FindActivitiesByTime(t time.Time) ([]domain.Activity, error) {
 	res := []Activity{}
-	if err := repo.db.Where("time >= ?", t).Order("time DESC").Find(&res).Error; err != nil {
+	if err := repo.owned().Preload("Tags").Where("time >= ?", t).Order("time DESC").Find(&res).Error; err != nil {
 		return []domain.Activity{}, err
 	}
 	activities := make([]domain.Activity, len(res))
@@ -54,10 +56,24 @@ func (repo Repository) FindActivitiesByTime(t time.Time) ([]domain.Activity, err
 	return activities, nil
 }
 
+// FindActivitiesByTimeRange returns activities
+// with Time field between from and to ( inclusive )
+func (repo Repository) FindActivitiesByTimeRange(from time.Time, to time.Time) ([]domain.Activity, error) {
+	res := []Activity{}
+	if err := repo.owned().Preload("Tags").Where("time >= ? AND time <= ?", from, to).Order("time DESC").Find(&res).Error; err != nil {
+		return []domain.Activity{}, err
+	}
+	activities := make([]domain.Activity, len(res))
+	for i, act := range res {
+		activities[i] = act.ToDomain()
+	}
+	return activities, nil
+}
+
 // FindActivitiesByTag returns activities that have the provided tag in their Tags field
 func (repo Repository) FindActivitiesByTag(tid domain.TagID) ([]domain.Activity, error) {
 	var tag Tag
-	if err := repo.db.Preload("Activities", func(db *gorm.DB) *gorm.DB {
+	if err := repo.owned().Preload("Activities", func(db *gorm.DB) *gorm.DB {
 		return db.Order("activities.time DESC") // Order activities by time
 	}).First(&tag, tid).Error; err != nil {
 		return []domain.Activity{}, err
@@ -69,14 +85,10 @@ func (repo Repository) FindActivitiesByTag(tid domain.TagID) ([]domain.Activity,
 	return activities, nil
 }
 
-// DeleteActivity removes activity with provided ID from memory
+// DeleteActivity moves activity with provided ID to the trash.
+// Its tags are kept so it can be restored.
 func (repo Repository) DeleteActivity(id domain.ActivityID) error {
-	// Clear Tags Association
-	if err := repo.db.Model(&Activity{ID: id}).Association("Tags").Clear(); err != nil {
-		return err
-	}
-	// Delete Activity
-	res := repo.db.Delete(&Activity{ID: id})
+	res := repo.owned().Delete(&Activity{ID: id})
 	if res.Error != nil {
 		return res.Error
 	}
@@ -88,20 +100,22 @@ func (repo Repository) DeleteActivity(id domain.ActivityID) error {
 
 // EditActivity edits given activity in memory
 func (repo Repository) EditActivity(act domain.Activity) error {
-	if err := repo.db.First(&Activity{}, act.ID).Error; err != nil {
+	if err := repo.owned().First(&Activity{}, act.ID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return store.ErrActivityNotFound
 		}
 		return err
 	}
 	// Update primary fields
-	res := repo.db.Save(&Activity{
+	res := repo.owned().Save(&Activity{
 		ID:       act.ID,
 		Label:    act.Label,
 		Place:    act.Place,
 		Desc:     act.Desc,
 		Time:     act.Time,
 		Duration: act.Duration,
+		Status:   act.Status,
+		UserID:   repo.owner,
 	})
 	if res.RowsAffected != 1 {
 		return fmt.Errorf("%d Rows were affected", res.RowsAffected)
@@ -117,3 +131,49 @@ func (repo Repository) EditActivity(act domain.Activity) error {
 	err := repo.db.Model(&Activity{ID: act.ID}).Association("Tags").Replace(tags)
 	return err
 }
+
+// findActivitiesPage returns the requested page of activities returned by the given query
+func (repo Repository) findActivitiesPage(query *gorm.DB, p store.PageRequest) ([]domain.Activity, store.Page, error) {
+	if p.Status != "" {
+		query = query.Where("activities.status = ?", p.Status)
+	}
+	query, total, err := paginate(query, "activities", activitySortColumns, p)
+	if err != nil {
+		return []domain.Activity{}, store.Page{}, err
+	}
+	res := []Activity{}
+	if err := query.Preload("Tags").Find(&res).Error; err != nil {
+		return []domain.Activity{}, store.Page{}, err
+	}
+	page := nextPage(p, total, len(res), func(last int) store.Cursor {
+		act := res[last]
+		if p.Sort == store.SortByLabel {
+			return store.NewCursor(act.Label, uint(act.ID))
+		}
+		return store.NewCursor(act.Time, uint(act.ID))
+	})
+	if len(res) > p.Limit {
+		res = res[:p.Limit]
+	}
+	activities := make([]domain.Activity, len(res))
+	for i, act := range res {
+		activities[i] = act.ToDomain()
+	}
+	return activities, page, nil
+}
+
+// FindActivitiesByTimeRangePage returns the requested page of activities
+// with Time field between from and to ( inclusive )
+func (repo Repository) FindActivitiesByTimeRangePage(from time.Time, to time.Time, p store.PageRequest) ([]domain.Activity, store.Page, error) {
+	query := repo.owned().Model(&Activity{}).Where("activities.time >= ? AND activities.time <= ?", from, to)
+	return repo.findActivitiesPage(query, p)
+}
+
+// FindActivitiesByTagPage returns the requested page of activities
+// that have the provided tag in their Tags field
+func (repo Repository) FindActivitiesByTagPage(tid domain.TagID, p store.PageRequest) ([]domain.Activity, store.Page, error) {
+	query := repo.owned().Model(&Activity{}).
+		Joins("JOIN activity_tags ON activity_tags.activity_id = activities.id").
+		Where("activity_tags.tag_id = ?", tid)
+	return repo.findActivitiesPage(query, p)
+}
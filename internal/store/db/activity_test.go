@@ -121,6 +121,52 @@ func TestFindActivitiesByTime(t *testing.T) {
 	}
 }
 
+func TestFindActivitiesByTimeRange(t *testing.T) {
+	// Create test 100 activities:
+	// one for each day starting from today going backward
+	defer clearDB()
+	const nbrActivities int = 100
+	activities := make([]db.Activity, nbrActivities)
+	now := time.Now()
+	tag := db.Tag{ID: 1, Name: "test-tag"}
+	for i := 0; i < nbrActivities; i++ {
+		activities[i] = db.Activity{
+			Label:    fmt.Sprintf("Test Activity %d", i),
+			Place:    "Somewhere",
+			Desc:     "Details",
+			Time:     now.AddDate(0, 0, -i),
+			Duration: time.Duration(time.Hour),
+			Tags:     []db.Tag{tag},
+		}
+	}
+	if err := grmDb.Create(&activities).Error; err != nil {
+		t.Fatalf("\nError while creating test activities:\n  %v", err)
+	}
+	// Test Get Activities from 10 days ago to 5 days ago (Should be 6 activities)
+	minTime := now.AddDate(0, 0, -10)
+	maxTime := now.AddDate(0, 0, -5)
+	nbrExpected := 6
+	res, err := repo.FindActivitiesByTimeRange(minTime, maxTime)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(res) != nbrExpected {
+		t.Fatalf("\nExpecting %d activities\nReturned %d activities", nbrExpected, len(res))
+	}
+	// Test Range, Order by time & Tags
+	for i, elem := range res {
+		if elem.Time.Before(minTime) || elem.Time.After(maxTime) {
+			t.Fatalf("\nElement in result has time field out of range:\n\t%v", elem)
+		}
+		if i < len(res)-1 && elem.Time.Before(res[i+1].Time) {
+			t.Fatal("\nActivities not ordered by time")
+		}
+		if len(elem.Tags) != 1 || elem.Tags[0].Name != tag.Name {
+			t.Fatalf("\nExpected activity with its tag %s\nReturned: %v", tag.Name, elem)
+		}
+	}
+}
+
 func TestFindActivitiesByTag(t *testing.T) {
 	// Create test activities & tags:
 	defer clearDB()
@@ -293,3 +339,72 @@ func TestEditActivity(t *testing.T) {
 		}
 	})
 }
+
+func TestFindActivitiesPage(t *testing.T) {
+	defer clearDB()
+	tag := db.Tag{ID: 11, Name: "test-tag-1"}
+	if err := grmDb.Create(&tag).Error; err != nil {
+		t.Fatalf("\nError while creating test tag:\n  %v", err)
+	}
+	now := time.Now()
+	activities := []db.Activity{
+		{ID: 1, Label: "Activity C", Time: now.AddDate(0, 0, -1), Duration: time.Hour, Tags: []db.Tag{tag}},
+		{ID: 2, Label: "Activity A", Time: now.AddDate(0, 0, -2), Duration: time.Hour},
+		{ID: 3, Label: "Activity B", Time: now.AddDate(0, 0, -3), Duration: time.Hour, Tags: []db.Tag{tag}},
+		{ID: 4, Label: "Activity A", Time: now.AddDate(0, 0, -4), Duration: time.Hour, Tags: []db.Tag{tag}},
+	}
+	if err := grmDb.Create(&activities).Error; err != nil {
+		t.Fatalf("\nError while creating test activities:\n  %v", err)
+	}
+	tests := map[string]struct {
+		find        func(store.PageRequest) ([]domain.Activity, store.Page, error)
+		sort        store.SortField
+		desc        bool
+		expectedIDs []domain.ActivityID // In order !
+	}{
+		"Time Range / Time Desc": {
+			find: func(p store.PageRequest) ([]domain.Activity, store.Page, error) {
+				return repo.FindActivitiesByTimeRangePage(now.AddDate(0, 0, -10), now, p)
+			},
+			sort: store.SortByTime, desc: true, expectedIDs: []domain.ActivityID{1, 2, 3, 4},
+		},
+		"Time Range / Label Asc": {
+			find: func(p store.PageRequest) ([]domain.Activity, store.Page, error) {
+				return repo.FindActivitiesByTimeRangePage(now.AddDate(0, 0, -10), now, p)
+			},
+			sort: store.SortByLabel, desc: false, expectedIDs: []domain.ActivityID{2, 4, 3, 1},
+		},
+		"Tag / Label Desc": {
+			find: func(p store.PageRequest) ([]domain.Activity, store.Page, error) {
+				return repo.FindActivitiesByTagPage(tag.ID, p)
+			},
+			sort: store.SortByLabel, desc: true, expectedIDs: []domain.ActivityID{1, 3, 4},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Walk through pages of 1 activity
+			ids := []domain.ActivityID{}
+			p := store.PageRequest{Limit: 1, Sort: test.sort, Desc: test.desc}
+			for i := 0; i < 5; i++ {
+				res, page, err := test.find(p)
+				if err != nil {
+					t.Fatalf("\nUnexpected Error: %v", err)
+				}
+				if page.Total != len(test.expectedIDs) {
+					t.Fatalf("\nExpected Total: %d\nReturned Total: %d", len(test.expectedIDs), page.Total)
+				}
+				for _, act := range res {
+					ids = append(ids, act.ID)
+				}
+				if page.NextCursor == "" {
+					break
+				}
+				p.Cursor = page.NextCursor
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(test.expectedIDs) {
+				t.Fatalf("\nExpected: %v\nReturned: %v", test.expectedIDs, ids)
+			}
+		})
+	}
+}
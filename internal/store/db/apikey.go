@@ -0,0 +1,64 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"gorm.io/gorm"
+)
+
+// SaveAPIKey stores the given API key in db and returns its ID
+func (repo Repository) SaveAPIKey(k domain.APIKey) (domain.APIKeyID, error) {
+	key := APIKey{
+		UserID:    k.UserID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Hash:      k.Hash,
+		Scope:     k.Scope,
+		ExpiresAt: k.ExpiresAt,
+		CreatedAt: k.CreatedAt,
+	}
+	res := repo.db.Create(&key)
+	return key.ID, res.Error
+}
+
+// FindAPIKeys returns the API keys of the user with given ID
+func (repo Repository) FindAPIKeys(uid domain.UserID) ([]domain.APIKey, error) {
+	var keys []APIKey
+	if err := repo.db.Where("user_id = ?", uid).Order("id").Find(&keys).Error; err != nil {
+		return []domain.APIKey{}, err
+	}
+	res := make([]domain.APIKey, len(keys))
+	for i, k := range keys {
+		res[i] = k.ToDomain()
+	}
+	return res, nil
+}
+
+// FindAPIKey returns the API key with given ID.
+// It returns ErrAPIKeyNotFound if no key was found.
+func (repo Repository) FindAPIKey(id domain.APIKeyID) (domain.APIKey, error) {
+	var k APIKey
+	err := repo.db.Where("id = ?", id).First(&k).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = store.ErrAPIKeyNotFound
+	}
+	return k.ToDomain(), err
+}
+
+// FindAPIKeyByHash returns the API key with given hash.
+// It returns ErrAPIKeyNotFound if no key was found.
+func (repo Repository) FindAPIKeyByHash(hash string) (domain.APIKey, error) {
+	var k APIKey
+	err := repo.db.Where("hash = ?", hash).First(&k).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = store.ErrAPIKeyNotFound
+	}
+	return k.ToDomain(), err
+}
+
+// DeleteAPIKey deletes the API key with given ID from db
+func (repo Repository) DeleteAPIKey(id domain.APIKeyID) error {
+	return repo.db.Delete(&APIKey{}, id).Error
+}
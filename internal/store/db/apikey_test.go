@@ -0,0 +1,40 @@
+package db_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+func TestAPIKeys(t *testing.T) {
+	defer clearDB()
+	exp := time.Now().Add(time.Hour).Round(time.Second)
+	id, err := repo.SaveAPIKey(domain.APIKey{UserID: 1, Name: "script", Prefix: "lflg_0123", Hash: "hash1", Scope: domain.APIKeyScopeRead, ExpiresAt: exp})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if _, err := repo.SaveAPIKey(domain.APIKey{UserID: 2, Name: "other", Prefix: "lflg_4567", Hash: "hash2", Scope: domain.APIKeyScopeReadWrite}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if _, err := repo.SaveAPIKey(domain.APIKey{UserID: 2, Name: "duplicate", Hash: "hash2"}); err == nil {
+		t.Fatal("\nExpected Error on duplicate hash")
+	}
+	if k, err := repo.FindAPIKeyByHash("hash1"); err != nil || k.ID != id || k.Name != "script" || !k.ExpiresAt.Equal(exp) {
+		t.Fatalf("\nExpected key %d\nReturned: %v, %v", id, k, err)
+	}
+	if _, err := repo.FindAPIKeyByHash("unknown"); !errors.Is(err, store.ErrAPIKeyNotFound) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrAPIKeyNotFound, err)
+	}
+	if keys, err := repo.FindAPIKeys(1); err != nil || len(keys) != 1 || keys[0].ID != id {
+		t.Fatalf("\nExpected key %d of user 1\nReturned: %v, %v", id, keys, err)
+	}
+	if err := repo.DeleteAPIKey(id); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if _, err := repo.FindAPIKey(id); !errors.Is(err, store.ErrAPIKeyNotFound) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrAPIKeyNotFound, err)
+	}
+}
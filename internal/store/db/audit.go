@@ -0,0 +1,31 @@
+package db
+
+import "github.com/elhamza90/lifelog/internal/domain"
+
+// SaveAuditEvent stores the given audit event in db and returns its ID
+func (repo Repository) SaveAuditEvent(ev domain.AuditEvent) (domain.AuditEventID, error) {
+	dbEv := AuditEvent{
+		Entity:    ev.Entity,
+		EntityID:  ev.EntityID,
+		Action:    ev.Action,
+		Principal: ev.Principal,
+		Time:      ev.Time,
+		Before:    string(ev.Before),
+		After:     string(ev.After),
+		UserID:    repo.owner,
+	}
+	err := repo.db.Create(&dbEv).Error
+	return dbEv.ID, err
+}
+
+// FindAuditEvents returns the audit events of the entity with given kind & ID
+// ordered from the oldest to the most recent
+func (repo Repository) FindAuditEvents(entity domain.AuditEntity, id uint) ([]domain.AuditEvent, error) {
+	var events []AuditEvent
+	err := repo.owned().Where("entity = ? AND entity_id = ?", entity, id).Order("time, id").Find(&events).Error
+	res := make([]domain.AuditEvent, len(events))
+	for i, ev := range events {
+		res[i] = ev.ToDomain()
+	}
+	return res, err
+}
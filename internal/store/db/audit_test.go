@@ -0,0 +1,34 @@
+package db_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+func TestAuditEvents(t *testing.T) {
+	defer clearDB()
+	now := time.Now()
+	events := []domain.AuditEvent{
+		{Entity: domain.AuditTag, EntityID: 1, Action: domain.AuditUpdate, Principal: "admin", Time: now, Before: json.RawMessage(`{"name":"sport"}`), After: json.RawMessage(`{"name":"sports"}`)},
+		{Entity: domain.AuditTag, EntityID: 1, Action: domain.AuditCreate, Principal: "admin", Time: now.Add(-time.Hour), After: json.RawMessage(`{"name":"sport"}`)},
+		{Entity: domain.AuditActivity, EntityID: 1, Action: domain.AuditCreate, Principal: "admin", Time: now},
+	}
+	for _, ev := range events {
+		if _, err := repo.SaveAuditEvent(ev); err != nil {
+			t.Fatalf("\nUnexpected Error: %v", err)
+		}
+	}
+	res, err := repo.FindAuditEvents(domain.AuditTag, 1)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(res) != 2 || res[0].Action != domain.AuditCreate || res[1].Action != domain.AuditUpdate {
+		t.Fatalf("\nExpected create then update of tag 1\nReturned: %v", res)
+	}
+	if res[0].Before != nil || string(res[0].After) != `{"name":"sport"}` || string(res[1].Before) != `{"name":"sport"}` {
+		t.Fatalf("\nExpected snapshots to be kept\nReturned: %s %s | %s %s", res[0].Before, res[0].After, res[1].Before, res[1].After)
+	}
+}
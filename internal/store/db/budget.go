@@ -0,0 +1,87 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"gorm.io/gorm"
+)
+
+// FindBudgetByID searches for a budget with the given ID and returns it.
+// It returns ErrBudgetNotFound if no budget was found.
+func (repo Repository) FindBudgetByID(id domain.BudgetID) (domain.Budget, error) {
+	var b Budget
+	err := repo.owned().First(&b, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = store.ErrBudgetNotFound
+	}
+	return b.ToDomain(), err
+}
+
+// FindAllBudgets returns all stored budgets in db
+func (repo Repository) FindAllBudgets() ([]domain.Budget, error) {
+	return repo.findBudgets(repo.owned())
+}
+
+// FindBudgetsByTag returns budgets limiting expenses with the given tag
+func (repo Repository) FindBudgetsByTag(tid domain.TagID) ([]domain.Budget, error) {
+	return repo.findBudgets(repo.owned().Where("tag_id = ?", tid))
+}
+
+// findBudgets returns budgets returned by the given query ordered by ID
+func (repo Repository) findBudgets(query *gorm.DB) ([]domain.Budget, error) {
+	res := []Budget{}
+	if err := query.Order("id").Find(&res).Error; err != nil {
+		return []domain.Budget{}, err
+	}
+	budgets := make([]domain.Budget, len(res))
+	for i, b := range res {
+		budgets[i] = b.ToDomain()
+	}
+	return budgets, nil
+}
+
+// SaveBudget stores the given budget in db and returns created budget's ID
+func (repo Repository) SaveBudget(b domain.Budget) (domain.BudgetID, error) {
+	dbBudget := Budget{
+		TagID:    b.TagID,
+		Period:   b.Period,
+		Amount:   b.Amount,
+		Currency: b.Currency,
+		UserID:   repo.owner,
+	}
+	res := repo.db.Create(&dbBudget)
+	return dbBudget.ID, res.Error
+}
+
+// EditBudget edits given budget in db
+// It returns ErrBudgetNotFound if budget does not exist
+func (repo Repository) EditBudget(b domain.Budget) error {
+	res := repo.owned().Model(&Budget{ID: b.ID}).Updates(map[string]interface{}{
+		"tag_id":   b.TagID,
+		"period":   b.Period,
+		"amount":   b.Amount,
+		"currency": b.Currency,
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected != 1 {
+		return store.ErrBudgetNotFound
+	}
+	return nil
+}
+
+// DeleteBudget deletes budget from db
+// It returns ErrBudgetNotFound if budget does not exist
+func (repo Repository) DeleteBudget(id domain.BudgetID) error {
+	res := repo.owned().Delete(&Budget{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected != 1 {
+		return store.ErrBudgetNotFound
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/store/db"
+)
+
+func TestSaveBudget(t *testing.T) {
+	defer clearDB()
+	b := domain.Budget{TagID: 3, Period: domain.PeriodMonth, Amount: 30000, Currency: "EUR"}
+	id, err := repo.SaveBudget(b)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	var created db.Budget
+	if err := grmDb.First(&created, id).Error; err != nil {
+		t.Fatalf("\nUnexpected Error while fetching created budget: %v", err)
+	}
+	b.ID = id
+	if created.ToDomain() != b {
+		t.Fatalf("\nExpected: %v\nReturned: %v", b, created.ToDomain())
+	}
+}
+
+func TestFindBudgets(t *testing.T) {
+	defer clearDB()
+	budgets := []db.Budget{
+		{ID: 1, TagID: 0, Period: domain.PeriodMonth, Amount: 100000, Currency: "EUR"},
+		{ID: 2, TagID: 5, Period: domain.PeriodWeek, Amount: 5000, Currency: "EUR"},
+		{ID: 3, TagID: 5, Period: domain.PeriodMonth, Amount: 20000, Currency: "USD"},
+	}
+	if err := grmDb.Create(&budgets).Error; err != nil {
+		t.Fatalf("\nUnexpected Error while creating test budgets: %v", err)
+	}
+	// Subcase: By ID
+	if _, err := repo.FindBudgetByID(2); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if _, err := repo.FindBudgetByID(234); err != store.ErrBudgetNotFound {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrBudgetNotFound, err)
+	}
+	// Subcase: All
+	if res, err := repo.FindAllBudgets(); err != nil || len(res) != 3 {
+		t.Fatalf("\nExpected 3 budgets\nReturned: %v ( error: %v )", res, err)
+	}
+	// Subcase: By Tag
+	if res, err := repo.FindBudgetsByTag(5); err != nil || len(res) != 2 || res[0].ID != 2 || res[1].ID != 3 {
+		t.Fatalf("\nExpected budgets 2 and 3\nReturned: %v ( error: %v )", res, err)
+	}
+}
+
+func TestEditBudget(t *testing.T) {
+	defer clearDB()
+	b := db.Budget{ID: 1, TagID: 0, Period: domain.PeriodMonth, Amount: 100000, Currency: "EUR"}
+	if err := grmDb.Create(&b).Error; err != nil {
+		t.Fatalf("\nUnexpected Error while creating test budget: %v", err)
+	}
+	edited := domain.Budget{ID: 1, TagID: 2, Period: domain.PeriodWeek, Amount: 2550, Currency: "USD"}
+	if err := repo.EditBudget(edited); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if res, _ := repo.FindBudgetByID(1); res != edited {
+		t.Fatalf("\nExpected: %v\nReturned: %v", edited, res)
+	}
+	// Subcase: Non-existing budget
+	edited.ID = 234
+	if err := repo.EditBudget(edited); err != store.ErrBudgetNotFound {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrBudgetNotFound, err)
+	}
+}
+
+func TestDeleteBudget(t *testing.T) {
+	defer clearDB()
+	b := db.Budget{ID: 1, Period: domain.PeriodMonth, Amount: 100000, Currency: "EUR"}
+	if err := grmDb.Create(&b).Error; err != nil {
+		t.Fatalf("\nUnexpected Error while creating test budget: %v", err)
+	}
+	if err := repo.DeleteBudget(1); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := repo.DeleteBudget(1); err != store.ErrBudgetNotFound {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrBudgetNotFound, err)
+	}
+}
@@ -22,16 +22,33 @@ func TestMain(m *testing.M) {
 		fmt.Println("failed to connect database")
 		os.Exit(1)
 	}
-	grmDb.AutoMigrate(&db.Tag{}, &db.Expense{}, &db.Activity{})
+	if err := db.MigrateExpenseAmounts(grmDb); err != nil {
+		fmt.Println("failed to migrate expense amounts")
+		os.Exit(1)
+	}
+	if err := db.MigrateRateOwners(grmDb); err != nil {
+		fmt.Println("failed to migrate exchange rate owners")
+		os.Exit(1)
+	}
+	grmDb.AutoMigrate(&db.Tag{}, &db.Expense{}, &db.Activity{}, &db.ExchangeRate{}, &db.Budget{}, &db.Template{}, &db.Occurrence{}, &db.AuditEvent{}, &db.User{}, &db.RefreshToken{}, &db.APIKey{})
 	repo = db.NewRepository(grmDb)
 	log.Debug("Test Setup Complete")
 	os.Exit(m.Run())
 }
 
 func clearDB() {
-	grmDb.Where("1 = 1").Delete(&db.Expense{})
-	grmDb.Where("1 = 1").Delete(&db.Activity{})
-	grmDb.Where("1 = 1").Delete(&db.Tag{})
+	grmDb.Unscoped().Where("1 = 1").Delete(&db.Expense{})
+	grmDb.Unscoped().Where("1 = 1").Delete(&db.Activity{})
+	grmDb.Unscoped().Where("1 = 1").Delete(&db.Tag{})
+	grmDb.Where("1 = 1").Delete(&db.ExchangeRate{})
+	grmDb.Where("1 = 1").Delete(&db.Budget{})
+	grmDb.Where("1 = 1").Delete(&db.Occurrence{})
+	grmDb.Where("1 = 1").Delete(&db.Template{})
+	grmDb.Where("1 = 1").Delete(&db.AuditEvent{})
+	grmDb.Where("1 = 1").Delete(&db.User{})
+	grmDb.Where("1 = 1").Delete(&db.RefreshToken{})
+	grmDb.Where("1 = 1").Delete(&db.APIKey{})
+	defer grmDb.Exec("DELETE FROM template_tags")
 	defer grmDb.Exec("DELETE FROM expense_tags")
 	defer grmDb.Exec("DELETE FROM activity_tags")
 }
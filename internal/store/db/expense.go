@@ -15,7 +15,7 @@ import (
 // It returns an error if expense not found
 func (repo Repository) FindExpenseByID(id domain.ExpenseID) (domain.Expense, error) {
 	var exp Expense
-	err := repo.db.Preload("Tags").First(&exp, id).Error
+	err := repo.owned().Preload("Tags").First(&exp, id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		err = store.ErrExpenseNotFound
 	}
@@ -35,7 +35,9 @@ func (repo Repository) SaveExpense(exp domain.Expense) (domain.ExpenseID, error)
 		Value:      exp.Value,
 		Unit:       exp.Unit,
 		ActivityID: exp.ActivityID,
+		Status:     exp.Status,
 		Tags:       tags,
+		UserID:     repo.owner,
 	}
 	res := repo.db.Create(&dbExp)
 	return domain.ExpenseID(dbExp.ID), res.Error
@@ -45,7 +47,21 @@ func (repo Repository) SaveExpense(exp domain.Expense) (domain.ExpenseID, error)
 // greater than or equal to provided time
 func (repo Repository) FindExpensesByTime(t time.Time) ([]domain.Expense, error) {
 	res := []Expense{}
-	if err := repo.db.Where("time >= ?", t).Order("time DESC").Find(&res).Error; err != nil {
+	if err := repo.owned().Preload("Tags").Where("time >= ?", t).Order("time DESC").Find(&res).Error; err != nil {
+		return []domain.Expense{}, err
+	}
+	expenses := make([]domain.Expense, len(res))
+	for i, exp := range res {
+		expenses[i] = exp.ToDomain()
+	}
+	return expenses, nil
+}
+
+// FindExpensesByTimeRange returns expenses with Time field
+// between from and to ( inclusive ) with their tags
+func (repo Repository) FindExpensesByTimeRange(from time.Time, to time.Time) ([]domain.Expense, error) {
+	res := []Expense{}
+	if err := repo.owned().Preload("Tags").Where("time >= ? AND time <= ?", from, to).Order("time DESC").Find(&res).Error; err != nil {
 		return []domain.Expense{}, err
 	}
 	expenses := make([]domain.Expense, len(res))
@@ -58,7 +74,7 @@ func (repo Repository) FindExpensesByTime(t time.Time) ([]domain.Expense, error)
 // FindExpensesByTag returns expenses that have the provided tag in their Tags field
 func (repo Repository) FindExpensesByTag(tid domain.TagID) ([]domain.Expense, error) {
 	var tag Tag
-	if err := repo.db.Preload("Expenses", func(db *gorm.DB) *gorm.DB {
+	if err := repo.owned().Preload("Expenses", func(db *gorm.DB) *gorm.DB {
 		return db.Order("expenses.time DESC") // Order expenses by time
 	}).First(&tag, tid).Error; err != nil {
 		return []domain.Expense{}, err
@@ -73,7 +89,7 @@ func (repo Repository) FindExpensesByTag(tid domain.TagID) ([]domain.Expense, er
 // FindExpensesByActivity returns expenses with ActivityID matching given id
 func (repo Repository) FindExpensesByActivity(aid domain.ActivityID) ([]domain.Expense, error) {
 	var act Activity
-	if err := repo.db.Preload("Expenses", func(db *gorm.DB) *gorm.DB {
+	if err := repo.owned().Preload("Expenses", func(db *gorm.DB) *gorm.DB {
 		return db.Order("expenses.time DESC") // Order expenses by time
 	}).First(&act, aid).Error; err != nil {
 		return []domain.Expense{}, err
@@ -85,9 +101,9 @@ func (repo Repository) FindExpensesByActivity(aid domain.ActivityID) ([]domain.E
 	return expenses, nil
 }
 
-// DeleteExpense deletes expense from DB
+// DeleteExpense moves expense to the trash
 func (repo Repository) DeleteExpense(id domain.ExpenseID) error {
-	res := repo.db.Delete(&Expense{ID: id})
+	res := repo.owned().Delete(&Expense{ID: id})
 	if res.Error != nil {
 		return res.Error
 	}
@@ -97,9 +113,9 @@ func (repo Repository) DeleteExpense(id domain.ExpenseID) error {
 	return nil
 }
 
-// DeleteExpensesByActivity deletes all expenses with given ActivityID
+// DeleteExpensesByActivity moves all expenses with given ActivityID to the trash
 func (repo Repository) DeleteExpensesByActivity(aid domain.ActivityID) error {
-	if err := repo.db.Where("activity_id = ?", aid).Delete(&Expense{}).Error; err != nil {
+	if err := repo.owned().Where("activity_id = ?", aid).Delete(&Expense{}).Error; err != nil {
 		return err
 	}
 	return nil
@@ -107,20 +123,22 @@ func (repo Repository) DeleteExpensesByActivity(aid domain.ActivityID) error {
 
 // EditExpense edits given expense in memory
 func (repo Repository) EditExpense(exp domain.Expense) error {
-	if err := repo.db.First(&Expense{}, exp.ID).Error; err != nil {
+	if err := repo.owned().First(&Expense{}, exp.ID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return store.ErrExpenseNotFound
 		}
 		return err
 	}
 	// Update Primary fields
-	res := repo.db.Save(&Expense{
+	res := repo.owned().Save(&Expense{
 		ID:         exp.ID,
 		Label:      exp.Label,
 		Time:       exp.Time,
 		Value:      exp.Value,
 		Unit:       exp.Unit,
 		ActivityID: exp.ActivityID,
+		Status:     exp.Status,
+		UserID:     repo.owner,
 	})
 	if res.RowsAffected != 1 {
 		return fmt.Errorf("%d Rows were affected", res.RowsAffected)
@@ -137,3 +155,53 @@ func (repo Repository) EditExpense(exp domain.Expense) error {
 	err := repo.db.Model(&Expense{ID: exp.ID}).Association("Tags").Replace(tags)
 	return err
 }
+
+// findExpensesPage returns the requested page of expenses returned by the given query
+func (repo Repository) findExpensesPage(query *gorm.DB, p store.PageRequest) ([]domain.Expense, store.Page, error) {
+	if p.Status != "" {
+		query = query.Where("expenses.status = ?", p.Status)
+	}
+	query, total, err := paginate(query, "expenses", expenseSortColumns, p)
+	if err != nil {
+		return []domain.Expense{}, store.Page{}, err
+	}
+	res := []Expense{}
+	if err := query.Preload("Tags").Find(&res).Error; err != nil {
+		return []domain.Expense{}, store.Page{}, err
+	}
+	page := nextPage(p, total, len(res), func(last int) store.Cursor {
+		exp := res[last]
+		switch p.Sort {
+		case store.SortByLabel:
+			return store.NewCursor(exp.Label, uint(exp.ID))
+		case store.SortByValue:
+			return store.NewCursor(int64(exp.Value), uint(exp.ID))
+		default:
+			return store.NewCursor(exp.Time, uint(exp.ID))
+		}
+	})
+	if len(res) > p.Limit {
+		res = res[:p.Limit]
+	}
+	expenses := make([]domain.Expense, len(res))
+	for i, exp := range res {
+		expenses[i] = exp.ToDomain()
+	}
+	return expenses, page, nil
+}
+
+// FindExpensesByTimeRangePage returns the requested page of expenses
+// with Time field between from and to ( inclusive )
+func (repo Repository) FindExpensesByTimeRangePage(from time.Time, to time.Time, p store.PageRequest) ([]domain.Expense, store.Page, error) {
+	query := repo.owned().Model(&Expense{}).Where("expenses.time >= ? AND expenses.time <= ?", from, to)
+	return repo.findExpensesPage(query, p)
+}
+
+// FindExpensesByTagPage returns the requested page of expenses
+// that have the provided tag in their Tags field
+func (repo Repository) FindExpensesByTagPage(tid domain.TagID, p store.PageRequest) ([]domain.Expense, store.Page, error) {
+	query := repo.owned().Model(&Expense{}).
+		Joins("JOIN expense_tags ON expense_tags.expense_id = expenses.id").
+		Where("expense_tags.tag_id = ?", tid)
+	return repo.findExpensesPage(query, p)
+}
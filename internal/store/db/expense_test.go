@@ -121,6 +121,48 @@ func TestFindExpensesByTime(t *testing.T) {
 	}
 }
 
+func TestFindExpensesByTimeRange(t *testing.T) {
+	// Create test 100 expenses:
+	// one for each day starting from today going backward
+	defer clearDB()
+	const nbrExpenses int = 100
+	expenses := make([]db.Expense, nbrExpenses)
+	now := time.Now()
+	for i := 0; i < nbrExpenses; i++ {
+		expenses[i] = db.Expense{
+			Label:      fmt.Sprintf("Test Expense %d", i),
+			Time:       now.AddDate(0, 0, -i),
+			Value:      10,
+			Unit:       "eu",
+			ActivityID: 0,
+			Tags:       []db.Tag{},
+		}
+	}
+	if err := grmDb.Create(&expenses).Error; err != nil {
+		t.Fatalf("\nError while creating test expenses:\n  %v", err)
+	}
+	// Test Get Expenses from 10 days ago to 5 days ago (Should be 6 expenses)
+	minTime := now.AddDate(0, 0, -10)
+	maxTime := now.AddDate(0, 0, -5)
+	nbrExpected := 6
+	res, err := repo.FindExpensesByTimeRange(minTime, maxTime)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(res) != nbrExpected {
+		t.Fatalf("\nExpecting %d expenses\nReturned %d expenses", nbrExpected, len(res))
+	}
+	// Test Range & Order by time
+	for i, elem := range res {
+		if elem.Time.Before(minTime) || elem.Time.After(maxTime) {
+			t.Fatalf("\nElement in result has time field out of range:\n\t%v", elem)
+		}
+		if i < len(res)-1 && elem.Time.Before(res[i+1].Time) {
+			t.Fatal("\nExpenses not ordered by time")
+		}
+	}
+}
+
 func TestFindExpensesByTag(t *testing.T) {
 	// Create test expenses & tags:
 	defer clearDB()
@@ -410,3 +452,98 @@ func TestEditExpense(t *testing.T) {
 		}
 	})
 }
+
+func TestFindExpensesByTimeRangePage(t *testing.T) {
+	// Create test expenses: some of them sharing same value
+	defer clearDB()
+	now := time.Now()
+	expenses := []db.Expense{
+		{ID: 1, Label: "Exp A", Time: now.AddDate(0, 0, -1), Value: 1000, Unit: "eu"},
+		{ID: 2, Label: "Exp B", Time: now.AddDate(0, 0, -2), Value: 500, Unit: "eu"},
+		{ID: 3, Label: "Exp C", Time: now.AddDate(0, 0, -3), Value: 1000, Unit: "eu"},
+		{ID: 4, Label: "Exp D", Time: now.AddDate(0, 0, -4), Value: 750, Unit: "eu"},
+		{ID: 5, Label: "Exp E", Time: now.AddDate(0, 0, -5), Value: 1000, Unit: "eu"},
+		{ID: 6, Label: "Exp out of range", Time: now.AddDate(0, -1, 0), Value: 100, Unit: "eu"},
+	}
+	if err := grmDb.Create(&expenses).Error; err != nil {
+		t.Fatalf("\nError while creating test expenses:\n  %v", err)
+	}
+	tests := map[string]struct {
+		sort        store.SortField
+		desc        bool
+		expectedIDs []domain.ExpenseID // In order !
+	}{
+		"Time Desc":  {sort: store.SortByTime, desc: true, expectedIDs: []domain.ExpenseID{1, 2, 3, 4, 5}},
+		"Time Asc":   {sort: store.SortByTime, desc: false, expectedIDs: []domain.ExpenseID{5, 4, 3, 2, 1}},
+		"Value Desc": {sort: store.SortByValue, desc: true, expectedIDs: []domain.ExpenseID{5, 3, 1, 4, 2}},
+		"Label Asc":  {sort: store.SortByLabel, desc: false, expectedIDs: []domain.ExpenseID{1, 2, 3, 4, 5}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Walk through pages of 2 expenses
+			ids := []domain.ExpenseID{}
+			p := store.PageRequest{Limit: 2, Sort: test.sort, Desc: test.desc}
+			for i := 0; i < 5; i++ {
+				res, page, err := repo.FindExpensesByTimeRangePage(now.AddDate(0, 0, -10), now, p)
+				if err != nil {
+					t.Fatalf("\nUnexpected Error: %v", err)
+				}
+				if page.Total != len(test.expectedIDs) {
+					t.Fatalf("\nExpected Total: %d\nReturned Total: %d", len(test.expectedIDs), page.Total)
+				}
+				for _, exp := range res {
+					ids = append(ids, exp.ID)
+				}
+				if page.NextCursor == "" {
+					break
+				}
+				p.Cursor = page.NextCursor
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(test.expectedIDs) {
+				t.Fatalf("\nExpected: %v\nReturned: %v", test.expectedIDs, ids)
+			}
+		})
+	}
+	// Subcase: Invalid Cursor
+	t.Run("Invalid Cursor", func(t *testing.T) {
+		p := store.PageRequest{Limit: 2, Sort: store.SortByTime, Cursor: "not-a-cursor"}
+		if _, _, err := repo.FindExpensesByTimeRangePage(now.AddDate(0, 0, -10), now, p); err != store.ErrCursorInvalid {
+			t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrCursorInvalid, err)
+		}
+	})
+}
+
+func TestFindExpensesByTagPage(t *testing.T) {
+	defer clearDB()
+	tag := db.Tag{ID: 11, Name: "test-tag-1"}
+	if err := grmDb.Create(&tag).Error; err != nil {
+		t.Fatalf("\nError while creating test tag:\n  %v", err)
+	}
+	now := time.Now()
+	expenses := []db.Expense{
+		{ID: 1, Label: "Exp A", Time: now.AddDate(0, 0, -1), Value: 1000, Unit: "eu", Tags: []db.Tag{tag}},
+		{ID: 2, Label: "Exp B", Time: now.AddDate(0, 0, -2), Value: 500, Unit: "eu"},
+		{ID: 3, Label: "Exp C", Time: now.AddDate(0, 0, -3), Value: 1000, Unit: "eu", Tags: []db.Tag{tag}},
+		{ID: 4, Label: "Exp D", Time: now.AddDate(0, 0, -4), Value: 750, Unit: "eu", Tags: []db.Tag{tag}},
+	}
+	if err := grmDb.Create(&expenses).Error; err != nil {
+		t.Fatalf("\nError while creating test expenses:\n  %v", err)
+	}
+	res, page, err := repo.FindExpensesByTagPage(tag.ID, store.PageRequest{Limit: 2, Sort: store.SortByTime, Desc: true})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(res) != 2 || res[0].ID != 1 || res[1].ID != 3 || page.Total != 3 || page.NextCursor == "" {
+		t.Fatalf("\nUnexpected first page: %v ( %+v )", res, page)
+	}
+	if len(res[0].Tags) != 1 || res[0].Tags[0].Name != tag.Name {
+		t.Fatalf("\nExpected tags of expenses to be fetched\nReturned: %v", res[0].Tags)
+	}
+	res, page, err = repo.FindExpensesByTagPage(tag.ID, store.PageRequest{Limit: 2, Sort: store.SortByTime, Desc: true, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(res) != 1 || res[0].ID != 4 || page.NextCursor != "" {
+		t.Fatalf("\nUnexpected last page: %v ( %+v )", res, page)
+	}
+}
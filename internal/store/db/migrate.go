@@ -0,0 +1,117 @@
+package db
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"gorm.io/gorm"
+)
+
+// legacyExpense is an expense row with the float value column
+// used before amounts were stored in minor units.
+type legacyExpense struct {
+	ID    domain.ExpenseID
+	Value float64
+	Unit  string
+}
+
+// MigrateExpenseAmounts converts the float "value" column of the expenses table
+// to the integer "amount" column holding values in the minor unit of their currency.
+// Legacy free-text units are converted to ISO-4217 codes: the migration
+// fails without changes if a unit is unknown, as its minor unit can not be known.
+// Values were float32 so they are first formatted with the shortest float32
+// representation ( ex: 13.399999618 is 13.4 ) then converted without rounding drift.
+// It does nothing if the table was already migrated.
+func MigrateExpenseAmounts(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&Expense{}) || !migrator.HasColumn(&Expense{}, "value") {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if !tx.Migrator().HasColumn(&Expense{}, "amount") {
+			if err := tx.Migrator().AddColumn(&Expense{}, "Value"); err != nil {
+				return err
+			}
+		}
+		rows := []legacyExpense{}
+		if err := tx.Table("expenses").Select("id, value, unit").Find(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			cur, err := domain.ParseLegacyCurrency(row.Unit)
+			if err != nil {
+				return fmt.Errorf("expense %d has unknown unit %q ( set it to an ISO-4217 code and retry ): %w", row.ID, row.Unit, err)
+			}
+			amount, err := legacyAmount(row.Value, cur)
+			if err != nil {
+				return err
+			}
+			if err := tx.Table("expenses").Where("id = ?", row.ID).Updates(map[string]interface{}{"amount": amount, "unit": cur}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Migrator().DropColumn(&Expense{}, "value")
+	})
+}
+
+// legacyAmount converts a legacy float32 value to an amount in the given currency.
+// Values with more decimals than the currency are rounded to the nearest minor unit.
+func legacyAmount(val float64, cur domain.Currency) (domain.Amount, error) {
+	str := strconv.FormatFloat(val, 'f', -1, 32)
+	amount, err := domain.ParseAmount(str, cur)
+	if err != domain.ErrAmountPrecision {
+		return amount, err
+	}
+	return domain.Amount(math.Round(val * math.Pow10(cur.MinorUnits()))), nil
+}
+
+// legacyRateIndex is the unique index of exchange rates created before rates had owners
+const legacyRateIndex string = "idx_rate_pair_date"
+
+// MigrateRateOwners drops the unique index of exchange rates on their currencies
+// and date created before rates had owners: it would keep users from storing
+// rates of the same currencies and date. It must run before auto-migrating
+// the exchange rates, which creates the index including their owner.
+// It does nothing if the index does not exist.
+func MigrateRateOwners(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&ExchangeRate{}) || !migrator.HasIndex(&ExchangeRate{}, legacyRateIndex) {
+		return nil
+	}
+	return migrator.DropIndex(&ExchangeRate{}, legacyRateIndex)
+}
+
+// ownedModels are the models of the rows belonging to a user
+var ownedModels []interface{} = []interface{}{&Tag{}, &Activity{}, &Expense{}, &Budget{}, &Template{}, &AuditEvent{}, &ExchangeRate{}}
+
+// MigrateLegacyUser gives the rows stored before user accounts existed
+// ( owned by no user ) to the user with given name.
+// The user is created with given password hash if it does not exist.
+// It does nothing if there are no such rows.
+func MigrateLegacyUser(db *gorm.DB, name string, hash string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		legacy := false
+		for _, model := range ownedModels {
+			var count int64
+			if err := tx.Unscoped().Model(model).Where("user_id = 0").Count(&count).Error; err != nil {
+				return err
+			}
+			legacy = legacy || count > 0
+		}
+		if !legacy {
+			return nil
+		}
+		usr := User{Name: name, Hash: hash}
+		if err := tx.Where(User{Name: name}).FirstOrCreate(&usr).Error; err != nil {
+			return err
+		}
+		for _, model := range ownedModels {
+			if err := tx.Unscoped().Model(model).Where("user_id = 0").Update("user_id", usr.ID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
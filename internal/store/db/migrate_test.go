@@ -0,0 +1,155 @@
+package db_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store/db"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMigrateExpenseAmounts(t *testing.T) {
+	legacyDb, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	// Legacy table with float32 values
+	type Expense struct {
+		ID    domain.ExpenseID
+		Label string
+		Value float32
+		Unit  string
+	}
+	if err := legacyDb.AutoMigrate(&Expense{}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	legacyDb.Create(&[]Expense{
+		{ID: 1, Label: "Exp A", Value: 13.4, Unit: "eur"},
+		{ID: 2, Label: "Exp B", Value: 0.1, Unit: "EUR"},
+		{ID: 3, Label: "Exp C", Value: 1500, Unit: "JPY"},
+		{ID: 4, Label: "Exp D", Value: 9.99, Unit: "eu"},
+		{ID: 5, Label: "Exp E", Value: 1.0049, Unit: "EUR"},
+		{ID: 6, Label: "Exp F", Value: 25.5, Unit: "dh"},
+		{ID: 7, Label: "Exp G", Value: 3, Unit: "$"},
+	})
+	if err := db.MigrateExpenseAmounts(legacyDb); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if legacyDb.Migrator().HasColumn(&db.Expense{}, "value") {
+		t.Fatal("\nExpected value column to be dropped")
+	}
+	expected := map[domain.ExpenseID]domain.Amount{1: 1340, 2: 10, 3: 1500, 4: 999, 5: 100, 6: 2550, 7: 300}
+	expectedUnits := map[domain.ExpenseID]domain.Currency{1: "EUR", 2: "EUR", 3: "JPY", 4: "EUR", 5: "EUR", 6: "MAD", 7: "USD"}
+	res := []db.Expense{}
+	if err := legacyDb.Unscoped().Find(&res).Error; err != nil { // Legacy table has no deleted_at column
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	for _, exp := range res {
+		if exp.Value != expected[exp.ID] {
+			t.Fatalf("\nExpected expense %d amount: %d\nReturned: %d", exp.ID, expected[exp.ID], exp.Value)
+		}
+		if exp.Unit != expectedUnits[exp.ID] {
+			t.Fatalf("\nExpected expense %d unit: %s\nReturned: %s", exp.ID, expectedUnits[exp.ID], exp.Unit)
+		}
+	}
+	// Subcase: Migrating twice does nothing
+	if err := db.MigrateExpenseAmounts(legacyDb); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+}
+
+func TestMigrateExpenseAmountsUnknownUnit(t *testing.T) {
+	legacyDb, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	type Expense struct {
+		ID    domain.ExpenseID
+		Label string
+		Value float32
+		Unit  string
+	}
+	if err := legacyDb.AutoMigrate(&Expense{}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	legacyDb.Create(&[]Expense{
+		{ID: 1, Label: "Exp A", Value: 13.4, Unit: "EUR"},
+		{ID: 2, Label: "Exp B", Value: 500, Unit: "bitcoins"},
+	})
+	if err := db.MigrateExpenseAmounts(legacyDb); !errors.Is(err, domain.ErrCurrencyInvalid) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", domain.ErrCurrencyInvalid, err)
+	}
+	// Nothing was migrated
+	if !legacyDb.Migrator().HasColumn(&Expense{}, "value") {
+		t.Fatal("\nExpected value column to be kept")
+	}
+	var exp Expense
+	if err := legacyDb.First(&exp, 1).Error; err != nil || exp.Unit != "EUR" || exp.Value != 13.4 {
+		t.Fatalf("\nExpected expense 1 unchanged\nReturned: %v, %v", exp, err)
+	}
+}
+
+func TestMigrateLegacyUser(t *testing.T) {
+	defer clearDB()
+	legacy := db.Tag{ID: 1, Name: "legacy"}
+	if err := grmDb.Create(&legacy).Error; err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := db.MigrateLegacyUser(grmDb, "admin", "hash"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	usr, err := repo.FindUserByName("admin")
+	if err != nil || usr.PasswordHash != "hash" {
+		t.Fatalf("\nExpected admin user\nReturned: %v, %v", usr, err)
+	}
+	if _, err := repo.FindTagByID(legacy.ID); err == nil {
+		t.Fatal("\nExpected legacy tag to belong to admin")
+	}
+	if _, err := repo.ForUser(usr.ID).FindTagByID(legacy.ID); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	// Subcase: Migrating twice does nothing
+	if err := db.MigrateLegacyUser(grmDb, "admin", "other"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if users, _ := repo.FindAllUsers(); len(users) != 1 || users[0].PasswordHash != "hash" {
+		t.Fatalf("\nExpected only the admin user\nReturned: %v", users)
+	}
+}
+
+func TestMigrateRateOwners(t *testing.T) {
+	legacyDb, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	// Legacy table with a unique index on currencies & date
+	type ExchangeRate struct {
+		ID    uint
+		Base  domain.Currency `gorm:"uniqueIndex:idx_rate_pair_date"`
+		Quote domain.Currency `gorm:"uniqueIndex:idx_rate_pair_date"`
+		Date  time.Time       `gorm:"uniqueIndex:idx_rate_pair_date"`
+		Rate  float64
+	}
+	if err := legacyDb.AutoMigrate(&ExchangeRate{}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := db.MigrateRateOwners(legacyDb); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := legacyDb.AutoMigrate(&db.ExchangeRate{}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	day := time.Date(2020, 11, 19, 0, 0, 0, 0, time.UTC)
+	for _, usr := range []domain.UserID{1, 2} {
+		if _, err := db.NewRepository(legacyDb).ForUser(usr).SaveExchangeRates([]domain.ExchangeRate{{Base: "EUR", Quote: "USD", Date: day, Rate: 1.2}}); err != nil {
+			t.Fatalf("\nUnexpected Error while saving rate of user %d: %v", usr, err)
+		}
+	}
+	// Running it again does nothing
+	if err := db.MigrateRateOwners(legacyDb); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+}
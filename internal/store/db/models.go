@@ -1,20 +1,24 @@
 package db
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/elhamza90/lifelog/internal/domain"
+	"gorm.io/gorm"
 )
 
 // Tag Model
 type Tag struct {
 	ID         domain.TagID
 	Name       string
-	Expenses   []*Expense  `gorm:"many2many:expense_tags;"`
-	Activities []*Activity `gorm:"many2many:activity_tags;"`
+	Expenses   []*Expense    `gorm:"many2many:expense_tags;"`
+	Activities []*Activity   `gorm:"many2many:activity_tags;"`
+	UserID     domain.UserID `gorm:"not null;default:0;index"` // Owner
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"` // Set when moved to the trash
 }
 
 // String returns a one line string representation of a Tag
@@ -36,12 +40,15 @@ type Expense struct {
 	ID         domain.ExpenseID
 	Label      string
 	Time       time.Time
-	Value      float32
-	Unit       string
+	Value      domain.Amount `gorm:"column:amount"` // In the minor unit of the currency
+	Unit       domain.Currency
 	ActivityID domain.ActivityID // Foreign Key
+	Status     domain.Status     `gorm:"default:done"`
 	Tags       []Tag             `gorm:"many2many:expense_tags;"`
+	UserID     domain.UserID     `gorm:"not null;default:0;index"` // Owner
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"` // Set when moved to the trash
 }
 
 // ToDomain converts calling Expense to Domain Expense
@@ -57,13 +64,14 @@ func (exp Expense) ToDomain() domain.Expense {
 		Value:      exp.Value,
 		Unit:       exp.Unit,
 		ActivityID: exp.ActivityID,
+		Status:     exp.Status,
 		Tags:       tags,
 	}
 }
 
 // String returns a one line string representation of a Expense
 func (exp Expense) String() string {
-	return fmt.Sprintf("[ %d | %s ( %.2f %s) | %s | (%d tags) ]", exp.ID, exp.Label, exp.Value, exp.Unit, exp.Time.Format("2006-01-02 15:04"), len(exp.Tags))
+	return fmt.Sprintf("[ %d | %s ( %s %s) | %s | (%d tags) ]", exp.ID, exp.Label, exp.Value.Format(exp.Unit), exp.Unit, exp.Time.Format("2006-01-02 15:04"), len(exp.Tags))
 }
 
 // TableName specifies the name of the table for the expense model
@@ -77,10 +85,13 @@ type Activity struct {
 	Desc      string
 	Time      time.Time
 	Duration  time.Duration
-	Tags      []Tag `gorm:"many2many:activity_tags;"`
+	Status    domain.Status `gorm:"default:done"`
+	Tags      []Tag         `gorm:"many2many:activity_tags;"`
 	Expenses  []Expense
+	UserID    domain.UserID `gorm:"not null;default:0;index"` // Owner
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"` // Set when moved to the trash
 }
 
 // String returns a one line string representation of a Activity
@@ -104,6 +115,282 @@ func (act Activity) ToDomain() domain.Activity {
 		Desc:     act.Desc,
 		Time:     act.Time,
 		Duration: act.Duration,
+		Status:   act.Status,
 		Tags:     tags,
 	}
 }
+
+// ExchangeRate Model
+type ExchangeRate struct {
+	ID        uint
+	Base      domain.Currency `gorm:"uniqueIndex:idx_rate_owner_pair_date"`
+	Quote     domain.Currency `gorm:"uniqueIndex:idx_rate_owner_pair_date"`
+	Date      time.Time       `gorm:"uniqueIndex:idx_rate_owner_pair_date"`
+	Rate      float64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	UserID    domain.UserID `gorm:"not null;default:0;uniqueIndex:idx_rate_owner_pair_date"` // Owner
+}
+
+// String returns a one line string representation of an ExchangeRate
+func (er ExchangeRate) String() string {
+	return fmt.Sprintf("[ %s | 1 %s = %f %s ]", er.Date.Format("2006-01-02"), er.Base, er.Rate, er.Quote)
+}
+
+// TableName specifies the name of the table for the exchange rate model
+func (er ExchangeRate) TableName() string { return "exchange_rates" }
+
+// ToDomain converts calling ExchangeRate to Domain ExchangeRate
+func (er ExchangeRate) ToDomain() domain.ExchangeRate {
+	return domain.ExchangeRate{
+		Base:  er.Base,
+		Quote: er.Quote,
+		Date:  er.Date.UTC(),
+		Rate:  er.Rate,
+	}
+}
+
+// Budget Model
+type Budget struct {
+	ID        domain.BudgetID
+	TagID     domain.TagID `gorm:"index"` // 0 means all expenses
+	Period    domain.Period
+	Amount    domain.Amount // In the minor unit of the currency
+	Currency  domain.Currency
+	UserID    domain.UserID `gorm:"not null;default:0;index"` // Owner
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// String returns a one line string representation of a Budget
+func (b Budget) String() string {
+	return fmt.Sprintf("[ %d | tag %d | %s %s per %s ]", b.ID, b.TagID, b.Amount.Format(b.Currency), b.Currency, b.Period)
+}
+
+// TableName specifies the name of the table for the budget model
+func (b Budget) TableName() string { return "budgets" }
+
+// ToDomain converts calling Budget to Domain Budget
+func (b Budget) ToDomain() domain.Budget {
+	return domain.Budget{
+		ID:       b.ID,
+		TagID:    b.TagID,
+		Period:   b.Period,
+		Amount:   b.Amount,
+		Currency: b.Currency,
+	}
+}
+
+// Template Model
+// It stores the recurrence rule and the fields of the model expense or activity.
+type Template struct {
+	ID         domain.TemplateID
+	Kind       domain.TemplateKind
+	Freq       domain.Frequency
+	Interval   int `gorm:"column:interval_count"`
+	Start      time.Time
+	Until      *time.Time // Null means forever
+	Label      string
+	Place      string
+	Desc       string
+	Value      domain.Amount `gorm:"column:amount"` // In the minor unit of the currency
+	Unit       domain.Currency
+	Duration   time.Duration
+	ActivityID domain.ActivityID
+	Tags       []Tag         `gorm:"many2many:template_tags;"`
+	UserID     domain.UserID `gorm:"not null;default:0;index"` // Owner
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// String returns a one line string representation of a Template
+func (tmpl Template) String() string {
+	return fmt.Sprintf("[ %d | %s %s | every %d %s ]", tmpl.ID, tmpl.Kind, tmpl.Label, tmpl.Interval, tmpl.Freq)
+}
+
+// TableName specifies the name of the table for the template model
+func (tmpl Template) TableName() string { return "templates" }
+
+// ToDomain converts calling Template to Domain Template
+func (tmpl Template) ToDomain() domain.Template {
+	tags := []domain.Tag{}
+	for _, t := range tmpl.Tags {
+		tags = append(tags, t.ToDomain())
+	}
+	res := domain.Template{
+		ID:   tmpl.ID,
+		Kind: tmpl.Kind,
+		Rule: domain.RecurrenceRule{
+			Freq:     tmpl.Freq,
+			Interval: tmpl.Interval,
+			Start:    tmpl.Start,
+		},
+	}
+	if tmpl.Until != nil {
+		res.Rule.Until = *tmpl.Until
+	}
+	switch tmpl.Kind {
+	case domain.TemplateActivity:
+		res.Activity = domain.Activity{
+			Label:    tmpl.Label,
+			Place:    tmpl.Place,
+			Desc:     tmpl.Desc,
+			Duration: tmpl.Duration,
+			Tags:     tags,
+		}
+	default:
+		res.Expense = domain.Expense{
+			Label:      tmpl.Label,
+			Value:      tmpl.Value,
+			Unit:       tmpl.Unit,
+			ActivityID: tmpl.ActivityID,
+			Tags:       tags,
+		}
+	}
+	return res
+}
+
+// Occurrence Model
+// A template occurrence is recorded once at most.
+type Occurrence struct {
+	ID         uint
+	TemplateID domain.TemplateID `gorm:"uniqueIndex:idx_occurrence_template_time"`
+	Time       time.Time         `gorm:"uniqueIndex:idx_occurrence_template_time"`
+	Skipped    bool
+	ExpenseID  domain.ExpenseID
+	ActivityID domain.ActivityID
+	CreatedAt  time.Time
+}
+
+// String returns a one line string representation of an Occurrence
+func (occ Occurrence) String() string {
+	return fmt.Sprintf("[ template %d | %s | skipped: %t ]", occ.TemplateID, occ.Time.Format("2006-01-02 15:04"), occ.Skipped)
+}
+
+// TableName specifies the name of the table for the occurrence model
+func (occ Occurrence) TableName() string { return "occurrences" }
+
+// ToDomain converts calling Occurrence to Domain Occurrence
+func (occ Occurrence) ToDomain() domain.Occurrence {
+	return domain.Occurrence{
+		TemplateID: occ.TemplateID,
+		Time:       occ.Time,
+		Skipped:    occ.Skipped,
+		ExpenseID:  occ.ExpenseID,
+		ActivityID: occ.ActivityID,
+	}
+}
+
+// AuditEvent Model
+// Snapshots are stored as JSON text.
+type AuditEvent struct {
+	ID        domain.AuditEventID
+	Entity    domain.AuditEntity `gorm:"index:idx_audit_entity"`
+	EntityID  uint               `gorm:"index:idx_audit_entity"`
+	Action    domain.AuditAction
+	Principal string
+	Time      time.Time
+	Before    string
+	After     string
+	UserID    domain.UserID `gorm:"not null;default:0;index"` // Owner
+}
+
+// TableName specifies the name of the table for the audit event model
+func (ev AuditEvent) TableName() string { return "audit_events" }
+
+// ToDomain converts calling AuditEvent to Domain AuditEvent
+func (ev AuditEvent) ToDomain() domain.AuditEvent {
+	res := domain.AuditEvent{
+		ID:        ev.ID,
+		Entity:    ev.Entity,
+		EntityID:  ev.EntityID,
+		Action:    ev.Action,
+		Principal: ev.Principal,
+		Time:      ev.Time,
+	}
+	if ev.Before != "" {
+		res.Before = json.RawMessage(ev.Before)
+	}
+	if ev.After != "" {
+		res.After = json.RawMessage(ev.After)
+	}
+	return res
+}
+
+// User Model
+type User struct {
+	ID        domain.UserID
+	Name      string `gorm:"uniqueIndex"`
+	Hash      string // bcrypt hash of the password
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName specifies the name of the table for the user model
+func (usr User) TableName() string { return "users" }
+
+// ToDomain converts calling User to Domain User
+func (usr User) ToDomain() domain.User {
+	return domain.User{
+		ID:           usr.ID,
+		Name:         usr.Name,
+		PasswordHash: usr.Hash,
+	}
+}
+
+// RefreshToken Model
+type RefreshToken struct {
+	ID        string        `gorm:"primaryKey"`
+	Family    string        `gorm:"index"`
+	UserID    domain.UserID `gorm:"index"`
+	ExpiresAt time.Time
+	Used      bool
+	Revoked   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName specifies the name of the table for the refresh token model
+func (rt RefreshToken) TableName() string { return "refresh_tokens" }
+
+// ToDomain converts calling RefreshToken to Domain RefreshToken
+func (rt RefreshToken) ToDomain() domain.RefreshToken {
+	return domain.RefreshToken{
+		ID:        rt.ID,
+		Family:    rt.Family,
+		UserID:    rt.UserID,
+		ExpiresAt: rt.ExpiresAt,
+		Used:      rt.Used,
+		Revoked:   rt.Revoked,
+	}
+}
+
+// APIKey Model
+// Only the hash of the key is stored.
+type APIKey struct {
+	ID        domain.APIKeyID `gorm:"primaryKey"`
+	UserID    domain.UserID   `gorm:"index"`
+	Name      string
+	Prefix    string
+	Hash      string `gorm:"uniqueIndex"`
+	Scope     domain.APIKeyScope
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// TableName specifies the name of the table for the API key model
+func (k APIKey) TableName() string { return "api_keys" }
+
+// ToDomain converts calling APIKey to Domain APIKey
+func (k APIKey) ToDomain() domain.APIKey {
+	return domain.APIKey{
+		ID:        k.ID,
+		UserID:    k.UserID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Hash:      k.Hash,
+		Scope:     k.Scope,
+		ExpiresAt: k.ExpiresAt,
+		CreatedAt: k.CreatedAt,
+	}
+}
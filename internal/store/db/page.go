@@ -0,0 +1,66 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/elhamza90/lifelog/internal/store"
+	"gorm.io/gorm"
+)
+
+// paginate counts the items returned by the given query then restricts it
+// to the requested page using keyset pagination on the sort column and the ID.
+// One more item than the page limit is queried to know if there is a next page.
+// sortColumns maps the sort fields allowed for the table to its columns.
+func paginate(query *gorm.DB, table string, sortColumns map[store.SortField]string, p store.PageRequest) (*gorm.DB, int, error) {
+	var total int64
+	if err := query.Session(&gorm.Session{WithConditions: true}).Count(&total).Error; err != nil {
+		return query, 0, err
+	}
+	col := table + "." + sortColumns[p.Sort]
+	idCol := table + ".id"
+	dir, cmp := "ASC", ">"
+	if p.Desc {
+		dir, cmp = "DESC", "<"
+	}
+	if p.Cursor != "" {
+		c, err := store.DecodeCursor(p.Cursor)
+		if err != nil {
+			return query, 0, err
+		}
+		val, err := c.SortValue(p.Sort)
+		if err != nil {
+			return query, 0, err
+		}
+		cond := fmt.Sprintf("((%s %s ?) OR (%s = ? AND %s %s ?))", col, cmp, col, idCol, cmp)
+		query = query.Where(cond, val, val, c.ID)
+	}
+	query = query.Order(col + " " + dir).Order(idCol + " " + dir).Limit(p.Limit + 1)
+	return query, int(total), nil
+}
+
+// nextPage returns page information given the total count of items
+// and the number of fetched items. cursor is called to construct
+// the cursor of the last item of the page when there is a next page.
+func nextPage(p store.PageRequest, total int, nbrFetched int, cursor func(last int) store.Cursor) store.Page {
+	page := store.Page{Total: total}
+	if nbrFetched > p.Limit {
+		page.NextCursor = cursor(p.Limit - 1).Encode()
+	}
+	return page
+}
+
+// Sort columns of tables
+var (
+	activitySortColumns = map[store.SortField]string{
+		store.SortByTime:  "time",
+		store.SortByLabel: "label",
+	}
+	expenseSortColumns = map[store.SortField]string{
+		store.SortByTime:  "time",
+		store.SortByLabel: "label",
+		store.SortByValue: "amount",
+	}
+	tagSortColumns = map[store.SortField]string{
+		store.SortByLabel: "name",
+	}
+)
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SaveExchangeRates stores the given exchange rates of the owner in db.
+// A rate already stored by the owner for the same currencies and date is replaced.
+// It returns the number of saved rates.
+func (repo Repository) SaveExchangeRates(rates []domain.ExchangeRate) (int, error) {
+	if len(rates) == 0 {
+		return 0, nil
+	}
+	dbRates := make([]ExchangeRate, len(rates))
+	for i, r := range rates {
+		dbRates[i] = ExchangeRate{Base: r.Base, Quote: r.Quote, Date: r.Date.UTC(), Rate: r.Rate, UserID: repo.owner}
+	}
+	res := repo.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "base"}, {Name: "quote"}, {Name: "date"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+	}).Create(&dbRates)
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return len(dbRates), nil
+}
+
+// FindExchangeRate returns the most recent rate of base in quote stored by the owner
+// with a date before or equal to the given time.
+// It returns ErrRateNotFound if no rate was found.
+func (repo Repository) FindExchangeRate(base domain.Currency, quote domain.Currency, t time.Time) (domain.ExchangeRate, error) {
+	var rate ExchangeRate
+	err := repo.owned().Where("base = ? AND quote = ? AND date <= ?", base, quote, t.UTC()).Order("date DESC").First(&rate).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ExchangeRate{}, store.ErrRateNotFound
+	}
+	return rate.ToDomain(), err
+}
@@ -0,0 +1,79 @@
+package db_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+func TestExchangeRates(t *testing.T) {
+	defer clearDB()
+	day1 := time.Date(2020, 11, 19, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2020, 11, 20, 0, 0, 0, 0, time.UTC)
+	rates := []domain.ExchangeRate{
+		{Base: "EUR", Quote: "USD", Date: day1, Rate: 1.2},
+		{Base: "EUR", Quote: "USD", Date: day2, Rate: 1.3},
+		{Base: "EUR", Quote: "JPY", Date: day1, Rate: 125},
+	}
+	if count, err := repo.SaveExchangeRates(rates); err != nil || count != 3 {
+		t.Fatalf("\nUnexpected result while saving rates: %d ( error: %v )", count, err)
+	}
+	// Saving a rate for same currencies & date replaces it
+	if _, err := repo.SaveExchangeRates([]domain.ExchangeRate{{Base: "EUR", Quote: "USD", Date: day2, Rate: 1.25}}); err != nil {
+		t.Fatalf("\nUnexpected Error while replacing rate: %v", err)
+	}
+	var count int64
+	grmDb.Table("exchange_rates").Count(&count)
+	if count != 3 {
+		t.Fatalf("\nExpected 3 stored rates\nReturned: %d", count)
+	}
+	tests := map[string]struct {
+		base        domain.Currency
+		quote       domain.Currency
+		time        time.Time
+		expected    float64
+		expectedErr error
+	}{
+		"Same Day":          {base: "EUR", quote: "USD", time: day1.Add(10 * time.Hour), expected: 1.2},
+		"Replaced Rate":     {base: "EUR", quote: "USD", time: day2, expected: 1.25},
+		"Later Day":         {base: "EUR", quote: "JPY", time: day2.AddDate(0, 1, 0), expected: 125},
+		"Non-UTC Time":      {base: "EUR", quote: "USD", time: time.Date(2020, 11, 20, 0, 30, 0, 0, time.FixedZone("UTC-1", -3600)), expected: 1.25},
+		"Before First Rate": {base: "EUR", quote: "USD", time: day1.Add(-time.Hour), expectedErr: store.ErrRateNotFound},
+		"Other Direction":   {base: "USD", quote: "EUR", time: day2, expectedErr: store.ErrRateNotFound},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rate, err := repo.FindExchangeRate(test.base, test.quote, test.time)
+			if err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if rate.Rate != test.expected {
+				t.Fatalf("\nExpected Rate: %v\nReturned Rate: %v", test.expected, rate.Rate)
+			}
+		})
+	}
+}
+
+func TestExchangeRatesOwners(t *testing.T) {
+	defer clearDB()
+	alice, bob := repo.ForUser(1), repo.ForUser(2)
+	day := time.Date(2020, 11, 19, 0, 0, 0, 0, time.UTC)
+	if _, err := alice.SaveExchangeRates([]domain.ExchangeRate{{Base: "EUR", Quote: "USD", Date: day, Rate: 1.2}}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if _, err := bob.FindExchangeRate("EUR", "USD", day); err != store.ErrRateNotFound {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrRateNotFound, err)
+	}
+	// Another user can store a rate of the same currencies & date without replacing it
+	if _, err := bob.SaveExchangeRates([]domain.ExchangeRate{{Base: "EUR", Quote: "USD", Date: day, Rate: 1.5}}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if rate, err := alice.FindExchangeRate("EUR", "USD", day); err != nil || rate.Rate != 1.2 {
+		t.Fatalf("\nExpected rate of user untouched\nReturned: %v ( error: %v )", rate, err)
+	}
+	if rate, err := bob.FindExchangeRate("EUR", "USD", day); err != nil || rate.Rate != 1.5 {
+		t.Fatalf("\nExpected rate of other user\nReturned: %v ( error: %v )", rate, err)
+	}
+}
@@ -0,0 +1,192 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"gorm.io/gorm"
+)
+
+// expenseTotal is used to scan rows of an expense aggregate query
+type expenseTotal struct {
+	Grp   string
+	Unit  domain.Currency
+	Total domain.Amount
+	Count int
+}
+
+// toDomain converts calling expenseTotal to domain.ExpenseTotal
+func (et expenseTotal) toDomain() domain.ExpenseTotal {
+	return domain.ExpenseTotal{
+		Group: et.Grp,
+		Unit:  et.Unit,
+		Total: et.Total,
+		Count: et.Count,
+	}
+}
+
+// isSqlite checks if the repository is backed by an sqlite database.
+// Aggregate queries rely on date functions that differ between sqlite and postgres.
+func (repo Repository) isSqlite() bool {
+	return repo.db.Dialector.Name() == "sqlite"
+}
+
+// periodExpr returns the SQL expression of the key of the period
+// containing the time in given column ( start date formatted as yyyy-mm-dd ).
+func (repo Repository) periodExpr(p domain.Period, col string) string {
+	if repo.isSqlite() {
+		switch p {
+		case domain.PeriodWeek:
+			return fmt.Sprintf("date(%s, '-6 days', 'weekday 1')", col)
+		case domain.PeriodMonth:
+			return fmt.Sprintf("strftime('%%Y-%%m-01', %s)", col)
+		case domain.PeriodYear:
+			return fmt.Sprintf("strftime('%%Y-01-01', %s)", col)
+		default:
+			return fmt.Sprintf("date(%s)", col)
+		}
+	}
+	return fmt.Sprintf("to_char(date_trunc('%s', %s AT TIME ZONE 'UTC'), 'YYYY-MM-DD')", p, col)
+}
+
+// sumExpenses executes an aggregate query on done expenses between from and to
+// grouped by the given SQL expression and unit.
+// join can be used to add joins & conditions to the query.
+func (repo Repository) sumExpenses(grpExpr string, from time.Time, to time.Time, join func(*gorm.DB) *gorm.DB) ([]domain.ExpenseTotal, error) {
+	res := []expenseTotal{}
+	query := repo.owned().Model(&Expense{}).
+		Select(grpExpr+" AS grp, expenses.unit AS unit, SUM(expenses.amount) AS total, COUNT(*) AS count").
+		Where("expenses.time >= ? AND expenses.time <= ? AND expenses.status = ?", from, to, domain.StatusDone)
+	if join != nil {
+		query = join(query)
+	}
+	if err := query.Group(grpExpr + ", expenses.unit").Scan(&res).Error; err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	totals := make([]domain.ExpenseTotal, len(res))
+	for i, et := range res {
+		totals[i] = et.toDomain()
+	}
+	return totals, nil
+}
+
+// joinExpenseTags joins expenses with the tags associated to them
+func joinExpenseTags(db *gorm.DB) *gorm.DB {
+	return db.Joins("JOIN expense_tags ON expense_tags.expense_id = expenses.id").
+		Joins("JOIN tags ON tags.id = expense_tags.tag_id AND tags.deleted_at IS NULL")
+}
+
+// SumExpensesByPeriod returns sums of values of expenses between from and to
+// grouped by period and unit. If tid is not zero, only expenses having
+// the tag are summed. Periods are computed in UTC.
+func (repo Repository) SumExpensesByPeriod(p domain.Period, from time.Time, to time.Time, tid domain.TagID) ([]domain.ExpenseTotal, error) {
+	var join func(*gorm.DB) *gorm.DB
+	if tid > 0 {
+		join = func(db *gorm.DB) *gorm.DB {
+			return joinExpenseTags(db).Where("tags.id = ?", tid)
+		}
+	}
+	return repo.sumExpenses(repo.periodExpr(p, "expenses.time"), from, to, join)
+}
+
+// SumExpensesByTag returns sums of values of expenses between from and to
+// grouped by tag name and unit.
+func (repo Repository) SumExpensesByTag(from time.Time, to time.Time) ([]domain.ExpenseTotal, error) {
+	return repo.sumExpenses("tags.name", from, to, joinExpenseTags)
+}
+
+// SumExpensesByUnit returns sums of values of expenses between from and to
+// grouped by unit.
+func (repo Repository) SumExpensesByUnit(from time.Time, to time.Time) ([]domain.ExpenseTotal, error) {
+	return repo.sumExpenses("expenses.unit", from, to, nil)
+}
+
+// activityTotal is used to scan rows of an activity aggregate query
+type activityTotal struct {
+	Grp   string
+	Total time.Duration
+	Count int
+}
+
+// toDomain converts calling activityTotal to domain.ActivityTotal
+func (at activityTotal) toDomain() domain.ActivityTotal {
+	res := domain.ActivityTotal{
+		Group: at.Grp,
+		Total: at.Total,
+		Count: at.Count,
+	}
+	if at.Count > 0 {
+		res.Average = at.Total / time.Duration(at.Count)
+	}
+	return res
+}
+
+// sumActivities executes an aggregate query on done activities between from and to
+// grouped by the given SQL expression.
+// join can be used to add joins & conditions to the query.
+func (repo Repository) sumActivities(grpExpr string, from time.Time, to time.Time, join func(*gorm.DB) *gorm.DB) ([]domain.ActivityTotal, error) {
+	res := []activityTotal{}
+	query := repo.owned().Model(&Activity{}).
+		Select(grpExpr+" AS grp, SUM(activities.duration) AS total, COUNT(*) AS count").
+		Where("activities.time >= ? AND activities.time <= ? AND activities.status = ?", from, to, domain.StatusDone)
+	if join != nil {
+		query = join(query)
+	}
+	if err := query.Group(grpExpr).Scan(&res).Error; err != nil {
+		return []domain.ActivityTotal{}, err
+	}
+	totals := make([]domain.ActivityTotal, len(res))
+	for i, at := range res {
+		totals[i] = at.toDomain()
+	}
+	return totals, nil
+}
+
+// SumActivitiesByTag returns total durations of activities between from and to
+// grouped by tag name.
+func (repo Repository) SumActivitiesByTag(from time.Time, to time.Time) ([]domain.ActivityTotal, error) {
+	return repo.sumActivities("tags.name", from, to, func(db *gorm.DB) *gorm.DB {
+		return db.Joins("JOIN activity_tags ON activity_tags.activity_id = activities.id").
+			Joins("JOIN tags ON tags.id = activity_tags.tag_id AND tags.deleted_at IS NULL")
+	})
+}
+
+// SumActivitiesByPlace returns total durations of activities between from and to
+// grouped by place.
+func (repo Repository) SumActivitiesByPlace(from time.Time, to time.Time) ([]domain.ActivityTotal, error) {
+	return repo.sumActivities("activities.place", from, to, nil)
+}
+
+// SumActivitiesByWeekday returns total durations of activities between from and to
+// grouped by the weekday ( in UTC ) they started on.
+func (repo Repository) SumActivitiesByWeekday(from time.Time, to time.Time) ([]domain.ActivityTotal, error) {
+	// Weekdays are returned as numbers starting from sunday = 0
+	expr := "CAST(EXTRACT(DOW FROM activities.time AT TIME ZONE 'UTC') AS INTEGER)"
+	if repo.isSqlite() {
+		expr = "strftime('%w', activities.time)"
+	}
+	totals, err := repo.sumActivities(expr, from, to, nil)
+	if err != nil {
+		return totals, err
+	}
+	for i, total := range totals {
+		day, err := strconv.Atoi(total.Group)
+		if err != nil {
+			return []domain.ActivityTotal{}, err
+		}
+		totals[i].Group = time.Weekday(day).String()
+	}
+	return totals, nil
+}
+
+// SumActivitiesByHour returns total durations of activities between from and to
+// grouped by the hour of day ( in UTC ) they started at, formatted as 2 digits.
+func (repo Repository) SumActivitiesByHour(from time.Time, to time.Time) ([]domain.ActivityTotal, error) {
+	expr := "to_char(activities.time AT TIME ZONE 'UTC', 'HH24')"
+	if repo.isSqlite() {
+		expr = "strftime('%H', activities.time)"
+	}
+	return repo.sumActivities(expr, from, to, nil)
+}
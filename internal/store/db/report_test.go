@@ -0,0 +1,231 @@
+package db_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store/db"
+)
+
+// createReportExpenses creates test tags & expenses used by report tests
+func createReportExpenses(t *testing.T) (from time.Time, to time.Time) {
+	var (
+		tag1 db.Tag = db.Tag{ID: 11, Name: "test-tag-1"}
+		tag2 db.Tag = db.Tag{ID: 12, Name: "test-tag-2"}
+	)
+	if err := grmDb.Create(&[]db.Tag{tag1, tag2}).Error; err != nil {
+		t.Fatalf("\nError while creating test tags:\n  %v", err)
+	}
+	expenses := []db.Expense{
+		{Label: "Exp 1", Time: time.Date(2020, 10, 5, 10, 0, 0, 0, time.UTC), Value: 10, Unit: "eu", Tags: []db.Tag{tag1}},
+		{Label: "Exp 2", Time: time.Date(2020, 10, 6, 10, 0, 0, 0, time.UTC), Value: 5, Unit: "eu", Tags: []db.Tag{tag1, tag2}},
+		{Label: "Exp 3", Time: time.Date(2020, 10, 20, 10, 0, 0, 0, time.UTC), Value: 20, Unit: "dh", Tags: []db.Tag{tag2}},
+		{Label: "Exp 4", Time: time.Date(2020, 11, 2, 10, 0, 0, 0, time.UTC), Value: 7, Unit: "eu", Tags: []db.Tag{}},
+		{Label: "Exp out of range", Time: time.Date(2019, 11, 2, 10, 0, 0, 0, time.UTC), Value: 100, Unit: "eu", Tags: []db.Tag{tag1}},
+		{Label: "Exp cancelled", Time: time.Date(2020, 10, 5, 12, 0, 0, 0, time.UTC), Value: 50, Unit: "eu", Tags: []db.Tag{tag1}, Status: domain.StatusCancelled},
+		{Label: "Exp planned", Time: time.Now().AddDate(0, 1, 0), Value: 80, Unit: "eu", Tags: []db.Tag{tag1}, Status: domain.StatusPlanned},
+	}
+	if err := grmDb.Create(&expenses).Error; err != nil {
+		t.Fatalf("\nError while creating test expenses:\n  %v", err)
+	}
+	// Range ending in the future to check planned expenses are not summed
+	return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Now().AddDate(1, 0, 0)
+}
+
+// checkExpenseTotals checks returned totals contain exactly the expected ones
+func checkExpenseTotals(res []domain.ExpenseTotal, expected []domain.ExpenseTotal) string {
+	errMsg := fmt.Sprintf("\nExpected: %v\nReturned: %v", expected, res)
+	if len(res) != len(expected) {
+		return errMsg
+	}
+	for _, exp := range expected {
+		found := false
+		for _, r := range res {
+			if r == exp {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errMsg
+		}
+	}
+	return ""
+}
+
+func TestSumExpensesByPeriod(t *testing.T) {
+	defer clearDB()
+	from, to := createReportExpenses(t)
+	tests := map[string]struct {
+		period   domain.Period
+		tid      domain.TagID
+		expected []domain.ExpenseTotal
+	}{
+		"Month": {
+			period: domain.PeriodMonth,
+			expected: []domain.ExpenseTotal{
+				{Group: "2020-10-01", Unit: "eu", Total: 15, Count: 2},
+				{Group: "2020-10-01", Unit: "dh", Total: 20, Count: 1},
+				{Group: "2020-11-01", Unit: "eu", Total: 7, Count: 1},
+			},
+		},
+		"Week": {
+			period: domain.PeriodWeek,
+			expected: []domain.ExpenseTotal{
+				{Group: "2020-10-05", Unit: "eu", Total: 15, Count: 2},
+				{Group: "2020-10-19", Unit: "dh", Total: 20, Count: 1},
+				{Group: "2020-11-02", Unit: "eu", Total: 7, Count: 1},
+			},
+		},
+		"Year with Tag": {
+			period: domain.PeriodYear,
+			tid:    11,
+			expected: []domain.ExpenseTotal{
+				{Group: "2020-01-01", Unit: "eu", Total: 15, Count: 2},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := repo.SumExpensesByPeriod(test.period, from, to, test.tid)
+			if err != nil {
+				t.Fatalf("\nUnexpected Error: %v", err)
+			}
+			if msg := checkExpenseTotals(res, test.expected); msg != "" {
+				t.Fatal(msg)
+			}
+		})
+	}
+}
+
+func TestSumExpensesByTag(t *testing.T) {
+	defer clearDB()
+	from, to := createReportExpenses(t)
+	res, err := repo.SumExpensesByTag(from, to)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	expected := []domain.ExpenseTotal{
+		{Group: "test-tag-1", Unit: "eu", Total: 15, Count: 2},
+		{Group: "test-tag-2", Unit: "eu", Total: 5, Count: 1},
+		{Group: "test-tag-2", Unit: "dh", Total: 20, Count: 1},
+	}
+	if msg := checkExpenseTotals(res, expected); msg != "" {
+		t.Fatal(msg)
+	}
+}
+
+func TestSumExpensesByUnit(t *testing.T) {
+	defer clearDB()
+	from, to := createReportExpenses(t)
+	res, err := repo.SumExpensesByUnit(from, to)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	expected := []domain.ExpenseTotal{
+		{Group: "eu", Unit: "eu", Total: 22, Count: 3},
+		{Group: "dh", Unit: "dh", Total: 20, Count: 1},
+	}
+	if msg := checkExpenseTotals(res, expected); msg != "" {
+		t.Fatal(msg)
+	}
+}
+
+// createReportActivities creates test tags & activities used by report tests
+func createReportActivities(t *testing.T) (from time.Time, to time.Time) {
+	var (
+		tag1 db.Tag = db.Tag{ID: 11, Name: "test-tag-1"}
+		tag2 db.Tag = db.Tag{ID: 12, Name: "test-tag-2"}
+	)
+	if err := grmDb.Create(&[]db.Tag{tag1, tag2}).Error; err != nil {
+		t.Fatalf("\nError while creating test tags:\n  %v", err)
+	}
+	activities := []db.Activity{
+		// Monday
+		{Label: "Activity 1", Place: "gym", Time: time.Date(2020, 10, 5, 10, 0, 0, 0, time.UTC), Duration: time.Hour, Tags: []db.Tag{tag1}},
+		// Monday
+		{Label: "Activity 2", Place: "gym", Time: time.Date(2020, 10, 12, 10, 30, 0, 0, time.UTC), Duration: 2 * time.Hour, Tags: []db.Tag{tag1, tag2}},
+		// Sunday
+		{Label: "Activity 3", Place: "home", Time: time.Date(2020, 10, 18, 18, 0, 0, 0, time.UTC), Duration: 30 * time.Minute, Tags: []db.Tag{tag2}},
+		{Label: "Activity out of range", Place: "gym", Time: time.Date(2019, 10, 5, 10, 0, 0, 0, time.UTC), Duration: time.Hour, Tags: []db.Tag{tag1}},
+		{Label: "Activity cancelled", Place: "gym", Time: time.Date(2020, 10, 6, 10, 0, 0, 0, time.UTC), Duration: time.Hour, Tags: []db.Tag{tag1}, Status: domain.StatusCancelled},
+		{Label: "Activity planned", Place: "pool", Time: time.Now().AddDate(0, 1, 0), Duration: time.Hour, Tags: []db.Tag{tag2}, Status: domain.StatusPlanned},
+	}
+	if err := grmDb.Create(&activities).Error; err != nil {
+		t.Fatalf("\nError while creating test activities:\n  %v", err)
+	}
+	// Range ending in the future to check planned activities are not summed
+	return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Now().AddDate(1, 0, 0)
+}
+
+// checkActivityTotals checks returned totals contain exactly the expected ones
+func checkActivityTotals(res []domain.ActivityTotal, expected []domain.ActivityTotal) string {
+	errMsg := fmt.Sprintf("\nExpected: %v\nReturned: %v", expected, res)
+	if len(res) != len(expected) {
+		return errMsg
+	}
+	for _, exp := range expected {
+		found := false
+		for _, r := range res {
+			if r == exp {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errMsg
+		}
+	}
+	return ""
+}
+
+func TestSumActivities(t *testing.T) {
+	defer clearDB()
+	from, to := createReportActivities(t)
+	tests := map[string]struct {
+		sum      func(time.Time, time.Time) ([]domain.ActivityTotal, error)
+		expected []domain.ActivityTotal
+	}{
+		"By Tag": {
+			sum: repo.SumActivitiesByTag,
+			expected: []domain.ActivityTotal{
+				{Group: "test-tag-1", Total: 3 * time.Hour, Average: 90 * time.Minute, Count: 2},
+				{Group: "test-tag-2", Total: 150 * time.Minute, Average: 75 * time.Minute, Count: 2},
+			},
+		},
+		"By Place": {
+			sum: repo.SumActivitiesByPlace,
+			expected: []domain.ActivityTotal{
+				{Group: "gym", Total: 3 * time.Hour, Average: 90 * time.Minute, Count: 2},
+				{Group: "home", Total: 30 * time.Minute, Average: 30 * time.Minute, Count: 1},
+			},
+		},
+		"By Weekday": {
+			sum: repo.SumActivitiesByWeekday,
+			expected: []domain.ActivityTotal{
+				{Group: "Monday", Total: 3 * time.Hour, Average: 90 * time.Minute, Count: 2},
+				{Group: "Sunday", Total: 30 * time.Minute, Average: 30 * time.Minute, Count: 1},
+			},
+		},
+		"By Hour": {
+			sum: repo.SumActivitiesByHour,
+			expected: []domain.ActivityTotal{
+				{Group: "10", Total: 3 * time.Hour, Average: 90 * time.Minute, Count: 2},
+				{Group: "18", Total: 30 * time.Minute, Average: 30 * time.Minute, Count: 1},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := test.sum(from, to)
+			if err != nil {
+				t.Fatalf("\nUnexpected Error: %v", err)
+			}
+			if msg := checkActivityTotals(res, test.expected); msg != "" {
+				t.Fatal(msg)
+			}
+		})
+	}
+}
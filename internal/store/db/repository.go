@@ -3,17 +3,39 @@ package db
 import (
 	"errors"
 
+	"github.com/elhamza90/lifelog/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var errNotImplemented error = errors.New("Repo Method not yet implemented")
 
 // Repository manages data in a Database using GORM orm
 type Repository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	owner domain.UserID // User whose rows are queried and stored ( see ForUser )
 }
 
 // NewRepository returns new repository.
 func NewRepository(db *gorm.DB) Repository {
-	return Repository{db}
+	return Repository{db: db}
+}
+
+// ForUser returns a copy of the repository which only queries
+// and stores the tags, activities, expenses, budgets, templates
+// and audit events of the user with given ID
+func (repo Repository) ForUser(id domain.UserID) Repository {
+	repo.owner = id
+	return repo
+}
+
+// owned returns a query on the rows of the owner of the repository
+func (repo Repository) owned() *gorm.DB {
+	return ownedBy(repo.db, repo.owner)
+}
+
+// ownedBy restricts the given query to the rows of given user
+// in the table of the query
+func ownedBy(db *gorm.DB, owner domain.UserID) *gorm.DB {
+	return db.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "user_id"}, Value: owner})
 }
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"gorm.io/gorm"
+)
+
+// Restore stores the given tags, activities and expenses with new IDs.
+// The IDs of the given entities are only used to resolve the tags of activities
+// & expenses and the activity of expenses, which are rewritten to the new IDs.
+// It returns the new IDs in the order of the given entities.
+// Everything is stored in one transaction: nothing is stored if one fails.
+func (repo Repository) Restore(tags []domain.Tag, activities []domain.Activity, expenses []domain.Expense) ([]domain.TagID, []domain.ActivityID, []domain.ExpenseID, error) {
+	var (
+		tagIDs      = make([]domain.TagID, len(tags))
+		activityIDs = make([]domain.ActivityID, len(activities))
+		expenseIDs  = make([]domain.ExpenseID, len(expenses))
+	)
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		newTags := make(map[domain.TagID]Tag, len(tags))
+		for i, t := range tags {
+			dbTag := Tag{Name: t.Name, UserID: repo.owner}
+			if err := tx.Create(&dbTag).Error; err != nil {
+				return err
+			}
+			newTags[t.ID] = Tag{ID: dbTag.ID, Name: dbTag.Name}
+			tagIDs[i] = dbTag.ID
+		}
+		newActivities := make(map[domain.ActivityID]domain.ActivityID, len(activities))
+		for i, act := range activities {
+			actTags, err := restoredTags(act.Tags, newTags)
+			if err != nil {
+				return err
+			}
+			dbAct := Activity{
+				Label:    act.Label,
+				Place:    act.Place,
+				Desc:     act.Desc,
+				Time:     act.Time,
+				Duration: act.Duration,
+				Status:   act.Status,
+				Tags:     actTags,
+				UserID:   repo.owner,
+			}
+			if err := tx.Create(&dbAct).Error; err != nil {
+				return err
+			}
+			newActivities[act.ID] = dbAct.ID
+			activityIDs[i] = dbAct.ID
+		}
+		for i, exp := range expenses {
+			expTags, err := restoredTags(exp.Tags, newTags)
+			if err != nil {
+				return err
+			}
+			var actID domain.ActivityID
+			if exp.ActivityID > 0 {
+				var ok bool
+				if actID, ok = newActivities[exp.ActivityID]; !ok {
+					return store.ErrActivityNotFound
+				}
+			}
+			dbExp := Expense{
+				Label:      exp.Label,
+				Time:       exp.Time,
+				Value:      exp.Value,
+				Unit:       exp.Unit,
+				ActivityID: actID,
+				Status:     exp.Status,
+				Tags:       expTags,
+				UserID:     repo.owner,
+			}
+			if err := tx.Create(&dbExp).Error; err != nil {
+				return err
+			}
+			expenseIDs[i] = dbExp.ID
+		}
+		return nil
+	})
+	return tagIDs, activityIDs, expenseIDs, err
+}
+
+// restoredTags returns the tag models restored for the given tags.
+// It returns ErrTagNotFound if one of them was not restored.
+func restoredTags(tags []domain.Tag, newTags map[domain.TagID]Tag) ([]Tag, error) {
+	res := make([]Tag, len(tags))
+	for i, t := range tags {
+		newTag, ok := newTags[t.ID]
+		if !ok {
+			return nil, store.ErrTagNotFound
+		}
+		res[i] = newTag
+	}
+	return res, nil
+}
@@ -0,0 +1,57 @@
+package db_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+func TestRestore(t *testing.T) {
+	defer clearDB()
+	alice, bob := repo.ForUser(1), repo.ForUser(2)
+	actTime := time.Now().AddDate(0, 0, -1)
+	// Entities of another user with the IDs of the archived ones
+	bobTag := domain.Tag{Name: "food"}
+	bobTag.ID, _ = bob.SaveTag(bobTag)
+	bobActID, _ := bob.SaveActivity(domain.Activity{Label: "Run", Time: actTime, Duration: time.Hour})
+	bobExpID, _ := bob.SaveExpense(domain.Expense{Label: "Shoes", Time: actTime, Value: 5000, Unit: "EUR", ActivityID: bobActID})
+	tags := []domain.Tag{{ID: bobTag.ID, Name: "food"}, {ID: bobTag.ID + 1, Name: "sport"}}
+	activities := []domain.Activity{
+		{ID: bobActID, Label: "Football", Time: actTime, Duration: time.Hour, Tags: []domain.Tag{tags[1]}},
+	}
+	expenses := []domain.Expense{
+		{ID: bobExpID, Label: "Drinks", Time: actTime, Value: 350, Unit: "EUR", ActivityID: bobActID, Tags: tags},
+	}
+	tagIDs, activityIDs, expenseIDs, err := alice.Restore(tags, activities, expenses)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(tagIDs) != 2 || tagIDs[0] == bobTag.ID || len(activityIDs) != 1 || activityIDs[0] == bobActID || len(expenseIDs) != 1 || expenseIDs[0] == bobExpID {
+		t.Fatalf("\nExpected new IDs\nReturned: %v %v %v", tagIDs, activityIDs, expenseIDs)
+	}
+	// Check relations are rewritten to the new IDs
+	exp, err := alice.FindExpenseByID(expenseIDs[0])
+	if err != nil || exp.ActivityID != activityIDs[0] || len(exp.Tags) != 2 {
+		t.Fatalf("\nExpected expense restored with its activity & tags\nReturned: %v ( error: %v )", exp, err)
+	}
+	act, err := alice.FindActivityByID(activityIDs[0])
+	if err != nil || len(act.Tags) != 1 || act.Tags[0].ID != tagIDs[1] {
+		t.Fatalf("\nExpected activity restored with its tag\nReturned: %v ( error: %v )", act, err)
+	}
+	// Check entities of the other user are untouched
+	if exp, err := bob.FindExpenseByID(bobExpID); err != nil || exp.Label != "Shoes" || exp.ActivityID != bobActID {
+		t.Fatalf("\nExpected expense of other user untouched\nReturned: %v ( error: %v )", exp, err)
+	}
+	// Check nothing is stored when restore fails
+	clearDB()
+	expenses[0].ActivityID = bobActID + 1
+	if _, _, _, err := alice.Restore(tags, activities, expenses); !errors.Is(err, store.ErrActivityNotFound) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrActivityNotFound, err)
+	}
+	if all, _ := alice.FindAllTags(); len(all) != 0 {
+		t.Fatalf("\nExpected nothing restored\nReturned tags: %v", all)
+	}
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"gorm.io/gorm"
+)
+
+// Postgres full-text search vectors of searched tables.
+// Labels get the highest weight ( A ), then places ( B ) and descriptions ( C ).
+// Indexes created by CreateSearchIndexes use the same expressions.
+const (
+	activitySearchVector string = `setweight(to_tsvector('simple', coalesce(label, '')), 'A') || ` +
+		`setweight(to_tsvector('simple', coalesce(place, '')), 'B') || ` +
+		`setweight(to_tsvector('simple', coalesce("desc", '')), 'C')`
+	expenseSearchVector string = `setweight(to_tsvector('simple', coalesce(label, '')), 'A')`
+)
+
+// searchResult is used to scan rows of a search query
+type searchResult struct {
+	ID    uint
+	Label string
+	Place string
+	Desc  string
+	Time  time.Time
+	Rank  float64
+}
+
+// toDomain converts calling searchResult to domain.SearchResult of given kind
+func (sr searchResult) toDomain(kind domain.SearchResultKind) domain.SearchResult {
+	return domain.SearchResult{
+		Kind:  kind,
+		ID:    sr.ID,
+		Label: sr.Label,
+		Time:  sr.Time,
+		Rank:  sr.Rank,
+	}
+}
+
+// CreateSearchIndexes creates the GIN indexes used by full-text searches.
+// It does nothing when the database is not postgres.
+func CreateSearchIndexes(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS activities_search_idx ON activities USING GIN ((" + activitySearchVector + "))").Error; err != nil {
+		return err
+	}
+	return db.Exec("CREATE INDEX IF NOT EXISTS expenses_search_idx ON expenses USING GIN ((" + expenseSearchVector + "))").Error
+}
+
+// search runs a full-text search on given table using the given tsvector expression.
+// On sqlite, which has no full-text search by default, rows containing all
+// query words are fetched using LIKE then ranked using store.SearchRank.
+func (repo Repository) search(table string, vector string, fields []string, q string, limit int) ([]searchResult, error) {
+	res := []searchResult{}
+	if !repo.isSqlite() {
+		err := repo.owned().Table(table).
+			Select("id, label, time, ts_rank("+vector+", plainto_tsquery('simple', ?)) AS rank", q).
+			Where(vector+" @@ plainto_tsquery('simple', ?)", q).
+			Where("deleted_at IS NULL").
+			Order("rank DESC").Order("time DESC").Limit(limit).
+			Scan(&res).Error
+		return res, err
+	}
+	terms := store.Tokenize(q)
+	text := "lower(" + strings.Join(fields, " || ' ' || ") + ")"
+	query := repo.owned().Table(table).Select(strings.Join(append([]string{"id", "time"}, fields...), ", ")).
+		Where("deleted_at IS NULL")
+	for _, term := range terms {
+		query = query.Where(text+" LIKE ?", "%"+term+"%")
+	}
+	candidates := []searchResult{}
+	if err := query.Scan(&candidates).Error; err != nil {
+		return res, err
+	}
+	for _, c := range candidates {
+		c.Rank = store.SearchRank(terms,
+			store.SearchField{Text: c.Label, Weight: store.SearchWeightLabel},
+			store.SearchField{Text: c.Place, Weight: store.SearchWeightPlace},
+			store.SearchField{Text: c.Desc, Weight: store.SearchWeightDesc})
+		// LIKE also matches parts of words
+		if c.Rank > 0 {
+			res = append(res, c)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Rank != res[j].Rank {
+			return res[i].Rank > res[j].Rank
+		}
+		return res[i].Time.After(res[j].Time)
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+// SearchActivities returns at most limit activities whose Label, Desc
+// or Place match all words of the query. Best ranked ones come first.
+func (repo Repository) SearchActivities(q string, limit int) ([]domain.SearchResult, error) {
+	rows, err := repo.search("activities", activitySearchVector, []string{"label", "place", `"desc"`}, q, limit)
+	if err != nil {
+		return []domain.SearchResult{}, err
+	}
+	res := make([]domain.SearchResult, len(rows))
+	for i, r := range rows {
+		res[i] = r.toDomain(domain.SearchResultActivity)
+	}
+	return res, nil
+}
+
+// SearchExpenses returns at most limit expenses whose Label
+// matches all words of the query. Best ranked ones come first.
+func (repo Repository) SearchExpenses(q string, limit int) ([]domain.SearchResult, error) {
+	rows, err := repo.search("expenses", expenseSearchVector, []string{"label"}, q, limit)
+	if err != nil {
+		return []domain.SearchResult{}, err
+	}
+	res := make([]domain.SearchResult, len(rows))
+	for i, r := range rows {
+		res[i] = r.toDomain(domain.SearchResultExpense)
+	}
+	return res, nil
+}
@@ -0,0 +1,70 @@
+package db_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store/db"
+)
+
+func TestSearch(t *testing.T) {
+	defer clearDB()
+	now := time.Now()
+	activities := []db.Activity{
+		{ID: 1, Label: "Dinner with friends", Place: "Le Petit Bistrot", Time: now.AddDate(0, 0, -3), Duration: time.Hour},
+		{ID: 2, Label: "Running", Place: "Park", Desc: "Short run before dinner", Time: now.AddDate(0, 0, -2), Duration: time.Hour},
+		{ID: 3, Label: "Dinners review", Place: "Home", Time: now.AddDate(0, 0, -1), Duration: time.Hour},
+	}
+	if err := grmDb.Create(&activities).Error; err != nil {
+		t.Fatalf("\nError while creating test activities:\n  %v", err)
+	}
+	expenses := []db.Expense{
+		{ID: 1, Label: "Dinner", Value: 45, Unit: "eu", Time: now.AddDate(0, 0, -4)},
+		{ID: 2, Label: "Running shoes", Value: 80, Unit: "eu", Time: now.AddDate(0, -1, 0)},
+	}
+	if err := grmDb.Create(&expenses).Error; err != nil {
+		t.Fatalf("\nError while creating test expenses:\n  %v", err)
+	}
+	tests := map[string]struct {
+		search   func() ([]domain.SearchResult, error)
+		expected []string // kind + id of results, in order !
+	}{
+		"Activities Label & Description": {
+			search:   func() ([]domain.SearchResult, error) { return repo.SearchActivities("dinner", 10) },
+			expected: []string{"activity 1", "activity 2"}, // "Dinners" is another word
+		},
+		"Activities Place": {
+			search:   func() ([]domain.SearchResult, error) { return repo.SearchActivities("BISTROT dinner", 10) },
+			expected: []string{"activity 1"},
+		},
+		"Activities Limit": {
+			search:   func() ([]domain.SearchResult, error) { return repo.SearchActivities("dinner", 1) },
+			expected: []string{"activity 1"},
+		},
+		"Expenses": {
+			search:   func() ([]domain.SearchResult, error) { return repo.SearchExpenses("running", 10) },
+			expected: []string{"expense 2"},
+		},
+		"Expenses No Match": {
+			search:   func() ([]domain.SearchResult, error) { return repo.SearchExpenses("bistrot", 10) },
+			expected: []string{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := test.search()
+			if err != nil {
+				t.Fatalf("\nUnexpected Error: %v", err)
+			}
+			returned := []string{}
+			for _, r := range res {
+				returned = append(returned, fmt.Sprintf("%s %d", r.Kind, r.ID))
+			}
+			if fmt.Sprint(returned) != fmt.Sprint(test.expected) {
+				t.Fatalf("\nExpected: %v\nReturned: %v", test.expected, returned)
+			}
+		})
+	}
+}
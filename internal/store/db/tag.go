@@ -13,7 +13,7 @@ import (
 // It returns ErrTagNotFound if no tag was found.
 func (repo Repository) FindTagByID(id domain.TagID) (domain.Tag, error) {
 	var t Tag
-	err := repo.db.First(&t, id).Error
+	err := repo.owned().First(&t, id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		err = store.ErrTagNotFound
 	}
@@ -24,7 +24,7 @@ func (repo Repository) FindTagByID(id domain.TagID) (domain.Tag, error) {
 // It returns an Empty Tag if not found.
 func (repo Repository) FindTagByName(name string) (domain.Tag, error) {
 	var t Tag
-	err := repo.db.Where("name = ?", name).First(&t).Error
+	err := repo.owned().Where("name = ?", name).First(&t).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		err = store.ErrTagNotFound
 	}
@@ -33,7 +33,7 @@ func (repo Repository) FindTagByName(name string) (domain.Tag, error) {
 
 // SaveTag stores the given Tag in db and returns created tag ID
 func (repo Repository) SaveTag(t domain.Tag) (domain.TagID, error) {
-	dbTag := Tag{Name: t.Name}
+	dbTag := Tag{Name: t.Name, UserID: repo.owner}
 	res := repo.db.Create(&dbTag)
 	return domain.TagID(dbTag.ID), res.Error
 }
@@ -41,7 +41,7 @@ func (repo Repository) SaveTag(t domain.Tag) (domain.TagID, error) {
 // FindAllTags returns all stored tags in db
 func (repo Repository) FindAllTags() ([]domain.Tag, error) {
 	var res []Tag
-	if err := repo.db.Find(&res).Error; err != nil {
+	if err := repo.owned().Find(&res).Error; err != nil {
 		return []domain.Tag{}, err
 	}
 	tags := []domain.Tag{}
@@ -51,15 +51,39 @@ func (repo Repository) FindAllTags() ([]domain.Tag, error) {
 	return tags, nil
 }
 
-// DeleteTag deletes tag from db
+// FindTagsPage returns the requested page of tags stored in db.
+// Tags can only be sorted by name ( label ).
+func (repo Repository) FindTagsPage(p store.PageRequest) ([]domain.Tag, store.Page, error) {
+	query, total, err := paginate(repo.owned().Model(&Tag{}), "tags", tagSortColumns, p)
+	if err != nil {
+		return []domain.Tag{}, store.Page{}, err
+	}
+	res := []Tag{}
+	if err := query.Find(&res).Error; err != nil {
+		return []domain.Tag{}, store.Page{}, err
+	}
+	page := nextPage(p, total, len(res), func(last int) store.Cursor {
+		return store.NewCursor(res[last].Name, uint(res[last].ID))
+	})
+	if len(res) > p.Limit {
+		res = res[:p.Limit]
+	}
+	tags := make([]domain.Tag, len(res))
+	for i, t := range res {
+		tags[i] = t.ToDomain()
+	}
+	return tags, page, nil
+}
+
+// DeleteTag moves tag to the trash
 func (repo Repository) DeleteTag(id domain.TagID) error {
-	err := repo.db.Delete(&Tag{}, id).Error
+	err := repo.owned().Delete(&Tag{}, id).Error
 	return err
 }
 
 // EditTag edits given tag in DB
 func (repo Repository) EditTag(t domain.Tag) error {
-	res := repo.db.Model(&Tag{ID: t.ID}).Updates(map[string]interface{}{"name": t.Name})
+	res := repo.owned().Model(&Tag{ID: t.ID}).Updates(map[string]interface{}{"name": t.Name})
 	log.Print(res.RowsAffected)
 	return res.Error
 }
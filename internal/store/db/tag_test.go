@@ -184,3 +184,25 @@ func TestEditTag(t *testing.T) {
 		}
 	})
 }
+
+func TestFindTagsPage(t *testing.T) {
+	defer clearDB()
+	tags := []db.Tag{{ID: 1, Name: "tag-c"}, {ID: 2, Name: "tag-a"}, {ID: 3, Name: "tag-b"}}
+	if err := grmDb.Create(&tags).Error; err != nil {
+		t.Fatalf("\nError while creating test tags:\n  %v", err)
+	}
+	res, page, err := repo.FindTagsPage(store.PageRequest{Limit: 2, Sort: store.SortByLabel})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(res) != 2 || res[0].Name != "tag-a" || res[1].Name != "tag-b" || page.Total != 3 || page.NextCursor == "" {
+		t.Fatalf("\nUnexpected first page: %v ( %+v )", res, page)
+	}
+	res, page, err = repo.FindTagsPage(store.PageRequest{Limit: 2, Sort: store.SortByLabel, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(res) != 1 || res[0].Name != "tag-c" || page.NextCursor != "" {
+		t.Fatalf("\nUnexpected last page: %v ( %+v )", res, page)
+	}
+}
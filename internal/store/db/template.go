@@ -0,0 +1,174 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"gorm.io/gorm"
+)
+
+// FindTemplateByID searches for a template with the given ID and returns it.
+// It returns ErrTemplateNotFound if no template was found.
+func (repo Repository) FindTemplateByID(id domain.TemplateID) (domain.Template, error) {
+	var tmpl Template
+	err := repo.owned().Preload("Tags").First(&tmpl, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = store.ErrTemplateNotFound
+	}
+	return tmpl.ToDomain(), err
+}
+
+// FindAllTemplates returns all stored templates in db ordered by ID
+func (repo Repository) FindAllTemplates() ([]domain.Template, error) {
+	res := []Template{}
+	if err := repo.owned().Preload("Tags").Order("id").Find(&res).Error; err != nil {
+		return []domain.Template{}, err
+	}
+	templates := make([]domain.Template, len(res))
+	for i, tmpl := range res {
+		templates[i] = tmpl.ToDomain()
+	}
+	return templates, nil
+}
+
+// SaveTemplate stores the given template in db and returns created template's ID
+func (repo Repository) SaveTemplate(tmpl domain.Template) (domain.TemplateID, error) {
+	dbTmpl := Template{
+		Kind:     tmpl.Kind,
+		Freq:     tmpl.Rule.Freq,
+		Interval: tmpl.Rule.Interval,
+		Start:    tmpl.Rule.Start,
+		UserID:   repo.owner,
+	}
+	if !tmpl.Rule.Until.IsZero() {
+		dbTmpl.Until = &tmpl.Rule.Until
+	}
+	var tags []domain.Tag
+	switch tmpl.Kind {
+	case domain.TemplateActivity:
+		dbTmpl.Label = tmpl.Activity.Label
+		dbTmpl.Place = tmpl.Activity.Place
+		dbTmpl.Desc = tmpl.Activity.Desc
+		dbTmpl.Duration = tmpl.Activity.Duration
+		tags = tmpl.Activity.Tags
+	default:
+		dbTmpl.Label = tmpl.Expense.Label
+		dbTmpl.Value = tmpl.Expense.Value
+		dbTmpl.Unit = tmpl.Expense.Unit
+		dbTmpl.ActivityID = tmpl.Expense.ActivityID
+		tags = tmpl.Expense.Tags
+	}
+	for _, t := range tags {
+		dbTmpl.Tags = append(dbTmpl.Tags, Tag{ID: t.ID, Name: t.Name})
+	}
+	res := repo.db.Create(&dbTmpl)
+	return dbTmpl.ID, res.Error
+}
+
+// DeleteTemplate deletes template and its occurrences from db
+// It returns ErrTemplateNotFound if template does not exist
+func (repo Repository) DeleteTemplate(id domain.TemplateID) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("template_id = ?", id).Delete(&Occurrence{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&Template{ID: id}).Association("Tags").Clear(); err != nil {
+			return err
+		}
+		res := ownedBy(tx, repo.owner).Delete(&Template{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return store.ErrTemplateNotFound
+		}
+		return nil
+	})
+}
+
+// SaveOccurrence records the given occurrence of a template in db.
+// It returns ErrOccurrenceExists if the occurrence was already recorded.
+func (repo Repository) SaveOccurrence(occ domain.Occurrence) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		_, err := claimOccurrence(tx, occ)
+		return err
+	})
+}
+
+// claimOccurrence records the given occurrence of a template in the transaction
+// and returns its row. It returns ErrOccurrenceExists if it was already recorded.
+// The unique index on template & time rejects occurrences recorded concurrently.
+func claimOccurrence(tx *gorm.DB, occ domain.Occurrence) (Occurrence, error) {
+	var count int64
+	t := occ.Time.UTC()
+	if err := tx.Model(&Occurrence{}).Where("template_id = ? AND time = ?", occ.TemplateID, t).Count(&count).Error; err != nil {
+		return Occurrence{}, err
+	}
+	if count > 0 {
+		return Occurrence{}, store.ErrOccurrenceExists
+	}
+	row := Occurrence{
+		TemplateID: occ.TemplateID,
+		Time:       t,
+		Skipped:    occ.Skipped,
+		ExpenseID:  occ.ExpenseID,
+		ActivityID: occ.ActivityID,
+	}
+	return row, tx.Create(&row).Error
+}
+
+// SaveOccurrenceActivity records the given occurrence of a template in db
+// and stores the given activity in the same transaction.
+// It returns the ID of the activity or ErrOccurrenceExists if the occurrence
+// was already recorded, in which case the activity is not stored.
+func (repo Repository) SaveOccurrenceActivity(occ domain.Occurrence, act domain.Activity) (domain.ActivityID, error) {
+	var id domain.ActivityID
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		row, err := claimOccurrence(tx, occ)
+		if err != nil {
+			return err
+		}
+		txRepo := repo
+		txRepo.db = tx
+		if id, err = txRepo.SaveActivity(act); err != nil {
+			return err
+		}
+		return tx.Model(&row).Update("activity_id", id).Error
+	})
+	return id, err
+}
+
+// SaveOccurrenceExpense records the given occurrence of a template in db
+// and stores the given expense in the same transaction.
+// It returns the ID of the expense or ErrOccurrenceExists if the occurrence
+// was already recorded, in which case the expense is not stored.
+func (repo Repository) SaveOccurrenceExpense(occ domain.Occurrence, exp domain.Expense) (domain.ExpenseID, error) {
+	var id domain.ExpenseID
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		row, err := claimOccurrence(tx, occ)
+		if err != nil {
+			return err
+		}
+		txRepo := repo
+		txRepo.db = tx
+		if id, err = txRepo.SaveExpense(exp); err != nil {
+			return err
+		}
+		return tx.Model(&row).Update("expense_id", id).Error
+	})
+	return id, err
+}
+
+// FindOccurrences returns recorded occurrences of the given template ordered by time
+func (repo Repository) FindOccurrences(tid domain.TemplateID) ([]domain.Occurrence, error) {
+	res := []Occurrence{}
+	if err := repo.db.Where("template_id = ?", tid).Order("time").Find(&res).Error; err != nil {
+		return []domain.Occurrence{}, err
+	}
+	occurrences := make([]domain.Occurrence, len(res))
+	for i, occ := range res {
+		occurrences[i] = occ.ToDomain()
+	}
+	return occurrences, nil
+}
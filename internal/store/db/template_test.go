@@ -0,0 +1,151 @@
+package db_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/store/db"
+)
+
+func TestSaveAndFindTemplate(t *testing.T) {
+	defer clearDB()
+	tag := db.Tag{ID: 1, Name: "home"}
+	if err := grmDb.Create(&tag).Error; err != nil {
+		t.Fatalf("\nUnexpected Error while creating test tag: %v", err)
+	}
+	start := time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)
+	tmpl := domain.Template{
+		Kind:    domain.TemplateExpense,
+		Rule:    domain.RecurrenceRule{Freq: domain.FrequencyMonthly, Interval: 1, Start: start},
+		Expense: domain.Expense{Label: "Rent", Value: 80000, Unit: "EUR", Tags: []domain.Tag{tag.ToDomain()}},
+	}
+	id, err := repo.SaveTemplate(tmpl)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	res, err := repo.FindTemplateByID(id)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if res.Kind != tmpl.Kind || !res.Rule.Start.Equal(start) || !res.Rule.Until.IsZero() || res.Rule.Interval != 1 ||
+		res.Expense.Label != "Rent" || res.Expense.Value != 80000 || len(res.Expense.Tags) != 1 {
+		t.Fatalf("\nExpected: %v\nReturned: %v", tmpl, res)
+	}
+	// Subcase: Activity template with an end
+	act := domain.Template{
+		Kind:     domain.TemplateActivity,
+		Rule:     domain.RecurrenceRule{Freq: domain.FrequencyWeekly, Interval: 1, Start: start, Until: start.AddDate(1, 0, 0)},
+		Activity: domain.Activity{Label: "Gym session", Place: "gym", Duration: time.Hour},
+	}
+	id, err = repo.SaveTemplate(act)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if res, _ := repo.FindTemplateByID(id); res.Activity.Duration != time.Hour || !res.Rule.Until.Equal(act.Rule.Until) {
+		t.Fatalf("\nExpected: %v\nReturned: %v", act, res)
+	}
+	if res, err := repo.FindAllTemplates(); err != nil || len(res) != 2 {
+		t.Fatalf("\nExpected 2 templates\nReturned: %v ( error: %v )", res, err)
+	}
+	// Subcase: Non-existing template
+	if _, err := repo.FindTemplateByID(234); err != store.ErrTemplateNotFound {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrTemplateNotFound, err)
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	defer clearDB()
+	tmpl := db.Template{ID: 1, Kind: domain.TemplateExpense, Freq: domain.FrequencyDaily, Interval: 1, Start: time.Now()}
+	if err := grmDb.Create(&tmpl).Error; err != nil {
+		t.Fatalf("\nUnexpected Error while creating test template: %v", err)
+	}
+	day := time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)
+	if err := repo.SaveOccurrence(domain.Occurrence{TemplateID: 1, Time: day.AddDate(0, 0, 1), Skipped: true}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := repo.SaveOccurrence(domain.Occurrence{TemplateID: 1, Time: day, ExpenseID: 3}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	// Subcase: Same occurrence twice
+	if err := repo.SaveOccurrence(domain.Occurrence{TemplateID: 1, Time: day, ExpenseID: 4}); err != store.ErrOccurrenceExists {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrOccurrenceExists, err)
+	}
+	res, err := repo.FindOccurrences(1)
+	if err != nil || len(res) != 2 || !res[0].Time.Equal(day) || res[0].ExpenseID != 3 || !res[1].Skipped {
+		t.Fatalf("\nExpected 2 occurrences ordered by time\nReturned: %v ( error: %v )", res, err)
+	}
+	// Subcase: Deleting template deletes occurrences
+	if err := repo.DeleteTemplate(1); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if res, _ := repo.FindOccurrences(1); len(res) != 0 {
+		t.Fatalf("\nExpected occurrences to be deleted\nReturned: %v", res)
+	}
+	if err := repo.DeleteTemplate(1); err != store.ErrTemplateNotFound {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrTemplateNotFound, err)
+	}
+}
+
+func TestSaveOccurrenceExpense(t *testing.T) {
+	defer clearDB()
+	tmpl := db.Template{ID: 1, Kind: domain.TemplateExpense, Freq: domain.FrequencyDaily, Interval: 1, Start: time.Now()}
+	if err := grmDb.Create(&tmpl).Error; err != nil {
+		t.Fatalf("\nUnexpected Error while creating test template: %v", err)
+	}
+	day := time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)
+	exp := domain.Expense{Label: "Rent", Time: day, Value: 80000, Unit: "EUR"}
+	id, err := repo.SaveOccurrenceExpense(domain.Occurrence{TemplateID: 1, Time: day}, exp)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if res, _ := repo.FindOccurrences(1); len(res) != 1 || res[0].ExpenseID != id {
+		t.Fatalf("\nExpected occurrence of expense %d\nReturned: %v", id, res)
+	}
+	// Subcase: Same occurrence twice does not store the expense
+	if _, err := repo.SaveOccurrenceExpense(domain.Occurrence{TemplateID: 1, Time: day}, exp); err != store.ErrOccurrenceExists {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrOccurrenceExists, err)
+	}
+	var count int64
+	if grmDb.Model(&db.Expense{}).Count(&count); count != 1 {
+		t.Fatalf("\nExpected 1 expense\nReturned: %d", count)
+	}
+	// Subcase: Occurrence is not recorded if the expense can not be stored
+	next := day.AddDate(0, 0, 1)
+	exp.ID = id // Conflicting primary key
+	if _, err := repo.SaveOccurrenceExpense(domain.Occurrence{TemplateID: 1, Time: next}, exp); err == nil {
+		t.Fatal("\nExpected an error while storing the expense")
+	}
+	if res, _ := repo.FindOccurrences(1); len(res) != 1 {
+		t.Fatalf("\nExpected the occurrence not to be recorded\nReturned: %v", res)
+	}
+	// Subcase: Unique index rejects occurrences recorded concurrently
+	if err := grmDb.Create(&db.Occurrence{TemplateID: 1, Time: day}).Error; err == nil {
+		t.Fatal("\nExpected the unique index to reject a duplicate occurrence")
+	}
+}
+
+func TestSaveOccurrenceActivity(t *testing.T) {
+	defer clearDB()
+	tmpl := db.Template{ID: 1, Kind: domain.TemplateActivity, Freq: domain.FrequencyDaily, Interval: 1, Start: time.Now()}
+	if err := grmDb.Create(&tmpl).Error; err != nil {
+		t.Fatalf("\nUnexpected Error while creating test template: %v", err)
+	}
+	day := time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)
+	act := domain.Activity{Label: "Gym session", Time: day, Duration: time.Hour}
+	id, err := repo.SaveOccurrenceActivity(domain.Occurrence{TemplateID: 1, Time: day}, act)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if res, _ := repo.FindOccurrences(1); len(res) != 1 || res[0].ActivityID != id {
+		t.Fatalf("\nExpected occurrence of activity %d\nReturned: %v", id, res)
+	}
+	if _, err := repo.SaveOccurrenceActivity(domain.Occurrence{TemplateID: 1, Time: day}, act); err != store.ErrOccurrenceExists {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrOccurrenceExists, err)
+	}
+	var count int64
+	if grmDb.Model(&db.Activity{}).Count(&count); count != 1 {
+		t.Fatalf("\nExpected 1 activity\nReturned: %d", count)
+	}
+}
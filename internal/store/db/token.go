@@ -0,0 +1,64 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"gorm.io/gorm"
+)
+
+// SaveRefreshToken stores the given refresh token in db
+func (repo Repository) SaveRefreshToken(rt domain.RefreshToken) error {
+	return repo.db.Create(&RefreshToken{
+		ID:        rt.ID,
+		Family:    rt.Family,
+		UserID:    rt.UserID,
+		ExpiresAt: rt.ExpiresAt,
+		Used:      rt.Used,
+		Revoked:   rt.Revoked,
+	}).Error
+}
+
+// FindRefreshToken returns the refresh token with given ID.
+// It returns ErrRefreshTokenNotFound if no token was found.
+func (repo Repository) FindRefreshToken(id string) (domain.RefreshToken, error) {
+	var rt RefreshToken
+	err := repo.db.Where("id = ?", id).First(&rt).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = store.ErrRefreshTokenNotFound
+	}
+	return rt.ToDomain(), err
+}
+
+// UseRefreshToken flags the refresh token with given ID as used.
+// The flag is only set if it was not, so a token is used once
+// even by concurrent requests: ErrRefreshTokenUsed is returned otherwise.
+func (repo Repository) UseRefreshToken(id string) error {
+	res := repo.db.Model(&RefreshToken{}).Where("id = ? AND used = ?", id, false).Update("used", true)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected != 1 {
+		return store.ErrRefreshTokenUsed
+	}
+	return nil
+}
+
+// RevokeRefreshTokenFamily flags the refresh tokens of given family as revoked
+func (repo Repository) RevokeRefreshTokenFamily(family string) error {
+	return repo.db.Model(&RefreshToken{}).Where("family = ?", family).Update("revoked", true).Error
+}
+
+// RevokeUserRefreshTokens flags the refresh tokens of the user with given ID as revoked
+func (repo Repository) RevokeUserRefreshTokens(uid domain.UserID) error {
+	return repo.db.Model(&RefreshToken{}).Where("user_id = ?", uid).Update("revoked", true).Error
+}
+
+// PurgeRefreshTokens deletes the refresh tokens expired before given time
+// and returns how many were deleted.
+func (repo Repository) PurgeRefreshTokens(before time.Time) (int, error) {
+	res := repo.db.Where("expires_at < ?", before).Delete(&RefreshToken{})
+	return int(res.RowsAffected), res.Error
+}
@@ -0,0 +1,73 @@
+package db_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+func TestRefreshTokens(t *testing.T) {
+	defer clearDB()
+	exp := time.Now().Add(time.Hour).Round(time.Second)
+	tokens := []domain.RefreshToken{
+		{ID: "a1", Family: "a1", UserID: 1, ExpiresAt: exp},
+		{ID: "a2", Family: "a1", UserID: 1, ExpiresAt: exp},
+		{ID: "b1", Family: "b1", UserID: 1, ExpiresAt: exp},
+		{ID: "c1", Family: "c1", UserID: 2, ExpiresAt: exp},
+	}
+	for _, rt := range tokens {
+		if err := repo.SaveRefreshToken(rt); err != nil {
+			t.Fatalf("\nUnexpected Error: %v", err)
+		}
+	}
+	if rt, err := repo.FindRefreshToken("a1"); err != nil || rt.Family != "a1" || rt.UserID != 1 || !rt.ExpiresAt.Equal(exp) {
+		t.Fatalf("\nExpected token a1\nReturned: %v, %v", rt, err)
+	}
+	if _, err := repo.FindRefreshToken("unknown"); !errors.Is(err, store.ErrRefreshTokenNotFound) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrRefreshTokenNotFound, err)
+	}
+	// A token is used once
+	if err := repo.UseRefreshToken("a1"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := repo.UseRefreshToken("a1"); !errors.Is(err, store.ErrRefreshTokenUsed) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrRefreshTokenUsed, err)
+	}
+	if rt, _ := repo.FindRefreshToken("a1"); !rt.Used {
+		t.Fatal("\nExpected token a1 to be used")
+	}
+	// Revoke family
+	if err := repo.RevokeRefreshTokenFamily("a1"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	for id, revoked := range map[string]bool{"a1": true, "a2": true, "b1": false, "c1": false} {
+		if rt, _ := repo.FindRefreshToken(id); rt.Revoked != revoked {
+			t.Fatalf("\nExpected token %s revoked: %v\nReturned: %v", id, revoked, rt)
+		}
+	}
+	// Revoke user tokens
+	if err := repo.RevokeUserRefreshTokens(1); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	for id, revoked := range map[string]bool{"b1": true, "c1": false} {
+		if rt, _ := repo.FindRefreshToken(id); rt.Revoked != revoked {
+			t.Fatalf("\nExpected token %s revoked: %v\nReturned: %v", id, revoked, rt)
+		}
+	}
+	// Purge expired tokens
+	if err := repo.SaveRefreshToken(domain.RefreshToken{ID: "d1", Family: "d1", UserID: 2, ExpiresAt: time.Now().Add(-time.Hour)}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if purged, err := repo.PurgeRefreshTokens(time.Now()); err != nil || purged != 1 {
+		t.Fatalf("\nExpected 1 purged token\nReturned: %d, %v", purged, err)
+	}
+	if _, err := repo.FindRefreshToken("d1"); !errors.Is(err, store.ErrRefreshTokenNotFound) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrRefreshTokenNotFound, err)
+	}
+	if _, err := repo.FindRefreshToken("c1"); err != nil {
+		t.Fatalf("\nExpected unexpired token c1 to be kept\nReturned Error: %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package db
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"gorm.io/gorm"
+)
+
+// trashed returns a query on the soft-deleted rows only
+func (repo Repository) trashed() *gorm.DB {
+	return repo.owned().Unscoped().Where("deleted_at IS NOT NULL")
+}
+
+// FindTrash returns all items in the trash
+// ordered from the most recently deleted to the oldest
+func (repo Repository) FindTrash() ([]domain.TrashItem, error) {
+	res := []domain.TrashItem{}
+	tags := []Tag{}
+	if err := repo.trashed().Find(&tags).Error; err != nil {
+		return res, err
+	}
+	for _, t := range tags {
+		res = append(res, domain.TrashItem{Kind: domain.TrashTag, ID: uint(t.ID), Label: t.Name, DeletedAt: t.DeletedAt.Time})
+	}
+	activities := []Activity{}
+	if err := repo.trashed().Find(&activities).Error; err != nil {
+		return res, err
+	}
+	for _, act := range activities {
+		res = append(res, domain.TrashItem{Kind: domain.TrashActivity, ID: uint(act.ID), Label: act.Label, DeletedAt: act.DeletedAt.Time})
+	}
+	expenses := []Expense{}
+	if err := repo.trashed().Find(&expenses).Error; err != nil {
+		return res, err
+	}
+	for _, exp := range expenses {
+		res = append(res, domain.TrashItem{Kind: domain.TrashExpense, ID: uint(exp.ID), Label: exp.Label, DeletedAt: exp.DeletedAt.Time})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].DeletedAt.After(res[j].DeletedAt) })
+	return res, nil
+}
+
+// findTrashed fetches the soft-deleted row with given ID into dest.
+// It returns ErrTrashItemNotFound if the row is not in the trash
+func (repo Repository) findTrashed(dest interface{}, id uint) error {
+	err := repo.trashed().Preload("Tags").First(dest, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return store.ErrTrashItemNotFound
+	}
+	return err
+}
+
+// FindTrashedTagByID returns the tag with given ID in the trash.
+// It returns ErrTrashItemNotFound if the tag is not in the trash
+func (repo Repository) FindTrashedTagByID(id domain.TagID) (domain.Tag, error) {
+	var t Tag
+	err := repo.trashed().First(&t, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = store.ErrTrashItemNotFound
+	}
+	return t.ToDomain(), err
+}
+
+// FindTrashedActivityByID returns the activity with given ID in the trash.
+// It returns ErrTrashItemNotFound if the activity is not in the trash
+func (repo Repository) FindTrashedActivityByID(id domain.ActivityID) (domain.Activity, error) {
+	var act Activity
+	err := repo.findTrashed(&act, uint(id))
+	return act.ToDomain(), err
+}
+
+// FindTrashedExpenseByID returns the expense with given ID in the trash.
+// It returns ErrTrashItemNotFound if the expense is not in the trash
+func (repo Repository) FindTrashedExpenseByID(id domain.ExpenseID) (domain.Expense, error) {
+	var exp Expense
+	err := repo.findTrashed(&exp, uint(id))
+	return exp.ToDomain(), err
+}
+
+// restore clears the deletion time of the row of given model with given ID.
+// It returns ErrTrashItemNotFound if the row is not in the trash
+func (repo Repository) restore(model interface{}, id uint) error {
+	res := repo.trashed().Model(model).Where("id = ?", id).Update("deleted_at", nil)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected != 1 {
+		return store.ErrTrashItemNotFound
+	}
+	return nil
+}
+
+// RestoreTag takes the tag with given ID out of the trash
+func (repo Repository) RestoreTag(id domain.TagID) error {
+	return repo.restore(&Tag{}, uint(id))
+}
+
+// RestoreActivity takes the activity with given ID out of the trash
+func (repo Repository) RestoreActivity(id domain.ActivityID) error {
+	return repo.restore(&Activity{}, uint(id))
+}
+
+// RestoreExpense takes the expense with given ID out of the trash
+func (repo Repository) RestoreExpense(id domain.ExpenseID) error {
+	return repo.restore(&Expense{}, uint(id))
+}
+
+// purgeTags permanently deletes the tags with given IDs and their associations
+func purgeTags(tx *gorm.DB, ids []uint) error {
+	for _, table := range []string{"expense_tags", "activity_tags", "template_tags"} {
+		if err := tx.Exec("DELETE FROM "+table+" WHERE tag_id IN ?", ids).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Unscoped().Delete(&Tag{}, ids).Error
+}
+
+// purgeExpenses permanently deletes the expenses with given IDs and their tags associations
+func purgeExpenses(tx *gorm.DB, ids []uint) error {
+	if err := tx.Exec("DELETE FROM expense_tags WHERE expense_id IN ?", ids).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(&Expense{}, ids).Error
+}
+
+// purgeActivities permanently deletes the activities with given IDs,
+// their trashed expenses and their tags associations.
+// Their live expenses are kept and unlinked from them.
+func purgeActivities(tx *gorm.DB, ids []uint) error {
+	var expIDs []uint
+	if err := tx.Unscoped().Model(&Expense{}).Where("activity_id IN ? AND deleted_at IS NOT NULL", ids).Pluck("id", &expIDs).Error; err != nil {
+		return err
+	}
+	if len(expIDs) > 0 {
+		if err := purgeExpenses(tx, expIDs); err != nil {
+			return err
+		}
+	}
+	if err := tx.Model(&Expense{}).Where("activity_id IN ?", ids).Update("activity_id", 0).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM activity_tags WHERE activity_id IN ?", ids).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(&Activity{}, ids).Error
+}
+
+// purge permanently deletes the row of given model with given ID using purgeFunc.
+// It returns ErrTrashItemNotFound if the row is not in the trash
+func (repo Repository) purge(model interface{}, id uint, purgeFunc func(*gorm.DB, []uint) error) error {
+	var count int64
+	if err := repo.trashed().Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count != 1 {
+		return store.ErrTrashItemNotFound
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		return purgeFunc(tx, []uint{id})
+	})
+}
+
+// PurgeTag permanently deletes the tag with given ID from the trash
+func (repo Repository) PurgeTag(id domain.TagID) error {
+	return repo.purge(&Tag{}, uint(id), purgeTags)
+}
+
+// PurgeActivity permanently deletes the activity with given ID from the trash
+// along with its trashed expenses. Its live expenses are unlinked from it.
+func (repo Repository) PurgeActivity(id domain.ActivityID) error {
+	return repo.purge(&Activity{}, uint(id), purgeActivities)
+}
+
+// PurgeExpense permanently deletes the expense with given ID from the trash
+func (repo Repository) PurgeExpense(id domain.ExpenseID) error {
+	return repo.purge(&Expense{}, uint(id), purgeExpenses)
+}
+
+// PurgeTrash permanently deletes the items deleted before given time
+// and returns how many were purged.
+// Everything is purged in one transaction: nothing is purged if one fails.
+func (repo Repository) PurgeTrash(before time.Time) (int, error) {
+	purged := 0
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		purged = 0
+		// Expenses first as purging an activity purges its expenses
+		for _, step := range []struct {
+			model     interface{}
+			purgeFunc func(*gorm.DB, []uint) error
+		}{
+			{&Expense{}, purgeExpenses},
+			{&Activity{}, purgeActivities},
+			{&Tag{}, purgeTags},
+		} {
+			var ids []uint
+			if err := ownedBy(tx, repo.owner).Unscoped().Model(step.model).Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			if len(ids) == 0 {
+				continue
+			}
+			if err := step.purgeFunc(tx, ids); err != nil {
+				return err
+			}
+			purged += len(ids)
+		}
+		return nil
+	})
+	return purged, err
+}
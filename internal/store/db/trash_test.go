@@ -0,0 +1,128 @@
+package db_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/store/db"
+)
+
+func TestTrash(t *testing.T) {
+	defer clearDB()
+	now := time.Now()
+	tag := db.Tag{ID: 1, Name: "sport"}
+	act := db.Activity{ID: 1, Label: "Football", Time: now.AddDate(0, 0, -1), Duration: time.Hour, Tags: []db.Tag{tag}}
+	exp := db.Expense{ID: 1, Label: "Ticket", Time: now.AddDate(0, 0, -1), Value: 1000, Unit: "EUR", ActivityID: 1, Tags: []db.Tag{tag}}
+	if err := grmDb.Create(&act).Error; err != nil {
+		t.Fatalf("\nUnexpected Error while creating test activity: %v", err)
+	}
+	if err := grmDb.Create(&exp).Error; err != nil {
+		t.Fatalf("\nUnexpected Error while creating test expense: %v", err)
+	}
+	// Soft delete everything
+	if err := repo.DeleteExpense(exp.ID); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := repo.DeleteActivity(act.ID); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := repo.DeleteTag(tag.ID); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if _, err := repo.FindActivityByID(act.ID); !errors.Is(err, store.ErrActivityNotFound) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrActivityNotFound, err)
+	}
+	items, err := repo.FindTrash()
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("\nExpected 3 trashed items\nReturned: %v", items)
+	}
+	// Restore Activity with its tag association
+	if err := repo.RestoreTag(tag.ID); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := repo.RestoreActivity(act.ID); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := repo.RestoreActivity(act.ID); !errors.Is(err, store.ErrTrashItemNotFound) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrTrashItemNotFound, err)
+	}
+	restored, err := repo.FindActivityByID(act.ID)
+	if err != nil || len(restored.Tags) != 1 {
+		t.Fatalf("\nExpected restored activity with its tag\nReturned: %v, %v", restored, err)
+	}
+	// Trashed expense
+	trashedExp, err := repo.FindTrashedExpenseByID(exp.ID)
+	if err != nil || trashedExp.ActivityID != act.ID {
+		t.Fatalf("\nExpected trashed expense of activity %d\nReturned: %v, %v", act.ID, trashedExp, err)
+	}
+	// Purge items deleted before now
+	if purged, err := repo.PurgeTrash(now); err != nil || purged != 0 {
+		t.Fatalf("\nExpected nothing purged\nReturned: %d, %v", purged, err)
+	}
+	if purged, err := repo.PurgeTrash(time.Now().Add(time.Second)); err != nil || purged != 1 {
+		t.Fatalf("\nExpected 1 purged item\nReturned: %d, %v", purged, err)
+	}
+	if err := repo.PurgeExpense(exp.ID); !errors.Is(err, store.ErrTrashItemNotFound) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrTrashItemNotFound, err)
+	}
+	var count int64
+	grmDb.Unscoped().Model(&db.Expense{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("\nExpected purged expense\nReturned: %d expenses", count)
+	}
+	// Purge activity
+	if err := repo.DeleteActivity(act.ID); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := repo.PurgeActivity(act.ID); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if items, _ := repo.FindTrash(); len(items) != 0 {
+		t.Fatalf("\nExpected empty trash\nReturned: %v", items)
+	}
+	if _, err := repo.FindTrashedActivityByID(domain.ActivityID(act.ID)); !errors.Is(err, store.ErrTrashItemNotFound) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrTrashItemNotFound, err)
+	}
+}
+
+func TestPurgeActivityKeepsLiveExpenses(t *testing.T) {
+	defer clearDB()
+	yesterday := time.Now().AddDate(0, 0, -1)
+	act := db.Activity{ID: 1, Label: "Football", Time: yesterday, Duration: time.Hour}
+	if err := grmDb.Create(&act).Error; err != nil {
+		t.Fatalf("\nUnexpected Error while creating test activity: %v", err)
+	}
+	for _, exp := range []db.Expense{
+		{ID: 1, Label: "Ticket", Time: yesterday, Value: 1000, Unit: "EUR", ActivityID: 1},
+		{ID: 2, Label: "Drinks", Time: yesterday, Value: 500, Unit: "EUR", ActivityID: 1},
+	} {
+		if err := grmDb.Create(&exp).Error; err != nil {
+			t.Fatalf("\nUnexpected Error while creating test expense: %v", err)
+		}
+	}
+	if err := repo.DeleteExpense(1); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := repo.DeleteActivity(1); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := repo.PurgeActivity(1); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	// Trashed expense is purged with the activity
+	var count int64
+	if grmDb.Unscoped().Model(&db.Expense{}).Where("id = 1").Count(&count); count != 0 {
+		t.Fatal("\nExpected trashed expense to be purged")
+	}
+	// Live expense is kept without its activity
+	exp, err := repo.FindExpenseByID(2)
+	if err != nil || exp.ActivityID != 0 {
+		t.Fatalf("\nExpected live expense kept without activity\nReturned: %v, %v", exp, err)
+	}
+}
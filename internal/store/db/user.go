@@ -0,0 +1,51 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"gorm.io/gorm"
+)
+
+// FindUserByName searches for a user with the given name and returns it.
+// It returns ErrUserNotFound if no user was found.
+func (repo Repository) FindUserByName(name string) (domain.User, error) {
+	var usr User
+	err := repo.db.Where("name = ?", name).First(&usr).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = store.ErrUserNotFound
+	}
+	return usr.ToDomain(), err
+}
+
+// FindUserByID searches for a user with the given ID and returns it.
+// It returns ErrUserNotFound if no user was found.
+func (repo Repository) FindUserByID(id domain.UserID) (domain.User, error) {
+	var usr User
+	err := repo.db.Where("id = ?", id).First(&usr).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = store.ErrUserNotFound
+	}
+	return usr.ToDomain(), err
+}
+
+// FindAllUsers returns all stored users in db ordered by ID
+func (repo Repository) FindAllUsers() ([]domain.User, error) {
+	res := []User{}
+	if err := repo.db.Order("id").Find(&res).Error; err != nil {
+		return []domain.User{}, err
+	}
+	users := make([]domain.User, len(res))
+	for i, usr := range res {
+		users[i] = usr.ToDomain()
+	}
+	return users, nil
+}
+
+// SaveUser stores the given user in db and returns created user's ID
+func (repo Repository) SaveUser(usr domain.User) (domain.UserID, error) {
+	dbUser := User{Name: usr.Name, Hash: usr.PasswordHash}
+	res := repo.db.Create(&dbUser)
+	return dbUser.ID, res.Error
+}
@@ -0,0 +1,108 @@
+package db_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/store/db"
+)
+
+func TestUsers(t *testing.T) {
+	defer clearDB()
+	id, err := repo.SaveUser(domain.User{Name: "hamza", PasswordHash: "hash"})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	usr, err := repo.FindUserByName("hamza")
+	if err != nil || usr.ID != id || usr.PasswordHash != "hash" {
+		t.Fatalf("\nExpected user %d with its hash\nReturned: %v, %v", id, usr, err)
+	}
+	if _, err := repo.FindUserByName("someone"); !errors.Is(err, store.ErrUserNotFound) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrUserNotFound, err)
+	}
+	if usr, err := repo.FindUserByID(id); err != nil || usr.Name != "hamza" {
+		t.Fatalf("\nExpected user hamza\nReturned: %v, %v", usr, err)
+	}
+	if _, err := repo.FindUserByID(id + 1); !errors.Is(err, store.ErrUserNotFound) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrUserNotFound, err)
+	}
+	if _, err := repo.SaveUser(domain.User{Name: "hamza", PasswordHash: "hash"}); err == nil {
+		t.Fatal("\nExpected Error on duplicate user name")
+	}
+	if users, err := repo.FindAllUsers(); err != nil || len(users) != 1 {
+		t.Fatalf("\nExpected 1 user\nReturned: %v, %v", users, err)
+	}
+}
+
+func TestForUser(t *testing.T) {
+	defer clearDB()
+	alice, bob := repo.ForUser(1), repo.ForUser(2)
+	now := time.Now()
+	tid, err := alice.SaveTag(domain.Tag{Name: "sport"})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	tag := domain.Tag{ID: tid, Name: "sport"}
+	aid, err := alice.SaveActivity(domain.Activity{Label: "Football", Time: now.AddDate(0, 0, -1), Duration: time.Hour, Tags: []domain.Tag{tag}})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	eid, err := alice.SaveExpense(domain.Expense{Label: "Ticket", Time: now.AddDate(0, 0, -1), Value: 1000, Unit: "EUR", ActivityID: aid, Tags: []domain.Tag{tag}})
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if _, err := alice.SaveBudget(domain.Budget{TagID: tid, Period: domain.PeriodMonth, Amount: 1000, Currency: "EUR"}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	// Bob sees none of the entities of alice
+	if _, err := bob.FindTagByID(tid); !errors.Is(err, store.ErrTagNotFound) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrTagNotFound, err)
+	}
+	if _, err := bob.FindActivityByID(aid); !errors.Is(err, store.ErrActivityNotFound) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrActivityNotFound, err)
+	}
+	if expenses, _ := bob.FindExpensesByTimeRange(now.AddDate(0, 0, -2), now); len(expenses) != 0 {
+		t.Fatalf("\nExpected no expenses\nReturned: %v", expenses)
+	}
+	if budgets, _ := bob.FindAllBudgets(); len(budgets) != 0 {
+		t.Fatalf("\nExpected no budgets\nReturned: %v", budgets)
+	}
+	if totals, _ := bob.SumExpensesByTag(now.AddDate(0, 0, -2), now); len(totals) != 0 {
+		t.Fatalf("\nExpected no totals\nReturned: %v", totals)
+	}
+	if err := bob.DeleteExpense(eid); !errors.Is(err, store.ErrExpenseNotFound) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", store.ErrExpenseNotFound, err)
+	}
+	if err := bob.EditTag(domain.Tag{ID: tid, Name: "stolen"}); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	// Alice still sees her entities unchanged
+	if res, err := alice.FindTagByID(tid); err != nil || res.Name != "sport" {
+		t.Fatalf("\nExpected tag sport\nReturned: %v, %v", res, err)
+	}
+	if res, err := alice.FindExpenseByID(eid); err != nil || len(res.Tags) != 1 {
+		t.Fatalf("\nExpected expense with its tag\nReturned: %v, %v", res, err)
+	}
+	// Trash is per user too
+	if err := alice.DeleteExpense(eid); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if items, _ := bob.FindTrash(); len(items) != 0 {
+		t.Fatalf("\nExpected empty trash\nReturned: %v", items)
+	}
+	if purged, err := bob.PurgeTrash(time.Now().Add(time.Second)); err != nil || purged != 0 {
+		t.Fatalf("\nExpected nothing purged\nReturned: %d, %v", purged, err)
+	}
+	if items, _ := alice.FindTrash(); len(items) != 1 {
+		t.Fatalf("\nExpected 1 trashed item\nReturned: %v", items)
+	}
+	// Rows are stored with their owner
+	var count int64
+	grmDb.Model(&db.Tag{}).Where("user_id = ?", 1).Count(&count)
+	if count != 1 {
+		t.Fatalf("\nExpected 1 tag of user 1\nReturned: %d", count)
+	}
+}
@@ -1,10 +1,68 @@
 package store
 
-import "errors"
+import "github.com/elhamza90/lifelog/internal/domain"
 
 // Errors
 var (
-	ErrTagNotFound      error = errors.New("Tag not found")
-	ErrExpenseNotFound  error = errors.New("Expense Not Found")
-	ErrActivityNotFound error = errors.New("Activity Not Found")
+	ErrTagNotFound error = &domain.Error{
+		Kind:    domain.KindNotFound,
+		Code:    "tag_not_found",
+		Message: "Tag not found",
+	}
+	ErrExpenseNotFound error = &domain.Error{
+		Kind:    domain.KindNotFound,
+		Code:    "expense_not_found",
+		Message: "Expense Not Found",
+	}
+	ErrActivityNotFound error = &domain.Error{
+		Kind:    domain.KindNotFound,
+		Code:    "activity_not_found",
+		Message: "Activity Not Found",
+	}
+	ErrRateNotFound error = &domain.Error{
+		Kind:    domain.KindNotFound,
+		Code:    "rate_not_found",
+		Message: "Exchange Rate Not Found",
+	}
+	ErrBudgetNotFound error = &domain.Error{
+		Kind:    domain.KindNotFound,
+		Code:    "budget_not_found",
+		Message: "Budget Not Found",
+	}
+	ErrTemplateNotFound error = &domain.Error{
+		Kind:    domain.KindNotFound,
+		Code:    "template_not_found",
+		Message: "Template Not Found",
+	}
+	ErrTrashItemNotFound error = &domain.Error{
+		Kind:    domain.KindNotFound,
+		Code:    "trash_item_not_found",
+		Message: "Item not found in trash",
+	}
+	ErrUserNotFound error = &domain.Error{
+		Kind:    domain.KindNotFound,
+		Code:    "user_not_found",
+		Message: "User Not Found",
+	}
+	ErrRefreshTokenNotFound error = &domain.Error{
+		Kind:    domain.KindNotFound,
+		Code:    "refresh_token_not_found",
+		Message: "Refresh Token Not Found",
+	}
+	ErrRefreshTokenUsed error = &domain.Error{
+		Kind:    domain.KindConflict,
+		Code:    "refresh_token_used",
+		Message: "Refresh Token was already used",
+	}
+	ErrAPIKeyNotFound error = &domain.Error{
+		Kind:    domain.KindNotFound,
+		Code:    "api_key_not_found",
+		Message: "API Key Not Found",
+	}
+	ErrOccurrenceExists error = &domain.Error{
+		Kind:    domain.KindConflict,
+		Code:    "occurrence_exists",
+		Message: "Occurrence was already materialized or skipped",
+		Field:   "time",
+	}
 )
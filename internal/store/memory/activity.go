@@ -17,7 +17,7 @@ func generateRandomActivityID() domain.ActivityID {
 // FindActivityByID returns activity with given ID.
 // If none is found, returns error
 func (repo Repository) FindActivityByID(id domain.ActivityID) (domain.Activity, error) {
-	for _, act := range repo.Activities {
+	for _, act := range repo.activities() {
 		if act.ID == id {
 			return act, nil
 		}
@@ -29,6 +29,7 @@ func (repo Repository) FindActivityByID(id domain.ActivityID) (domain.Activity,
 func (repo Repository) SaveActivity(act domain.Activity) (domain.ActivityID, error) {
 	act.ID = generateRandomActivityID()
 	repo.Activities[act.ID] = act
+	repo.own(string(domain.TrashActivity), uint(act.ID))
 	return act.ID, nil
 }
 
@@ -36,7 +37,7 @@ func (repo Repository) SaveActivity(act domain.Activity) (domain.ActivityID, err
 // with Time field greater than or equal to the given time
 func (repo Repository) FindActivitiesByTime(t time.Time) ([]domain.Activity, error) {
 	res := []domain.Activity{}
-	for _, act := range repo.Activities {
+	for _, act := range repo.activities() {
 		if !act.Time.Before(t) {
 			res = append(res, act)
 		}
@@ -44,10 +45,22 @@ func (repo Repository) FindActivitiesByTime(t time.Time) ([]domain.Activity, err
 	return res, nil
 }
 
+// FindActivitiesByTimeRange returns activities
+// with Time field between from and to ( inclusive )
+func (repo Repository) FindActivitiesByTimeRange(from time.Time, to time.Time) ([]domain.Activity, error) {
+	res := []domain.Activity{}
+	for _, act := range repo.activities() {
+		if inRange(act.Time, from, to) {
+			res = append(res, act)
+		}
+	}
+	return res, nil
+}
+
 // FindActivitiesByTag returns actenses that have the provided tag in their Tags field
 func (repo Repository) FindActivitiesByTag(tid domain.TagID) ([]domain.Activity, error) {
 	res := []domain.Activity{}
-	for _, act := range repo.Activities {
+	for _, act := range repo.activities() {
 		for _, tag := range act.Tags {
 			if tag.ID == tid {
 				res = append(res, act)
@@ -57,9 +70,12 @@ func (repo Repository) FindActivitiesByTag(tid domain.TagID) ([]domain.Activity,
 	return res, nil
 }
 
-// DeleteActivity removes activity with provided ID from memory
+// DeleteActivity moves activity with provided ID to the trash
 func (repo Repository) DeleteActivity(id domain.ActivityID) error {
-	delete(repo.Activities, id)
+	if _, ok := repo.activities()[id]; !ok {
+		return store.ErrActivityNotFound
+	}
+	repo.trash(domain.TrashActivity, uint(id))
 	return nil
 }
 
@@ -68,3 +84,36 @@ func (repo Repository) EditActivity(act domain.Activity) error {
 	repo.Activities[act.ID] = act
 	return nil
 }
+
+// activitiesPage returns the requested page of given activities
+func (repo Repository) activitiesPage(activities []domain.Activity, p store.PageRequest) ([]domain.Activity, store.Page, error) {
+	items := []pageItem{}
+	for _, act := range activities {
+		if hasStatus(act.Status, p.Status) {
+			items = append(items, pageItem{id: uint(act.ID), time: act.Time, label: act.Label})
+		}
+	}
+	items, page, err := paginate(items, p)
+	if err != nil {
+		return []domain.Activity{}, page, err
+	}
+	res := make([]domain.Activity, len(items))
+	for i, it := range items {
+		res[i] = repo.Activities[domain.ActivityID(it.id)]
+	}
+	return res, page, nil
+}
+
+// FindActivitiesByTimeRangePage returns the requested page of activities
+// with Time field between from and to ( inclusive )
+func (repo Repository) FindActivitiesByTimeRangePage(from time.Time, to time.Time, p store.PageRequest) ([]domain.Activity, store.Page, error) {
+	activities, _ := repo.FindActivitiesByTimeRange(from, to)
+	return repo.activitiesPage(activities, p)
+}
+
+// FindActivitiesByTagPage returns the requested page of activities
+// that have the provided tag in their Tags field
+func (repo Repository) FindActivitiesByTagPage(tid domain.TagID, p store.PageRequest) ([]domain.Activity, store.Page, error) {
+	activities, _ := repo.FindActivitiesByTag(tid)
+	return repo.activitiesPage(activities, p)
+}
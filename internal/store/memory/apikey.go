@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// generateRandomAPIKeyID generates a random non-zero ID for API keys
+func generateRandomAPIKeyID() domain.APIKeyID {
+	rand.Seed(time.Now().UnixNano())
+	res := rand.Intn(10000) + 1
+	return domain.APIKeyID(res)
+}
+
+// SaveAPIKey stores the given API key in memory and returns its ID
+func (repo Repository) SaveAPIKey(k domain.APIKey) (domain.APIKeyID, error) {
+	k.ID = generateRandomAPIKeyID()
+	for _, exists := repo.APIKeys[k.ID]; exists; _, exists = repo.APIKeys[k.ID] {
+		k.ID = generateRandomAPIKeyID()
+	}
+	repo.APIKeys[k.ID] = k
+	return k.ID, nil
+}
+
+// FindAPIKeys returns the API keys of the user with given ID
+func (repo Repository) FindAPIKeys(uid domain.UserID) ([]domain.APIKey, error) {
+	res := []domain.APIKey{}
+	for _, k := range repo.APIKeys {
+		if k.UserID == uid {
+			res = append(res, k)
+		}
+	}
+	return res, nil
+}
+
+// FindAPIKey returns the API key with given ID.
+// It returns ErrAPIKeyNotFound if no key was found.
+func (repo Repository) FindAPIKey(id domain.APIKeyID) (domain.APIKey, error) {
+	k, ok := repo.APIKeys[id]
+	if !ok {
+		return domain.APIKey{}, store.ErrAPIKeyNotFound
+	}
+	return k, nil
+}
+
+// FindAPIKeyByHash returns the API key with given hash.
+// It returns ErrAPIKeyNotFound if no key was found.
+func (repo Repository) FindAPIKeyByHash(hash string) (domain.APIKey, error) {
+	for _, k := range repo.APIKeys {
+		if k.Hash == hash {
+			return k, nil
+		}
+	}
+	return domain.APIKey{}, store.ErrAPIKeyNotFound
+}
+
+// DeleteAPIKey deletes the API key with given ID from memory
+func (repo Repository) DeleteAPIKey(id domain.APIKeyID) error {
+	delete(repo.APIKeys, id)
+	return nil
+}
@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// generateRandomAuditEventID generates a random ID for audit events
+func generateRandomAuditEventID() domain.AuditEventID {
+	rand.Seed(time.Now().UnixNano())
+	res := rand.Intn(10000)
+	return domain.AuditEventID(res)
+}
+
+// SaveAuditEvent stores the given audit event in memory and returns its ID
+func (repo Repository) SaveAuditEvent(ev domain.AuditEvent) (domain.AuditEventID, error) {
+	ev.ID = generateRandomAuditEventID()
+	for _, exists := repo.AuditEvents[ev.ID]; exists; _, exists = repo.AuditEvents[ev.ID] {
+		ev.ID = generateRandomAuditEventID()
+	}
+	repo.AuditEvents[ev.ID] = ev
+	repo.own(ownedAuditEvent, uint(ev.ID))
+	return ev.ID, nil
+}
+
+// FindAuditEvents returns the audit events of the entity with given kind & ID
+// ordered from the oldest to the most recent
+func (repo Repository) FindAuditEvents(entity domain.AuditEntity, id uint) ([]domain.AuditEvent, error) {
+	res := []domain.AuditEvent{}
+	for _, ev := range repo.AuditEvents {
+		if ev.Entity == entity && ev.EntityID == id && repo.owns(ownedAuditEvent, uint(ev.ID)) {
+			res = append(res, ev)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Time.Before(res[j].Time) })
+	return res, nil
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+func generateRandomBudgetID() domain.BudgetID {
+	rand.Seed(time.Now().UnixNano())
+	res := rand.Intn(10000)
+	return domain.BudgetID(res)
+}
+
+// FindBudgetByID searches for a budget with the given ID and returns it.
+// It returns ErrBudgetNotFound if no budget was found.
+func (repo Repository) FindBudgetByID(id domain.BudgetID) (domain.Budget, error) {
+	b, ok := repo.budgets()[id]
+	if !ok {
+		return domain.Budget{}, store.ErrBudgetNotFound
+	}
+	return b, nil
+}
+
+// FindAllBudgets returns all stored budgets in memory
+func (repo Repository) FindAllBudgets() ([]domain.Budget, error) {
+	res := []domain.Budget{}
+	for _, b := range repo.budgets() {
+		res = append(res, b)
+	}
+	return res, nil
+}
+
+// FindBudgetsByTag returns budgets limiting expenses with the given tag
+func (repo Repository) FindBudgetsByTag(tid domain.TagID) ([]domain.Budget, error) {
+	res := []domain.Budget{}
+	for _, b := range repo.budgets() {
+		if b.TagID == tid {
+			res = append(res, b)
+		}
+	}
+	return res, nil
+}
+
+// SaveBudget stores the given budget in memory and returns created budget's ID
+func (repo Repository) SaveBudget(b domain.Budget) (domain.BudgetID, error) {
+	b.ID = generateRandomBudgetID()
+	repo.Budgets[b.ID] = b
+	repo.own(ownedBudget, uint(b.ID))
+	return b.ID, nil
+}
+
+// EditBudget edits given budget in memory
+func (repo Repository) EditBudget(b domain.Budget) error {
+	if _, ok := repo.budgets()[b.ID]; !ok {
+		return store.ErrBudgetNotFound
+	}
+	repo.Budgets[b.ID] = b
+	return nil
+}
+
+// DeleteBudget deletes budget from memory
+func (repo Repository) DeleteBudget(id domain.BudgetID) error {
+	if _, ok := repo.budgets()[id]; !ok {
+		return store.ErrBudgetNotFound
+	}
+	delete(repo.Budgets, id)
+	delete(repo.Owners, ownerKey(ownedBudget, uint(id)))
+	return nil
+}
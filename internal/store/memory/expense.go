@@ -17,7 +17,7 @@ func generateRandomExpenseID() domain.ExpenseID {
 // FindExpenseByID returns expense with given ID
 // It returns an error if expense not found
 func (repo Repository) FindExpenseByID(id domain.ExpenseID) (domain.Expense, error) {
-	for _, exp := range repo.Expenses {
+	for _, exp := range repo.expenses() {
 		if exp.ID == id {
 			return exp, nil
 		}
@@ -29,6 +29,7 @@ func (repo Repository) FindExpenseByID(id domain.ExpenseID) (domain.Expense, err
 func (repo Repository) SaveExpense(exp domain.Expense) (domain.ExpenseID, error) {
 	exp.ID = generateRandomExpenseID()
 	repo.Expenses[exp.ID] = exp
+	repo.own(string(domain.TrashExpense), uint(exp.ID))
 	return exp.ID, nil
 }
 
@@ -36,7 +37,7 @@ func (repo Repository) SaveExpense(exp domain.Expense) (domain.ExpenseID, error)
 // field greater than or equal to provided tim
 func (repo Repository) FindExpensesByTime(t time.Time) ([]domain.Expense, error) {
 	res := []domain.Expense{}
-	for _, exp := range repo.Expenses {
+	for _, exp := range repo.expenses() {
 		if !exp.Time.Before(t) {
 			res = append(res, exp)
 		}
@@ -44,10 +45,22 @@ func (repo Repository) FindExpensesByTime(t time.Time) ([]domain.Expense, error)
 	return res, nil
 }
 
+// FindExpensesByTimeRange returns expenses with Time
+// field between from and to ( inclusive )
+func (repo Repository) FindExpensesByTimeRange(from time.Time, to time.Time) ([]domain.Expense, error) {
+	res := []domain.Expense{}
+	for _, exp := range repo.expenses() {
+		if inRange(exp.Time, from, to) {
+			res = append(res, exp)
+		}
+	}
+	return res, nil
+}
+
 // FindExpensesByTag returns expenses that have the provided tag in their Tags field
 func (repo Repository) FindExpensesByTag(tid domain.TagID) ([]domain.Expense, error) {
 	res := []domain.Expense{}
-	for _, exp := range repo.Expenses {
+	for _, exp := range repo.expenses() {
 		for _, tag := range exp.Tags {
 			if tag.ID == tid {
 				res = append(res, exp)
@@ -60,7 +73,7 @@ func (repo Repository) FindExpensesByTag(tid domain.TagID) ([]domain.Expense, er
 // FindExpensesByActivity returns expenses with ActivityID matching given id
 func (repo Repository) FindExpensesByActivity(aid domain.ActivityID) ([]domain.Expense, error) {
 	res := []domain.Expense{}
-	for _, exp := range repo.Expenses {
+	for _, exp := range repo.expenses() {
 		if exp.ActivityID == aid {
 			res = append(res, exp)
 		}
@@ -68,25 +81,25 @@ func (repo Repository) FindExpensesByActivity(aid domain.ActivityID) ([]domain.E
 	return res, nil
 }
 
-// DeleteExpense deletes expense from memory
+// DeleteExpense moves expense to the trash
 func (repo Repository) DeleteExpense(id domain.ExpenseID) error {
-	if _, ok := repo.Expenses[id]; !ok {
+	if _, ok := repo.expenses()[id]; !ok {
 		return store.ErrExpenseNotFound
 	}
-	delete(repo.Expenses, id)
+	repo.trash(domain.TrashExpense, uint(id))
 	return nil
 }
 
-// DeleteExpensesByActivity deletes all expenses with given ActivityID
+// DeleteExpensesByActivity moves all expenses with given ActivityID to the trash
 func (repo Repository) DeleteExpensesByActivity(aid domain.ActivityID) error {
 	ids := []domain.ExpenseID{}
-	for id, exp := range repo.Expenses {
+	for id, exp := range repo.expenses() {
 		if exp.ActivityID == aid {
 			ids = append(ids, id)
 		}
 	}
 	for _, id := range ids {
-		delete(repo.Expenses, id)
+		repo.trash(domain.TrashExpense, uint(id))
 	}
 	return nil
 }
@@ -97,3 +110,36 @@ func (repo Repository) EditExpense(exp domain.Expense) error {
 	repo.Expenses[exp.ID] = exp
 	return nil
 }
+
+// expensesPage returns the requested page of given expenses
+func (repo Repository) expensesPage(expenses []domain.Expense, p store.PageRequest) ([]domain.Expense, store.Page, error) {
+	items := []pageItem{}
+	for _, exp := range expenses {
+		if hasStatus(exp.Status, p.Status) {
+			items = append(items, pageItem{id: uint(exp.ID), time: exp.Time, label: exp.Label, value: int64(exp.Value)})
+		}
+	}
+	items, page, err := paginate(items, p)
+	if err != nil {
+		return []domain.Expense{}, page, err
+	}
+	res := make([]domain.Expense, len(items))
+	for i, it := range items {
+		res[i] = repo.Expenses[domain.ExpenseID(it.id)]
+	}
+	return res, page, nil
+}
+
+// FindExpensesByTimeRangePage returns the requested page of expenses
+// with Time field between from and to ( inclusive )
+func (repo Repository) FindExpensesByTimeRangePage(from time.Time, to time.Time, p store.PageRequest) ([]domain.Expense, store.Page, error) {
+	expenses, _ := repo.FindExpensesByTimeRange(from, to)
+	return repo.expensesPage(expenses, p)
+}
+
+// FindExpensesByTagPage returns the requested page of expenses
+// that have the provided tag in their Tags field
+func (repo Repository) FindExpensesByTagPage(tid domain.TagID, p store.PageRequest) ([]domain.Expense, store.Page, error) {
+	expenses, _ := repo.FindExpensesByTag(tid)
+	return repo.expensesPage(expenses, p)
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"strconv"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// Kinds of owned items which are not in the trash
+const (
+	ownedBudget     string = "budget"
+	ownedTemplate   string = "template"
+	ownedAuditEvent string = "audit"
+)
+
+// ForUser returns a copy of the repository sharing the same maps
+// which only sees and stores the items of the user with given ID
+func (repo Repository) ForUser(id domain.UserID) Repository {
+	repo.Owner = id
+	return repo
+}
+
+// ownerKey returns the key of an item in the Owners map
+func ownerKey(kind string, id uint) string {
+	return kind + "/" + strconv.Itoa(int(id))
+}
+
+// owns checks if the item with given kind & ID belongs to the owner of the repository.
+// Items missing from the Owners map belong to no user ( ID 0 ).
+func (repo Repository) owns(kind string, id uint) bool {
+	return repo.Owners[ownerKey(kind, id)] == repo.Owner
+}
+
+// own makes the owner of the repository the owner of the item with given kind & ID
+func (repo Repository) own(kind string, id uint) {
+	if repo.Owner == 0 {
+		delete(repo.Owners, ownerKey(kind, id))
+		return
+	}
+	repo.Owners[ownerKey(kind, id)] = repo.Owner
+}
+
+// budgets returns the budgets of the owner
+func (repo Repository) budgets() map[domain.BudgetID]domain.Budget {
+	res := map[domain.BudgetID]domain.Budget{}
+	for id, b := range repo.Budgets {
+		if repo.owns(ownedBudget, uint(id)) {
+			res[id] = b
+		}
+	}
+	return res
+}
+
+// templates returns the templates of the owner
+func (repo Repository) templates() map[domain.TemplateID]domain.Template {
+	res := map[domain.TemplateID]domain.Template{}
+	for id, tmpl := range repo.Templates {
+		if repo.owns(ownedTemplate, uint(id)) {
+			res[id] = tmpl
+		}
+	}
+	return res
+}
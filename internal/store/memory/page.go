@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// pageItem holds the ID of an entity and the values it can be sorted with
+type pageItem struct {
+	id    uint
+	time  time.Time
+	label string
+	value int64
+}
+
+// less checks if item a comes before item b when sorting
+// ascendently using the given field then the ID
+func (a pageItem) less(b pageItem, f store.SortField) bool {
+	switch f {
+	case store.SortByTime:
+		if !a.time.Equal(b.time) {
+			return a.time.Before(b.time)
+		}
+	case store.SortByLabel:
+		if a.label != b.label {
+			return a.label < b.label
+		}
+	case store.SortByValue:
+		if a.value != b.value {
+			return a.value < b.value
+		}
+	}
+	return a.id < b.id
+}
+
+// sortValue returns the value of the given sort field of the item
+func (a pageItem) sortValue(f store.SortField) interface{} {
+	switch f {
+	case store.SortByTime:
+		return a.time
+	case store.SortByValue:
+		return a.value
+	default:
+		return a.label
+	}
+}
+
+// paginate sorts the given items and returns the ones in the requested page.
+func paginate(items []pageItem, p store.PageRequest) ([]pageItem, store.Page, error) {
+	page := store.Page{Total: len(items)}
+	before := func(a pageItem, b pageItem) bool {
+		if p.Desc {
+			return b.less(a, p.Sort)
+		}
+		return a.less(b, p.Sort)
+	}
+	sort.Slice(items, func(i, j int) bool { return before(items[i], items[j]) })
+	// Skip items up to the cursor
+	start := 0
+	if p.Cursor != "" {
+		c, err := store.DecodeCursor(p.Cursor)
+		if err != nil {
+			return []pageItem{}, page, err
+		}
+		val, err := c.SortValue(p.Sort)
+		if err != nil {
+			return []pageItem{}, page, err
+		}
+		cur := pageItem{id: c.ID}
+		switch v := val.(type) {
+		case time.Time:
+			cur.time = v
+		case int64:
+			cur.value = v
+		case string:
+			cur.label = v
+		}
+		for start < len(items) && !before(cur, items[start]) {
+			start++
+		}
+	}
+	end := start + p.Limit
+	if end >= len(items) {
+		end = len(items)
+	} else {
+		last := items[end-1]
+		page.NextCursor = store.NewCursor(last.sortValue(p.Sort), last.id).Encode()
+	}
+	return items[start:end], page, nil
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"strings"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// rateKey returns the key identifying a rate of the owner in the Rates map
+func (repo Repository) rateKey(base domain.Currency, quote domain.Currency, date time.Time) string {
+	return repo.Owner.String() + "/" + string(base) + "/" + string(quote) + "/" + date.UTC().Format("2006-01-02")
+}
+
+// SaveExchangeRates stores the given exchange rates of the owner in memory.
+// A rate already stored by the owner for the same currencies and date is replaced.
+// It returns the number of saved rates.
+func (repo Repository) SaveExchangeRates(rates []domain.ExchangeRate) (int, error) {
+	for _, r := range rates {
+		repo.Rates[repo.rateKey(r.Base, r.Quote, r.Date)] = r
+	}
+	return len(rates), nil
+}
+
+// FindExchangeRate returns the most recent rate of base in quote stored by the owner
+// with a date before or equal to the given time.
+// It returns ErrRateNotFound if no rate was found.
+func (repo Repository) FindExchangeRate(base domain.Currency, quote domain.Currency, t time.Time) (domain.ExchangeRate, error) {
+	var (
+		res   domain.ExchangeRate
+		found bool
+	)
+	prefix := repo.Owner.String() + "/"
+	for key, r := range repo.Rates {
+		if !strings.HasPrefix(key, prefix) || r.Base != base || r.Quote != quote || r.Date.After(t) {
+			continue
+		}
+		if !found || r.Date.After(res.Date) {
+			res, found = r, true
+		}
+	}
+	if !found {
+		return domain.ExchangeRate{}, store.ErrRateNotFound
+	}
+	return res, nil
+}
@@ -0,0 +1,140 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// expenseTotalKey identifies an expense total by its group and unit
+type expenseTotalKey struct {
+	group string
+	unit  domain.Currency
+}
+
+// sumExpenses sums values of done expenses between from and to
+// using groupsOf to get the groups each expense belongs to.
+func (repo Repository) sumExpenses(from time.Time, to time.Time, groupsOf func(domain.Expense) []string) []domain.ExpenseTotal {
+	totals := map[expenseTotalKey]domain.ExpenseTotal{}
+	for _, exp := range repo.expenses() {
+		if !exp.Status.Done() || !inRange(exp.Time, from, to) {
+			continue
+		}
+		for _, grp := range groupsOf(exp) {
+			key := expenseTotalKey{group: grp, unit: exp.Unit}
+			total := totals[key]
+			total.Group = grp
+			total.Unit = exp.Unit
+			total.Total += exp.Value
+			total.Count++
+			totals[key] = total
+		}
+	}
+	res := []domain.ExpenseTotal{}
+	for _, total := range totals {
+		res = append(res, total)
+	}
+	return res
+}
+
+// SumExpensesByPeriod returns sums of values of expenses between from and to
+// grouped by period and unit. If tid is not zero, only expenses having
+// the tag are summed. Periods are computed in UTC.
+func (repo Repository) SumExpensesByPeriod(p domain.Period, from time.Time, to time.Time, tid domain.TagID) ([]domain.ExpenseTotal, error) {
+	return repo.sumExpenses(from, to, func(exp domain.Expense) []string {
+		if tid > 0 && !hasTag(exp.Tags, tid) {
+			return []string{}
+		}
+		return []string{p.Key(exp.Time.UTC())}
+	}), nil
+}
+
+// SumExpensesByTag returns sums of values of expenses between from and to
+// grouped by tag name and unit.
+func (repo Repository) SumExpensesByTag(from time.Time, to time.Time) ([]domain.ExpenseTotal, error) {
+	return repo.sumExpenses(from, to, func(exp domain.Expense) []string {
+		names := []string{}
+		for _, t := range exp.Tags {
+			names = append(names, t.Name)
+		}
+		return names
+	}), nil
+}
+
+// SumExpensesByUnit returns sums of values of expenses between from and to
+// grouped by unit.
+func (repo Repository) SumExpensesByUnit(from time.Time, to time.Time) ([]domain.ExpenseTotal, error) {
+	return repo.sumExpenses(from, to, func(exp domain.Expense) []string {
+		return []string{string(exp.Unit)}
+	}), nil
+}
+
+// hasTag checks if a tag with given ID is in tags
+func hasTag(tags []domain.Tag, tid domain.TagID) bool {
+	for _, t := range tags {
+		if t.ID == tid {
+			return true
+		}
+	}
+	return false
+}
+
+// sumActivities sums durations of done activities between from and to
+// using groupsOf to get the groups each activity belongs to.
+func (repo Repository) sumActivities(from time.Time, to time.Time, groupsOf func(domain.Activity) []string) []domain.ActivityTotal {
+	totals := map[string]domain.ActivityTotal{}
+	for _, act := range repo.activities() {
+		if !act.Status.Done() || !inRange(act.Time, from, to) {
+			continue
+		}
+		for _, grp := range groupsOf(act) {
+			total := totals[grp]
+			total.Group = grp
+			total.Total += act.Duration
+			total.Count++
+			totals[grp] = total
+		}
+	}
+	res := []domain.ActivityTotal{}
+	for _, total := range totals {
+		total.Average = total.Total / time.Duration(total.Count)
+		res = append(res, total)
+	}
+	return res
+}
+
+// SumActivitiesByTag returns total durations of activities between from and to
+// grouped by tag name.
+func (repo Repository) SumActivitiesByTag(from time.Time, to time.Time) ([]domain.ActivityTotal, error) {
+	return repo.sumActivities(from, to, func(act domain.Activity) []string {
+		names := []string{}
+		for _, t := range act.Tags {
+			names = append(names, t.Name)
+		}
+		return names
+	}), nil
+}
+
+// SumActivitiesByPlace returns total durations of activities between from and to
+// grouped by place.
+func (repo Repository) SumActivitiesByPlace(from time.Time, to time.Time) ([]domain.ActivityTotal, error) {
+	return repo.sumActivities(from, to, func(act domain.Activity) []string {
+		return []string{act.Place}
+	}), nil
+}
+
+// SumActivitiesByWeekday returns total durations of activities between from and to
+// grouped by the weekday ( in UTC ) they started on.
+func (repo Repository) SumActivitiesByWeekday(from time.Time, to time.Time) ([]domain.ActivityTotal, error) {
+	return repo.sumActivities(from, to, func(act domain.Activity) []string {
+		return []string{act.Time.UTC().Weekday().String()}
+	}), nil
+}
+
+// SumActivitiesByHour returns total durations of activities between from and to
+// grouped by the hour of day ( in UTC ) they started at, formatted as 2 digits.
+func (repo Repository) SumActivitiesByHour(from time.Time, to time.Time) ([]domain.ActivityTotal, error) {
+	return repo.sumActivities(from, to, func(act domain.Activity) []string {
+		return []string{act.Time.UTC().Format("15")}
+	}), nil
+}
@@ -2,21 +2,60 @@ package memory
 
 // This package is used only for testing services
 
-import "github.com/elhamza90/lifelog/internal/domain"
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
 
 // Repository manages data in memory using maps
 type Repository struct {
-	Tags       map[domain.TagID]domain.Tag
-	Expenses   map[domain.ExpenseID]domain.Expense
-	Activities map[domain.ActivityID]domain.Activity
+	Tags          map[domain.TagID]domain.Tag
+	Expenses      map[domain.ExpenseID]domain.Expense
+	Activities    map[domain.ActivityID]domain.Activity
+	Rates         map[string]domain.ExchangeRate // Keyed by owner/base/quote/yyyy-mm-dd
+	Budgets       map[domain.BudgetID]domain.Budget
+	Templates     map[domain.TemplateID]domain.Template
+	Occurrences   map[string]domain.Occurrence // Keyed by template/time
+	Trash         map[string]time.Time         // Deletion times of trashed items keyed by kind/id
+	AuditEvents   map[domain.AuditEventID]domain.AuditEvent
+	Users         map[domain.UserID]domain.User
+	RefreshTokens map[string]domain.RefreshToken // Keyed by token ID
+	APIKeys       map[domain.APIKeyID]domain.APIKey
+	Owners        map[string]domain.UserID       // Owners of items keyed by kind/id
+	Owner         domain.UserID                  // User whose items are seen and stored ( see ForUser )
 }
 
 // NewRepository returns a new memory Repository with
 // map pointers initialized to empty maps
 func NewRepository() Repository {
 	return Repository{
-		Tags:       map[domain.TagID]domain.Tag{},
-		Expenses:   map[domain.ExpenseID]domain.Expense{},
-		Activities: map[domain.ActivityID]domain.Activity{},
+		Tags:          map[domain.TagID]domain.Tag{},
+		Expenses:      map[domain.ExpenseID]domain.Expense{},
+		Activities:    map[domain.ActivityID]domain.Activity{},
+		Rates:         map[string]domain.ExchangeRate{},
+		Budgets:       map[domain.BudgetID]domain.Budget{},
+		Templates:     map[domain.TemplateID]domain.Template{},
+		Occurrences:   map[string]domain.Occurrence{},
+		Trash:         map[string]time.Time{},
+		AuditEvents:   map[domain.AuditEventID]domain.AuditEvent{},
+		Users:         map[domain.UserID]domain.User{},
+		RefreshTokens: map[string]domain.RefreshToken{},
+		APIKeys:       map[domain.APIKeyID]domain.APIKey{},
+		Owners:        map[string]domain.UserID{},
+	}
+}
+
+// hasStatus checks if st matches the status filter ( empty for all ).
+// An empty status is considered done.
+func hasStatus(st domain.Status, filter domain.Status) bool {
+	if st == "" {
+		st = domain.StatusDone
 	}
+	return filter == "" || st == filter
+}
+
+// inRange checks if t is between from and to ( inclusive )
+func inRange(t time.Time, from time.Time, to time.Time) bool {
+	return !t.Before(from) && !t.After(to)
 }
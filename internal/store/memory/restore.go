@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// Restore stores the given tags, activities and expenses in memory with new IDs.
+// The IDs of the given entities are only used to resolve the tags of activities
+// & expenses and the activity of expenses, which are rewritten to the new IDs.
+// It returns the new IDs in the order of the given entities.
+// References are all resolved before anything is stored.
+func (repo Repository) Restore(tags []domain.Tag, activities []domain.Activity, expenses []domain.Expense) ([]domain.TagID, []domain.ActivityID, []domain.ExpenseID, error) {
+	var (
+		tagIDs      = make([]domain.TagID, len(tags))
+		activityIDs = make([]domain.ActivityID, len(activities))
+		expenseIDs  = make([]domain.ExpenseID, len(expenses))
+		newTags     = map[domain.TagID]domain.Tag{}
+		newActs     = map[domain.ActivityID]domain.ActivityID{}
+		usedTags    = map[domain.TagID]bool{}      // New IDs must not collide
+		usedActs    = map[domain.ActivityID]bool{} // with each other either
+		usedExps    = map[domain.ExpenseID]bool{}
+	)
+	for i, t := range tags {
+		id := generateRandomTagID()
+		for _, exists := repo.Tags[id]; exists || usedTags[id]; _, exists = repo.Tags[id] {
+			id = generateRandomTagID()
+		}
+		usedTags[id] = true
+		newTags[t.ID] = domain.Tag{ID: id, Name: t.Name}
+		tagIDs[i] = id
+	}
+	restoredActs := make([]domain.Activity, len(activities))
+	for i, act := range activities {
+		var err error
+		if act.Tags, err = rewriteTags(act.Tags, newTags); err != nil {
+			return nil, nil, nil, err
+		}
+		id := generateRandomActivityID()
+		for _, exists := repo.Activities[id]; exists || usedActs[id]; _, exists = repo.Activities[id] {
+			id = generateRandomActivityID()
+		}
+		usedActs[id] = true
+		newActs[act.ID] = id
+		act.ID, activityIDs[i] = id, id
+		restoredActs[i] = act
+	}
+	restoredExps := make([]domain.Expense, len(expenses))
+	for i, exp := range expenses {
+		var err error
+		if exp.Tags, err = rewriteTags(exp.Tags, newTags); err != nil {
+			return nil, nil, nil, err
+		}
+		if exp.ActivityID > 0 {
+			actID, ok := newActs[exp.ActivityID]
+			if !ok {
+				return nil, nil, nil, store.ErrActivityNotFound
+			}
+			exp.ActivityID = actID
+		}
+		id := generateRandomExpenseID()
+		for _, exists := repo.Expenses[id]; exists || usedExps[id]; _, exists = repo.Expenses[id] {
+			id = generateRandomExpenseID()
+		}
+		usedExps[id] = true
+		exp.ID, expenseIDs[i] = id, id
+		restoredExps[i] = exp
+	}
+	for _, t := range newTags {
+		repo.Tags[t.ID] = t
+		repo.own(string(domain.TrashTag), uint(t.ID))
+	}
+	for _, act := range restoredActs {
+		repo.Activities[act.ID] = act
+		repo.own(string(domain.TrashActivity), uint(act.ID))
+	}
+	for _, exp := range restoredExps {
+		repo.Expenses[exp.ID] = exp
+		repo.own(string(domain.TrashExpense), uint(exp.ID))
+	}
+	return tagIDs, activityIDs, expenseIDs, nil
+}
+
+// rewriteTags returns the restored tags of the given tags.
+// It returns ErrTagNotFound if one of them was not restored.
+func rewriteTags(tags []domain.Tag, newTags map[domain.TagID]domain.Tag) ([]domain.Tag, error) {
+	res := make([]domain.Tag, len(tags))
+	for i, t := range tags {
+		newTag, ok := newTags[t.ID]
+		if !ok {
+			return nil, store.ErrTagNotFound
+		}
+		res[i] = newTag
+	}
+	return res, nil
+}
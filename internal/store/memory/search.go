@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// searchIndex maps tokens to the IDs of the entities containing them.
+type searchIndex map[string]map[uint]bool
+
+// add indexes the tokens of given texts for the entity with given ID
+func (idx searchIndex) add(id uint, texts ...string) {
+	for _, text := range texts {
+		for _, tok := range store.Tokenize(text) {
+			if idx[tok] == nil {
+				idx[tok] = map[uint]bool{}
+			}
+			idx[tok][id] = true
+		}
+	}
+}
+
+// match returns IDs of entities containing all given terms
+func (idx searchIndex) match(terms []string) []uint {
+	ids := []uint{}
+	if len(terms) == 0 {
+		return ids
+	}
+	for id := range idx[terms[0]] {
+		found := true
+		for _, term := range terms[1:] {
+			if !idx[term][id] {
+				found = false
+				break
+			}
+		}
+		if found {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// limitSearchResults sorts results by rank then time descendent
+// and returns the first limit ones.
+func limitSearchResults(res []domain.SearchResult, limit int) []domain.SearchResult {
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Rank != res[j].Rank {
+			return res[i].Rank > res[j].Rank
+		}
+		return res[i].Time.After(res[j].Time)
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res
+}
+
+// SearchActivities returns at most limit activities whose Label, Desc
+// or Place contain all words of the query. Best ranked ones come first.
+func (repo Repository) SearchActivities(q string, limit int) ([]domain.SearchResult, error) {
+	idx := searchIndex{}
+	for id, act := range repo.activities() {
+		idx.add(uint(id), act.Label, act.Place, act.Desc)
+	}
+	terms := store.Tokenize(q)
+	res := []domain.SearchResult{}
+	for _, id := range idx.match(terms) {
+		act := repo.Activities[domain.ActivityID(id)]
+		res = append(res, domain.SearchResult{
+			Kind:  domain.SearchResultActivity,
+			ID:    id,
+			Label: act.Label,
+			Time:  act.Time,
+			Rank: store.SearchRank(terms,
+				store.SearchField{Text: act.Label, Weight: store.SearchWeightLabel},
+				store.SearchField{Text: act.Place, Weight: store.SearchWeightPlace},
+				store.SearchField{Text: act.Desc, Weight: store.SearchWeightDesc}),
+		})
+	}
+	return limitSearchResults(res, limit), nil
+}
+
+// SearchExpenses returns at most limit expenses whose Label
+// contains all words of the query. Best ranked ones come first.
+func (repo Repository) SearchExpenses(q string, limit int) ([]domain.SearchResult, error) {
+	idx := searchIndex{}
+	for id, exp := range repo.expenses() {
+		idx.add(uint(id), exp.Label)
+	}
+	terms := store.Tokenize(q)
+	res := []domain.SearchResult{}
+	for _, id := range idx.match(terms) {
+		exp := repo.Expenses[domain.ExpenseID(id)]
+		res = append(res, domain.SearchResult{
+			Kind:  domain.SearchResultExpense,
+			ID:    id,
+			Label: exp.Label,
+			Time:  exp.Time,
+			Rank:  store.SearchRank(terms, store.SearchField{Text: exp.Label, Weight: store.SearchWeightLabel}),
+		})
+	}
+	return limitSearchResults(res, limit), nil
+}
@@ -17,7 +17,7 @@ func generateRandomTagID() domain.TagID {
 // FindTagByID searches for a tag with the given ID and returns it.
 // It returns ErrTagNotFound if no tag was found.
 func (repo Repository) FindTagByID(id domain.TagID) (domain.Tag, error) {
-	for _, t := range repo.Tags {
+	for _, t := range repo.tags() {
 		if t.ID == id {
 			return t, nil
 		}
@@ -28,7 +28,7 @@ func (repo Repository) FindTagByID(id domain.TagID) (domain.Tag, error) {
 // FindTagByName searches for a tag with the given name and returns it.
 // It returns an Empty Tag if not found.
 func (repo Repository) FindTagByName(n string) (domain.Tag, error) {
-	for _, t := range repo.Tags {
+	for _, t := range repo.tags() {
 		if t.Name == n {
 			return t, nil
 		}
@@ -40,24 +40,43 @@ func (repo Repository) FindTagByName(n string) (domain.Tag, error) {
 func (repo Repository) SaveTag(t domain.Tag) (domain.TagID, error) {
 	t.ID = generateRandomTagID()
 	repo.Tags[t.ID] = t
+	repo.own(string(domain.TrashTag), uint(t.ID))
 	return t.ID, nil
 }
 
 // FindAllTags returns all stored tags in memory
 func (repo Repository) FindAllTags() ([]domain.Tag, error) {
 	tags := []domain.Tag{}
-	for _, t := range repo.Tags {
+	for _, t := range repo.tags() {
 		tags = append(tags, t)
 	}
 	return tags, nil
 }
 
-// DeleteTag deletes tag from memory
+// FindTagsPage returns the requested page of tags stored in memory.
+// Tags can only be sorted by name ( label ).
+func (repo Repository) FindTagsPage(p store.PageRequest) ([]domain.Tag, store.Page, error) {
+	items := []pageItem{}
+	for _, t := range repo.tags() {
+		items = append(items, pageItem{id: uint(t.ID), label: t.Name})
+	}
+	items, page, err := paginate(items, p)
+	if err != nil {
+		return []domain.Tag{}, page, err
+	}
+	res := make([]domain.Tag, len(items))
+	for i, it := range items {
+		res[i] = repo.Tags[domain.TagID(it.id)]
+	}
+	return res, page, nil
+}
+
+// DeleteTag moves tag to the trash
 func (repo Repository) DeleteTag(id domain.TagID) error {
-	if _, ok := repo.Tags[id]; !ok {
+	if _, ok := repo.tags()[id]; !ok {
 		return store.ErrTagNotFound
 	}
-	delete(repo.Tags, id)
+	repo.trash(domain.TrashTag, uint(id))
 	return nil
 }
 
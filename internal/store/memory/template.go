@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+func generateRandomTemplateID() domain.TemplateID {
+	rand.Seed(time.Now().UnixNano())
+	res := rand.Intn(10000)
+	return domain.TemplateID(res)
+}
+
+// occurrenceKey returns the key of an occurrence in the Occurrences map
+func occurrenceKey(tid domain.TemplateID, t time.Time) string {
+	return tid.String() + "/" + t.UTC().Format(time.RFC3339Nano)
+}
+
+// FindTemplateByID searches for a template with the given ID and returns it.
+// It returns ErrTemplateNotFound if no template was found.
+func (repo Repository) FindTemplateByID(id domain.TemplateID) (domain.Template, error) {
+	tmpl, ok := repo.templates()[id]
+	if !ok {
+		return domain.Template{}, store.ErrTemplateNotFound
+	}
+	return tmpl, nil
+}
+
+// FindAllTemplates returns all stored templates in memory
+func (repo Repository) FindAllTemplates() ([]domain.Template, error) {
+	res := []domain.Template{}
+	for _, tmpl := range repo.templates() {
+		res = append(res, tmpl)
+	}
+	return res, nil
+}
+
+// SaveTemplate stores the given template in memory and returns created template's ID
+func (repo Repository) SaveTemplate(tmpl domain.Template) (domain.TemplateID, error) {
+	tmpl.ID = generateRandomTemplateID()
+	repo.Templates[tmpl.ID] = tmpl
+	repo.own(ownedTemplate, uint(tmpl.ID))
+	return tmpl.ID, nil
+}
+
+// DeleteTemplate deletes template and its occurrences from memory
+func (repo Repository) DeleteTemplate(id domain.TemplateID) error {
+	if _, ok := repo.templates()[id]; !ok {
+		return store.ErrTemplateNotFound
+	}
+	delete(repo.Templates, id)
+	delete(repo.Owners, ownerKey(ownedTemplate, uint(id)))
+	for key, occ := range repo.Occurrences {
+		if occ.TemplateID == id {
+			delete(repo.Occurrences, key)
+		}
+	}
+	return nil
+}
+
+// SaveOccurrence records the given occurrence of a template in memory.
+// It returns ErrOccurrenceExists if the occurrence was already recorded.
+func (repo Repository) SaveOccurrence(occ domain.Occurrence) error {
+	key := occurrenceKey(occ.TemplateID, occ.Time)
+	if _, ok := repo.Occurrences[key]; ok {
+		return store.ErrOccurrenceExists
+	}
+	repo.Occurrences[key] = occ
+	return nil
+}
+
+// SaveOccurrenceActivity records the given occurrence of a template in memory
+// with the given activity, which it stores and returns the ID of.
+// It returns ErrOccurrenceExists if the occurrence was already recorded.
+func (repo Repository) SaveOccurrenceActivity(occ domain.Occurrence, act domain.Activity) (domain.ActivityID, error) {
+	if _, ok := repo.Occurrences[occurrenceKey(occ.TemplateID, occ.Time)]; ok {
+		return 0, store.ErrOccurrenceExists
+	}
+	id, err := repo.SaveActivity(act)
+	if err != nil {
+		return 0, err
+	}
+	occ.ActivityID = id
+	return id, repo.SaveOccurrence(occ)
+}
+
+// SaveOccurrenceExpense records the given occurrence of a template in memory
+// with the given expense, which it stores and returns the ID of.
+// It returns ErrOccurrenceExists if the occurrence was already recorded.
+func (repo Repository) SaveOccurrenceExpense(occ domain.Occurrence, exp domain.Expense) (domain.ExpenseID, error) {
+	if _, ok := repo.Occurrences[occurrenceKey(occ.TemplateID, occ.Time)]; ok {
+		return 0, store.ErrOccurrenceExists
+	}
+	id, err := repo.SaveExpense(exp)
+	if err != nil {
+		return 0, err
+	}
+	occ.ExpenseID = id
+	return id, repo.SaveOccurrence(occ)
+}
+
+// FindOccurrences returns recorded occurrences of the given template ordered by time
+func (repo Repository) FindOccurrences(tid domain.TemplateID) ([]domain.Occurrence, error) {
+	res := []domain.Occurrence{}
+	for _, occ := range repo.Occurrences {
+		if occ.TemplateID == tid {
+			res = append(res, occ)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Time.Before(res[j].Time) })
+	return res, nil
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// Throttles stores the state of rate limited keys in memory.
+// Unlike Repository, it is also used by the server as rate limiting is in-process.
+type Throttles map[string]domain.Throttle
+
+// FindThrottle returns the throttle of given key
+// or an empty throttle if the key is unknown
+func (t Throttles) FindThrottle(key string) (domain.Throttle, error) {
+	if th, ok := t[key]; ok {
+		return th, nil
+	}
+	return domain.Throttle{Key: key}, nil
+}
+
+// SaveThrottle stores the given throttle in memory
+func (t Throttles) SaveThrottle(th domain.Throttle) error {
+	t[th.Key] = th
+	return nil
+}
+
+// DeleteThrottlesBefore deletes the throttles last seen before given time
+// and returns their number
+func (t Throttles) DeleteThrottlesBefore(before time.Time) (int, error) {
+	deleted := 0
+	for key, th := range t {
+		if th.LastSeen.Before(before) {
+			delete(t, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
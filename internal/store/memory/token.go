@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// SaveRefreshToken stores the given refresh token in memory
+func (repo Repository) SaveRefreshToken(rt domain.RefreshToken) error {
+	repo.RefreshTokens[rt.ID] = rt
+	return nil
+}
+
+// FindRefreshToken returns the refresh token with given ID.
+// It returns ErrRefreshTokenNotFound if no token was found.
+func (repo Repository) FindRefreshToken(id string) (domain.RefreshToken, error) {
+	rt, ok := repo.RefreshTokens[id]
+	if !ok {
+		return domain.RefreshToken{}, store.ErrRefreshTokenNotFound
+	}
+	return rt, nil
+}
+
+// UseRefreshToken flags the refresh token with given ID as used.
+// It returns ErrRefreshTokenUsed if it was already used.
+func (repo Repository) UseRefreshToken(id string) error {
+	rt, ok := repo.RefreshTokens[id]
+	if !ok {
+		return store.ErrRefreshTokenNotFound
+	}
+	if rt.Used {
+		return store.ErrRefreshTokenUsed
+	}
+	rt.Used = true
+	repo.RefreshTokens[id] = rt
+	return nil
+}
+
+// RevokeRefreshTokenFamily flags the refresh tokens of given family as revoked
+func (repo Repository) RevokeRefreshTokenFamily(family string) error {
+	for id, rt := range repo.RefreshTokens {
+		if rt.Family == family {
+			rt.Revoked = true
+			repo.RefreshTokens[id] = rt
+		}
+	}
+	return nil
+}
+
+// RevokeUserRefreshTokens flags the refresh tokens of the user with given ID as revoked
+func (repo Repository) RevokeUserRefreshTokens(uid domain.UserID) error {
+	for id, rt := range repo.RefreshTokens {
+		if rt.UserID == uid {
+			rt.Revoked = true
+			repo.RefreshTokens[id] = rt
+		}
+	}
+	return nil
+}
+
+// PurgeRefreshTokens deletes the refresh tokens expired before given time
+// and returns how many were deleted
+func (repo Repository) PurgeRefreshTokens(before time.Time) (int, error) {
+	purged := 0
+	for id, rt := range repo.RefreshTokens {
+		if rt.ExpiresAt.Before(before) {
+			delete(repo.RefreshTokens, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,204 @@
+package memory
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// trashKey returns the key of an item in the Trash map
+func trashKey(kind domain.TrashItemKind, id uint) string {
+	return string(kind) + "/" + strconv.Itoa(int(id))
+}
+
+// trashed checks if the item with given kind & ID of the owner is in the trash
+func (repo Repository) trashed(kind domain.TrashItemKind, id uint) bool {
+	_, ok := repo.Trash[trashKey(kind, id)]
+	return ok && repo.owns(string(kind), id)
+}
+
+// trash flags the item with given kind & ID as deleted now
+func (repo Repository) trash(kind domain.TrashItemKind, id uint) {
+	repo.Trash[trashKey(kind, id)] = time.Now()
+}
+
+// tags returns the tags of the owner which are not in the trash
+func (repo Repository) tags() map[domain.TagID]domain.Tag {
+	res := map[domain.TagID]domain.Tag{}
+	for id, t := range repo.Tags {
+		if repo.owns(string(domain.TrashTag), uint(id)) && !repo.trashed(domain.TrashTag, uint(id)) {
+			res[id] = t
+		}
+	}
+	return res
+}
+
+// activities returns the activities of the owner which are not in the trash
+func (repo Repository) activities() map[domain.ActivityID]domain.Activity {
+	res := map[domain.ActivityID]domain.Activity{}
+	for id, act := range repo.Activities {
+		if repo.owns(string(domain.TrashActivity), uint(id)) && !repo.trashed(domain.TrashActivity, uint(id)) {
+			res[id] = act
+		}
+	}
+	return res
+}
+
+// expenses returns the expenses of the owner which are not in the trash
+func (repo Repository) expenses() map[domain.ExpenseID]domain.Expense {
+	res := map[domain.ExpenseID]domain.Expense{}
+	for id, exp := range repo.Expenses {
+		if repo.owns(string(domain.TrashExpense), uint(id)) && !repo.trashed(domain.TrashExpense, uint(id)) {
+			res[id] = exp
+		}
+	}
+	return res
+}
+
+// FindTrash returns all items in the trash
+// ordered from the most recently deleted to the oldest
+func (repo Repository) FindTrash() ([]domain.TrashItem, error) {
+	res := []domain.TrashItem{}
+	add := func(kind domain.TrashItemKind, id uint, label string) {
+		if deleted, ok := repo.Trash[trashKey(kind, id)]; ok && repo.owns(string(kind), id) {
+			res = append(res, domain.TrashItem{Kind: kind, ID: id, Label: label, DeletedAt: deleted})
+		}
+	}
+	for id, t := range repo.Tags {
+		add(domain.TrashTag, uint(id), t.Name)
+	}
+	for id, act := range repo.Activities {
+		add(domain.TrashActivity, uint(id), act.Label)
+	}
+	for id, exp := range repo.Expenses {
+		add(domain.TrashExpense, uint(id), exp.Label)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].DeletedAt.After(res[j].DeletedAt) })
+	return res, nil
+}
+
+// FindTrashedTagByID returns the tag with given ID in the trash.
+// It returns ErrTrashItemNotFound if the tag is not in the trash
+func (repo Repository) FindTrashedTagByID(id domain.TagID) (domain.Tag, error) {
+	t, ok := repo.Tags[id]
+	if !ok || !repo.trashed(domain.TrashTag, uint(id)) {
+		return domain.Tag{}, store.ErrTrashItemNotFound
+	}
+	return t, nil
+}
+
+// FindTrashedActivityByID returns the activity with given ID in the trash.
+// It returns ErrTrashItemNotFound if the activity is not in the trash
+func (repo Repository) FindTrashedActivityByID(id domain.ActivityID) (domain.Activity, error) {
+	act, ok := repo.Activities[id]
+	if !ok || !repo.trashed(domain.TrashActivity, uint(id)) {
+		return domain.Activity{}, store.ErrTrashItemNotFound
+	}
+	return act, nil
+}
+
+// FindTrashedExpenseByID returns the expense with given ID in the trash.
+// It returns ErrTrashItemNotFound if the expense is not in the trash
+func (repo Repository) FindTrashedExpenseByID(id domain.ExpenseID) (domain.Expense, error) {
+	exp, ok := repo.Expenses[id]
+	if !ok || !repo.trashed(domain.TrashExpense, uint(id)) {
+		return domain.Expense{}, store.ErrTrashItemNotFound
+	}
+	return exp, nil
+}
+
+// RestoreTag takes the tag with given ID out of the trash
+func (repo Repository) RestoreTag(id domain.TagID) error {
+	return repo.restore(domain.TrashTag, uint(id))
+}
+
+// RestoreActivity takes the activity with given ID out of the trash
+func (repo Repository) RestoreActivity(id domain.ActivityID) error {
+	return repo.restore(domain.TrashActivity, uint(id))
+}
+
+// RestoreExpense takes the expense with given ID out of the trash
+func (repo Repository) RestoreExpense(id domain.ExpenseID) error {
+	return repo.restore(domain.TrashExpense, uint(id))
+}
+
+// restore removes the deletion flag of the item with given kind & ID
+func (repo Repository) restore(kind domain.TrashItemKind, id uint) error {
+	if !repo.trashed(kind, id) {
+		return store.ErrTrashItemNotFound
+	}
+	delete(repo.Trash, trashKey(kind, id))
+	return nil
+}
+
+// PurgeTag permanently deletes the tag with given ID from the trash
+func (repo Repository) PurgeTag(id domain.TagID) error {
+	if !repo.trashed(domain.TrashTag, uint(id)) {
+		return store.ErrTrashItemNotFound
+	}
+	delete(repo.Tags, id)
+	delete(repo.Trash, trashKey(domain.TrashTag, uint(id)))
+	return nil
+}
+
+// PurgeActivity permanently deletes the activity with given ID from the trash
+// along with its trashed expenses. Its live expenses are unlinked from it.
+func (repo Repository) PurgeActivity(id domain.ActivityID) error {
+	if !repo.trashed(domain.TrashActivity, uint(id)) {
+		return store.ErrTrashItemNotFound
+	}
+	for expID, exp := range repo.Expenses {
+		if exp.ActivityID != id || !repo.owns(string(domain.TrashExpense), uint(expID)) {
+			continue
+		}
+		if repo.trashed(domain.TrashExpense, uint(expID)) {
+			delete(repo.Expenses, expID)
+			delete(repo.Trash, trashKey(domain.TrashExpense, uint(expID)))
+		} else {
+			exp.ActivityID = 0
+			repo.Expenses[expID] = exp
+		}
+	}
+	delete(repo.Activities, id)
+	delete(repo.Trash, trashKey(domain.TrashActivity, uint(id)))
+	return nil
+}
+
+// PurgeExpense permanently deletes the expense with given ID from the trash
+func (repo Repository) PurgeExpense(id domain.ExpenseID) error {
+	if !repo.trashed(domain.TrashExpense, uint(id)) {
+		return store.ErrTrashItemNotFound
+	}
+	delete(repo.Expenses, id)
+	delete(repo.Trash, trashKey(domain.TrashExpense, uint(id)))
+	return nil
+}
+
+// PurgeTrash permanently deletes the items deleted before given time
+// and returns how many were purged
+func (repo Repository) PurgeTrash(before time.Time) (int, error) {
+	items, _ := repo.FindTrash()
+	purged := 0
+	// Expenses first as purging an activity purges its expenses
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Kind == domain.TrashExpense && items[j].Kind != domain.TrashExpense
+	})
+	for _, it := range items {
+		if !it.DeletedAt.Before(before) || !repo.trashed(it.Kind, it.ID) {
+			continue
+		}
+		switch it.Kind {
+		case domain.TrashTag:
+			repo.PurgeTag(domain.TagID(it.ID))
+		case domain.TrashActivity:
+			repo.PurgeActivity(domain.ActivityID(it.ID))
+		case domain.TrashExpense:
+			repo.PurgeExpense(domain.ExpenseID(it.ID))
+		}
+		purged++
+	}
+	return purged, nil
+}
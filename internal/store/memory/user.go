@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// generateRandomUserID generates a random non-zero ID for users
+func generateRandomUserID() domain.UserID {
+	rand.Seed(time.Now().UnixNano())
+	res := rand.Intn(10000) + 1
+	return domain.UserID(res)
+}
+
+// FindUserByName searches for a user with the given name and returns it.
+// It returns ErrUserNotFound if no user was found.
+func (repo Repository) FindUserByName(name string) (domain.User, error) {
+	for _, usr := range repo.Users {
+		if usr.Name == name {
+			return usr, nil
+		}
+	}
+	return domain.User{}, store.ErrUserNotFound
+}
+
+// FindUserByID searches for a user with the given ID and returns it.
+// It returns ErrUserNotFound if no user was found.
+func (repo Repository) FindUserByID(id domain.UserID) (domain.User, error) {
+	usr, ok := repo.Users[id]
+	if !ok {
+		return domain.User{}, store.ErrUserNotFound
+	}
+	return usr, nil
+}
+
+// FindAllUsers returns all stored users in memory
+func (repo Repository) FindAllUsers() ([]domain.User, error) {
+	res := []domain.User{}
+	for _, usr := range repo.Users {
+		res = append(res, usr)
+	}
+	return res, nil
+}
+
+// SaveUser stores the given user in memory and returns its ID
+func (repo Repository) SaveUser(usr domain.User) (domain.UserID, error) {
+	usr.ID = generateRandomUserID()
+	for _, exists := repo.Users[usr.ID]; exists; _, exists = repo.Users[usr.ID] {
+		usr.ID = generateRandomUserID()
+	}
+	repo.Users[usr.ID] = usr
+	return usr.ID, nil
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// SortField specifies the field a list is sorted by
+type SortField string
+
+// Sort Fields
+const (
+	SortByTime  SortField = "time"
+	SortByLabel SortField = "label" // Name for tags
+	SortByValue SortField = "value"
+)
+
+// PageRequest specifies which page of a sorted list must be returned.
+// Lists are sorted by the sort field then by ID to break ties.
+type PageRequest struct {
+	Limit  int    // Maximum number of items in the page
+	Cursor string // Cursor returned with the previous page. Empty for first page
+	Sort   SortField
+	Desc   bool
+	Status domain.Status // Only activities & expenses with this status. Empty for all
+}
+
+// Page contains information about a returned page of a list
+type Page struct {
+	NextCursor string // Empty when there are no more items
+	Total      int    // Number of items in the whole list
+}
+
+// Cursor points to the last item of a page.
+// The next page starts right after it.
+type Cursor struct {
+	Value string `json:"v"` // Value of the sort field of the item
+	ID    uint   `json:"id"`
+}
+
+// ErrCursorInvalid is returned when a page cursor can not be decoded
+var ErrCursorInvalid error = &domain.Error{
+	Kind:    domain.KindInvalid,
+	Code:    "cursor_invalid",
+	Message: "Page cursor is invalid",
+	Field:   "cursor",
+}
+
+// NewCursor constructs a cursor pointing to the item with given ID and sort value.
+// The sort value must be a time.Time, a string or an int64 amount.
+func NewCursor(val interface{}, id uint) Cursor {
+	c := Cursor{ID: id}
+	switch v := val.(type) {
+	case time.Time:
+		// Offset is kept so the time can be compared to the stored one
+		c.Value = v.Format(time.RFC3339Nano)
+	case string:
+		c.Value = v
+	case int64:
+		c.Value = strconv.FormatInt(v, 10)
+	}
+	return c
+}
+
+// Encode returns an opaque string representation of the cursor
+func (c Cursor) Encode() string {
+	js, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(js)
+}
+
+// DecodeCursor decodes a cursor returned by Cursor.Encode
+func DecodeCursor(str string) (Cursor, error) {
+	var c Cursor
+	js, err := base64.RawURLEncoding.DecodeString(str)
+	if err != nil {
+		return c, ErrCursorInvalid
+	}
+	if err := json.Unmarshal(js, &c); err != nil {
+		return c, ErrCursorInvalid
+	}
+	return c, nil
+}
+
+// SortValue returns the typed sort value of the cursor:
+// a time.Time, a string or an int64 depending on the sort field.
+func (c Cursor) SortValue(f SortField) (interface{}, error) {
+	switch f {
+	case SortByTime:
+		t, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, ErrCursorInvalid
+		}
+		return t, nil
+	case SortByValue:
+		v, err := strconv.ParseInt(c.Value, 10, 64)
+		if err != nil {
+			return nil, ErrCursorInvalid
+		}
+		return v, nil
+	default:
+		return c.Value, nil
+	}
+}
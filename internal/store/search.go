@@ -0,0 +1,54 @@
+package store
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Weights of searched fields in the rank of a search result.
+// A match in the label counts more than one in the place or description.
+const (
+	SearchWeightLabel float64 = 1.0
+	SearchWeightPlace float64 = 0.4
+	SearchWeightDesc  float64 = 0.2
+)
+
+// SearchField is a text field searched by a full-text search
+// with its weight in the rank of the result.
+type SearchField struct {
+	Text   string
+	Weight float64
+}
+
+// Tokenize splits the given text into lower case words
+// made of letters and digits.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// SearchRank returns the rank of the given fields for the given search terms.
+// Each occurrence of a term in a field adds the weight of the field to the rank.
+// It returns 0 when one of the terms is not found in any of the fields.
+func SearchRank(terms []string, fields ...SearchField) float64 {
+	counts := make([]map[string]int, len(fields))
+	for i, f := range fields {
+		counts[i] = map[string]int{}
+		for _, tok := range Tokenize(f.Text) {
+			counts[i][tok]++
+		}
+	}
+	var rank float64
+	for _, term := range terms {
+		var termRank float64
+		for i, f := range fields {
+			termRank += float64(counts[i][term]) * f.Weight
+		}
+		if termRank == 0 {
+			return 0
+		}
+		rank += termRank
+	}
+	return rank
+}
@@ -4,26 +4,39 @@ import (
 	"github.com/elhamza90/lifelog/internal/domain"
 )
 
-// NewActivity validates the activity and calls the repo to store it.
+// NewActivity validates the activity, calls the repo to store it
+// and records it was created by principal.
 // It does the following checks:
 //	- Check primitive fields are valid
 //	- Check Tags exist in DB
-func (srv Service) NewActivity(act domain.Activity) (domain.ActivityID, error) {
-	// Check primitive fields are valid
-	if err := act.Validate(); err != nil {
+func (srv Service) NewActivity(act domain.Activity, principal string) (domain.ActivityID, error) {
+	act, err := srv.CheckActivity(act)
+	if err != nil {
+		return 0, err
+	}
+	if act.ID, err = srv.repo.SaveActivity(act); err != nil {
 		return 0, err
 	}
+	return act.ID, srv.auditor.Record(domain.AuditActivity, uint(act.ID), domain.AuditCreate, principal, nil, act)
+}
+
+// CheckActivity does the checks of NewActivity without storing the activity.
+// It returns the activity with its tags fetched.
+func (srv Service) CheckActivity(act domain.Activity) (domain.Activity, error) {
+	// Check primitive fields are valid
+	if err := act.Validate(srv.policy); err != nil {
+		return act, err
+	}
 
 	// Check & Fetch Tags
 	fetchedTags := []domain.Tag{}
 	for _, t := range act.Tags {
 		fetched, err := srv.repo.FindTagByID(t.ID)
 		if err != nil {
-			return 0, err
+			return act, err
 		}
 		fetchedTags = append(fetchedTags, fetched)
 	}
 	act.Tags = fetchedTags
-
-	return srv.repo.SaveActivity(act)
+	return act, nil
 }
@@ -1,12 +1,14 @@
 package adding_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/elhamza90/lifelog/internal/domain"
 	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/usecase/adding"
 )
 
 func TestNewActivity(t *testing.T) {
@@ -98,8 +100,8 @@ func TestNewActivity(t *testing.T) {
 				Duration: test.dur,
 				Tags:     test.tags,
 			}
-			createdID, err := adder.NewActivity(act)
-			testFailed := err != test.expectedErr
+			createdID, err := adder.NewActivity(act, "admin")
+			testFailed := !errors.Is(err, test.expectedErr)
 			if testFailed {
 				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
 			}
@@ -114,3 +116,16 @@ func TestNewActivity(t *testing.T) {
 		})
 	}
 }
+
+func TestNewActivityPolicy(t *testing.T) {
+	policy := domain.DefaultPolicy()
+	policy.ActivityLabelMinLen = 3
+	lenient := adding.NewService(&repo, policy)
+	act := domain.Activity{Label: "Gym", Time: time.Now().Add(-time.Hour), Duration: time.Hour}
+	if _, err := adder.NewActivity(act, "admin"); !errors.Is(err, domain.ErrActivityLabelLength) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", domain.ErrActivityLabelLength, err)
+	}
+	if _, err := lenient.NewActivity(act, "admin"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/elhamza90/lifelog/internal/domain"
 	"github.com/elhamza90/lifelog/internal/store/memory"
 	"github.com/elhamza90/lifelog/internal/usecase/adding"
 )
@@ -14,7 +15,7 @@ var repo memory.Repository // Repository used by service
 
 func TestMain(m *testing.M) {
 	log.Println("Setting up tests")
-	repo = memory.NewRepository()    // Work with In-Memory DB
-	adder = adding.NewService(&repo) // Passing by reference to change db when testing
+	repo = memory.NewRepository()                            // Work with In-Memory DB
+	adder = adding.NewService(&repo, domain.DefaultPolicy()) // Passing by reference to change db when testing
 	os.Exit(m.Run())
 }
@@ -0,0 +1,25 @@
+package adding
+
+import (
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// NewBudget validates the new budget and calls the service repository to store it.
+// It does the following checks:
+//	- Check primitive fields are valid
+//	- Check Tag with provided TagID exists ( if any )
+func (srv Service) NewBudget(b domain.Budget) (domain.BudgetID, error) {
+	// Check primitive fields are valid
+	if err := b.Validate(); err != nil {
+		return 0, err
+	}
+
+	// Check Tag exists
+	if b.TagID > 0 {
+		if _, err := srv.repo.FindTagByID(b.TagID); err != nil {
+			return 0, err
+		}
+	}
+
+	return srv.repo.SaveBudget(b)
+}
@@ -0,0 +1,56 @@
+package adding_test
+
+import (
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+func TestNewBudget(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "groceries"},
+	}
+	// Sub-tests Definitions
+	tests := map[string]struct {
+		budget      domain.Budget
+		expectedErr error
+	}{
+		"Correct Tag Budget": {
+			budget:      domain.Budget{TagID: 1, Period: domain.PeriodMonth, Amount: 30000, Currency: "eur"},
+			expectedErr: nil,
+		},
+		"Correct Global Budget": {
+			budget:      domain.Budget{Period: domain.PeriodWeek, Amount: 15000, Currency: "EUR"},
+			expectedErr: nil,
+		},
+		"Non-Existing Tag": {
+			budget:      domain.Budget{TagID: 2, Period: domain.PeriodMonth, Amount: 30000, Currency: "EUR"},
+			expectedErr: store.ErrTagNotFound,
+		},
+		"Invalid Period": {
+			budget:      domain.Budget{Period: "quarter", Amount: 30000, Currency: "EUR"},
+			expectedErr: domain.ErrPeriodInvalid,
+		},
+		"Negative Amount": {
+			budget:      domain.Budget{Period: domain.PeriodMonth, Amount: -100, Currency: "EUR"},
+			expectedErr: domain.ErrBudgetAmount,
+		},
+		"Invalid Currency": {
+			budget:      domain.Budget{Period: domain.PeriodMonth, Amount: 30000, Currency: "euro"},
+			expectedErr: domain.ErrCurrencyInvalid,
+		},
+	}
+	// Sub-tests Execution
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, err := adder.NewBudget(test.budget)
+			if err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if err == nil && repo.Budgets[id].Currency != "EUR" {
+				t.Fatalf("\nExpected stored budget in EUR\nReturned: %v", repo.Budgets[id])
+			}
+		})
+	}
+}
@@ -4,22 +4,35 @@ import (
 	"github.com/elhamza90/lifelog/internal/domain"
 )
 
-// NewExpense validates the new expense and calls the service repository to store it.
+// NewExpense validates the new expense, calls the service repository to store it
+// and records it was created by principal.
 // It does the following checks:
 //	- Check primitive fields are valid
 //	- Check Activity with provided ActivityID exists
 //	- Checks Tags exist and fetch them
-func (srv Service) NewExpense(exp domain.Expense) (domain.ExpenseID, error) {
+func (srv Service) NewExpense(exp domain.Expense, principal string) (domain.ExpenseID, error) {
+	exp, err := srv.CheckExpense(exp)
+	if err != nil {
+		return 0, err
+	}
+	if exp.ID, err = srv.repo.SaveExpense(exp); err != nil {
+		return 0, err
+	}
+	return exp.ID, srv.auditor.Record(domain.AuditExpense, uint(exp.ID), domain.AuditCreate, principal, nil, exp)
+}
 
+// CheckExpense does the checks of NewExpense without storing the expense.
+// It returns the expense with its tags fetched.
+func (srv Service) CheckExpense(exp domain.Expense) (domain.Expense, error) {
 	// Check primitive fields are valid
-	if err := exp.Validate(); err != nil {
-		return 0, err
+	if err := exp.Validate(srv.policy); err != nil {
+		return exp, err
 	}
 
 	// Check Activity exists
 	if exp.ActivityID > 0 {
 		if _, err := srv.repo.FindActivityByID(exp.ActivityID); err != nil {
-			return 0, err
+			return exp, err
 		}
 	}
 
@@ -28,11 +41,10 @@ func (srv Service) NewExpense(exp domain.Expense) (domain.ExpenseID, error) {
 	for _, t := range exp.Tags {
 		fetched, err := srv.repo.FindTagByID(t.ID)
 		if err != nil {
-			return 0, err
+			return exp, err
 		}
 		fetchedTags = append(fetchedTags, fetched)
 	}
 	exp.Tags = fetchedTags
-
-	return srv.repo.SaveExpense(exp)
+	return exp, nil
 }
@@ -1,6 +1,7 @@
 package adding_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -29,7 +30,7 @@ func TestNewExpense(t *testing.T) {
 	tests := map[string]struct {
 		label       string
 		time        time.Time
-		val         float32
+		val         domain.Amount
 		unit        string
 		tags        []domain.Tag
 		activityID  domain.ActivityID
@@ -38,8 +39,8 @@ func TestNewExpense(t *testing.T) {
 		"Correct-with-activity": {
 			label:       "my expense",
 			time:        now.AddDate(0, 0, -1),
-			val:         15.5,
-			unit:        "Dh",
+			val:         1550,
+			unit:        "mad",
 			tags:        []domain.Tag{{ID: 100001}, {ID: 100005}},
 			activityID:  100000,
 			expectedErr: nil,
@@ -47,8 +48,8 @@ func TestNewExpense(t *testing.T) {
 		"Correct-without-activity": {
 			label:       "my expense",
 			time:        now.AddDate(0, 0, -1),
-			val:         15.5,
-			unit:        "Dh",
+			val:         1550,
+			unit:        "mad",
 			tags:        []domain.Tag{{ID: 100001}, {ID: 100005}},
 			activityID:  0,
 			expectedErr: nil,
@@ -56,8 +57,8 @@ func TestNewExpense(t *testing.T) {
 		"Non-existing-Activity": {
 			label:       "my expense",
 			time:        now.AddDate(0, 0, -1),
-			val:         15.5,
-			unit:        "Dh",
+			val:         1550,
+			unit:        "mad",
 			tags:        []domain.Tag{{ID: 100001}, {ID: 100005}},
 			activityID:  98899889,
 			expectedErr: store.ErrActivityNotFound,
@@ -66,7 +67,7 @@ func TestNewExpense(t *testing.T) {
 			label:       "my expense",
 			time:        now.AddDate(0, 0, -1),
 			val:         0,
-			unit:        "Dh",
+			unit:        "mad",
 			activityID:  100000,
 			tags:        []domain.Tag{{ID: 100001}, {ID: 100005}},
 			expectedErr: domain.ErrExpenseValue,
@@ -74,8 +75,8 @@ func TestNewExpense(t *testing.T) {
 		"Time Future": {
 			label:       "my expense",
 			time:        now.AddDate(0, 0, 1),
-			val:         15.5,
-			unit:        "Dh",
+			val:         1550,
+			unit:        "mad",
 			activityID:  100000,
 			tags:        []domain.Tag{{ID: 100001}, {ID: 100005}},
 			expectedErr: domain.ErrExpenseTimeFuture,
@@ -83,8 +84,8 @@ func TestNewExpense(t *testing.T) {
 		"Short Label": {
 			label:       "my",
 			time:        now.AddDate(0, 0, -1),
-			val:         15.5,
-			unit:        "Dh",
+			val:         1550,
+			unit:        "mad",
 			activityID:  100000,
 			tags:        []domain.Tag{},
 			expectedErr: domain.ErrExpenseLabelLength,
@@ -92,35 +93,35 @@ func TestNewExpense(t *testing.T) {
 		"Long Label": {
 			label:       "my ver ver ver very very ver very very very very very very very very very long label",
 			time:        now.AddDate(0, 0, -1),
-			val:         15.5,
-			unit:        "Dh",
+			val:         1550,
+			unit:        "mad",
 			activityID:  100000,
 			tags:        []domain.Tag{},
 			expectedErr: domain.ErrExpenseLabelLength,
 		},
-		"Long Unit": {
+		"Unknown Currency": {
 			label:       "my expense",
 			time:        now.AddDate(0, 0, -1),
-			val:         15.5,
-			unit:        "LongLongUnit",
+			val:         1550,
+			unit:        "Dollar",
 			activityID:  100000,
 			tags:        []domain.Tag{},
-			expectedErr: domain.ErrExpenseUnitLength,
+			expectedErr: domain.ErrCurrencyInvalid,
 		},
 		"Short Unit": {
 			label:       "my expense",
 			time:        now.AddDate(0, 0, -1),
-			val:         15.5,
+			val:         1550,
 			unit:        "D",
 			activityID:  100000,
 			tags:        []domain.Tag{},
-			expectedErr: domain.ErrExpenseUnitLength,
+			expectedErr: domain.ErrCurrencyInvalid,
 		},
 		"Non-Existing Tag": {
 			label:       "my expense",
 			time:        now.AddDate(0, 0, -1),
-			val:         15.5,
-			unit:        "Dh",
+			val:         1550,
+			unit:        "mad",
 			activityID:  100000,
 			tags:        []domain.Tag{{ID: 200000}},
 			expectedErr: store.ErrTagNotFound,
@@ -133,12 +134,12 @@ func TestNewExpense(t *testing.T) {
 				Label:      test.label,
 				Time:       test.time,
 				Value:      test.val,
-				Unit:       test.unit,
+				Unit:       domain.Currency(test.unit),
 				ActivityID: test.activityID,
 				Tags:       test.tags,
 			}
-			createdID, err := adder.NewExpense(exp)
-			testFailed := err != test.expectedErr
+			createdID, err := adder.NewExpense(exp, "admin")
+			testFailed := !errors.Is(err, test.expectedErr)
 			if testFailed {
 				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
 			}
@@ -146,8 +147,8 @@ func TestNewExpense(t *testing.T) {
 			if err == nil {
 				// Fetch created expense directly from repo
 				createdExpense := repo.Expenses[createdID]
-				// Check unit was transformed to lowercase
-				expectedUnit := strings.ToLower(test.unit)
+				// Check unit was transformed to uppercase currency code
+				expectedUnit := domain.Currency(strings.ToUpper(test.unit))
 				if createdExpense.Unit != expectedUnit {
 					t.Fatalf("\nExpected Unit: %s\nReturned Unit: %s", expectedUnit, createdExpense.Unit)
 				}
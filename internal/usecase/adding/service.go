@@ -2,37 +2,51 @@ package adding
 
 import (
 	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/auditing"
 )
 
 // Service provides methods that create entities
 // and call the given repository to store them
 type Service struct {
-	repo Repository
+	repo    Repository
+	policy  domain.Policy    // Limits of validation rules
+	auditor auditing.Service // Records created tags, activities & expenses
 }
 
-// NewService returns a new adding service with provided repository
-func NewService(r Repository) Service {
-	return Service{repo: r}
+// NewService returns a new adding service with provided repository and validation policy
+func NewService(r Repository, p domain.Policy) Service {
+	return Service{repo: r, policy: p, auditor: auditing.NewService(r)}
+}
+
+// Policy returns the validation policy of the service
+func (srv Service) Policy() domain.Policy {
+	return srv.policy
 }
 
 // Repository is the interface that wraps the methods
 // that must be implemented by the repository
 // in order for adding service to perform its job.
 //
-// - SaveTag, SaveExpense and SaveActivity are the main
-// methods to store the objects.
+// - SaveTag, SaveExpense, SaveActivity, SaveBudget and SaveTemplate
+// are the main methods to store the objects.
 //
 // - FindTagByName is used to check for duplicate tag names.
 //
 // - FindTagByID is used to check that tags exist when
-//   creating an activity or an expense with tags.
+//   creating an activity, an expense, a budget or a template with tags.
 //
 // - FindActivityByID is used to check that an activity
 //   exists when creating an expense.
+//
+// - The auditing repository methods are used to record
+//   created tags, activities & expenses.
 type Repository interface {
+	auditing.Repository
 	SaveTag(domain.Tag) (domain.TagID, error)
 	SaveExpense(domain.Expense) (domain.ExpenseID, error)
 	SaveActivity(domain.Activity) (domain.ActivityID, error)
+	SaveBudget(domain.Budget) (domain.BudgetID, error)
+	SaveTemplate(domain.Template) (domain.TemplateID, error)
 	FindTagByName(string) (domain.Tag, error)
 	FindTagByID(domain.TagID) (domain.Tag, error)
 	FindActivityByID(domain.ActivityID) (domain.Activity, error)
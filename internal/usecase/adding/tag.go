@@ -7,21 +7,34 @@ import (
 	"github.com/elhamza90/lifelog/internal/store"
 )
 
-// NewTag validates tag and calls the service repository to store it.
+// NewTag validates tag, calls the service repository to store it
+// and records it was created by principal.
 //	- It transforms name to lowercase
 //	- checks repo for tag with same name ( duplicate tags are not allowed )
-func (srv Service) NewTag(t domain.Tag) (domain.TagID, error) {
-	// Check fields valid
-	if err := t.Validate(); err != nil {
+func (srv Service) NewTag(t domain.Tag, principal string) (domain.TagID, error) {
+	t, err := srv.CheckTag(t)
+	if err != nil {
 		return 0, err
 	}
+	// Call repo to store it
+	if t.ID, err = srv.repo.SaveTag(t); err != nil {
+		return 0, err
+	}
+	return t.ID, srv.auditor.Record(domain.AuditTag, uint(t.ID), domain.AuditCreate, principal, nil, t)
+}
+
+// CheckTag does the checks of NewTag without storing the tag.
+func (srv Service) CheckTag(t domain.Tag) (domain.Tag, error) {
+	// Check fields valid
+	if err := t.Validate(srv.policy); err != nil {
+		return t, err
+	}
 
 	// Check tag name is not duplicate
-	if t, err := srv.repo.FindTagByName(t.Name); (err != nil) && !errors.Is(err, store.ErrTagNotFound) {
-		return 0, err
-	} else if len(t.Name) > 0 {
-		return 0, domain.ErrTagNameDuplicate
+	if found, err := srv.repo.FindTagByName(t.Name); (err != nil) && !errors.Is(err, store.ErrTagNotFound) {
+		return t, err
+	} else if len(found.Name) > 0 {
+		return t, domain.ErrTagNameDuplicate
 	}
-	// Call repo to store it
-	return srv.repo.SaveTag(t)
+	return t, nil
 }
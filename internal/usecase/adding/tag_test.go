@@ -1,6 +1,7 @@
 package adding_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -12,6 +13,7 @@ func TestNewTag(t *testing.T) {
 	repo.Tags = map[domain.TagID]domain.Tag{
 		100000: {Name: "duplicate-tag"},
 	}
+	defer func() { repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{} }()
 
 	// Sub-tests Definitions
 	tests := map[string]struct {
@@ -32,8 +34,8 @@ func TestNewTag(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			tag := domain.Tag{Name: test.name}
-			createdID, err := adder.NewTag(tag)
-			testFailed := err != test.expectedErr
+			createdID, err := adder.NewTag(tag, "admin")
+			testFailed := !errors.Is(err, test.expectedErr)
 			if testFailed {
 				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
 			}
@@ -45,6 +47,11 @@ func TestNewTag(t *testing.T) {
 				if createdTag.Name != expectedName {
 					t.Fatalf("\nExpected Tag Name: %s\nReturned Tag Name: %s", expectedName, createdTag.Name)
 				}
+				// Check creation is recorded
+				events, _ := repo.FindAuditEvents(domain.AuditTag, uint(createdID))
+				if len(events) != 1 || events[0].Action != domain.AuditCreate || events[0].Principal != "admin" || events[0].Before != nil {
+					t.Fatalf("\nExpected creation by admin to be recorded\nReturned: %v", events)
+				}
 			}
 		})
 	}
@@ -0,0 +1,45 @@
+package adding
+
+import (
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// NewTemplate validates the new template and calls the service repository to store it.
+// It does the following checks:
+//	- Check recurrence rule and primitive fields of the model entity are valid
+//	- Check Activity with provided ActivityID exists ( expense templates )
+//	- Checks Tags exist and fetch them
+func (srv Service) NewTemplate(tmpl domain.Template) (domain.TemplateID, error) {
+	// Check primitive fields are valid
+	if err := tmpl.Validate(srv.policy); err != nil {
+		return 0, err
+	}
+
+	// Check Activity exists
+	if tmpl.Kind == domain.TemplateExpense && tmpl.Expense.ActivityID > 0 {
+		if _, err := srv.repo.FindActivityByID(tmpl.Expense.ActivityID); err != nil {
+			return 0, err
+		}
+	}
+
+	// Check & Fetch Tags
+	tags := tmpl.Expense.Tags
+	if tmpl.Kind == domain.TemplateActivity {
+		tags = tmpl.Activity.Tags
+	}
+	fetchedTags := []domain.Tag{}
+	for _, t := range tags {
+		fetched, err := srv.repo.FindTagByID(t.ID)
+		if err != nil {
+			return 0, err
+		}
+		fetchedTags = append(fetchedTags, fetched)
+	}
+	if tmpl.Kind == domain.TemplateActivity {
+		tmpl.Activity.Tags = fetchedTags
+	} else {
+		tmpl.Expense.Tags = fetchedTags
+	}
+
+	return srv.repo.SaveTemplate(tmpl)
+}
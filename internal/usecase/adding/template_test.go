@@ -0,0 +1,71 @@
+package adding_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+func TestNewTemplate(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "home"},
+	}
+	rule := domain.RecurrenceRule{Freq: domain.FrequencyMonthly, Interval: 1, Start: time.Now().AddDate(0, 1, 0)}
+	// Sub-tests Definitions
+	tests := map[string]struct {
+		tmpl        domain.Template
+		expectedErr error
+	}{
+		"Correct Expense Template": {
+			tmpl: domain.Template{Kind: domain.TemplateExpense, Rule: rule,
+				Expense: domain.Expense{Label: "Rent", Value: 80000, Unit: "eur", Tags: []domain.Tag{{ID: 1}}}},
+			expectedErr: nil,
+		},
+		"Correct Activity Template": {
+			tmpl: domain.Template{Kind: domain.TemplateActivity, Rule: rule,
+				Activity: domain.Activity{Label: "Gym session", Duration: time.Hour}},
+			expectedErr: nil,
+		},
+		"Unknown Kind": {
+			tmpl:        domain.Template{Kind: "task", Rule: rule},
+			expectedErr: domain.ErrTemplateKind,
+		},
+		"Invalid Rule": {
+			tmpl: domain.Template{Kind: domain.TemplateExpense, Rule: domain.RecurrenceRule{Freq: "yearly", Interval: 1},
+				Expense: domain.Expense{Label: "Rent", Value: 80000, Unit: "EUR"}},
+			expectedErr: domain.ErrRecurrenceFreq,
+		},
+		"Invalid Expense": {
+			tmpl: domain.Template{Kind: domain.TemplateExpense, Rule: rule,
+				Expense: domain.Expense{Label: "Rent", Value: 0, Unit: "EUR"}},
+			expectedErr: domain.ErrExpenseValue,
+		},
+		"Non-Existing Tag": {
+			tmpl: domain.Template{Kind: domain.TemplateActivity, Rule: rule,
+				Activity: domain.Activity{Label: "Gym session", Duration: time.Hour, Tags: []domain.Tag{{ID: 2}}}},
+			expectedErr: store.ErrTagNotFound,
+		},
+		"Non-Existing Activity": {
+			tmpl: domain.Template{Kind: domain.TemplateExpense, Rule: rule,
+				Expense: domain.Expense{Label: "Rent", Value: 80000, Unit: "EUR", ActivityID: 5}},
+			expectedErr: store.ErrActivityNotFound,
+		},
+	}
+	// Sub-tests Execution
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, err := adder.NewTemplate(test.tmpl)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if err == nil && test.tmpl.Kind == domain.TemplateExpense {
+				if created := repo.Templates[id]; created.Expense.Unit != "EUR" || created.Expense.Tags[0].Name != "home" {
+					t.Fatalf("\nExpected currency and tags to be fetched\nReturned: %v", created.Expense)
+				}
+			}
+		})
+	}
+}
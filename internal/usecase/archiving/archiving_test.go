@@ -0,0 +1,29 @@
+package archiving_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store/memory"
+	"github.com/elhamza90/lifelog/internal/usecase/archiving"
+)
+
+var archiver archiving.Service // Instance of service we will be testing
+var repo memory.Repository     // Repository used by service
+
+func TestMain(m *testing.M) {
+	repo = memory.NewRepository()                                  // Work with In-Memory DB
+	archiver = archiving.NewService(&repo, domain.DefaultPolicy()) // Passing by reference to change db when testing
+	os.Exit(m.Run())
+}
+
+// clearRepo removes all tags, activities, expenses and audit events
+func clearRepo() {
+	repo.Tags = map[domain.TagID]domain.Tag{}
+	repo.Activities = map[domain.ActivityID]domain.Activity{}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	repo.Trash = map[string]time.Time{}
+}
@@ -0,0 +1,73 @@
+package archiving
+
+import (
+	"sort"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// tagIds returns the ids of the given tags sorted
+func tagIds(tags []domain.Tag) []domain.TagID {
+	ids := make([]domain.TagID, len(tags))
+	for i, t := range tags {
+		ids[i] = t.ID
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// Backup returns an archive of all tags, activities and expenses
+// created at the given time. Entities are sorted by ID.
+func (srv Service) Backup(now time.Time) (Archive, error) {
+	tags, err := srv.repo.FindAllTags()
+	if err != nil {
+		return Archive{}, err
+	}
+	activities, err := srv.repo.FindActivitiesByTime(time.Time{})
+	if err != nil {
+		return Archive{}, err
+	}
+	expenses, err := srv.repo.FindExpensesByTime(time.Time{})
+	if err != nil {
+		return Archive{}, err
+	}
+	archive := Archive{
+		Version:    ArchiveVersion,
+		CreatedAt:  now,
+		Tags:       make([]ArchiveTag, len(tags)),
+		Activities: make([]ArchiveActivity, len(activities)),
+		Expenses:   make([]ArchiveExpense, len(expenses)),
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].ID < tags[j].ID })
+	for i, t := range tags {
+		archive.Tags[i] = ArchiveTag{ID: t.ID, Name: t.Name}
+	}
+	sort.Slice(activities, func(i, j int) bool { return activities[i].ID < activities[j].ID })
+	for i, act := range activities {
+		archive.Activities[i] = ArchiveActivity{
+			ID:       act.ID,
+			Label:    act.Label,
+			Place:    act.Place,
+			Desc:     act.Desc,
+			Time:     act.Time,
+			Duration: act.Duration,
+			Status:   act.Status,
+			TagIds:   tagIds(act.Tags),
+		}
+	}
+	sort.Slice(expenses, func(i, j int) bool { return expenses[i].ID < expenses[j].ID })
+	for i, exp := range expenses {
+		archive.Expenses[i] = ArchiveExpense{
+			ID:         exp.ID,
+			Label:      exp.Label,
+			Time:       exp.Time,
+			Value:      exp.Value,
+			Unit:       exp.Unit,
+			ActivityID: exp.ActivityID,
+			Status:     exp.Status,
+			TagIds:     tagIds(exp.Tags),
+		}
+	}
+	return archive, nil
+}
@@ -0,0 +1,46 @@
+package archiving_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/archiving"
+)
+
+func TestBackup(t *testing.T) {
+	defer clearRepo()
+	actTime := time.Date(2020, 10, 5, 18, 0, 0, 0, time.UTC)
+	repo.Tags = map[domain.TagID]domain.Tag{
+		2: {ID: 2, Name: "sport"},
+		1: {ID: 1, Name: "food"},
+	}
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		7: {ID: 7, Label: "Football", Place: "stadium", Time: actTime, Duration: time.Hour, Tags: []domain.Tag{repo.Tags[2], repo.Tags[1]}},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		4: {ID: 4, Label: "Drinks", Time: actTime.Add(time.Hour), Value: 350, Unit: "EUR", ActivityID: 7, Tags: []domain.Tag{repo.Tags[1]}},
+		3: {ID: 3, Label: "Ticket", Time: actTime, Value: 1500, Unit: "EUR"},
+	}
+	now := time.Now()
+	archive, err := archiver.Backup(now)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	expected := archiving.Archive{
+		Version:   archiving.ArchiveVersion,
+		CreatedAt: now,
+		Tags:      []archiving.ArchiveTag{{ID: 1, Name: "food"}, {ID: 2, Name: "sport"}},
+		Activities: []archiving.ArchiveActivity{
+			{ID: 7, Label: "Football", Place: "stadium", Time: actTime, Duration: time.Hour, TagIds: []domain.TagID{1, 2}},
+		},
+		Expenses: []archiving.ArchiveExpense{
+			{ID: 3, Label: "Ticket", Time: actTime, Value: 1500, Unit: "EUR", TagIds: []domain.TagID{}},
+			{ID: 4, Label: "Drinks", Time: actTime.Add(time.Hour), Value: 350, Unit: "EUR", ActivityID: 7, TagIds: []domain.TagID{1}},
+		},
+	}
+	if !reflect.DeepEqual(archive, expected) {
+		t.Fatalf("\nExpected Archive: %+v\nReturned Archive: %+v", expected, archive)
+	}
+}
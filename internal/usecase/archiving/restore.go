@@ -0,0 +1,172 @@
+package archiving
+
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// Restore stores all entities of the archive with new IDs, keeping their statuses
+// and relations, and records them in their history as imported by principal.
+// Archived IDs are only used to resolve the relations.
+// It does the following checks before storing anything:
+//	- Check the archive version is supported
+//	- Check the store does not contain tags, activities or expenses,
+//	  including trashed ones
+//	- Check entities are valid and have unique positive IDs
+//	- Check referenced tags & activities are in the archive
+func (srv Service) Restore(archive Archive, principal string) error {
+	if archive.Version != ArchiveVersion {
+		return ErrArchiveVersion
+	}
+	if err := srv.checkStoreEmpty(); err != nil {
+		return err
+	}
+	// Tags
+	tags := make([]domain.Tag, len(archive.Tags))
+	tagsByID := map[domain.TagID]domain.Tag{}
+	names := map[string]bool{}
+	for i, at := range archive.Tags {
+		t := domain.Tag{ID: at.ID, Name: at.Name}
+		if err := t.Validate(srv.policy); err != nil {
+			return err
+		}
+		if _, dup := tagsByID[t.ID]; dup || t.ID == 0 {
+			return ErrArchiveIDs
+		}
+		if names[t.Name] {
+			return domain.ErrTagNameDuplicate
+		}
+		names[t.Name] = true
+		tagsByID[t.ID] = t
+		tags[i] = t
+	}
+	// Activities
+	activities := make([]domain.Activity, len(archive.Activities))
+	activityIDs := map[domain.ActivityID]bool{}
+	for i, aa := range archive.Activities {
+		actTags, err := archivedTags(aa.TagIds, tagsByID)
+		if err != nil {
+			return err
+		}
+		act := domain.Activity{
+			ID:       aa.ID,
+			Label:    aa.Label,
+			Place:    aa.Place,
+			Desc:     aa.Desc,
+			Time:     aa.Time,
+			Duration: aa.Duration,
+			Status:   aa.Status,
+			Tags:     actTags,
+		}
+		if err := act.Validate(srv.policy); err != nil {
+			return err
+		}
+		if activityIDs[act.ID] || act.ID == 0 {
+			return ErrArchiveIDs
+		}
+		activityIDs[act.ID] = true
+		activities[i] = act
+	}
+	// Expenses
+	expenses := make([]domain.Expense, len(archive.Expenses))
+	expenseIDs := map[domain.ExpenseID]bool{}
+	for i, ae := range archive.Expenses {
+		expTags, err := archivedTags(ae.TagIds, tagsByID)
+		if err != nil {
+			return err
+		}
+		if ae.ActivityID > 0 && !activityIDs[ae.ActivityID] {
+			return ErrArchiveReference
+		}
+		exp := domain.Expense{
+			ID:         ae.ID,
+			Label:      ae.Label,
+			Time:       ae.Time,
+			Value:      ae.Value,
+			Unit:       ae.Unit,
+			ActivityID: ae.ActivityID,
+			Status:     ae.Status,
+			Tags:       expTags,
+		}
+		if err := exp.Validate(srv.policy); err != nil {
+			return err
+		}
+		if expenseIDs[exp.ID] || exp.ID == 0 {
+			return ErrArchiveIDs
+		}
+		expenseIDs[exp.ID] = true
+		expenses[i] = exp
+	}
+	newTagIDs, newActivityIDs, newExpenseIDs, err := srv.repo.Restore(tags, activities, expenses)
+	if err != nil {
+		return err
+	}
+	return srv.recordRestore(principal, newTagIDs, newActivityIDs, newExpenseIDs)
+}
+
+// recordRestore records the restored entities with given IDs as imported by principal.
+// All entities are recorded even if one fails and the first error is returned.
+func (srv Service) recordRestore(principal string, tagIDs []domain.TagID, activityIDs []domain.ActivityID, expenseIDs []domain.ExpenseID) error {
+	var firstErr error
+	record := func(entity domain.AuditEntity, id uint, snapshot interface{}, err error) {
+		if err == nil {
+			err = srv.auditor.Record(entity, id, domain.AuditImport, principal, nil, snapshot)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, id := range tagIDs {
+		t, err := srv.repo.FindTagByID(id)
+		record(domain.AuditTag, uint(id), t, err)
+	}
+	for _, id := range activityIDs {
+		act, err := srv.repo.FindActivityByID(id)
+		record(domain.AuditActivity, uint(id), act, err)
+	}
+	for _, id := range expenseIDs {
+		exp, err := srv.repo.FindExpenseByID(id)
+		record(domain.AuditExpense, uint(id), exp, err)
+	}
+	return firstErr
+}
+
+// checkStoreEmpty returns ErrStoreNotEmpty if the store
+// or its trash contains tags, activities or expenses
+func (srv Service) checkStoreEmpty() error {
+	tags, err := srv.repo.FindAllTags()
+	if err != nil {
+		return err
+	}
+	activities, err := srv.repo.FindActivitiesByTime(time.Time{})
+	if err != nil {
+		return err
+	}
+	expenses, err := srv.repo.FindExpensesByTime(time.Time{})
+	if err != nil {
+		return err
+	}
+	trash, err := srv.repo.FindTrash()
+	if err != nil {
+		return err
+	}
+	if len(tags) > 0 || len(activities) > 0 || len(expenses) > 0 || len(trash) > 0 {
+		return ErrStoreNotEmpty
+	}
+	return nil
+}
+
+// archivedTags returns the archived tags with given ids.
+// It returns ErrArchiveReference if one of them is not archived.
+func archivedTags(ids []domain.TagID, tagsByID map[domain.TagID]domain.Tag) ([]domain.Tag, error) {
+	tags := make([]domain.Tag, len(ids))
+	for i, id := range ids {
+		t, ok := tagsByID[id]
+		if !ok {
+			return nil, ErrArchiveReference
+		}
+		tags[i] = t
+	}
+	return tags, nil
+}
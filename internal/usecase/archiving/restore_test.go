@@ -0,0 +1,200 @@
+package archiving_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/archiving"
+)
+
+// testArchive returns a valid archive
+func testArchive() archiving.Archive {
+	actTime := time.Date(2020, 10, 5, 18, 0, 0, 0, time.UTC)
+	return archiving.Archive{
+		Version: archiving.ArchiveVersion,
+		Tags:    []archiving.ArchiveTag{{ID: 10, Name: "food"}, {ID: 20, Name: "sport"}},
+		Activities: []archiving.ArchiveActivity{
+			{ID: 5, Label: "Football", Time: actTime, Duration: time.Hour, Status: domain.StatusDone, TagIds: []domain.TagID{20}},
+		},
+		Expenses: []archiving.ArchiveExpense{
+			{ID: 8, Label: "Drinks", Time: actTime, Value: 350, Unit: "EUR", ActivityID: 5, Status: domain.StatusDone, TagIds: []domain.TagID{10, 20}},
+		},
+	}
+}
+
+// describe returns one line per entity of the archive, sorted,
+// with relations resolved to tag names & activity labels
+// so archives of the same entities with different IDs are equal.
+func describe(a archiving.Archive) []string {
+	lines := []string{}
+	names := map[domain.TagID]string{}
+	for _, t := range a.Tags {
+		names[t.ID] = t.Name
+		lines = append(lines, "tag "+t.Name)
+	}
+	tagNames := func(ids []domain.TagID) string {
+		res := make([]string, len(ids))
+		for i, id := range ids {
+			res[i] = names[id]
+		}
+		sort.Strings(res)
+		return strings.Join(res, ";")
+	}
+	labels := map[domain.ActivityID]string{}
+	for _, act := range a.Activities {
+		labels[act.ID] = act.Label
+		lines = append(lines, fmt.Sprintf("activity %s | %s | %s | %s | %s | %s | %s", act.Label, act.Place, act.Desc, act.Time.Format(time.RFC3339), act.Duration, act.Status, tagNames(act.TagIds)))
+	}
+	for _, exp := range a.Expenses {
+		lines = append(lines, fmt.Sprintf("expense %s | %s | %d %s | %s | %s | %s", exp.Label, exp.Time.Format(time.RFC3339), exp.Value, exp.Unit, labels[exp.ActivityID], exp.Status, tagNames(exp.TagIds)))
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func TestRestore(t *testing.T) {
+	defer clearRepo()
+	tests := map[string]struct {
+		modify      func(*archiving.Archive)
+		expectedErr error
+	}{
+		"Valid":              {modify: func(a *archiving.Archive) {}},
+		"Wrong Version":      {modify: func(a *archiving.Archive) { a.Version = 2 }, expectedErr: archiving.ErrArchiveVersion},
+		"Duplicate Tag ID":   {modify: func(a *archiving.Archive) { a.Tags[1].ID = 10 }, expectedErr: archiving.ErrArchiveIDs},
+		"Zero Expense ID":    {modify: func(a *archiving.Archive) { a.Expenses[0].ID = 0 }, expectedErr: archiving.ErrArchiveIDs},
+		"Duplicate Tag Name": {modify: func(a *archiving.Archive) { a.Tags[1].Name = "FOOD" }, expectedErr: domain.ErrTagNameDuplicate},
+		"Unknown Tag":        {modify: func(a *archiving.Archive) { a.Activities[0].TagIds = []domain.TagID{30} }, expectedErr: archiving.ErrArchiveReference},
+		"Unknown Activity":   {modify: func(a *archiving.Archive) { a.Expenses[0].ActivityID = 6 }, expectedErr: archiving.ErrArchiveReference},
+		"Invalid Expense":    {modify: func(a *archiving.Archive) { a.Expenses[0].Unit = "EURO" }, expectedErr: domain.ErrCurrencyInvalid},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			clearRepo()
+			archive := testArchive()
+			test.modify(&archive)
+			if err := archiver.Restore(archive, "admin"); !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if test.expectedErr != nil && (len(repo.Tags) > 0 || len(repo.Activities) > 0 || len(repo.Expenses) > 0) {
+				t.Fatalf("\nExpected nothing to be restored")
+			}
+		})
+	}
+	// Subcase: Store is not empty
+	clearRepo()
+	repo.Tags = map[domain.TagID]domain.Tag{1: {ID: 1, Name: "existing"}}
+	if err := archiver.Restore(testArchive(), "admin"); err != archiving.ErrStoreNotEmpty {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", archiving.ErrStoreNotEmpty, err)
+	}
+	// Subcase: Trash is not empty
+	if err := repo.DeleteTag(1); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := archiver.Restore(testArchive(), "admin"); err != archiving.ErrStoreNotEmpty {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", archiving.ErrStoreNotEmpty, err)
+	}
+}
+
+func TestBackupRestoreRoundTrip(t *testing.T) {
+	defer clearRepo()
+	clearRepo()
+	archive := testArchive()
+	if err := archiver.Restore(archive, "admin"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	backup, err := archiver.Backup(archive.CreatedAt)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if !reflect.DeepEqual(describe(backup), describe(archive)) {
+		t.Fatalf("\nExpected Backup: %+v\nReturned Backup: %+v", archive, backup)
+	}
+	// Restored entities are recorded in their history with their new IDs
+	if len(repo.AuditEvents) != 4 {
+		t.Fatalf("\nExpected 4 recorded imports\nReturned: %v", repo.AuditEvents)
+	}
+	exp := backup.Expenses[0]
+	events, _ := repo.FindAuditEvents(domain.AuditExpense, uint(exp.ID))
+	expectedAfter := fmt.Sprintf(`"ActivityID":%d`, exp.ActivityID)
+	if len(events) != 1 || events[0].Action != domain.AuditImport || events[0].Principal != "admin" || !strings.Contains(string(events[0].After), expectedAfter) {
+		t.Fatalf("\nExpected import of expense %d by admin\nReturned: %v", exp.ID, events)
+	}
+}
+
+func TestRestoreNewIDs(t *testing.T) {
+	defer func() {
+		clearRepo()
+		repo.Owners = map[string]domain.UserID{}
+	}()
+	clearRepo()
+	// Entities of another user with the IDs of the archived ones
+	archive := testArchive()
+	repo.Tags[10] = domain.Tag{ID: 10, Name: "other"}
+	repo.Activities[5] = domain.Activity{ID: 5, Label: "Other"}
+	repo.Expenses[8] = domain.Expense{ID: 8, Label: "Other", ActivityID: 5}
+	repo.Owners["tag/10"], repo.Owners["activity/5"], repo.Owners["expense/8"] = 2, 2, 2
+	userRepo := repo.ForUser(1)
+	userArchiver := archiving.NewService(&userRepo, domain.DefaultPolicy())
+	if err := userArchiver.Restore(archive, "admin"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if tag, exp := repo.Tags[10], repo.Expenses[8]; tag.Name != "other" || exp.Label != "Other" || exp.ActivityID != 5 {
+		t.Fatalf("\nExpected entities of other user untouched\nReturned: %v %v", tag, exp)
+	}
+	backup, err := userArchiver.Backup(archive.CreatedAt)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if !reflect.DeepEqual(describe(backup), describe(archive)) {
+		t.Fatalf("\nExpected Backup: %+v\nReturned Backup: %+v", archive, backup)
+	}
+	for _, exp := range backup.Expenses {
+		if exp.ID == 8 || exp.ActivityID == 5 {
+			t.Fatalf("\nExpected new IDs\nReturned: %+v", exp)
+		}
+	}
+}
+
+func TestBackupRestoreStatuses(t *testing.T) {
+	defer clearRepo()
+	clearRepo()
+	future := time.Now().Add(24 * time.Hour).Round(time.Second).UTC()
+	archive := testArchive()
+	archive.Activities = append(archive.Activities,
+		archiving.ArchiveActivity{ID: 6, Label: "Match", Time: future, Duration: time.Hour, Status: domain.StatusPlanned, TagIds: []domain.TagID{}},
+		archiving.ArchiveActivity{ID: 7, Label: "Training", Time: archive.Activities[0].Time, Duration: time.Hour, Status: domain.StatusCancelled, TagIds: []domain.TagID{}},
+	)
+	archive.Expenses = append(archive.Expenses,
+		archiving.ArchiveExpense{ID: 9, Label: "Tickets", Time: future, Value: 2000, Unit: "EUR", ActivityID: 6, Status: domain.StatusPlanned, TagIds: []domain.TagID{}},
+		archiving.ArchiveExpense{ID: 10, Label: "Court", Time: archive.Expenses[0].Time, Value: 1500, Unit: "EUR", Status: domain.StatusCancelled, TagIds: []domain.TagID{}},
+	)
+	if err := archiver.Restore(archive, "admin"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	backup, err := archiver.Backup(archive.CreatedAt)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if !reflect.DeepEqual(describe(backup), describe(archive)) {
+		t.Fatalf("\nExpected Backup: %+v\nReturned Backup: %+v", archive, backup)
+	}
+	// Subcase: Archives without statuses are restored as done
+	clearRepo()
+	legacy := testArchive()
+	legacy.Activities[0].Status = ""
+	legacy.Expenses[0].Status = ""
+	if err := archiver.Restore(legacy, "admin"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	for _, exp := range repo.Expenses {
+		if exp.Status != domain.StatusDone {
+			t.Fatalf("\nExpected done expense\nReturned: %v", exp)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package archiving
+
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/auditing"
+	"github.com/elhamza90/lifelog/internal/usecase/listing"
+)
+
+// Service provides methods that back up all entities
+// to an archive and restore them from it
+type Service struct {
+	repo    Repository
+	policy  domain.Policy    // Limits of validation rules of restored entities
+	auditor auditing.Service // Records restored entities
+}
+
+// NewService returns a new archiving service with provided repository and validation policy
+func NewService(r Repository, p domain.Policy) Service {
+	return Service{repo: r, policy: p, auditor: auditing.NewService(r)}
+}
+
+// Repository is the interface that wraps the methods
+// that must be implemented by the repository
+// in order for archiving service to perform its job.
+//
+//	- The listing repository methods are used to read all entities
+//	  when backing up and to check the store is empty before restoring.
+//
+//	- FindTrash is used to check the trash is empty before restoring.
+//
+//	- Restore stores entities with new IDs, rewriting their references,
+//	  and returns the new IDs.
+//
+//	- The auditing repository methods are used to record restored entities.
+type Repository interface {
+	listing.Repository
+	auditing.Repository
+	FindTrash() ([]domain.TrashItem, error)
+	Restore([]domain.Tag, []domain.Activity, []domain.Expense) ([]domain.TagID, []domain.ActivityID, []domain.ExpenseID, error)
+}
+
+// ArchiveVersion is the version of the archive format written by Backup.
+// It must be incremented when the format changes.
+const ArchiveVersion int = 1
+
+// Errors
+var (
+	ErrArchiveVersion error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "archive_version",
+		Message: "Archive version is not supported",
+		Field:   "version",
+	}
+	ErrArchiveIDs error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "archive_ids",
+		Message: "Archive entities must have unique positive IDs",
+	}
+	ErrArchiveReference error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "archive_reference",
+		Message: "Archive references a tag or an activity it does not contain",
+	}
+	ErrStoreNotEmpty error = &domain.Error{
+		Kind:    domain.KindConflict,
+		Code:    "store_not_empty",
+		Message: "Archive can only be restored into an empty store and trash",
+	}
+)
+
+// Archive is a versioned snapshot of all tags, activities and expenses.
+// Relations are kept as IDs: tags of activities & expenses
+// and the activity of expenses. IDs only relate entities of the archive:
+// restored entities are given new IDs.
+type Archive struct {
+	Version    int               `json:"version"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	Tags       []ArchiveTag      `json:"tags"`
+	Activities []ArchiveActivity `json:"activities"`
+	Expenses   []ArchiveExpense  `json:"expenses"`
+}
+
+// ArchiveTag is the archived form of a tag
+type ArchiveTag struct {
+	ID   domain.TagID `json:"id"`
+	Name string       `json:"name"`
+}
+
+// ArchiveActivity is the archived form of an activity.
+// Duration is in nanoseconds. Status is empty in archives
+// written before statuses existed: the activity is restored as done.
+type ArchiveActivity struct {
+	ID       domain.ActivityID `json:"id"`
+	Label    string            `json:"label"`
+	Place    string            `json:"place"`
+	Desc     string            `json:"desc"`
+	Time     time.Time         `json:"time"`
+	Duration time.Duration     `json:"duration"`
+	Status   domain.Status     `json:"status,omitempty"`
+	TagIds   []domain.TagID    `json:"tagIds"`
+}
+
+// ArchiveExpense is the archived form of an expense.
+// Value is in the minor unit of the currency so it is exact.
+// Status is empty in archives written before statuses existed:
+// the expense is restored as done.
+type ArchiveExpense struct {
+	ID         domain.ExpenseID  `json:"id"`
+	Label      string            `json:"label"`
+	Time       time.Time         `json:"time"`
+	Value      domain.Amount     `json:"value"`
+	Unit       domain.Currency   `json:"unit"`
+	ActivityID domain.ActivityID `json:"activityId"`
+	Status     domain.Status     `json:"status,omitempty"`
+	TagIds     []domain.TagID    `json:"tagIds"`
+}
@@ -0,0 +1,45 @@
+package auditing
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// snapshot returns the JSON encoding of an entity ( nil if there is no entity )
+func snapshot(entity interface{}) (json.RawMessage, error) {
+	if entity == nil {
+		return nil, nil
+	}
+	return json.Marshal(entity)
+}
+
+// Record stores a change of the entity with given kind & ID made by principal.
+// before & after are the entity before and after the change:
+// before is nil for a creation and after is nil for a deletion.
+func (srv Service) Record(entity domain.AuditEntity, id uint, action domain.AuditAction, principal string, before interface{}, after interface{}) error {
+	ev := domain.AuditEvent{
+		Entity:    entity,
+		EntityID:  id,
+		Action:    action,
+		Principal: principal,
+		Time:      time.Now(),
+	}
+	var err error
+	if ev.Before, err = snapshot(before); err != nil {
+		return err
+	}
+	if ev.After, err = snapshot(after); err != nil {
+		return err
+	}
+	_, err = srv.repo.SaveAuditEvent(ev)
+	return err
+}
+
+// History returns the changes of the entity with given kind & ID
+// from the oldest to the most recent.
+// The history of a deleted entity is kept.
+func (srv Service) History(entity domain.AuditEntity, id uint) ([]domain.AuditEvent, error) {
+	return srv.repo.FindAuditEvents(entity, id)
+}
@@ -0,0 +1,41 @@
+package auditing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+func TestRecordHistory(t *testing.T) {
+	defer func() { repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{} }()
+	before := domain.Tag{ID: 1, Name: "sport"}
+	after := domain.Tag{ID: 1, Name: "sports"}
+	if err := auditor.Record(domain.AuditTag, 1, domain.AuditCreate, "admin", nil, before); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	time.Sleep(time.Millisecond) // Keep events ordered
+	if err := auditor.Record(domain.AuditTag, 1, domain.AuditUpdate, "admin", before, after); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if err := auditor.Record(domain.AuditTag, 2, domain.AuditDelete, "admin", after, nil); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	history, err := auditor.History(domain.AuditTag, 1)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("\nExpected 2 events\nReturned: %v", history)
+	}
+	created, updated := history[0], history[1]
+	if created.Action != domain.AuditCreate || created.Before != nil || string(created.After) != `{"ID":1,"Name":"sport"}` {
+		t.Fatalf("\nExpected creation of tag sport\nReturned: %v | %s | %s", created, created.Before, created.After)
+	}
+	if updated.Action != domain.AuditUpdate || string(updated.Before) != `{"ID":1,"Name":"sport"}` || string(updated.After) != `{"ID":1,"Name":"sports"}` {
+		t.Fatalf("\nExpected update of tag to sports\nReturned: %v | %s | %s", updated, updated.Before, updated.After)
+	}
+	if updated.Principal != "admin" || updated.Time.IsZero() {
+		t.Fatalf("\nExpected principal & time to be recorded\nReturned: %v", updated)
+	}
+}
@@ -0,0 +1,27 @@
+package auditing
+
+import "github.com/elhamza90/lifelog/internal/domain"
+
+// Service provides methods that record the changes of entities
+// and return their history
+type Service struct {
+	repo Repository
+}
+
+// NewService returns a new service with provided repository
+func NewService(r Repository) Service {
+	return Service{repo: r}
+}
+
+// Repository is the interface that wraps the methods that must be
+// implemented by the repository in order for auditing service
+// to perform its job
+//
+//	- SaveAuditEvent stores a recorded change
+//
+//	- FindAuditEvents returns the changes of an entity
+//	  from the oldest to the most recent
+type Repository interface {
+	SaveAuditEvent(domain.AuditEvent) (domain.AuditEventID, error)
+	FindAuditEvents(domain.AuditEntity, uint) ([]domain.AuditEvent, error)
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// apiKeyPrefix starts every API key so it can be told apart from a JWT
+const apiKeyPrefix string = "lflg_"
+
+// apiKeyPrefixLength specifies the number of characters of a key kept to recognize it
+const apiKeyPrefixLength int = len(apiKeyPrefix) + 8
+
+// IsAPIKey returns whether the given credential looks like an API key
+func IsAPIKey(key string) bool {
+	return len(key) > len(apiKeyPrefix) && key[:len(apiKeyPrefix)] == apiKeyPrefix
+}
+
+// hashAPIKey returns the hex-encoded SHA-256 hash of an API key.
+// Keys are random so they do not need a slow password hash.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewAPIKey validates and stores an API key of the user with given ID
+// with the name, scope and expiry ( zero for none ) of k.
+// granted are the scopes of the token creating the key:
+// it returns ErrAPIKeyScopeExceeded if they do not cover the scopes of the key.
+// It returns the stored key and the key itself, which is not stored.
+func (s Service) NewAPIKey(uid domain.UserID, k domain.APIKey, granted domain.Scopes) (domain.APIKey, string, error) {
+	if err := k.Validate(); err != nil {
+		return domain.APIKey{}, "", err
+	}
+	if !granted.Covers(k.Scopes()) {
+		return domain.APIKey{}, "", ErrAPIKeyScopeExceeded
+	}
+	now := time.Now()
+	if k.Expired(now) {
+		return domain.APIKey{}, "", ErrAPIKeyExpiry
+	}
+	id, err := NewTokenID()
+	if err != nil {
+		return domain.APIKey{}, "", err
+	}
+	key := apiKeyPrefix + id
+	k.UserID = uid
+	k.Prefix = key[:apiKeyPrefixLength]
+	k.Hash = hashAPIKey(key)
+	k.CreatedAt = now
+	if k.ID, err = s.repo.SaveAPIKey(k); err != nil {
+		return domain.APIKey{}, "", err
+	}
+	return k, key, nil
+}
+
+// APIKeys returns the API keys of the user with given ID
+func (s Service) APIKeys(uid domain.UserID) ([]domain.APIKey, error) {
+	return s.repo.FindAPIKeys(uid)
+}
+
+// RevokeAPIKey deletes the API key with given ID of the user with given ID.
+// It returns ErrAPIKeyNotFound if the user has no such key.
+func (s Service) RevokeAPIKey(uid domain.UserID, id domain.APIKeyID) error {
+	k, err := s.repo.FindAPIKey(id)
+	if err != nil {
+		return err
+	}
+	if k.UserID != uid {
+		return store.ErrAPIKeyNotFound
+	}
+	return s.repo.DeleteAPIKey(id)
+}
+
+// APIKeyOwner returns the user owning the given API key.
+// It returns ErrUserNotFound if the user does not exist.
+func (s Service) APIKeyOwner(k domain.APIKey) (domain.User, error) {
+	return s.repo.FindUserByID(k.UserID)
+}
+
+// AuthenticateAPIKey returns the stored API key matching the given key.
+// It returns ErrAPIKeyInvalid if there is none or if it expired.
+func (s Service) AuthenticateAPIKey(key string) (domain.APIKey, error) {
+	if !IsAPIKey(key) {
+		return domain.APIKey{}, ErrAPIKeyInvalid
+	}
+	k, err := s.repo.FindAPIKeyByHash(hashAPIKey(key))
+	if errors.Is(err, store.ErrAPIKeyNotFound) {
+		return domain.APIKey{}, ErrAPIKeyInvalid
+	} else if err != nil {
+		return domain.APIKey{}, err
+	}
+	if k.Expired(time.Now()) {
+		return domain.APIKey{}, ErrAPIKeyInvalid
+	}
+	return k, nil
+}
@@ -0,0 +1,101 @@
+package auth_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/usecase/auth"
+)
+
+// allScopes are the scopes of a token granting everything
+var allScopes = domain.Scopes{domain.ScopeAll}
+
+func TestNewAPIKey(t *testing.T) {
+	defer func() { repo.APIKeys = map[domain.APIKeyID]domain.APIKey{} }()
+	keysOnly := domain.Scopes{"keys:write"}
+	tests := map[string]struct {
+		key         domain.APIKey
+		granted     domain.Scopes // allScopes if nil
+		expectedErr error
+	}{
+		"No Expiry":                    {key: domain.APIKey{Name: "script", Scope: domain.APIKeyScopeRead}},
+		"Future Expiry":                {key: domain.APIKey{Name: "scanner", Scope: domain.APIKeyScopeReadWrite, ExpiresAt: time.Now().Add(time.Hour)}},
+		"Past Expiry":                  {key: domain.APIKey{Name: "old", Scope: domain.APIKeyScopeRead, ExpiresAt: time.Now().Add(-time.Hour)}, expectedErr: auth.ErrAPIKeyExpiry},
+		"Invalid Scope":                {key: domain.APIKey{Name: "script", Scope: "admin"}, expectedErr: domain.ErrAPIKeyScope},
+		"Read-Write with Narrow Token": {key: domain.APIKey{Name: "script", Scope: domain.APIKeyScopeReadWrite}, granted: keysOnly, expectedErr: auth.ErrAPIKeyScopeExceeded},
+		"Read with Narrow Token":       {key: domain.APIKey{Name: "script", Scope: domain.APIKeyScopeRead}, granted: keysOnly, expectedErr: auth.ErrAPIKeyScopeExceeded},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			granted := test.granted
+			if granted == nil {
+				granted = allScopes
+			}
+			k, key, err := authenticator.NewAPIKey(1, test.key, granted)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			stored := repo.APIKeys[k.ID]
+			if stored.UserID != 1 || !strings.HasPrefix(key, stored.Prefix) || stored.Hash == "" || strings.Contains(stored.Hash, key) {
+				t.Fatalf("\nExpected a hashed key of user 1 starting with its prefix\nReturned: %v ( key %s )", stored, key)
+			}
+		})
+	}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	defer func() { repo.APIKeys = map[domain.APIKeyID]domain.APIKey{} }()
+	valid, key, err := authenticator.NewAPIKey(1, domain.APIKey{Name: "script", Scope: domain.APIKeyScopeRead}, allScopes)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	expiring, expiredKey, _ := authenticator.NewAPIKey(1, domain.APIKey{Name: "expiring", Scope: domain.APIKeyScopeRead, ExpiresAt: time.Now().Add(time.Hour)}, allScopes)
+	// Expire the key
+	expiring.ExpiresAt = time.Now().Add(-time.Hour)
+	repo.APIKeys[expiring.ID] = expiring
+	tests := map[string]struct {
+		key         string
+		expectedErr error
+	}{
+		"Valid":     {key: key},
+		"Expired":   {key: expiredKey, expectedErr: auth.ErrAPIKeyInvalid},
+		"Unknown":   {key: key + "0", expectedErr: auth.ErrAPIKeyInvalid},
+		"Not A Key": {key: "eyJhbGciOiJIUzI1NiJ9", expectedErr: auth.ErrAPIKeyInvalid},
+		"Empty":     {key: "", expectedErr: auth.ErrAPIKeyInvalid},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			k, err := authenticator.AuthenticateAPIKey(test.key)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+			if err == nil && k.ID != valid.ID {
+				t.Fatalf("\nExpected key %s\nReturned: %v", valid.ID, k)
+			}
+		})
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	defer func() { repo.APIKeys = map[domain.APIKeyID]domain.APIKey{} }()
+	k, key, _ := authenticator.NewAPIKey(1, domain.APIKey{Name: "script", Scope: domain.APIKeyScopeRead}, allScopes)
+	if err := authenticator.RevokeAPIKey(2, k.ID); !errors.Is(err, store.ErrAPIKeyNotFound) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", store.ErrAPIKeyNotFound, err)
+	}
+	if err := authenticator.RevokeAPIKey(1, k.ID); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if _, err := authenticator.AuthenticateAPIKey(key); !errors.Is(err, auth.ErrAPIKeyInvalid) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", auth.ErrAPIKeyInvalid, err)
+	}
+	if keys, _ := authenticator.APIKeys(1); len(keys) != 0 {
+		t.Fatalf("\nExpected no keys\nReturned: %v", keys)
+	}
+}
@@ -1,35 +1,119 @@
 package auth
 
 import (
-	"errors"
-	"os"
 	"strconv"
+	"time"
 
-	"golang.org/x/crypto/bcrypt"
+	"github.com/elhamza90/lifelog/internal/domain"
 )
 
-// Service provides methods related to authentication
+// Service provides methods related to registration and authentication of users
 type Service struct {
-	// name of the environment variable where the hash of the password is stored
-	hashEnvVarName string
+	repo Repository
 }
 
-// NewService returns a new adding service with provided repository
-func NewService(hashVarName string) Service {
-	return Service{
-		hashEnvVarName: hashVarName,
-	}
+// NewService returns a new auth service with provided repository
+func NewService(r Repository) Service {
+	return Service{repo: r}
+}
+
+// Repository is the interface that wraps the methods that must be
+// implemented by the repository in order for auth service
+// to perform its job
+//
+//	- FindUserByName is used to check credentials and name duplicates
+//
+//	- FindUserByID is used to find the owners of API keys
+//
+//	- SaveUser is used to store registered users
+//
+//	- SaveRefreshToken, FindRefreshToken, UseRefreshToken are used
+//	  to issue and rotate refresh tokens
+//
+//	- RevokeRefreshTokenFamily, RevokeUserRefreshTokens are used
+//	  to invalidate refresh tokens
+//
+//	- PurgeRefreshTokens is used to delete expired refresh tokens
+//
+//	- SaveAPIKey, FindAPIKeys, FindAPIKey, DeleteAPIKey are used
+//	  to manage API keys of users
+//
+//	- FindAPIKeyByHash is used to authenticate requests with an API key
+type Repository interface {
+	FindUserByName(string) (domain.User, error)
+	FindUserByID(domain.UserID) (domain.User, error)
+	SaveUser(domain.User) (domain.UserID, error)
+	SaveRefreshToken(domain.RefreshToken) error
+	FindRefreshToken(id string) (domain.RefreshToken, error)
+	UseRefreshToken(id string) error
+	RevokeRefreshTokenFamily(family string) error
+	RevokeUserRefreshTokens(domain.UserID) error
+	PurgeRefreshTokens(before time.Time) (int, error)
+	SaveAPIKey(domain.APIKey) (domain.APIKeyID, error)
+	FindAPIKeys(domain.UserID) ([]domain.APIKey, error)
+	FindAPIKey(domain.APIKeyID) (domain.APIKey, error)
+	FindAPIKeyByHash(hash string) (domain.APIKey, error)
+	DeleteAPIKey(domain.APIKeyID) error
 }
 
 // passwordMinLength specifies minimum length given password should be.
 const passwordMinLength int = 8
-const passwordMaxLength int = 256
+
+// passwordMaxLength specifies maximum length in bytes given password should be.
+// bcrypt ignores the bytes after the 72nd, so longer passwords are rejected.
+const passwordMaxLength int = 72
+
+// bcryptCost specifies the cost used to hash passwords
+const bcryptCost int = 10
 
 // Errors
 var (
-	ErrPasswordLength       error = errors.New("Password must be " + strconv.Itoa(passwordMinLength) + " ~ " + strconv.Itoa(passwordMaxLength) + " characters")
-	ErrIncorrectCredentials error = errors.New("Incorrect credentials")
-	ErrHashNotFound         error = errors.New("Could not perform authentication")
+	ErrPasswordLength error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "password_length",
+		Message: "Password must be " + strconv.Itoa(passwordMinLength) + " ~ " + strconv.Itoa(passwordMaxLength) + " bytes",
+		Field:   "password",
+		Details: map[string]interface{}{"min": passwordMinLength, "max": passwordMaxLength},
+	}
+	ErrIncorrectCredentials error = &domain.Error{
+		Kind:    domain.KindUnauthorized,
+		Code:    "incorrect_credentials",
+		Message: "Incorrect credentials",
+	}
+	ErrRefreshTokenRevoked error = &domain.Error{
+		Kind:    domain.KindUnauthorized,
+		Code:    "refresh_token_revoked",
+		Message: "Refresh Token was revoked",
+		Field:   "refresh",
+	}
+	ErrRefreshTokenReused error = &domain.Error{
+		Kind:    domain.KindUnauthorized,
+		Code:    "refresh_token_reused",
+		Message: "Refresh Token was already used: all tokens of its session were revoked",
+		Field:   "refresh",
+	}
+	ErrAPIKeyInvalid error = &domain.Error{
+		Kind:    domain.KindUnauthorized,
+		Code:    "api_key_invalid",
+		Message: "API Key is invalid, expired or revoked",
+	}
+	ErrAPIKeyScope error = &domain.Error{
+		Kind:    domain.KindForbidden,
+		Code:    "api_key_scope",
+		Message: "API Key scope does not allow this request",
+	}
+	ErrAPIKeyExpiry error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "api_key_expiry",
+		Message: "API Key expiry must be in the future",
+		Field:   "expiresAt",
+	}
+	ErrAPIKeyScopeExceeded error = &domain.Error{
+		Kind:    domain.KindForbidden,
+		Code:    "api_key_scope_exceeded",
+		Message: "API Key scope can not grant more than the scopes of the token creating it",
+		Field:   "scope",
+	}
 )
 
 // ValidatePassword validates password format
@@ -39,23 +123,3 @@ func (s Service) validatePassword(pass string) error {
 	}
 	return nil
 }
-
-// getPasswordHash returns bcrypt hash of correct password
-func (s Service) getPasswordHash() string {
-	return os.Getenv(s.hashEnvVarName)
-}
-
-// Authenticate hashes the given password and compares it with stored hash
-func (s Service) Authenticate(pass string) error {
-	if err := s.validatePassword(pass); err != nil {
-		return err
-	}
-	hash := s.getPasswordHash()
-	if hash == "" {
-		return ErrHashNotFound
-	}
-	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(pass)); err != nil {
-		return ErrIncorrectCredentials
-	}
-	return nil
-}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// tokenIDBytes specifies the number of random bytes of a token ID
+const tokenIDBytes int = 16
+
+// NewTokenID returns a random hex-encoded ID for a token
+func NewTokenID() (string, error) {
+	b := make([]byte, tokenIDBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// NewRefreshToken stores and returns a refresh token of a new family
+// for the user with given ID, expiring at exp.
+func (s Service) NewRefreshToken(uid domain.UserID, exp time.Time) (domain.RefreshToken, error) {
+	return s.issueRefreshToken(uid, "", exp)
+}
+
+// issueRefreshToken stores and returns a refresh token of given family.
+// The token starts a new family if family is empty.
+func (s Service) issueRefreshToken(uid domain.UserID, family string, exp time.Time) (domain.RefreshToken, error) {
+	id, err := NewTokenID()
+	if err != nil {
+		return domain.RefreshToken{}, err
+	}
+	if family == "" {
+		family = id
+	}
+	rt := domain.RefreshToken{ID: id, Family: family, UserID: uid, ExpiresAt: exp}
+	if err := s.repo.SaveRefreshToken(rt); err != nil {
+		return domain.RefreshToken{}, err
+	}
+	return rt, nil
+}
+
+// RotateRefreshToken invalidates the refresh token with given ID of given user
+// and returns a new token of the same family expiring at exp.
+// Using a token twice revokes its whole family as it may have been stolen:
+// ErrRefreshTokenReused is returned.
+func (s Service) RotateRefreshToken(id string, uid domain.UserID, exp time.Time) (domain.RefreshToken, error) {
+	rt, err := s.findRefreshToken(id, uid)
+	if err != nil {
+		return domain.RefreshToken{}, err
+	}
+	if rt.Used {
+		return domain.RefreshToken{}, s.revokeReused(rt)
+	}
+	if err := s.repo.UseRefreshToken(rt.ID); errors.Is(err, store.ErrRefreshTokenUsed) {
+		// Used concurrently
+		return domain.RefreshToken{}, s.revokeReused(rt)
+	} else if err != nil {
+		return domain.RefreshToken{}, err
+	}
+	return s.issueRefreshToken(rt.UserID, rt.Family, exp)
+}
+
+// revokeReused revokes the family of a reused token
+// and returns ErrRefreshTokenReused.
+func (s Service) revokeReused(rt domain.RefreshToken) error {
+	if err := s.repo.RevokeRefreshTokenFamily(rt.Family); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// findRefreshToken returns the refresh token with given ID of given user
+// if it can still be used. It returns ErrRefreshTokenRevoked otherwise.
+func (s Service) findRefreshToken(id string, uid domain.UserID) (domain.RefreshToken, error) {
+	rt, err := s.repo.FindRefreshToken(id)
+	if errors.Is(err, store.ErrRefreshTokenNotFound) {
+		return domain.RefreshToken{}, ErrRefreshTokenRevoked
+	} else if err != nil {
+		return domain.RefreshToken{}, err
+	}
+	if rt.UserID != uid || rt.Revoked || !rt.ExpiresAt.After(time.Now()) {
+		return domain.RefreshToken{}, ErrRefreshTokenRevoked
+	}
+	return rt, nil
+}
+
+// Logout revokes the family of the refresh token with given ID of given user,
+// ending the session started at login.
+func (s Service) Logout(id string, uid domain.UserID) error {
+	rt, err := s.findRefreshToken(id, uid)
+	if err != nil {
+		return err
+	}
+	return s.repo.RevokeRefreshTokenFamily(rt.Family)
+}
+
+// LogoutAll revokes all refresh tokens of the user with given ID,
+// ending all of its sessions.
+func (s Service) LogoutAll(uid domain.UserID) error {
+	return s.repo.RevokeUserRefreshTokens(uid)
+}
+
+// PurgeRefreshTokens deletes the refresh tokens expired before now
+// and returns how many were deleted.
+// Rotated tokens are kept until they expire so that their reuse
+// is still detected and revokes their family.
+func (s Service) PurgeRefreshTokens(now time.Time) (int, error) {
+	return s.repo.PurgeRefreshTokens(now)
+}
@@ -0,0 +1,119 @@
+package auth_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/auth"
+)
+
+func TestRotateRefreshToken(t *testing.T) {
+	defer func() { repo.RefreshTokens = map[string]domain.RefreshToken{} }()
+	exp := time.Now().Add(time.Hour)
+	first, err := authenticator.NewRefreshToken(1, exp)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if first.ID == "" || first.Family != first.ID {
+		t.Fatalf("\nExpected a token starting its own family\nReturned: %v", first)
+	}
+	expired, _ := authenticator.NewRefreshToken(1, time.Now().Add(-time.Hour))
+	tests := map[string]struct {
+		id          string
+		uid         domain.UserID
+		expectedErr error
+	}{
+		"Unknown Token": {"unknown", 1, auth.ErrRefreshTokenRevoked},
+		"Other User":    {first.ID, 2, auth.ErrRefreshTokenRevoked},
+		"Expired Token": {expired.ID, 1, auth.ErrRefreshTokenRevoked},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := authenticator.RotateRefreshToken(test.id, test.uid, exp); !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+		})
+	}
+	// Rotate
+	second, err := authenticator.RotateRefreshToken(first.ID, 1, exp)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if second.ID == first.ID || second.Family != first.Family {
+		t.Fatalf("\nExpected a new token of the same family\nReturned: %v", second)
+	}
+	if !repo.RefreshTokens[first.ID].Used {
+		t.Fatal("\nExpected rotated token to be used")
+	}
+	// Reuse revokes the family
+	if _, err := authenticator.RotateRefreshToken(first.ID, 1, exp); !errors.Is(err, auth.ErrRefreshTokenReused) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", auth.ErrRefreshTokenReused, err)
+	}
+	if _, err := authenticator.RotateRefreshToken(second.ID, 1, exp); !errors.Is(err, auth.ErrRefreshTokenRevoked) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", auth.ErrRefreshTokenRevoked, err)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	defer func() { repo.RefreshTokens = map[string]domain.RefreshToken{} }()
+	exp := time.Now().Add(time.Hour)
+	first, _ := authenticator.NewRefreshToken(1, exp)
+	rotated, _ := authenticator.RotateRefreshToken(first.ID, 1, exp)
+	other, _ := authenticator.NewRefreshToken(1, exp)
+	if err := authenticator.Logout(rotated.ID, 2); !errors.Is(err, auth.ErrRefreshTokenRevoked) {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", auth.ErrRefreshTokenRevoked, err)
+	}
+	if err := authenticator.Logout(rotated.ID, 1); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if !repo.RefreshTokens[first.ID].Revoked || !repo.RefreshTokens[rotated.ID].Revoked {
+		t.Fatal("\nExpected the family of the token to be revoked")
+	}
+	if repo.RefreshTokens[other.ID].Revoked {
+		t.Fatal("\nExpected other sessions not to be revoked")
+	}
+}
+
+func TestLogoutAll(t *testing.T) {
+	defer func() { repo.RefreshTokens = map[string]domain.RefreshToken{} }()
+	exp := time.Now().Add(time.Hour)
+	first, _ := authenticator.NewRefreshToken(1, exp)
+	second, _ := authenticator.NewRefreshToken(1, exp)
+	other, _ := authenticator.NewRefreshToken(2, exp)
+	if err := authenticator.LogoutAll(1); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	for _, id := range []string{first.ID, second.ID} {
+		if _, err := authenticator.RotateRefreshToken(id, 1, exp); !errors.Is(err, auth.ErrRefreshTokenRevoked) {
+			t.Fatalf("\nExpected Error: %v\nReturned Error: %v", auth.ErrRefreshTokenRevoked, err)
+		}
+	}
+	if repo.RefreshTokens[other.ID].Revoked {
+		t.Fatal("\nExpected tokens of other users not to be revoked")
+	}
+}
+
+func TestPurgeRefreshTokens(t *testing.T) {
+	defer func() { repo.RefreshTokens = map[string]domain.RefreshToken{} }()
+	now := time.Now()
+	expired, _ := authenticator.NewRefreshToken(1, now.Add(-time.Hour))
+	first, _ := authenticator.NewRefreshToken(1, now.Add(time.Hour))
+	rotated, _ := authenticator.RotateRefreshToken(first.ID, 1, now.Add(time.Hour))
+	purged, err := authenticator.PurgeRefreshTokens(now)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if purged != 1 {
+		t.Fatalf("\nExpected 1 purged token\nReturned: %d", purged)
+	}
+	if _, ok := repo.RefreshTokens[expired.ID]; ok {
+		t.Fatal("\nExpected expired token to be purged")
+	}
+	for _, id := range []string{first.ID, rotated.ID} {
+		if _, ok := repo.RefreshTokens[id]; !ok {
+			t.Fatalf("\nExpected unexpired token %s to be kept", id)
+		}
+	}
+}
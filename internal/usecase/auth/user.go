@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyHash is compared against when the user is not found,
+// so that Authenticate does the same bcrypt work for unknown users.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy_password"), bcryptCost)
+
+// Register validates the given name and password, hashes the password
+// with bcrypt, stores the new user and returns it.
+func (s Service) Register(name string, pass string) (domain.User, error) {
+	usr := domain.User{Name: name}
+	if err := usr.Validate(); err != nil {
+		return domain.User{}, err
+	}
+	if err := s.validatePassword(pass); err != nil {
+		return domain.User{}, err
+	}
+	if _, err := s.repo.FindUserByName(usr.Name); err == nil {
+		return domain.User{}, domain.ErrUserNameDuplicate
+	} else if !errors.Is(err, store.ErrUserNotFound) {
+		return domain.User{}, err
+	}
+	hash, err := HashPassword(pass)
+	if err != nil {
+		return domain.User{}, err
+	}
+	usr.PasswordHash = hash
+	if usr.ID, err = s.repo.SaveUser(usr); err != nil {
+		return domain.User{}, err
+	}
+	return usr, nil
+}
+
+// Authenticate finds the user with given name and compares
+// the given password with its stored hash.
+func (s Service) Authenticate(name string, pass string) (domain.User, error) {
+	if err := s.validatePassword(pass); err != nil {
+		return domain.User{}, err
+	}
+	usr, err := s.repo.FindUserByName(strings.ToLower(strings.TrimSpace(name)))
+	if errors.Is(err, store.ErrUserNotFound) {
+		// Compare anyway so unknown users take as long as known ones
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(pass))
+		return domain.User{}, ErrIncorrectCredentials
+	} else if err != nil {
+		return domain.User{}, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(usr.PasswordHash), []byte(pass)); err != nil {
+		return domain.User{}, ErrIncorrectCredentials
+	}
+	return usr, nil
+}
+
+// HashPassword returns the bcrypt hash of given password
+func HashPassword(pass string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(pass), bcryptCost)
+	return string(hash), err
+}
@@ -0,0 +1,77 @@
+package auth_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/auth"
+)
+
+func TestRegister(t *testing.T) {
+	defer func() { repo.Users = map[domain.UserID]domain.User{} }()
+	const testPass string = "correct_pass"
+	tests := map[string]struct {
+		name        string
+		pass        string
+		expectedErr error
+	}{
+		"Valid":                   {"Hamza", testPass, nil},
+		"Duplicate Name":          {"hamza", testPass, domain.ErrUserNameDuplicate},
+		"Short Name":              {"ha", testPass, domain.ErrUserNameLength},
+		"Invalid Name Characters": {"ham za", testPass, domain.ErrUserNameInvalidCharacters},
+		"Short Password":          {"other", "pass", auth.ErrPasswordLength},
+	}
+	// The valid user must be registered before the duplicate one
+	for _, name := range []string{"Valid", "Duplicate Name", "Short Name", "Invalid Name Characters", "Short Password"} {
+		test := tests[name]
+		t.Run(name, func(t *testing.T) {
+			usr, err := authenticator.Register(test.name, test.pass)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			stored := repo.Users[usr.ID]
+			if stored.Name != "hamza" || stored.PasswordHash == "" || stored.PasswordHash == test.pass {
+				t.Fatalf("\nExpected stored user hamza with a hashed password\nReturned: %v", stored)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	defer func() { repo.Users = map[domain.UserID]domain.User{} }()
+	const testPass string = "correct_pass"
+	registered, err := authenticator.Register("hamza", testPass)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	tests := map[string]struct {
+		name        string
+		pass        string
+		expectedErr error
+	}{
+		"Short Password":           {"hamza", "pass", auth.ErrPasswordLength},
+		"Long Password":            {"hamza", strings.Repeat("pass", 100), auth.ErrPasswordLength},
+		"Password Over 72 Bytes":   {"hamza", testPass + strings.Repeat("x", 61), auth.ErrPasswordLength},
+		"Password Prefix Only":     {"hamza", testPass + strings.Repeat("x", 60), auth.ErrIncorrectCredentials},
+		"Valid Incorrect Password": {"hamza", "valid_wrong_pass", auth.ErrIncorrectCredentials},
+		"Unknown User":             {"someone", testPass, auth.ErrIncorrectCredentials},
+		"Correct Password":         {"Hamza", testPass, nil},
+	}
+	// Subtests execution
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			usr, err := authenticator.Authenticate(test.name, test.pass)
+			if err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if err == nil && usr.ID != registered.ID {
+				t.Fatalf("\nExpected User: %v\nReturned User: %v", registered, usr)
+			}
+		})
+	}
+}
@@ -1,21 +1,22 @@
 package deleting
 
-import (
-	"errors"
-
-	"github.com/elhamza90/lifelog/internal/domain"
-)
+import "github.com/elhamza90/lifelog/internal/domain"
 
 // ErrActivityHasExpenses is returned when activity to be deleted has expenses associated with it
-var ErrActivityHasExpenses error = errors.New("Activity can not be deleted because there are expenses associated with it")
+var ErrActivityHasExpenses error = &domain.Error{
+	Kind:    domain.KindUnprocessable,
+	Code:    "activity_has_expenses",
+	Message: "Activity can not be deleted because there are expenses associated with it",
+}
 
-// Activity deletes activity with provided ID.
+// Activity deletes activity with provided ID and records it was deleted by principal.
 // It does the following checks:
 // 	- Check Activity Exists
 //	- Check Activity has no expenses
-func (srv Service) Activity(id domain.ActivityID) error {
+func (srv Service) Activity(id domain.ActivityID, principal string) error {
 	// Check Activity Exists
-	if _, err := srv.repo.FindActivityByID(id); err != nil {
+	old, err := srv.repo.FindActivityByID(id)
+	if err != nil {
 		return err
 	}
 
@@ -25,5 +26,8 @@ func (srv Service) Activity(id domain.ActivityID) error {
 	} else if len(res) > 0 {
 		return ErrActivityHasExpenses
 	}
-	return srv.repo.DeleteActivity(id)
+	if err := srv.repo.DeleteActivity(id); err != nil {
+		return err
+	}
+	return srv.auditor.Record(domain.AuditActivity, uint(id), domain.AuditDelete, principal, old, nil)
 }
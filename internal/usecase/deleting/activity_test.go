@@ -10,6 +10,7 @@ import (
 )
 
 func TestDeleteActivity(t *testing.T) {
+	defer func() { repo.Trash = map[string]time.Time{} }() // Empty trash of deleted items
 	repo.Expenses = map[domain.ExpenseID]domain.Expense{
 		1: {
 			ID:         1,
@@ -46,7 +47,7 @@ func TestDeleteActivity(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			err := deleter.Activity(test.ID)
+			err := deleter.Activity(test.ID, "admin")
 			failed := err != test.expectedErr
 			if failed {
 				t.Fatalf("\nExpected Error: %v\nReturned Error; %v", err, test.expectedErr)
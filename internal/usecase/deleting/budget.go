@@ -0,0 +1,14 @@
+package deleting
+
+import "github.com/elhamza90/lifelog/internal/domain"
+
+// Budget calls the repo to delete the budget with provided ID
+// If budget with given ID does not exist returns error
+func (srv Service) Budget(id domain.BudgetID) error {
+	// Check budget exist
+	if _, err := srv.repo.FindBudgetByID(id); err != nil {
+		return err
+	}
+
+	return srv.repo.DeleteBudget(id)
+}
@@ -0,0 +1,31 @@
+package deleting_test
+
+import (
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+func TestDeleteBudget(t *testing.T) {
+	repo.Budgets = map[domain.BudgetID]domain.Budget{
+		1: {ID: 1, Period: domain.PeriodMonth, Amount: 10000, Currency: "EUR"},
+	}
+	tests := map[string]struct {
+		id          domain.BudgetID
+		expectedErr error
+	}{
+		"Existing Budget":     {id: 1, expectedErr: nil},
+		"Non-Existing Budget": {id: 2, expectedErr: store.ErrBudgetNotFound},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := deleter.Budget(test.id); err != test.expectedErr {
+				t.Fatalf("Expected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+		})
+	}
+	if len(repo.Budgets) != 0 {
+		t.Fatalf("\nExpected budget to be deleted\nReturned: %v", repo.Budgets)
+	}
+}
@@ -3,22 +3,41 @@ package deleting
 import "github.com/elhamza90/lifelog/internal/domain"
 
 // DeleteExpense calls the repo to delete the expense with provided ID
+// and records it was deleted by principal.
 // If expense with given ID does not exist returns error
-func (srv Service) Expense(id domain.ExpenseID) error {
+func (srv Service) Expense(id domain.ExpenseID, principal string) error {
 	// Check expense exist
-	if _, err := srv.repo.FindExpenseByID(id); err != nil {
+	old, err := srv.repo.FindExpenseByID(id)
+	if err != nil {
 		return err
 	}
 
-	return srv.repo.DeleteExpense(id)
+	if err := srv.repo.DeleteExpense(id); err != nil {
+		return err
+	}
+	return srv.auditor.Record(domain.AuditExpense, uint(id), domain.AuditDelete, principal, old, nil)
 }
 
 // ActivityExpenses calls repo to delete all expenses belonging to
-// provided activity
-func (srv Service) ActivityExpenses(aid domain.ActivityID) error {
+// provided activity and records they were deleted by principal.
+// All deletions are recorded even if one fails and the first error is returned.
+func (srv Service) ActivityExpenses(aid domain.ActivityID, principal string) error {
 	// Check if activity with provided ID exists
 	if _, err := srv.repo.FindActivityByID(aid); err != nil {
 		return err
 	}
-	return srv.repo.DeleteExpensesByActivity(aid)
+	expenses, err := srv.repo.FindExpensesByActivity(aid)
+	if err != nil {
+		return err
+	}
+	if err := srv.repo.DeleteExpensesByActivity(aid); err != nil {
+		return err
+	}
+	var firstErr error
+	for _, exp := range expenses {
+		if err := srv.auditor.Record(domain.AuditExpense, uint(exp.ID), domain.AuditDelete, principal, exp, nil); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
 }
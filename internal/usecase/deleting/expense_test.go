@@ -9,6 +9,10 @@ import (
 )
 
 func TestDeleteExpense(t *testing.T) {
+	defer func() {
+		repo.Trash = map[string]time.Time{} // Empty trash of deleted items
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	}()
 	repo.Expenses = map[domain.ExpenseID]domain.Expense{
 		9: {
 			ID:         9,
@@ -30,16 +34,22 @@ func TestDeleteExpense(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			err := deleter.Expense(test.ID)
+			err := deleter.Expense(test.ID, "admin")
 			failed := err != test.expectedErr
 			if failed {
 				t.Fatalf("Expecting Error: %v\nReturned Error; %v", err, test.expectedErr)
 			}
+			// Check deletion is recorded with the deleted expense
+			events, _ := repo.FindAuditEvents(domain.AuditExpense, uint(test.ID))
+			if recorded := len(events) == 1 && events[0].Action == domain.AuditDelete && events[0].Before != nil && events[0].After == nil; recorded != (err == nil) {
+				t.Fatalf("\nExpected deletion recorded: %v\nReturned: %v", err == nil, events)
+			}
 		})
 	}
 }
 
 func TestDeleteActivityExpenses(t *testing.T) {
+	defer func() { repo.Trash = map[string]time.Time{} }() // Empty trash of deleted items
 	now := time.Now()
 	const testActID domain.ActivityID = 1
 	repo.Activities = map[domain.ActivityID]domain.Activity{
@@ -67,7 +77,7 @@ func TestDeleteActivityExpenses(t *testing.T) {
 	// Subtests Execution
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			err := deleter.ActivityExpenses(test.actID)
+			err := deleter.ActivityExpenses(test.actID, "admin")
 			if err != test.expectedErr {
 				t.Fatalf("\nExpected err: %v\nReturned err: %v", test.expectedErr, err)
 			}
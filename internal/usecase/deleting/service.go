@@ -1,38 +1,53 @@
 package deleting
 
-import "github.com/elhamza90/lifelog/internal/domain"
+import (
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/auditing"
+)
 
 // Service provides methods that delete entities
 type Service struct {
-	repo Repository
+	repo    Repository
+	auditor auditing.Service // Records deleted tags, activities & expenses
 }
 
 // NewService returns a new service with provided repository
 func NewService(r Repository) Service {
-	return Service{repo: r}
+	return Service{repo: r, auditor: auditing.NewService(r)}
 }
 
 // Repository is the interface that wraps the methods that must be
 // implemented by the repository in order for deleting service
 // to perform its job
 //
-//	- DeleteExpense, DeleteExpensesByActivity, DeleteActivity, DeleteTag
-//	  are the main methods to delete entities
+//	- DeleteExpense, DeleteExpensesByActivity, DeleteActivity, DeleteTag,
+//	  DeleteBudget, DeleteTemplate are the main methods to delete entities
 //
-//	- FindExpenseByID, FindActivityByID, FindTagByID are used to check
-//	  for existance of entities before deleting them
+//	- FindExpenseByID, FindActivityByID, FindTagByID, FindBudgetByID,
+//	  FindTemplateByID are used to check for existance of entities
+//	  before deleting them
 //
-//	- FindExpensesByActivity, FindExpensesByTag, FindActivitiesByTag are used
-//	  to check if there are any things associated with tag before deleting it.
+//	- FindExpensesByActivity, FindExpensesByTag, FindActivitiesByTag,
+//	  FindBudgetsByTag are used to check if there are any things
+//	  associated with tag before deleting it.
+//
+//	- The auditing repository methods are used to record
+//	  deleted tags, activities & expenses
 type Repository interface {
+	auditing.Repository
 	DeleteTag(domain.TagID) error
 	DeleteExpense(id domain.ExpenseID) error
 	DeleteActivity(domain.ActivityID) error
 	DeleteExpensesByActivity(domain.ActivityID) error
+	DeleteBudget(domain.BudgetID) error
+	DeleteTemplate(domain.TemplateID) error
 	FindActivityByID(domain.ActivityID) (domain.Activity, error)
 	FindExpenseByID(id domain.ExpenseID) (domain.Expense, error)
 	FindTagByID(domain.TagID) (domain.Tag, error)
 	FindExpensesByActivity(domain.ActivityID) ([]domain.Expense, error)
 	FindExpensesByTag(domain.TagID) ([]domain.Expense, error)
 	FindActivitiesByTag(domain.TagID) ([]domain.Activity, error)
+	FindBudgetByID(domain.BudgetID) (domain.Budget, error)
+	FindBudgetsByTag(domain.TagID) ([]domain.Budget, error)
+	FindTemplateByID(domain.TemplateID) (domain.Template, error)
 }
@@ -1,25 +1,36 @@
 package deleting
 
-import (
-	"errors"
-
-	"github.com/elhamza90/lifelog/internal/domain"
-)
+import "github.com/elhamza90/lifelog/internal/domain"
 
 var (
 	//ErrTagHasExpenses is returned when tag to be deleted has expenses associated with it
-	ErrTagHasExpenses error = errors.New("Tag can not be deleted because there are expenses associated with it")
+	ErrTagHasExpenses error = &domain.Error{
+		Kind:    domain.KindUnprocessable,
+		Code:    "tag_has_expenses",
+		Message: "Tag can not be deleted because there are expenses associated with it",
+	}
 	//ErrTagHasActivities is returned when tag to be deleted has activities associated with it
-	ErrTagHasActivities error = errors.New("Tag can not be deleted because there are activities associated with it")
+	ErrTagHasActivities error = &domain.Error{
+		Kind:    domain.KindUnprocessable,
+		Code:    "tag_has_activities",
+		Message: "Tag can not be deleted because there are activities associated with it",
+	}
+	//ErrTagHasBudgets is returned when tag to be deleted has budgets associated with it
+	ErrTagHasBudgets error = &domain.Error{
+		Kind:    domain.KindUnprocessable,
+		Code:    "tag_has_budgets",
+		Message: "Tag can not be deleted because there are budgets associated with it",
+	}
 )
 
-// Tag calls repo to remove Tag
+// Tag calls repo to remove Tag and records it was deleted by principal
 // It does the following checks:
-//	- Check if tag exists
-//	- Check if there are any expenses/activities associated with tag
-func (srv Service) Tag(id domain.TagID) error {
+//   - Check if tag exists
+//   - Check if there are any expenses/activities/budgets associated with tag
+func (srv Service) Tag(id domain.TagID, principal string) error {
 	// Check if Tag exists
-	if _, err := srv.repo.FindTagByID(id); err != nil {
+	old, err := srv.repo.FindTagByID(id)
+	if err != nil {
 		return err
 	}
 
@@ -37,5 +48,15 @@ func (srv Service) Tag(id domain.TagID) error {
 		return ErrTagHasActivities
 	}
 
-	return srv.repo.DeleteTag(id)
+	// Check if tag has budgets
+	if res, err := srv.repo.FindBudgetsByTag(id); err != nil {
+		return err
+	} else if len(res) > 0 {
+		return ErrTagHasBudgets
+	}
+
+	if err := srv.repo.DeleteTag(id); err != nil {
+		return err
+	}
+	return srv.auditor.Record(domain.AuditTag, uint(id), domain.AuditDelete, principal, old, nil)
 }
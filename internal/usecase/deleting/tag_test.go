@@ -9,12 +9,19 @@ import (
 )
 
 func TestDeleteTag(t *testing.T) {
+	defer func() { repo.Trash = map[string]time.Time{} }() // Empty trash of deleted items
 	repo.Tags = map[domain.TagID]domain.Tag{
 		1: {ID: 1, Name: "tag-1"},
 		2: {ID: 2, Name: "tag-2"},
 		3: {ID: 3, Name: "tag-3"},
+		4: {ID: 4, Name: "tag-4"},
 	}
 
+	repo.Budgets = map[domain.BudgetID]domain.Budget{
+		1: {ID: 1, TagID: 4, Period: domain.PeriodMonth, Amount: 10000, Currency: "EUR"},
+	}
+	defer func() { repo.Budgets = map[domain.BudgetID]domain.Budget{} }()
+
 	repo.Expenses = map[domain.ExpenseID]domain.Expense{
 		1: {
 			ID:    1,
@@ -54,12 +61,16 @@ func TestDeleteTag(t *testing.T) {
 			id:          3,
 			expectedErr: deleting.ErrTagHasActivities,
 		},
+		"Tag with budget": {
+			id:          4,
+			expectedErr: deleting.ErrTagHasBudgets,
+		},
 	}
 
 	// Subtests Execution
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			err := deleter.Tag(test.id)
+			err := deleter.Tag(test.id, "admin")
 			if err != test.expectedErr {
 				t.Fatalf("Expected Err: %v\nReturned Err: %v", test.expectedErr, err)
 			}
@@ -0,0 +1,15 @@
+package deleting
+
+import "github.com/elhamza90/lifelog/internal/domain"
+
+// Template calls the repo to delete the recurring template with provided ID
+// and its recorded occurrences. Expenses and activities it created are kept.
+// If template with given ID does not exist returns error
+func (srv Service) Template(id domain.TemplateID) error {
+	// Check template exist
+	if _, err := srv.repo.FindTemplateByID(id); err != nil {
+		return err
+	}
+
+	return srv.repo.DeleteTemplate(id)
+}
@@ -1,19 +1,23 @@
 package editing
 
 import (
+	"time"
+
 	"github.com/elhamza90/lifelog/internal/domain"
 )
 
 // EditActivity calls repo to update given activity
-func (srv Service) EditActivity(act domain.Activity) error {
+// and records it was updated by principal
+func (srv Service) EditActivity(act domain.Activity, principal string) error {
 
 	// Check Activity Exists
-	if _, err := srv.repo.FindActivityByID(act.ID); err != nil {
+	old, err := srv.repo.FindActivityByID(act.ID)
+	if err != nil {
 		return err
 	}
 
 	// Check primitive fields are valid
-	if err := act.Validate(); err != nil {
+	if err := act.Validate(srv.policy); err != nil {
 		return err
 	}
 
@@ -28,5 +32,27 @@ func (srv Service) EditActivity(act domain.Activity) error {
 	}
 	act.Tags = fetchedTags
 
-	return srv.repo.EditActivity(act)
+	if err := srv.repo.EditActivity(act); err != nil {
+		return err
+	}
+	return srv.auditor.Record(domain.AuditActivity, uint(act.ID), domain.AuditUpdate, principal, old, act)
+}
+
+// CompleteActivity marks the planned activity with given ID as done,
+// records it was updated by principal and returns it.
+// A planned activity ending in the future is moved to end now.
+// It returns ErrStatusNotPlanned if the activity is not planned
+func (srv Service) CompleteActivity(id domain.ActivityID, principal string) (domain.Activity, error) {
+	old, err := srv.repo.FindActivityByID(id)
+	if err != nil {
+		return domain.Activity{}, err
+	}
+	act := old
+	if err := act.Complete(time.Now()); err != nil {
+		return domain.Activity{}, err
+	}
+	if err := srv.repo.EditActivity(act); err != nil {
+		return domain.Activity{}, err
+	}
+	return act, srv.auditor.Record(domain.AuditActivity, uint(id), domain.AuditUpdate, principal, old, act)
 }
@@ -1,6 +1,7 @@
 package editing_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -60,6 +61,17 @@ func TestEditActivity(t *testing.T) {
 			},
 			expectedErr: store.ErrTagNotFound,
 		},
+		"Planned In Future": {
+			act: domain.Activity{
+				ID:       1,
+				Label:    "Planned Test Activity",
+				Tags:     []domain.Tag{{ID: 1}},
+				Time:     time.Now().AddDate(0, 0, 1),
+				Duration: time.Duration(time.Hour),
+				Status:   domain.StatusPlanned,
+			},
+			expectedErr: nil,
+		},
 		"Field Invalid (Time future)": {
 			act: domain.Activity{
 				ID:       1,
@@ -75,8 +87,8 @@ func TestEditActivity(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			err := editor.EditActivity(test.act)
-			if err != test.expectedErr {
+			err := editor.EditActivity(test.act, "admin")
+			if !errors.Is(err, test.expectedErr) {
 				t.Fatalf("Expected Err: %v\nReturned Err: %v", test.expectedErr, err)
 			}
 
@@ -88,3 +100,43 @@ func TestEditActivity(t *testing.T) {
 		})
 	}
 }
+
+func TestCompleteActivity(t *testing.T) {
+	now := time.Now()
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Planned Yesterday", Time: now.AddDate(0, 0, -1), Duration: time.Hour, Status: domain.StatusPlanned},
+		2: {ID: 2, Label: "Planned Tomorrow", Time: now.AddDate(0, 0, 1), Duration: time.Hour, Status: domain.StatusPlanned},
+		3: {ID: 3, Label: "Cancelled Activity", Time: now.AddDate(0, 0, 1), Duration: time.Hour, Status: domain.StatusCancelled},
+		4: {ID: 4, Label: "Done Activity", Time: now.AddDate(0, 0, -1), Duration: time.Hour, Status: domain.StatusDone},
+	}
+	tests := map[string]struct {
+		id           domain.ActivityID
+		expectedTime time.Time
+		expectedErr  error
+	}{
+		"Planned In Past":   {id: 1, expectedTime: now.AddDate(0, 0, -1)},
+		"Planned In Future": {id: 2, expectedTime: now.Add(-time.Hour)},
+		"Cancelled":         {id: 3, expectedErr: domain.ErrStatusNotPlanned},
+		"Already Done":      {id: 4, expectedErr: domain.ErrStatusNotPlanned},
+		"Non Existing":      {id: 9898, expectedErr: store.ErrActivityNotFound},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := editor.CompleteActivity(test.id, "admin")
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			stored := repo.Activities[test.id]
+			if stored.Status != domain.StatusDone {
+				t.Fatalf("\nExpected Status: %s\nReturned Status: %s", domain.StatusDone, stored.Status)
+			}
+			// Allow a small delay between the test setup and the completion
+			if diff := stored.Time.Sub(test.expectedTime); diff < 0 || diff > time.Second {
+				t.Fatalf("\nExpected Time: %v\nReturned Time: %v", test.expectedTime, stored.Time)
+			}
+		})
+	}
+}
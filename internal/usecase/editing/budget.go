@@ -0,0 +1,22 @@
+package editing
+
+import (
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// EditBudget calls repo to update given budget
+func (srv Service) EditBudget(b domain.Budget) error {
+	// Check primitive fields are valid
+	if err := b.Validate(); err != nil {
+		return err
+	}
+
+	// Check Tag exists
+	if b.TagID > 0 {
+		if _, err := srv.repo.FindTagByID(b.TagID); err != nil {
+			return err
+		}
+	}
+
+	return srv.repo.EditBudget(b)
+}
@@ -0,0 +1,51 @@
+package editing_test
+
+import (
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+func TestEditBudget(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "groceries"},
+	}
+	repo.Budgets = map[domain.BudgetID]domain.Budget{
+		1: {ID: 1, Period: domain.PeriodMonth, Amount: 30000, Currency: "EUR"},
+	}
+	// Sub-tests Definitions
+	tests := map[string]struct {
+		budget      domain.Budget
+		expectedErr error
+	}{
+		"Correct": {
+			budget:      domain.Budget{ID: 1, TagID: 1, Period: domain.PeriodWeek, Amount: 5000, Currency: "usd"},
+			expectedErr: nil,
+		},
+		"Non-Existing Budget": {
+			budget:      domain.Budget{ID: 2, Period: domain.PeriodWeek, Amount: 5000, Currency: "USD"},
+			expectedErr: store.ErrBudgetNotFound,
+		},
+		"Non-Existing Tag": {
+			budget:      domain.Budget{ID: 1, TagID: 2, Period: domain.PeriodWeek, Amount: 5000, Currency: "USD"},
+			expectedErr: store.ErrTagNotFound,
+		},
+		"Zero Amount": {
+			budget:      domain.Budget{ID: 1, Period: domain.PeriodWeek, Amount: 0, Currency: "USD"},
+			expectedErr: domain.ErrBudgetAmount,
+		},
+	}
+	// Sub-tests Execution
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := editor.EditBudget(test.budget); err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+		})
+	}
+	expected := domain.Budget{ID: 1, TagID: 1, Period: domain.PeriodWeek, Amount: 5000, Currency: "USD"}
+	if repo.Budgets[1] != expected {
+		t.Fatalf("\nExpected: %v\nReturned: %v", expected, repo.Budgets[1])
+	}
+}
@@ -1,13 +1,22 @@
 package editing
 
 import (
+	"time"
+
 	"github.com/elhamza90/lifelog/internal/domain"
 )
 
 // EditExpense calls repo to update given expense
-func (srv Service) EditExpense(exp domain.Expense) error {
+// and records it was updated by principal
+func (srv Service) EditExpense(exp domain.Expense, principal string) error {
 	// Check primitive fields are valid
-	if err := exp.Validate(); err != nil {
+	if err := exp.Validate(srv.policy); err != nil {
+		return err
+	}
+
+	// Check Expense exists
+	old, err := srv.repo.FindExpenseByID(exp.ID)
+	if err != nil {
 		return err
 	}
 
@@ -29,6 +38,27 @@ func (srv Service) EditExpense(exp domain.Expense) error {
 	}
 	exp.Tags = fetchedTags
 
-	return srv.repo.EditExpense(exp)
+	if err := srv.repo.EditExpense(exp); err != nil {
+		return err
+	}
+	return srv.auditor.Record(domain.AuditExpense, uint(exp.ID), domain.AuditUpdate, principal, old, exp)
+}
 
+// CompleteExpense marks the planned expense with given ID as done,
+// records it was updated by principal and returns it.
+// A planned expense in the future is moved to now.
+// It returns ErrStatusNotPlanned if the expense is not planned
+func (srv Service) CompleteExpense(id domain.ExpenseID, principal string) (domain.Expense, error) {
+	old, err := srv.repo.FindExpenseByID(id)
+	if err != nil {
+		return domain.Expense{}, err
+	}
+	exp := old
+	if err := exp.Complete(time.Now()); err != nil {
+		return domain.Expense{}, err
+	}
+	if err := srv.repo.EditExpense(exp); err != nil {
+		return domain.Expense{}, err
+	}
+	return exp, srv.auditor.Record(domain.AuditExpense, uint(id), domain.AuditUpdate, principal, old, exp)
 }
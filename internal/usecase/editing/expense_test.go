@@ -1,6 +1,7 @@
 package editing_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -18,7 +19,7 @@ func TestEditExpense(t *testing.T) {
 			Tags:       []domain.Tag{},
 			Time:       time.Now().AddDate(0, -1, 0),
 			Value:      10,
-			Unit:       "Eu",
+			Unit:       "EUR",
 		},
 	}
 	repo.Tags = map[domain.TagID]domain.Tag{
@@ -45,7 +46,7 @@ func TestEditExpense(t *testing.T) {
 				Tags:       []domain.Tag{{ID: 1}},
 				Time:       time.Now().AddDate(0, 0, -1),
 				Value:      100,
-				Unit:       "Dollar",
+				Unit:       "usd",
 			},
 			expectedErr: nil,
 		},
@@ -57,7 +58,7 @@ func TestEditExpense(t *testing.T) {
 				Tags:       []domain.Tag{{ID: 1}},
 				Time:       time.Now().AddDate(0, 0, -1),
 				Value:      100,
-				Unit:       "Dollar",
+				Unit:       "usd",
 			},
 			expectedErr: store.ErrActivityNotFound,
 		},
@@ -69,7 +70,7 @@ func TestEditExpense(t *testing.T) {
 				Tags:       []domain.Tag{{ID: 9898}},
 				Time:       time.Now().AddDate(0, 0, -1),
 				Value:      100,
-				Unit:       "Dollar",
+				Unit:       "usd",
 			},
 			expectedErr: store.ErrTagNotFound,
 		},
@@ -81,22 +82,65 @@ func TestEditExpense(t *testing.T) {
 				Tags:       []domain.Tag{{ID: 1}},
 				Time:       time.Now().AddDate(0, 0, 1),
 				Value:      100,
-				Unit:       "Dollar",
+				Unit:       "usd",
 			},
 			expectedErr: domain.ErrExpenseTimeFuture,
 		},
+		"Field Invalid (Unknown Currency)": {
+			exp: domain.Expense{
+				ID:         1,
+				Label:      "Edited Test Expense",
+				ActivityID: 1,
+				Tags:       []domain.Tag{{ID: 1}},
+				Time:       time.Now().AddDate(0, 0, -1),
+				Value:      100,
+				Unit:       "Dollar",
+			},
+			expectedErr: domain.ErrCurrencyInvalid,
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			err := editor.EditExpense(test.exp)
-			if err != test.expectedErr {
+			err := editor.EditExpense(test.exp, "admin")
+			if !errors.Is(err, test.expectedErr) {
 				t.Fatalf("Expected Err: %v\nReturned Err: %v", test.expectedErr, err)
 			}
 			edited := repo.Expenses[test.exp.ID]
-			if err == nil && (edited.Unit != strings.ToLower(test.exp.Unit) || edited.Label != test.exp.Label || edited.Value != test.exp.Value || edited.ActivityID != test.exp.ActivityID || len(edited.Tags) != len(test.exp.Tags)) {
+			if err == nil && (edited.Unit != domain.Currency(strings.ToUpper(string(test.exp.Unit))) || edited.Label != test.exp.Label || edited.Value != test.exp.Value || edited.ActivityID != test.exp.ActivityID || len(edited.Tags) != len(test.exp.Tags)) {
 				t.Fatalf("Expected: %v\nReturned: %v", test.exp, edited)
 			}
 		})
 	}
 }
+
+func TestCompleteExpense(t *testing.T) {
+	now := time.Now()
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Prepaid booking", Time: now.AddDate(0, 0, 7), Value: 100, Unit: "EUR", Status: domain.StatusPlanned},
+		2: {ID: 2, Label: "Paid expense", Time: now.AddDate(0, 0, -1), Value: 10, Unit: "EUR"},
+	}
+	tests := map[string]struct {
+		id          domain.ExpenseID
+		expectedErr error
+	}{
+		"Planned In Future": {id: 1},
+		"Already Done":      {id: 2, expectedErr: domain.ErrStatusNotPlanned},
+		"Non Existing":      {id: 9898, expectedErr: store.ErrExpenseNotFound},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := editor.CompleteExpense(test.id, "admin")
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			stored := repo.Expenses[test.id]
+			if stored.Status != domain.StatusDone || stored.Time.After(time.Now()) {
+				t.Fatalf("\nExpected done expense in the past\nReturned: %+v", stored)
+			}
+		})
+	}
+}
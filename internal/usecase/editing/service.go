@@ -1,40 +1,54 @@
 package editing
 
 import (
-	"errors"
-
 	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/auditing"
 )
 
 // Service provides methods that delete entities
 type Service struct {
-	repo Repository
+	repo    Repository
+	policy  domain.Policy    // Limits of validation rules
+	auditor auditing.Service // Records edited tags, activities & expenses
+}
+
+// NewService returns a new service with provided repository and validation policy
+func NewService(r Repository, p domain.Policy) Service {
+	return Service{repo: r, policy: p, auditor: auditing.NewService(r)}
 }
 
-// NewService returns a new service with provided repository
-func NewService(r Repository) Service {
-	return Service{repo: r}
+// Policy returns the validation policy of the service
+func (srv Service) Policy() domain.Policy {
+	return srv.policy
 }
 
 // Repository is the interface that wraps the methods
 // that must be implemented by the repository in order
 // for editing service to perform its job
 //
-//	- EditTag, EditExpense, EditActivity are the main editing methods
+//	- EditTag, EditExpense, EditActivity, EditBudget are the main editing methods
 //
-//	- FindTagByID is used to check if tags exist when editing expenses/activities
+//	- FindTagByID is used to check if tags exist when editing expenses/activities/budgets
 //
 //	- FindTagByName is used to check for duplicate tags when editing tag
 //
 // 	- FindActivityByID is used to check if activity exists when editing expense
+//
+//	- FindExpenseByID is used to fetch the expense to edit or complete
+//
+//	- The auditing repository methods are used to record
+//	  edited tags, activities & expenses
 type Repository interface {
+	auditing.Repository
 	EditTag(domain.Tag) error
 	EditExpense(domain.Expense) error
 	EditActivity(domain.Activity) error
+	EditBudget(domain.Budget) error
 	FindTagByID(domain.TagID) (domain.Tag, error)
 	FindTagByName(string) (domain.Tag, error)
 	FindActivityByID(domain.ActivityID) (domain.Activity, error)
+	FindExpenseByID(domain.ExpenseID) (domain.Expense, error)
 }
 
 // ErrTagNameDuplicate is returned when trying to edit a tag with a name that already exists in store
-var ErrTagNameDuplicate error = errors.New("Tag name duplicate")
+var ErrTagNameDuplicate error = domain.ErrTagNameDuplicate
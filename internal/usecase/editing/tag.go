@@ -8,13 +8,15 @@ import (
 )
 
 // EditTag calls repo to edit the provided tag
-func (srv Service) EditTag(t domain.Tag) error {
+// and records it was updated by principal
+func (srv Service) EditTag(t domain.Tag, principal string) error {
 	// Check Tag valid
-	if err := t.Validate(); err != nil {
+	if err := t.Validate(srv.policy); err != nil {
 		return err
 	}
 	// Check Tag exists
-	if _, err := srv.repo.FindTagByID(t.ID); err != nil {
+	old, err := srv.repo.FindTagByID(t.ID)
+	if err != nil {
 		return err
 	}
 	// Check tag name is not duplicate
@@ -23,5 +25,8 @@ func (srv Service) EditTag(t domain.Tag) error {
 	} else if len(t.Name) > 0 {
 		return domain.ErrTagNameDuplicate
 	}
-	return srv.repo.EditTag(t)
+	if err := srv.repo.EditTag(t); err != nil {
+		return err
+	}
+	return srv.auditor.Record(domain.AuditTag, uint(t.ID), domain.AuditUpdate, principal, old, t)
 }
@@ -1,6 +1,7 @@
 package editing_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -13,6 +14,7 @@ func TestEditTag(t *testing.T) {
 		1: {ID: 1, Name: "tag-1"},
 		2: {ID: 2, Name: "duplicate"},
 	}
+	defer func() { repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{} }()
 
 	tests := map[string]struct {
 		tag         domain.Tag
@@ -35,8 +37,9 @@ func TestEditTag(t *testing.T) {
 	// Test Subcase: Non-existing Tag
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			err := editor.EditTag(test.tag)
-			if err != test.expectedErr {
+			before, _ := repo.FindAuditEvents(domain.AuditTag, uint(test.tag.ID))
+			err := editor.EditTag(test.tag, "admin")
+			if !errors.Is(err, test.expectedErr) {
 				t.Fatalf("Expected Err: %v\nReturned Err: %v", test.expectedErr, err)
 			}
 			expectedName := strings.ToLower(test.tag.Name)
@@ -44,6 +47,14 @@ func TestEditTag(t *testing.T) {
 			if err == nil && edited.Name != expectedName {
 				t.Fatalf("Expected name: %s\nReturned name: %s", expectedName, edited.Name)
 			}
+			// Check only updates are recorded
+			events, _ := repo.FindAuditEvents(domain.AuditTag, uint(test.tag.ID))
+			if err != nil && len(events) != len(before) {
+				t.Fatalf("\nExpected nothing recorded\nReturned: %v", events)
+			}
+			if err == nil && (len(events) != len(before)+1 || events[len(events)-1].Action != domain.AuditUpdate || events[len(events)-1].Principal != "admin") {
+				t.Fatalf("\nExpected update by admin to be recorded\nReturned: %v", events)
+			}
 		})
 	}
 
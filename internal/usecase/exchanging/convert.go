@@ -0,0 +1,86 @@
+package exchanging
+
+import (
+	"math"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// Rate returns the rate of a currency in another one as of the given time.
+// It uses the most recent stored rate between the two currencies
+// ( in one direction or the other ). When there is none, the rate
+// is computed through the euro, the base currency of ECB rates.
+// It returns ErrRateNotFound when no rate can be found.
+func (srv Service) Rate(from domain.Currency, to domain.Currency, t time.Time) (float64, error) {
+	if err := from.Validate(); err != nil {
+		return 0, err
+	}
+	if err := to.Validate(); err != nil {
+		return 0, err
+	}
+	if from == to {
+		return 1, nil
+	}
+	rate, err := srv.pairRate(from, to, t)
+	if err != store.ErrRateNotFound || from == pivotCurrency || to == pivotCurrency {
+		return rate, err
+	}
+	// Convert through pivot currency
+	fromRate, err := srv.pairRate(from, pivotCurrency, t)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := srv.pairRate(pivotCurrency, to, t)
+	if err != nil {
+		return 0, err
+	}
+	return fromRate * toRate, nil
+}
+
+// pairRate returns the rate of a currency in another one using the rate
+// stored for the two currencies or the inverse of the opposite one.
+func (srv Service) pairRate(from domain.Currency, to domain.Currency, t time.Time) (float64, error) {
+	rate, err := srv.repo.FindExchangeRate(from, to, t)
+	if err == nil {
+		return rate.Rate, nil
+	}
+	if err != store.ErrRateNotFound {
+		return 0, err
+	}
+	rate, err = srv.repo.FindExchangeRate(to, from, t)
+	if err != nil {
+		return 0, err
+	}
+	return 1 / rate.Rate, nil
+}
+
+// Convert converts an amount in a currency to another one
+// using the rate as of the given time.
+// The amount is expressed in the minor unit of each currency
+// and the result is rounded to the nearest minor unit.
+func (srv Service) Convert(val domain.Amount, from domain.Currency, to domain.Currency, t time.Time) (domain.Amount, error) {
+	rate, err := srv.Rate(from, to, t)
+	if err != nil {
+		return 0, err
+	}
+	scale := math.Pow10(to.MinorUnits() - from.MinorUnits())
+	return domain.Amount(math.Round(float64(val) * rate * scale)), nil
+}
+
+// ConvertExpenses returns the given expenses with their values
+// converted to the given currency as of the time of each expense.
+func (srv Service) ConvertExpenses(expenses []domain.Expense, to domain.Currency) ([]domain.Expense, error) {
+	res := make([]domain.Expense, len(expenses))
+	for i, exp := range expenses {
+		val, err := srv.Convert(exp.Value, exp.Unit, to, exp.Time)
+		if err != nil {
+			return []domain.Expense{}, err
+		}
+		exp.Value = val
+		exp.Unit = to
+		res[i] = exp
+	}
+	return res, nil
+}
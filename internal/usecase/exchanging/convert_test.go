@@ -0,0 +1,80 @@
+package exchanging_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// initRates initializes the repo with test exchange rates
+func initRates() {
+	day1 := time.Date(2020, 11, 19, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2020, 11, 20, 0, 0, 0, 0, time.UTC)
+	repo.Rates = map[string]domain.ExchangeRate{}
+	repo.SaveExchangeRates([]domain.ExchangeRate{
+		{Base: "EUR", Quote: "USD", Date: day1, Rate: 1.2},
+		{Base: "EUR", Quote: "USD", Date: day2, Rate: 1.25},
+		{Base: "EUR", Quote: "JPY", Date: day1, Rate: 125},
+		{Base: "USD", Quote: "MAD", Date: day1, Rate: 9},
+	})
+}
+
+func TestConvert(t *testing.T) {
+	initRates()
+	day1 := time.Date(2020, 11, 19, 15, 0, 0, 0, time.UTC)
+	day2 := time.Date(2020, 11, 20, 15, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		val         domain.Amount
+		from        domain.Currency
+		to          domain.Currency
+		time        time.Time
+		expected    domain.Amount
+		expectedErr error
+	}{
+		"Same Currency":       {val: 1000, from: "EUR", to: "EUR", time: day1, expected: 1000},
+		"Direct Rate":         {val: 1000, from: "EUR", to: "USD", time: day1, expected: 1200},
+		"Rate As Of Time":     {val: 1000, from: "EUR", to: "USD", time: day2, expected: 1250},
+		"Inverse Rate":        {val: 1200, from: "USD", to: "EUR", time: day1, expected: 1000},
+		"Through Euro":        {val: 1200, from: "USD", to: "JPY", time: day1, expected: 1250},
+		"Rounded Minor Units": {val: 1000, from: "USD", to: "EUR", time: day1, expected: 833},
+		"Rate Too Recent":     {val: 10, from: "EUR", to: "USD", time: day1.AddDate(0, 0, -1), expectedErr: store.ErrRateNotFound},
+		"No Rate":             {val: 10, from: "MAD", to: "JPY", time: day1, expectedErr: store.ErrRateNotFound},
+		"Invalid Currency":    {val: 10, from: "eu", to: "USD", time: day1, expectedErr: domain.ErrCurrencyInvalid},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := exchanger.Convert(test.val, test.from, test.to, test.time)
+			if err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if res != test.expected {
+				t.Fatalf("\nExpected: %v\nReturned: %v", test.expected, res)
+			}
+		})
+	}
+}
+
+func TestConvertExpenses(t *testing.T) {
+	initRates()
+	expenses := []domain.Expense{
+		{ID: 1, Label: "Exp 1", Value: 1000, Unit: "EUR", Time: time.Date(2020, 11, 19, 15, 0, 0, 0, time.UTC)},
+		{ID: 2, Label: "Exp 2", Value: 1000, Unit: "EUR", Time: time.Date(2020, 11, 20, 15, 0, 0, 0, time.UTC)},
+		{ID: 3, Label: "Exp 3", Value: 9000, Unit: "MAD", Time: time.Date(2020, 11, 20, 15, 0, 0, 0, time.UTC)},
+	}
+	res, err := exchanger.ConvertExpenses(expenses, "USD")
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	expected := []domain.Amount{1200, 1250, 1000}
+	for i, exp := range res {
+		if exp.Unit != "USD" || exp.Value != expected[i] {
+			t.Fatalf("\nExpected: %v USD\nReturned: %v", expected[i], exp)
+		}
+	}
+	// Subcase: given expenses are not modified
+	if expenses[0].Unit != "EUR" || expenses[0].Value != 1000 {
+		t.Fatalf("\nGiven expense was modified: %v", expenses[0])
+	}
+}
@@ -0,0 +1,180 @@
+package exchanging
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// rateDateFormat specifies the format of dates in exchange rates files
+const rateDateFormat string = "2006-01-02"
+
+// ImportRates reads exchange rates from the given file and stores them.
+// The format of the file is detected from its content:
+//	- ECB XML reference rates ( eurofxref-daily.xml, eurofxref-hist.xml )
+//	- ECB CSV reference rates ( eurofxref.csv, eurofxref-hist.csv )
+//	- CSV with columns date,base,quote,rate ( header is optional )
+// Rates of currencies that are not ISO-4217 codes are skipped in ECB files.
+// It returns the number of imported rates.
+func (srv Service) ImportRates(r io.Reader) (int, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	var rates []domain.ExchangeRate
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("<")) {
+		rates, err = parseECBXML(content)
+	} else {
+		rates, err = parseCSV(content)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(rates) == 0 {
+		return 0, ErrRatesEmpty
+	}
+	for i := range rates {
+		if err := rates[i].Validate(); err != nil {
+			return 0, err
+		}
+	}
+	return srv.repo.SaveExchangeRates(rates)
+}
+
+// ecbEnvelope is used to unmarshal ECB XML reference rates
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// parseECBXML parses ECB XML reference rates.
+// ECB rates are rates of the euro.
+func parseECBXML(content []byte) ([]domain.ExchangeRate, error) {
+	var env ecbEnvelope
+	if err := xml.Unmarshal(content, &env); err != nil {
+		return nil, ErrRatesFormat
+	}
+	rates := []domain.ExchangeRate{}
+	for _, day := range env.Days {
+		for _, r := range day.Rates {
+			rate, ok, err := parseECBRate(day.Time, r.Currency, r.Rate)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				rates = append(rates, rate)
+			}
+		}
+	}
+	return rates, nil
+}
+
+// parseECBRate parses a rate of the euro in a currency on a date.
+// ok is false when the rate must be skipped: currency is not an ISO-4217
+// code or the rate is not available ( "N/A" or empty in ECB CSV files ).
+func parseECBRate(dateStr string, curStr string, rateStr string) (rate domain.ExchangeRate, ok bool, err error) {
+	cur, err := domain.ParseCurrency(curStr)
+	if err != nil {
+		return rate, false, nil
+	}
+	rateStr = strings.TrimSpace(rateStr)
+	if rateStr == "" || rateStr == "N/A" {
+		return rate, false, nil
+	}
+	date, err := parseRateDate(dateStr)
+	if err != nil {
+		return rate, false, err
+	}
+	val, err := strconv.ParseFloat(rateStr, 64)
+	if err != nil {
+		return rate, false, ErrRatesFormat
+	}
+	return domain.ExchangeRate{Base: pivotCurrency, Quote: cur, Date: date, Rate: val}, true, nil
+}
+
+// parseRateDate parses a date in an exchange rates file.
+// ECB CSV daily files format dates as "02 January 2006".
+func parseRateDate(str string) (time.Time, error) {
+	str = strings.TrimSpace(str)
+	for _, frmt := range []string{rateDateFormat, "02 January 2006"} {
+		if date, err := time.Parse(frmt, str); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, ErrRatesFormat
+}
+
+// parseCSV parses exchange rates in CSV format.
+// A file with a header starting with "Date" followed by currency codes
+// is an ECB CSV file: each row holds the rates of the euro on a date.
+// Any other file must have columns date,base,quote,rate.
+func parseCSV(content []byte) ([]domain.ExchangeRate, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil || len(records) == 0 {
+		return nil, ErrRatesFormat
+	}
+	header := records[0]
+	if strings.EqualFold(strings.TrimSpace(header[0]), "date") && len(header) > 1 && !strings.EqualFold(strings.TrimSpace(header[1]), "base") {
+		return parseECBCSV(header, records[1:])
+	}
+	rates := []domain.ExchangeRate{}
+	for i, rec := range records {
+		if len(rec) != 4 {
+			return nil, ErrRatesFormat
+		}
+		// Skip header
+		if i == 0 && strings.EqualFold(strings.TrimSpace(rec[0]), "date") {
+			continue
+		}
+		date, err := parseRateDate(rec[0])
+		if err != nil {
+			return nil, err
+		}
+		val, err := strconv.ParseFloat(strings.TrimSpace(rec[3]), 64)
+		if err != nil {
+			return nil, ErrRatesFormat
+		}
+		rates = append(rates, domain.ExchangeRate{
+			Base:  domain.Currency(strings.ToUpper(strings.TrimSpace(rec[1]))),
+			Quote: domain.Currency(strings.ToUpper(strings.TrimSpace(rec[2]))),
+			Date:  date,
+			Rate:  val,
+		})
+	}
+	return rates, nil
+}
+
+// parseECBCSV parses the rows of an ECB CSV file with given header
+func parseECBCSV(header []string, rows [][]string) ([]domain.ExchangeRate, error) {
+	rates := []domain.ExchangeRate{}
+	for _, row := range rows {
+		if len(row) == 0 || strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		for i := 1; i < len(row) && i < len(header); i++ {
+			rate, ok, err := parseECBRate(row[0], header[i], row[i])
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				rates = append(rates, rate)
+			}
+		}
+	}
+	return rates, nil
+}
@@ -0,0 +1,75 @@
+package exchanging_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/exchanging"
+)
+
+const ecbDailyXML string = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time='2020-11-20'>
+			<Cube currency='USD' rate='1.1861'/>
+			<Cube currency='JPY' rate='123.21'/>
+		</Cube>
+		<Cube time='2020-11-19'>
+			<Cube currency='USD' rate='1.1833'/>
+			<Cube currency='XXX' rate='1.5'/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+const ecbHistCSV string = `Date,USD,JPY,CYP,
+2020-11-20,1.1861,123.21,N/A,
+2020-11-19,1.1833,122.91,N/A,
+`
+
+func TestImportRates(t *testing.T) {
+	tests := map[string]struct {
+		file          string
+		expectedCount int
+		expectedErr   error
+	}{
+		"ECB XML":                {file: ecbDailyXML, expectedCount: 3},
+		"ECB CSV":                {file: ecbHistCSV, expectedCount: 4},
+		"CSV With Header":        {file: "date,base,quote,rate\n2020-11-20,usd,MAD,9.05\n2020-11-19,USD,MAD,9.07\n", expectedCount: 2},
+		"CSV Without Header":     {file: "2020-11-20,GBP,EUR,1.12\n", expectedCount: 1},
+		"CSV Wrong Columns":      {file: "2020-11-20,GBP,EUR\n", expectedErr: exchanging.ErrRatesFormat},
+		"CSV Wrong Date":         {file: "20/11/2020,GBP,EUR,1.12\n", expectedErr: exchanging.ErrRatesFormat},
+		"CSV Wrong Rate":         {file: "2020-11-20,GBP,EUR,abc\n", expectedErr: exchanging.ErrRatesFormat},
+		"CSV Negative Rate":      {file: "2020-11-20,GBP,EUR,-1.12\n", expectedErr: domain.ErrExchangeRateValue},
+		"CSV Unknown Currency":   {file: "2020-11-20,GBP,EURO,1.12\n", expectedErr: domain.ErrCurrencyInvalid},
+		"Invalid XML":            {file: "<Envelope><Cube>", expectedErr: exchanging.ErrRatesFormat},
+		"Empty File":             {file: "", expectedErr: exchanging.ErrRatesFormat},
+		"Header Without Content": {file: "date,base,quote,rate\n", expectedErr: exchanging.ErrRatesEmpty},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo.Rates = map[string]domain.ExchangeRate{}
+			count, err := exchanger.ImportRates(strings.NewReader(test.file))
+			if err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if count != test.expectedCount || len(repo.Rates) != test.expectedCount {
+				t.Fatalf("\nExpected Count: %d\nReturned Count: %d ( %d stored )", test.expectedCount, count, len(repo.Rates))
+			}
+		})
+	}
+	// Subcase: Imported ECB rates are rates of the euro on UTC days
+	repo.Rates = map[string]domain.ExchangeRate{}
+	if _, err := exchanger.ImportRates(strings.NewReader(ecbDailyXML)); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	rate, err := repo.FindExchangeRate("EUR", "JPY", time.Date(2020, 11, 20, 0, 0, 0, 0, time.UTC))
+	if err != nil || rate.Rate != 123.21 {
+		t.Fatalf("\nExpected Rate: 123.21\nReturned Rate: %v ( error: %v )", rate, err)
+	}
+}
@@ -0,0 +1,50 @@
+package exchanging
+
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// Service provides methods that import exchange rates
+// and convert amounts between currencies
+type Service struct {
+	repo Repository
+}
+
+// NewService returns a new exchanging service with provided repository
+func NewService(r Repository) Service {
+	return Service{repo: r}
+}
+
+// Repository is the interface that wraps the methods
+// that must be implemented by the repository
+// in order for exchanging service to perform its job.
+//
+//	- SaveExchangeRates stores rates, replacing the ones
+//	  with same currencies and date.
+//
+//	- FindExchangeRate returns the most recent rate of a currency
+//	  in another one as of the given time.
+type Repository interface {
+	SaveExchangeRates([]domain.ExchangeRate) (int, error)
+	FindExchangeRate(domain.Currency, domain.Currency, time.Time) (domain.ExchangeRate, error)
+}
+
+// pivotCurrency is the currency used to convert between two currencies
+// that have no rate between them. It is the base currency of ECB rates.
+const pivotCurrency domain.Currency = "EUR"
+
+// Errors
+var (
+	ErrRatesFormat error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "rates_format",
+		Message: "Exchange rates must be a CSV ( date,base,quote,rate ) or an ECB XML/CSV file",
+	}
+	ErrRatesEmpty error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "rates_empty",
+		Message: "Exchange rates file does not contain any rate",
+	}
+)
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
 )
 
 // ActivitiesByTime returns activities with Time field greater than or equal to the given time.
@@ -25,6 +26,28 @@ func (srv Service) ActivitiesByTime(t time.Time) ([]domain.Activity, error) {
 	return res, nil
 }
 
+// ActivitiesByTimeRange returns activities with Time field between from and to ( inclusive ).
+// The returned activities are ordered from most recent to oldest
+// It returns ErrActivityTimeFuture when from is future
+// and ErrTimeRange when from is after to
+func (srv Service) ActivitiesByTimeRange(from time.Time, to time.Time) ([]domain.Activity, error) {
+	if from.After(time.Now()) {
+		return []domain.Activity{}, domain.ErrActivityTimeFuture
+	}
+	if from.After(to) {
+		return []domain.Activity{}, ErrTimeRange
+	}
+	res, err := srv.repo.FindActivitiesByTimeRange(from, to)
+	if err != nil {
+		return []domain.Activity{}, err
+	}
+	// Sort
+	sort.Slice(res, func(i, j int) bool {
+		return (res)[i].Time.After((res)[j].Time)
+	})
+	return res, nil
+}
+
 // ActivitiesByTag returns expenses that have the tag with given ID
 // in their Tags field
 // The returned expenses are ordered from most recent to oldest
@@ -48,6 +71,40 @@ func (srv Service) ActivitiesByTag(tid domain.TagID) ([]domain.Activity, error)
 	return res, nil
 }
 
+// ActivitiesByTimeRangePage returns the requested page of activities
+// with Time field between from and to ( inclusive ).
+// Future times are allowed unless only done activities are requested.
+// It returns ErrActivityTimeFuture when from is future and only done activities are requested,
+// ErrTimeRange when from is after to
+// and ErrPageLimit, ErrSortField or ErrStatusInvalid when the page request is invalid
+func (srv Service) ActivitiesByTimeRangePage(from time.Time, to time.Time, p store.PageRequest) ([]domain.Activity, store.Page, error) {
+	if p.Status == domain.StatusDone && from.After(time.Now()) {
+		return []domain.Activity{}, store.Page{}, domain.ErrActivityTimeFuture
+	}
+	if from.After(to) {
+		return []domain.Activity{}, store.Page{}, ErrTimeRange
+	}
+	if err := checkPage(&p, activitySortFields); err != nil {
+		return []domain.Activity{}, store.Page{}, err
+	}
+	return srv.repo.FindActivitiesByTimeRangePage(from, to, p)
+}
+
+// ActivitiesByTagPage returns the requested page of activities
+// that have the tag with given ID in their Tags field.
+// It returns an error if tag with given ID is not found
+// and ErrPageLimit, ErrSortField or ErrStatusInvalid when the page request is invalid
+func (srv Service) ActivitiesByTagPage(tid domain.TagID, p store.PageRequest) ([]domain.Activity, store.Page, error) {
+	if err := checkPage(&p, activitySortFields); err != nil {
+		return []domain.Activity{}, store.Page{}, err
+	}
+	// Check if Tag exists
+	if _, err := srv.repo.FindTagByID(tid); err != nil {
+		return []domain.Activity{}, store.Page{}, err
+	}
+	return srv.repo.FindActivitiesByTagPage(tid, p)
+}
+
 // Activity returns activity with given ID
 func (srv Service) Activity(id domain.ActivityID) (domain.Activity, error) {
 	return srv.repo.FindActivityByID(id)
@@ -0,0 +1,15 @@
+package listing
+
+import (
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// AllBudgets returns a list of all budgets stored in the repo
+func (srv Service) AllBudgets() ([]domain.Budget, error) {
+	return srv.repo.FindAllBudgets()
+}
+
+// Budget returns the budget with given ID
+func (srv Service) Budget(id domain.BudgetID) (domain.Budget, error) {
+	return srv.repo.FindBudgetByID(id)
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
 )
 
 // ExpensesByTime returns expenses with Time field greater than or equal to the given time.
@@ -27,6 +28,30 @@ func (srv Service) ExpensesByTime(t time.Time) ([]domain.Expense, error) {
 	return res, nil
 }
 
+// ExpensesByTimeRange returns expenses with Time field between from and to ( inclusive ).
+// The returned expenses are ordered from most recent to oldest
+// It returns ErrExpenseTimeFuture when from is future
+// and ErrTimeRange when from is after to
+func (srv Service) ExpensesByTimeRange(from time.Time, to time.Time) ([]domain.Expense, error) {
+	if from.After(time.Now()) {
+		return []domain.Expense{}, domain.ErrExpenseTimeFuture
+	}
+	if from.After(to) {
+		return []domain.Expense{}, ErrTimeRange
+	}
+	res, err := srv.repo.FindExpensesByTimeRange(from, to)
+	if err != nil {
+		return []domain.Expense{}, err
+	}
+	// Sort using Time field descendent
+	sort.Slice(res, func(i, j int) bool {
+		elemI := (res)[i]
+		elemJ := (res)[j]
+		return elemI.Time.After(elemJ.Time)
+	})
+	return res, nil
+}
+
 // ExpensesByTag returns expenses that have the tag with given ID
 // in their Tags field
 // The returned expenses are ordered from most recent to oldest
@@ -72,6 +97,40 @@ func (srv Service) ExpensesByActivity(aid domain.ActivityID) ([]domain.Expense,
 	return res, nil
 }
 
+// ExpensesByTimeRangePage returns the requested page of expenses
+// with Time field between from and to ( inclusive ).
+// Future times are allowed unless only done expenses are requested.
+// It returns ErrExpenseTimeFuture when from is future and only done expenses are requested,
+// ErrTimeRange when from is after to
+// and ErrPageLimit, ErrSortField or ErrStatusInvalid when the page request is invalid
+func (srv Service) ExpensesByTimeRangePage(from time.Time, to time.Time, p store.PageRequest) ([]domain.Expense, store.Page, error) {
+	if p.Status == domain.StatusDone && from.After(time.Now()) {
+		return []domain.Expense{}, store.Page{}, domain.ErrExpenseTimeFuture
+	}
+	if from.After(to) {
+		return []domain.Expense{}, store.Page{}, ErrTimeRange
+	}
+	if err := checkPage(&p, expenseSortFields); err != nil {
+		return []domain.Expense{}, store.Page{}, err
+	}
+	return srv.repo.FindExpensesByTimeRangePage(from, to, p)
+}
+
+// ExpensesByTagPage returns the requested page of expenses
+// that have the tag with given ID in their Tags field.
+// It returns an error if tag with given ID is not found
+// and ErrPageLimit, ErrSortField or ErrStatusInvalid when the page request is invalid
+func (srv Service) ExpensesByTagPage(tid domain.TagID, p store.PageRequest) ([]domain.Expense, store.Page, error) {
+	if err := checkPage(&p, expenseSortFields); err != nil {
+		return []domain.Expense{}, store.Page{}, err
+	}
+	// Check if Tag exists
+	if _, err := srv.repo.FindTagByID(tid); err != nil {
+		return []domain.Expense{}, store.Page{}, err
+	}
+	return srv.repo.FindExpensesByTagPage(tid, p)
+}
+
 // Expense returns expense with given ID
 func (srv Service) Expense(id domain.ExpenseID) (domain.Expense, error) {
 	return srv.repo.FindExpenseByID(id)
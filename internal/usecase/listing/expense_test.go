@@ -7,6 +7,7 @@ import (
 
 	"github.com/elhamza90/lifelog/internal/domain"
 	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/usecase/listing"
 )
 
 func TestExpensesByTime(t *testing.T) {
@@ -63,6 +64,64 @@ func TestExpensesByTime(t *testing.T) {
 	}
 }
 
+func TestExpensesByTimeRange(t *testing.T) {
+	now := time.Now()
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "exp a day ago", Time: now.AddDate(0, 0, -1), Value: 10, Unit: "Eu"},
+		2: {ID: 2, Label: "exp 1 month ago", Time: now.AddDate(0, -1, 0), Value: 10, Unit: "Eu"},
+		3: {ID: 3, Label: "exp 1 year ago", Time: now.AddDate(-1, 0, 0), Value: 10, Unit: "Eu"},
+		4: {ID: 4, Label: "exp 15 days ago", Time: now.AddDate(0, 0, -15), Value: 10, Unit: "Eu"},
+		5: {ID: 5, Label: "exp 2 months ago", Time: now.AddDate(0, -2, 0), Value: 10, Unit: "Eu"},
+	}
+
+	tests := map[string]struct {
+		from        time.Time
+		to          time.Time
+		expectedIDs []domain.ExpenseID // In descending order !
+		expectedErr error
+	}{
+		"3 months ago until 10 days ago": {
+			from:        now.AddDate(0, -3, 0),
+			to:          now.AddDate(0, 0, -10),
+			expectedIDs: []domain.ExpenseID{4, 2, 5},
+			expectedErr: nil,
+		},
+		"From after To": {
+			from:        now.AddDate(0, 0, -10),
+			to:          now.AddDate(0, -3, 0),
+			expectedIDs: []domain.ExpenseID{},
+			expectedErr: listing.ErrTimeRange,
+		},
+		"Future": {
+			from:        now.AddDate(1, 0, 0),
+			to:          now.AddDate(2, 0, 0),
+			expectedIDs: []domain.ExpenseID{},
+			expectedErr: domain.ErrExpenseTimeFuture,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := lister.ExpensesByTimeRange(test.from, test.to)
+			// Check returned error
+			if err != test.expectedErr {
+				t.Fatalf("Unexpected error: %v\nExpecting: %v", err, test.expectedErr)
+			}
+			errMsg := fmt.Sprintf("Expecting: %v\nGot: %v", test.expectedIDs, res)
+			// Check result length
+			if len(res) != len(test.expectedIDs) {
+				t.Fatalf(errMsg)
+			}
+			// Check content and order of result
+			for i, exp := range res {
+				if exp.ID != test.expectedIDs[i] {
+					t.Fatalf(errMsg)
+				}
+			}
+		})
+	}
+}
+
 func TestExpensesByTag(t *testing.T) {
 	now := time.Now()
 	repo.Tags = map[domain.TagID]domain.Tag{
@@ -0,0 +1,58 @@
+package listing
+
+import (
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// Page limits
+const (
+	DefaultPageLimit int = 50
+	MaxPageLimit     int = 500
+)
+
+// Errors
+var (
+	ErrPageLimit error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "page_limit",
+		Message: "Page limit must be between 1 and 500",
+		Field:   "limit",
+		Details: map[string]interface{}{"min": 1, "max": MaxPageLimit},
+	}
+	ErrSortField error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "sort_field",
+		Message: "List can not be sorted by given field",
+		Field:   "sort",
+	}
+)
+
+// Sort fields allowed for each entity
+var (
+	activitySortFields = []store.SortField{store.SortByTime, store.SortByLabel}
+	expenseSortFields  = []store.SortField{store.SortByTime, store.SortByLabel, store.SortByValue}
+	tagSortFields      = []store.SortField{store.SortByLabel}
+)
+
+// checkPage checks the page limit, the status filter and that the list can be sorted
+// by the requested sort field. A zero limit is replaced by DefaultPageLimit.
+func checkPage(p *store.PageRequest, allowed []store.SortField) error {
+	if p.Limit == 0 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit < 0 || p.Limit > MaxPageLimit {
+		return ErrPageLimit
+	}
+	if p.Status != "" {
+		if err := p.Status.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, f := range allowed {
+		if f == p.Sort {
+			return nil
+		}
+	}
+	return ErrSortField
+}
@@ -0,0 +1,162 @@
+package listing_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/usecase/listing"
+)
+
+func TestExpensesByTimeRangePage(t *testing.T) {
+	now := time.Now()
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Exp A", Time: now.AddDate(0, 0, -1), Value: 10, Unit: "eu"},
+		2: {ID: 2, Label: "Exp B", Time: now.AddDate(0, 0, -2), Value: 5, Unit: "eu"},
+		3: {ID: 3, Label: "Exp C", Time: now.AddDate(0, 0, -3), Value: 10, Unit: "eu"},
+		4: {ID: 4, Label: "Exp D", Time: now.AddDate(0, -1, 0), Value: 1, Unit: "eu"},
+	}
+	defer func() { repo.Expenses = map[domain.ExpenseID]domain.Expense{} }()
+	// Walk through pages of 1 expense sorted by value descendent
+	ids := []domain.ExpenseID{}
+	p := store.PageRequest{Limit: 1, Sort: store.SortByValue, Desc: true}
+	for i := 0; i < 5; i++ {
+		res, page, err := lister.ExpensesByTimeRangePage(now.AddDate(0, 0, -10), now, p)
+		if err != nil {
+			t.Fatalf("\nUnexpected Error: %v", err)
+		}
+		if page.Total != 3 {
+			t.Fatalf("\nExpected Total: %d\nReturned Total: %d", 3, page.Total)
+		}
+		for _, exp := range res {
+			ids = append(ids, exp.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		p.Cursor = page.NextCursor
+	}
+	expectedIDs := []domain.ExpenseID{3, 1, 2}
+	if fmt.Sprint(ids) != fmt.Sprint(expectedIDs) {
+		t.Fatalf("\nExpected: %v\nReturned: %v", expectedIDs, ids)
+	}
+}
+
+func TestActivitiesByStatusPage(t *testing.T) {
+	now := time.Now()
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Activity yesterday", Time: now.AddDate(0, 0, -1)},
+		2: {ID: 2, Label: "Activity tomorrow", Time: now.AddDate(0, 0, 1), Status: domain.StatusPlanned},
+		3: {ID: 3, Label: "Activity next week", Time: now.AddDate(0, 0, 7), Status: domain.StatusPlanned},
+		4: {ID: 4, Label: "Activity cancelled", Time: now.AddDate(0, 0, 2), Status: domain.StatusCancelled},
+	}
+	defer func() { repo.Activities = map[domain.ActivityID]domain.Activity{} }()
+	tests := map[string]struct {
+		from        time.Time
+		status      domain.Status
+		expectedIDs []domain.ActivityID // In order !
+	}{
+		"All":                 {from: now.AddDate(0, 0, -10), expectedIDs: []domain.ActivityID{1, 2, 4, 3}},
+		"Planned":             {from: now.AddDate(0, 0, -10), status: domain.StatusPlanned, expectedIDs: []domain.ActivityID{2, 3}},
+		"Done":                {from: now.AddDate(0, 0, -10), status: domain.StatusDone, expectedIDs: []domain.ActivityID{1}},
+		"Planned From Future": {from: now.AddDate(0, 0, 3), status: domain.StatusPlanned, expectedIDs: []domain.ActivityID{3}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := store.PageRequest{Sort: store.SortByTime, Status: test.status}
+			res, page, err := lister.ActivitiesByTimeRangePage(test.from, now.AddDate(0, 1, 0), p)
+			if err != nil {
+				t.Fatalf("\nUnexpected Error: %v", err)
+			}
+			ids := []domain.ActivityID{}
+			for _, act := range res {
+				ids = append(ids, act.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(test.expectedIDs) || page.Total != len(test.expectedIDs) {
+				t.Fatalf("\nExpected: %v\nReturned: %v ( total %d )", test.expectedIDs, ids, page.Total)
+			}
+		})
+	}
+}
+
+func TestPageRequestValidation(t *testing.T) {
+	now := time.Now()
+	from := now.AddDate(0, 0, -1)
+	tests := map[string]struct {
+		list        func() error
+		expectedErr error
+	}{
+		"Negative Limit": {
+			list: func() error {
+				_, _, err := lister.ActivitiesByTimeRangePage(from, now, store.PageRequest{Limit: -1, Sort: store.SortByTime})
+				return err
+			},
+			expectedErr: listing.ErrPageLimit,
+		},
+		"Limit Too High": {
+			list: func() error {
+				_, _, err := lister.ExpensesByTimeRangePage(from, now, store.PageRequest{Limit: listing.MaxPageLimit + 1, Sort: store.SortByTime})
+				return err
+			},
+			expectedErr: listing.ErrPageLimit,
+		},
+		"Default Limit": {
+			list: func() error {
+				_, _, err := lister.ExpensesByTimeRangePage(from, now, store.PageRequest{Sort: store.SortByTime})
+				return err
+			},
+			expectedErr: nil,
+		},
+		"Activities Sorted By Value": {
+			list: func() error {
+				_, _, err := lister.ActivitiesByTimeRangePage(from, now, store.PageRequest{Sort: store.SortByValue})
+				return err
+			},
+			expectedErr: listing.ErrSortField,
+		},
+		"Tags Sorted By Time": {
+			list: func() error {
+				_, _, err := lister.TagsPage(store.PageRequest{Sort: store.SortByTime})
+				return err
+			},
+			expectedErr: listing.ErrSortField,
+		},
+		"Invalid Status": {
+			list: func() error {
+				_, _, err := lister.ExpensesByTimeRangePage(from, now, store.PageRequest{Sort: store.SortByTime, Status: "unknown"})
+				return err
+			},
+			expectedErr: domain.ErrStatusInvalid,
+		},
+		"Future Done Activities": {
+			list: func() error {
+				_, _, err := lister.ActivitiesByTimeRangePage(now.AddDate(0, 0, 1), now.AddDate(0, 0, 2), store.PageRequest{Sort: store.SortByTime, Status: domain.StatusDone})
+				return err
+			},
+			expectedErr: domain.ErrActivityTimeFuture,
+		},
+		"Non-Existing Tag": {
+			list: func() error {
+				_, _, err := lister.ExpensesByTagPage(9898, store.PageRequest{Sort: store.SortByTime})
+				return err
+			},
+			expectedErr: store.ErrTagNotFound,
+		},
+		"Invalid Cursor": {
+			list: func() error {
+				_, _, err := lister.TagsPage(store.PageRequest{Sort: store.SortByLabel, Cursor: "%%%"})
+				return err
+			},
+			expectedErr: store.ErrCursorInvalid,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.list(); err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
 )
 
 // Service provides methods that list entities
@@ -23,8 +24,26 @@ type Repository interface {
 	FindExpenseByID(domain.ExpenseID) (domain.Expense, error)
 	FindAllTags() ([]domain.Tag, error)
 	FindExpensesByTime(time.Time) ([]domain.Expense, error)
+	FindExpensesByTimeRange(time.Time, time.Time) ([]domain.Expense, error)
 	FindExpensesByTag(domain.TagID) ([]domain.Expense, error)
 	FindExpensesByActivity(domain.ActivityID) ([]domain.Expense, error)
 	FindActivitiesByTag(domain.TagID) ([]domain.Activity, error)
 	FindActivitiesByTime(time.Time) ([]domain.Activity, error)
+	FindActivitiesByTimeRange(time.Time, time.Time) ([]domain.Activity, error)
+	FindTagsPage(store.PageRequest) ([]domain.Tag, store.Page, error)
+	FindExpensesByTimeRangePage(time.Time, time.Time, store.PageRequest) ([]domain.Expense, store.Page, error)
+	FindExpensesByTagPage(domain.TagID, store.PageRequest) ([]domain.Expense, store.Page, error)
+	FindActivitiesByTimeRangePage(time.Time, time.Time, store.PageRequest) ([]domain.Activity, store.Page, error)
+	FindActivitiesByTagPage(domain.TagID, store.PageRequest) ([]domain.Activity, store.Page, error)
+	FindBudgetByID(domain.BudgetID) (domain.Budget, error)
+	FindAllBudgets() ([]domain.Budget, error)
+	FindTemplateByID(domain.TemplateID) (domain.Template, error)
+	FindAllTemplates() ([]domain.Template, error)
+}
+
+// ErrTimeRange is returned when the start of a time range is after its end
+var ErrTimeRange error = &domain.Error{
+	Kind:    domain.KindInvalid,
+	Code:    "time_range",
+	Message: "Start of time range must be before its end",
 }
@@ -2,6 +2,7 @@ package listing
 
 import (
 	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
 )
 
 // AllTags returns a list of all tags stored in the repo
@@ -9,6 +10,16 @@ func (srv Service) AllTags() ([]domain.Tag, error) {
 	return srv.repo.FindAllTags()
 }
 
+// TagsPage returns the requested page of tags stored in the repo.
+// Tags can only be sorted by name ( label ).
+// It returns ErrPageLimit or ErrSortField when the page request is invalid
+func (srv Service) TagsPage(p store.PageRequest) ([]domain.Tag, store.Page, error) {
+	if err := checkPage(&p, tagSortFields); err != nil {
+		return []domain.Tag{}, store.Page{}, err
+	}
+	return srv.repo.FindTagsPage(p)
+}
+
 // GetTagByID returns a tag ith given ID
 func (srv Service) GetTagByID(id domain.TagID) (domain.Tag, error) {
 	return srv.repo.FindTagByID(id)
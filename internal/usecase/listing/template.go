@@ -0,0 +1,15 @@
+package listing
+
+import (
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// AllTemplates returns a list of all recurring templates stored in the repo
+func (srv Service) AllTemplates() ([]domain.Template, error) {
+	return srv.repo.FindAllTemplates()
+}
+
+// Template returns the recurring template with given ID
+func (srv Service) Template(id domain.TemplateID) (domain.Template, error) {
+	return srv.repo.FindTemplateByID(id)
+}
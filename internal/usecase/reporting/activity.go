@@ -0,0 +1,89 @@
+package reporting
+
+import (
+	"sort"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// ActivitiesByTag returns total & average durations of activities
+// between from and to grouped by tag name.
+// An activity with many tags is counted once in each of its tags.
+// The returned totals are ordered from longest to shortest total duration.
+func (srv Service) ActivitiesByTag(from time.Time, to time.Time) ([]domain.ActivityTotal, error) {
+	if err := checkRange(from, to); err != nil {
+		return []domain.ActivityTotal{}, err
+	}
+	res, err := srv.repo.SumActivitiesByTag(from, to)
+	if err != nil {
+		return []domain.ActivityTotal{}, err
+	}
+	sortActivityTotalsByDuration(res)
+	return res, nil
+}
+
+// ActivitiesByPlace returns total & average durations of activities
+// between from and to grouped by place.
+// The returned totals are ordered from longest to shortest total duration.
+func (srv Service) ActivitiesByPlace(from time.Time, to time.Time) ([]domain.ActivityTotal, error) {
+	if err := checkRange(from, to); err != nil {
+		return []domain.ActivityTotal{}, err
+	}
+	res, err := srv.repo.SumActivitiesByPlace(from, to)
+	if err != nil {
+		return []domain.ActivityTotal{}, err
+	}
+	sortActivityTotalsByDuration(res)
+	return res, nil
+}
+
+// ActivitiesByWeekday returns total & average durations of activities
+// between from and to grouped by the weekday they started on.
+// The returned totals are ordered by weekday starting from sunday.
+func (srv Service) ActivitiesByWeekday(from time.Time, to time.Time) ([]domain.ActivityTotal, error) {
+	if err := checkRange(from, to); err != nil {
+		return []domain.ActivityTotal{}, err
+	}
+	res, err := srv.repo.SumActivitiesByWeekday(from, to)
+	if err != nil {
+		return []domain.ActivityTotal{}, err
+	}
+	// Sort using weekday order
+	weekdays := map[string]time.Weekday{}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		weekdays[d.String()] = d
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return weekdays[res[i].Group] < weekdays[res[j].Group]
+	})
+	return res, nil
+}
+
+// ActivitiesByHour returns total & average durations of activities
+// between from and to grouped by the hour of day they started at.
+// The returned totals are ordered by hour.
+func (srv Service) ActivitiesByHour(from time.Time, to time.Time) ([]domain.ActivityTotal, error) {
+	if err := checkRange(from, to); err != nil {
+		return []domain.ActivityTotal{}, err
+	}
+	res, err := srv.repo.SumActivitiesByHour(from, to)
+	if err != nil {
+		return []domain.ActivityTotal{}, err
+	}
+	// Hours are formatted as 2 digits so they can be sorted as strings
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Group < res[j].Group
+	})
+	return res, nil
+}
+
+// sortActivityTotalsByDuration sorts totals using Total field descendent then Group
+func sortActivityTotalsByDuration(res []domain.ActivityTotal) {
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Total != res[j].Total {
+			return res[i].Total > res[j].Total
+		}
+		return res[i].Group < res[j].Group
+	})
+}
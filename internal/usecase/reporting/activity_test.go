@@ -0,0 +1,86 @@
+package reporting_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/reporting"
+)
+
+func TestActivitiesReports(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "tag-1"},
+		2: {ID: 2, Name: "tag-2"},
+	}
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		// Monday
+		1: {ID: 1, Label: "Activity 1", Place: "gym", Time: time.Date(2020, 10, 5, 10, 0, 0, 0, time.UTC), Duration: time.Hour, Tags: []domain.Tag{repo.Tags[1]}},
+		// Monday
+		2: {ID: 2, Label: "Activity 2", Place: "gym", Time: time.Date(2020, 10, 12, 10, 30, 0, 0, time.UTC), Duration: 2 * time.Hour, Tags: []domain.Tag{repo.Tags[1], repo.Tags[2]}},
+		// Sunday
+		3: {ID: 3, Label: "Activity 3", Place: "home", Time: time.Date(2020, 10, 18, 8, 0, 0, 0, time.UTC), Duration: 4 * time.Hour, Tags: []domain.Tag{repo.Tags[2]}},
+		// Out of range
+		4: {ID: 4, Label: "Activity 4", Place: "gym", Time: time.Date(2019, 10, 5, 10, 0, 0, 0, time.UTC), Duration: time.Hour, Tags: []domain.Tag{repo.Tags[1]}},
+	}
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		report      func(time.Time, time.Time) ([]domain.ActivityTotal, error)
+		from        time.Time
+		to          time.Time
+		expected    []domain.ActivityTotal // In order !
+		expectedErr error
+	}{
+		"By Tag": {
+			report: reporter.ActivitiesByTag, from: from, to: to,
+			expected: []domain.ActivityTotal{
+				{Group: "tag-2", Total: 6 * time.Hour, Average: 3 * time.Hour, Count: 2},
+				{Group: "tag-1", Total: 3 * time.Hour, Average: 90 * time.Minute, Count: 2},
+			},
+		},
+		"By Place": {
+			report: reporter.ActivitiesByPlace, from: from, to: to,
+			expected: []domain.ActivityTotal{
+				{Group: "home", Total: 4 * time.Hour, Average: 4 * time.Hour, Count: 1},
+				{Group: "gym", Total: 3 * time.Hour, Average: 90 * time.Minute, Count: 2},
+			},
+		},
+		"By Weekday": {
+			report: reporter.ActivitiesByWeekday, from: from, to: to,
+			expected: []domain.ActivityTotal{
+				{Group: "Sunday", Total: 4 * time.Hour, Average: 4 * time.Hour, Count: 1},
+				{Group: "Monday", Total: 3 * time.Hour, Average: 90 * time.Minute, Count: 2},
+			},
+		},
+		"By Hour": {
+			report: reporter.ActivitiesByHour, from: from, to: to,
+			expected: []domain.ActivityTotal{
+				{Group: "08", Total: 4 * time.Hour, Average: 4 * time.Hour, Count: 1},
+				{Group: "10", Total: 3 * time.Hour, Average: 90 * time.Minute, Count: 2},
+			},
+		},
+		"Invalid Range": {
+			report: reporter.ActivitiesByHour, from: to, to: from,
+			expected: []domain.ActivityTotal{}, expectedErr: reporting.ErrReportRange,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := test.report(test.from, test.to)
+			if err != test.expectedErr {
+				t.Fatalf("Expected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+			errMsg := fmt.Sprintf("Expected: %v\nReturned: %v", test.expected, res)
+			if len(res) != len(test.expected) {
+				t.Fatal(errMsg)
+			}
+			for i, total := range res {
+				if total != test.expected[i] {
+					t.Fatal(errMsg)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package reporting
+
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// BudgetStatus returns the status of the budget with given ID
+// in its period containing now.
+// The amount spent is the sum of the values of done expenses with the tag
+// of the budget ( or of all expenses if it has no tag ) since the start
+// of the period, converted to the budget currency as of the time of each expense.
+func (srv Service) BudgetStatus(id domain.BudgetID, now time.Time) (domain.BudgetStatus, error) {
+	b, err := srv.repo.FindBudgetByID(id)
+	if err != nil {
+		return domain.BudgetStatus{}, err
+	}
+	expenses, err := srv.repo.FindExpensesByTimeRange(b.Period.Start(now), now)
+	if err != nil {
+		return domain.BudgetStatus{}, err
+	}
+	budgetExpenses := []domain.Expense{}
+	for _, exp := range expenses {
+		if exp.Status.Done() && (b.TagID == 0 || hasTag(exp.Tags, b.TagID)) {
+			budgetExpenses = append(budgetExpenses, exp)
+		}
+	}
+	budgetExpenses, err = srv.exchanger.ConvertExpenses(budgetExpenses, b.Currency)
+	if err != nil {
+		return domain.BudgetStatus{}, err
+	}
+	var spent domain.Amount
+	for _, exp := range budgetExpenses {
+		spent += exp.Value
+	}
+	return b.Status(spent, now), nil
+}
@@ -0,0 +1,65 @@
+package reporting_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+func TestBudgetStatus(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "groceries"},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Exp 1", Time: time.Date(2020, 11, 2, 10, 0, 0, 0, time.UTC), Value: 6000, Unit: "EUR", Tags: []domain.Tag{repo.Tags[1]}},
+		2: {ID: 2, Label: "Exp 2", Time: time.Date(2020, 11, 5, 10, 0, 0, 0, time.UTC), Value: 10000, Unit: "MAD", Tags: []domain.Tag{repo.Tags[1]}},
+		3: {ID: 3, Label: "Exp 3", Time: time.Date(2020, 11, 6, 10, 0, 0, 0, time.UTC), Value: 5000, Unit: "EUR"},
+		4: {ID: 4, Label: "Exp 4", Time: time.Date(2020, 10, 30, 10, 0, 0, 0, time.UTC), Value: 9000, Unit: "EUR", Tags: []domain.Tag{repo.Tags[1]}},
+		// Not spent
+		5: {ID: 5, Label: "Exp 5", Time: time.Date(2020, 11, 3, 10, 0, 0, 0, time.UTC), Value: 3000, Unit: "EUR", Tags: []domain.Tag{repo.Tags[1]}, Status: domain.StatusCancelled},
+		6: {ID: 6, Label: "Exp 6", Time: time.Date(2020, 11, 10, 10, 0, 0, 0, time.UTC), Value: 4000, Unit: "EUR", Tags: []domain.Tag{repo.Tags[1]}, Status: domain.StatusPlanned},
+	}
+	repo.Rates = map[string]domain.ExchangeRate{}
+	repo.SaveExchangeRates([]domain.ExchangeRate{
+		{Base: "EUR", Quote: "MAD", Date: time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC), Rate: 10},
+	})
+	repo.Budgets = map[domain.BudgetID]domain.Budget{
+		1: {ID: 1, TagID: 1, Period: domain.PeriodMonth, Amount: 20000, Currency: "EUR"},
+		2: {ID: 2, Period: domain.PeriodMonth, Amount: 100000, Currency: "EUR"},
+		3: {ID: 3, Period: domain.PeriodMonth, Amount: 100000, Currency: "JPY"},
+	}
+	defer func() {
+		repo.Rates = map[string]domain.ExchangeRate{}
+		repo.Budgets = map[domain.BudgetID]domain.Budget{}
+	}()
+	// 10 days out of the 30 days of november have elapsed
+	now := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		id                domain.BudgetID
+		expectedSpent     domain.Amount
+		expectedProjected domain.Amount
+		expectedOverspend bool
+		expectedErr       error
+	}{
+		"Tag Budget":          {id: 1, expectedSpent: 7000, expectedProjected: 21000, expectedOverspend: true},
+		"Global Budget":       {id: 2, expectedSpent: 12000, expectedProjected: 36000},
+		"Missing Rate":        {id: 3, expectedErr: store.ErrRateNotFound},
+		"Non-Existing Budget": {id: 4, expectedErr: store.ErrBudgetNotFound},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			st, err := reporter.BudgetStatus(test.id, now)
+			if err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if st.Spent != test.expectedSpent || st.Projected != test.expectedProjected {
+				t.Fatalf("\nExpected Spent: %d, Projected: %d\nReturned: %d, %d", test.expectedSpent, test.expectedProjected, st.Spent, st.Projected)
+			}
+			if err == nil && (st.OverBudget() || st.ProjectedOverspend() != test.expectedOverspend) {
+				t.Fatalf("\nExpected Projected Overspend: %v\nReturned Status: %v", test.expectedOverspend, st)
+			}
+		})
+	}
+}
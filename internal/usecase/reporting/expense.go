@@ -0,0 +1,188 @@
+package reporting
+
+import (
+	"sort"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// ExpensesByPeriod returns sums of values of expenses between from and to
+// grouped by the given period and unit.
+// If tid is not zero, only expenses having the tag with given ID are summed.
+// The returned totals are ordered chronologically.
+// It does the following checks:
+//	- Check period is valid
+//	- Check time range is valid
+//	- Check Tag exists if provided
+func (srv Service) ExpensesByPeriod(p domain.Period, from time.Time, to time.Time, tid domain.TagID) ([]domain.ExpenseTotal, error) {
+	// Check period & range are valid
+	if err := p.Validate(); err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	if err := checkRange(from, to); err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	// Check Tag exists
+	if tid > 0 {
+		if _, err := srv.repo.FindTagByID(tid); err != nil {
+			return []domain.ExpenseTotal{}, err
+		}
+	}
+	res, err := srv.repo.SumExpensesByPeriod(p, from, to, tid)
+	if err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	sortExpenseTotals(res)
+	return res, nil
+}
+
+// ExpensesByTag returns sums of values of expenses between from and to
+// grouped by tag name and unit.
+// An expense with many tags is counted once in each of its tags.
+// The returned totals are ordered by tag name.
+func (srv Service) ExpensesByTag(from time.Time, to time.Time) ([]domain.ExpenseTotal, error) {
+	if err := checkRange(from, to); err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	res, err := srv.repo.SumExpensesByTag(from, to)
+	if err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	sortExpenseTotals(res)
+	return res, nil
+}
+
+// ExpensesByUnit returns sums of values of expenses between from and to
+// grouped by unit.
+// The returned totals are ordered by unit.
+func (srv Service) ExpensesByUnit(from time.Time, to time.Time) ([]domain.ExpenseTotal, error) {
+	if err := checkRange(from, to); err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	res, err := srv.repo.SumExpensesByUnit(from, to)
+	if err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	sortExpenseTotals(res)
+	return res, nil
+}
+
+// ExpensesByPeriodIn returns sums of values of expenses between from and to
+// grouped by the given period, converted to the given currency
+// as of the time of each expense.
+// If tid is not zero, only expenses having the tag with given ID are summed.
+// The returned totals are ordered chronologically.
+// It does the same checks as ExpensesByPeriod and checks the currency is valid.
+func (srv Service) ExpensesByPeriodIn(cur domain.Currency, p domain.Period, from time.Time, to time.Time, tid domain.TagID) ([]domain.ExpenseTotal, error) {
+	// Check period & range are valid
+	if err := p.Validate(); err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	if err := checkRange(from, to); err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	// Check Tag exists
+	if tid > 0 {
+		if _, err := srv.repo.FindTagByID(tid); err != nil {
+			return []domain.ExpenseTotal{}, err
+		}
+	}
+	return srv.sumConvertedExpenses(cur, from, to, func(exp domain.Expense) []string {
+		if tid > 0 && !hasTag(exp.Tags, tid) {
+			return []string{}
+		}
+		// Periods are computed in UTC as in repository sums
+		return []string{p.Key(exp.Time.UTC())}
+	})
+}
+
+// ExpensesByTagIn returns sums of values of expenses between from and to
+// grouped by tag name, converted to the given currency
+// as of the time of each expense.
+// An expense with many tags is counted once in each of its tags.
+// The returned totals are ordered by tag name.
+func (srv Service) ExpensesByTagIn(cur domain.Currency, from time.Time, to time.Time) ([]domain.ExpenseTotal, error) {
+	if err := checkRange(from, to); err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	return srv.sumConvertedExpenses(cur, from, to, func(exp domain.Expense) []string {
+		names := []string{}
+		for _, t := range exp.Tags {
+			names = append(names, t.Name)
+		}
+		return names
+	})
+}
+
+// ExpensesTotalIn returns the sum of values of all expenses between from and to
+// converted to the given currency as of the time of each expense.
+// The total is grouped under the currency code.
+func (srv Service) ExpensesTotalIn(cur domain.Currency, from time.Time, to time.Time) ([]domain.ExpenseTotal, error) {
+	if err := checkRange(from, to); err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	return srv.sumConvertedExpenses(cur, from, to, func(exp domain.Expense) []string {
+		return []string{string(cur)}
+	})
+}
+
+// sumConvertedExpenses converts values of done expenses between from and to
+// to the given currency then sums them using groupsOf to get the groups
+// each expense belongs to. Returned totals are sorted.
+func (srv Service) sumConvertedExpenses(cur domain.Currency, from time.Time, to time.Time, groupsOf func(domain.Expense) []string) ([]domain.ExpenseTotal, error) {
+	if err := cur.Validate(); err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	found, err := srv.repo.FindExpensesByTimeRange(from, to)
+	if err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	expenses := []domain.Expense{}
+	for _, exp := range found {
+		if exp.Status.Done() {
+			expenses = append(expenses, exp)
+		}
+	}
+	expenses, err = srv.exchanger.ConvertExpenses(expenses, cur)
+	if err != nil {
+		return []domain.ExpenseTotal{}, err
+	}
+	totals := map[string]domain.ExpenseTotal{}
+	for _, exp := range expenses {
+		for _, grp := range groupsOf(exp) {
+			total := totals[grp]
+			total.Group = grp
+			total.Unit = cur
+			total.Total += exp.Value
+			total.Count++
+			totals[grp] = total
+		}
+	}
+	res := []domain.ExpenseTotal{}
+	for _, total := range totals {
+		res = append(res, total)
+	}
+	sortExpenseTotals(res)
+	return res, nil
+}
+
+// hasTag checks if a tag with given ID is in tags
+func hasTag(tags []domain.Tag, tid domain.TagID) bool {
+	for _, t := range tags {
+		if t.ID == tid {
+			return true
+		}
+	}
+	return false
+}
+
+// sortExpenseTotals sorts totals using Group then Unit fields ascendent
+func sortExpenseTotals(res []domain.ExpenseTotal) {
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Group != res[j].Group {
+			return res[i].Group < res[j].Group
+		}
+		return res[i].Unit < res[j].Unit
+	})
+}
@@ -0,0 +1,223 @@
+package reporting_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/usecase/reporting"
+)
+
+// initReportExpenses initializes the repo with test tags & expenses
+func initReportExpenses() {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "tag-1"},
+		2: {ID: 2, Name: "tag-2"},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Exp 1", Time: time.Date(2020, 10, 5, 10, 0, 0, 0, time.UTC), Value: 10, Unit: "eu", Tags: []domain.Tag{repo.Tags[1]}},
+		2: {ID: 2, Label: "Exp 2", Time: time.Date(2020, 10, 6, 10, 0, 0, 0, time.UTC), Value: 5, Unit: "eu", Tags: []domain.Tag{repo.Tags[1], repo.Tags[2]}},
+		3: {ID: 3, Label: "Exp 3", Time: time.Date(2020, 10, 20, 10, 0, 0, 0, time.UTC), Value: 20, Unit: "dh", Tags: []domain.Tag{repo.Tags[2]}},
+		4: {ID: 4, Label: "Exp 4", Time: time.Date(2020, 11, 2, 10, 0, 0, 0, time.UTC), Value: 7, Unit: "eu"},
+		5: {ID: 5, Label: "Exp 5", Time: time.Date(2019, 11, 2, 10, 0, 0, 0, time.UTC), Value: 100, Unit: "eu", Tags: []domain.Tag{repo.Tags[1]}},
+		// Not summed
+		6: {ID: 6, Label: "Exp 6", Time: time.Date(2020, 10, 5, 12, 0, 0, 0, time.UTC), Value: 50, Unit: "eu", Tags: []domain.Tag{repo.Tags[1]}, Status: domain.StatusCancelled},
+		7: {ID: 7, Label: "Exp 7", Time: time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC), Value: 80, Unit: "dh", Tags: []domain.Tag{repo.Tags[2]}, Status: domain.StatusPlanned},
+	}
+}
+
+func TestExpensesByPeriod(t *testing.T) {
+	initReportExpenses()
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		period      domain.Period
+		from        time.Time
+		to          time.Time
+		tid         domain.TagID
+		expected    []domain.ExpenseTotal // In order !
+		expectedErr error
+	}{
+		"Month": {
+			period: domain.PeriodMonth, from: from, to: to,
+			expected: []domain.ExpenseTotal{
+				{Group: "2020-10-01", Unit: "dh", Total: 20, Count: 1},
+				{Group: "2020-10-01", Unit: "eu", Total: 15, Count: 2},
+				{Group: "2020-11-01", Unit: "eu", Total: 7, Count: 1},
+			},
+		},
+		"Day with Tag": {
+			period: domain.PeriodDay, from: from, to: to, tid: 2,
+			expected: []domain.ExpenseTotal{
+				{Group: "2020-10-06", Unit: "eu", Total: 5, Count: 1},
+				{Group: "2020-10-20", Unit: "dh", Total: 20, Count: 1},
+			},
+		},
+		"Invalid Period": {
+			period: domain.Period("decade"), from: from, to: to,
+			expected: []domain.ExpenseTotal{}, expectedErr: domain.ErrPeriodInvalid,
+		},
+		"Invalid Range": {
+			period: domain.PeriodMonth, from: to, to: from,
+			expected: []domain.ExpenseTotal{}, expectedErr: reporting.ErrReportRange,
+		},
+		"Non-Existing Tag": {
+			period: domain.PeriodMonth, from: from, to: to, tid: 988998,
+			expected: []domain.ExpenseTotal{}, expectedErr: store.ErrTagNotFound,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := reporter.ExpensesByPeriod(test.period, test.from, test.to, test.tid)
+			if err != test.expectedErr {
+				t.Fatalf("Expected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+			errMsg := fmt.Sprintf("Expected: %v\nReturned: %v", test.expected, res)
+			if len(res) != len(test.expected) {
+				t.Fatal(errMsg)
+			}
+			for i, total := range res {
+				if total != test.expected[i] {
+					t.Fatal(errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestExpensesByTag(t *testing.T) {
+	initReportExpenses()
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+	res, err := reporter.ExpensesByTag(from, to)
+	if err != nil {
+		t.Fatalf("Unexpected Error: %v", err)
+	}
+	expected := []domain.ExpenseTotal{
+		{Group: "tag-1", Unit: "eu", Total: 15, Count: 2},
+		{Group: "tag-2", Unit: "dh", Total: 20, Count: 1},
+		{Group: "tag-2", Unit: "eu", Total: 5, Count: 1},
+	}
+	errMsg := fmt.Sprintf("Expected: %v\nReturned: %v", expected, res)
+	if len(res) != len(expected) {
+		t.Fatal(errMsg)
+	}
+	for i, total := range res {
+		if total != expected[i] {
+			t.Fatal(errMsg)
+		}
+	}
+}
+
+func TestExpensesByUnit(t *testing.T) {
+	initReportExpenses()
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+	// Subcase: Invalid Range
+	t.Run("Invalid Range", func(t *testing.T) {
+		if _, err := reporter.ExpensesByUnit(to, from); err != reporting.ErrReportRange {
+			t.Fatalf("Expected Err: %v\nReturned Err: %v", reporting.ErrReportRange, err)
+		}
+	})
+	// Subcase: Valid Range
+	t.Run("Valid Range", func(t *testing.T) {
+		res, err := reporter.ExpensesByUnit(from, to)
+		if err != nil {
+			t.Fatalf("Unexpected Error: %v", err)
+		}
+		expected := []domain.ExpenseTotal{
+			{Group: "dh", Unit: "dh", Total: 20, Count: 1},
+			{Group: "eu", Unit: "eu", Total: 22, Count: 3},
+		}
+		errMsg := fmt.Sprintf("Expected: %v\nReturned: %v", expected, res)
+		if len(res) != len(expected) {
+			t.Fatal(errMsg)
+		}
+		for i, total := range res {
+			if total != expected[i] {
+				t.Fatal(errMsg)
+			}
+		}
+	})
+}
+
+func TestExpensesInCurrency(t *testing.T) {
+	initReportExpenses()
+	// Replace legacy units with currencies & init rates
+	for id, exp := range repo.Expenses {
+		exp.Unit = map[domain.Currency]domain.Currency{"eu": "EUR", "dh": "MAD"}[exp.Unit]
+		repo.Expenses[id] = exp
+	}
+	repo.Rates = map[string]domain.ExchangeRate{}
+	repo.SaveExchangeRates([]domain.ExchangeRate{
+		{Base: "EUR", Quote: "MAD", Date: time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC), Rate: 10},
+		{Base: "EUR", Quote: "MAD", Date: time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC), Rate: 11},
+	})
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		report      func() ([]domain.ExpenseTotal, error)
+		expected    []domain.ExpenseTotal // In order !
+		expectedErr error
+	}{
+		"By Month": {
+			report: func() ([]domain.ExpenseTotal, error) {
+				return reporter.ExpensesByPeriodIn("MAD", domain.PeriodMonth, from, to, 0)
+			},
+			expected: []domain.ExpenseTotal{
+				{Group: "2020-10-01", Unit: "MAD", Total: 170, Count: 3},
+				{Group: "2020-11-01", Unit: "MAD", Total: 77, Count: 1},
+			},
+		},
+		"By Month With Tag": {
+			report: func() ([]domain.ExpenseTotal, error) {
+				return reporter.ExpensesByPeriodIn("EUR", domain.PeriodMonth, from, to, 2)
+			},
+			expected: []domain.ExpenseTotal{
+				{Group: "2020-10-01", Unit: "EUR", Total: 7, Count: 2},
+			},
+		},
+		"By Tag": {
+			report: func() ([]domain.ExpenseTotal, error) {
+				return reporter.ExpensesByTagIn("EUR", from, to)
+			},
+			expected: []domain.ExpenseTotal{
+				{Group: "tag-1", Unit: "EUR", Total: 15, Count: 2},
+				{Group: "tag-2", Unit: "EUR", Total: 7, Count: 2},
+			},
+		},
+		"Total": {
+			report: func() ([]domain.ExpenseTotal, error) {
+				return reporter.ExpensesTotalIn("EUR", from, to)
+			},
+			expected: []domain.ExpenseTotal{
+				{Group: "EUR", Unit: "EUR", Total: 24, Count: 4},
+			},
+		},
+		"Missing Rate": {
+			report: func() ([]domain.ExpenseTotal, error) {
+				return reporter.ExpensesTotalIn("USD", from, to)
+			},
+			expected: []domain.ExpenseTotal{}, expectedErr: store.ErrRateNotFound,
+		},
+		"Invalid Currency": {
+			report: func() ([]domain.ExpenseTotal, error) {
+				return reporter.ExpensesTotalIn("euro", from, to)
+			},
+			expected: []domain.ExpenseTotal{}, expectedErr: domain.ErrCurrencyInvalid,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := test.report()
+			if err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if fmt.Sprint(res) != fmt.Sprint(test.expected) {
+				t.Fatalf("\nExpected: %v\nReturned: %v", test.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package reporting_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/store/memory"
+	"github.com/elhamza90/lifelog/internal/usecase/reporting"
+)
+
+var reporter reporting.Service // Instance of service we will be testing
+var repo memory.Repository     // Repository used by service
+
+func TestMain(m *testing.M) {
+	repo = memory.NewRepository()          // Work with In-Memory DB
+	reporter = reporting.NewService(&repo) // Passing by reference to change db when testing
+	os.Exit(m.Run())
+}
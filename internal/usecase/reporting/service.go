@@ -0,0 +1,67 @@
+package reporting
+
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/exchanging"
+)
+
+// Service provides methods that aggregate entities into reports
+type Service struct {
+	repo      Repository
+	exchanger exchanging.Service // Converts expenses in reports with a currency
+}
+
+// NewService returns a new reporting service with provided repository
+func NewService(r Repository) Service {
+	return Service{repo: r, exchanger: exchanging.NewService(r)}
+}
+
+// Repository is the interface that wraps the methods
+// that must be implemented by the repository
+// in order for reporting service to perform its job.
+//
+//	- SumExpensesByPeriod, SumExpensesByTag and SumExpensesByUnit
+//	  return sums of values of done expenses in a time range ( inclusive )
+//	  grouped by the given criteria and by unit.
+//
+//	- SumActivitiesByTag, SumActivitiesByPlace, SumActivitiesByWeekday
+//	  and SumActivitiesByHour return total durations of done activities
+//	  in a time range ( inclusive ) grouped by the given criteria.
+//
+//	- FindTagByID is used to check that a tag exists
+//	  when filtering a report by tag.
+//
+//	- FindExpensesByTimeRange and the exchanging repository methods
+//	  are used to convert expenses when reporting in a currency.
+//
+//	- FindBudgetByID is used to get the budget to compute its status.
+type Repository interface {
+	exchanging.Repository
+	FindTagByID(domain.TagID) (domain.Tag, error)
+	FindExpensesByTimeRange(time.Time, time.Time) ([]domain.Expense, error)
+	FindBudgetByID(domain.BudgetID) (domain.Budget, error)
+	SumExpensesByPeriod(domain.Period, time.Time, time.Time, domain.TagID) ([]domain.ExpenseTotal, error)
+	SumExpensesByTag(time.Time, time.Time) ([]domain.ExpenseTotal, error)
+	SumExpensesByUnit(time.Time, time.Time) ([]domain.ExpenseTotal, error)
+	SumActivitiesByTag(time.Time, time.Time) ([]domain.ActivityTotal, error)
+	SumActivitiesByPlace(time.Time, time.Time) ([]domain.ActivityTotal, error)
+	SumActivitiesByWeekday(time.Time, time.Time) ([]domain.ActivityTotal, error)
+	SumActivitiesByHour(time.Time, time.Time) ([]domain.ActivityTotal, error)
+}
+
+// ErrReportRange is returned when the start of a report's range is after its end
+var ErrReportRange error = &domain.Error{
+	Kind:    domain.KindInvalid,
+	Code:    "report_range",
+	Message: "Report start date must be before its end date",
+}
+
+// checkRange checks the given time range is valid
+func checkRange(from time.Time, to time.Time) error {
+	if from.After(to) {
+		return ErrReportRange
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package scheduling
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// recordedOccurrences returns the set of times of the recorded occurrences of a template
+func (srv Service) recordedOccurrences(id domain.TemplateID) (map[int64]bool, error) {
+	occurrences, err := srv.repo.FindOccurrences(id)
+	if err != nil {
+		return map[int64]bool{}, err
+	}
+	res := make(map[int64]bool, len(occurrences))
+	for _, occ := range occurrences {
+		res[occ.Time.UnixNano()] = true
+	}
+	return res, nil
+}
+
+// Upcoming returns the next occurrences of the template with given ID
+// from the given time, skipping the ones that were already recorded.
+// If limit is 0, DefaultUpcomingLimit is used.
+func (srv Service) Upcoming(id domain.TemplateID, from time.Time, limit int) ([]time.Time, error) {
+	if limit == 0 {
+		limit = DefaultUpcomingLimit
+	}
+	if limit < 0 || limit > MaxUpcomingLimit {
+		return []time.Time{}, ErrUpcomingLimit
+	}
+	tmpl, err := srv.repo.FindTemplateByID(id)
+	if err != nil {
+		return []time.Time{}, err
+	}
+	recorded, err := srv.recordedOccurrences(id)
+	if err != nil {
+		return []time.Time{}, err
+	}
+	res := []time.Time{}
+	for _, t := range tmpl.Rule.Between(from, time.Unix(math.MaxInt32, 0), limit+len(recorded)) {
+		if len(res) < limit && !recorded[t.UnixNano()] {
+			res = append(res, t)
+		}
+	}
+	return res, nil
+}
+
+// Skip records the occurrence of the template with given ID at the given time
+// as skipped so it is never materialized.
+// It returns ErrOccurrenceInvalid if the time is not an occurrence of the template
+// and ErrOccurrenceExists if the occurrence was already materialized or skipped.
+func (srv Service) Skip(id domain.TemplateID, t time.Time) error {
+	tmpl, err := srv.repo.FindTemplateByID(id)
+	if err != nil {
+		return err
+	}
+	if !tmpl.Rule.Includes(t) {
+		return ErrOccurrenceInvalid
+	}
+	return srv.repo.SaveOccurrence(domain.Occurrence{TemplateID: id, Time: t, Skipped: true})
+}
+
+// Run materializes the occurrences of all templates that are due at the given time
+// and were not recorded yet. It returns the number of created expenses & activities,
+// which are recorded in their history as created by PrincipalScheduler.
+// Templates are all processed even if one fails and the first error is returned.
+func (srv Service) Run(now time.Time) (int, error) {
+	templates, err := srv.repo.FindAllTemplates()
+	if err != nil {
+		return 0, err
+	}
+	var (
+		created  int
+		firstErr error
+	)
+	for _, tmpl := range templates {
+		n, err := srv.materialize(tmpl, now)
+		created += n
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return created, firstErr
+}
+
+// materialize creates the expenses or activities of the due occurrences
+// of the given template which were not recorded yet. Each occurrence is
+// recorded with its expense or activity at once, so a failure or a concurrent
+// run never creates it twice. It returns the number of created entities.
+func (srv Service) materialize(tmpl domain.Template, now time.Time) (int, error) {
+	recorded, err := srv.recordedOccurrences(tmpl.ID)
+	if err != nil {
+		return 0, err
+	}
+	created := 0
+	for _, t := range tmpl.Rule.Between(tmpl.Rule.Start, now, math.MaxInt32) {
+		if recorded[t.UnixNano()] || tmpl.DueAt(t).After(now) {
+			continue
+		}
+		occ := domain.Occurrence{TemplateID: tmpl.ID, Time: t}
+		var (
+			entity   domain.AuditEntity
+			id       uint
+			snapshot interface{}
+		)
+		if tmpl.Kind == domain.TemplateActivity {
+			var act domain.Activity
+			if act, err = srv.adder.CheckActivity(tmpl.ActivityAt(t)); err == nil {
+				act.ID, err = srv.repo.SaveOccurrenceActivity(occ, act)
+			}
+			entity, id, snapshot = domain.AuditActivity, uint(act.ID), act
+		} else {
+			var exp domain.Expense
+			if exp, err = srv.adder.CheckExpense(tmpl.ExpenseAt(t)); err == nil {
+				exp.ID, err = srv.repo.SaveOccurrenceExpense(occ, exp)
+			}
+			entity, id, snapshot = domain.AuditExpense, uint(exp.ID), exp
+		}
+		if errors.Is(err, store.ErrOccurrenceExists) {
+			continue // Recorded by a concurrent run
+		}
+		if err != nil {
+			return created, err
+		}
+		created++
+		if err := srv.auditor.Record(entity, id, domain.AuditCreate, domain.PrincipalScheduler, nil, snapshot); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
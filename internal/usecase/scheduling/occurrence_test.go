@@ -0,0 +1,154 @@
+package scheduling_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/usecase/scheduling"
+)
+
+// initTemplates initializes the repo with a monthly rent template
+// and a weekly gym template both starting on 2020-01-06 ( a monday )
+func initTemplates() time.Time {
+	start := time.Date(2020, 1, 6, 18, 0, 0, 0, time.UTC)
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "home"},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	repo.Activities = map[domain.ActivityID]domain.Activity{}
+	repo.Occurrences = map[string]domain.Occurrence{}
+	repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	repo.Templates = map[domain.TemplateID]domain.Template{
+		1: {
+			ID:      1,
+			Kind:    domain.TemplateExpense,
+			Rule:    domain.RecurrenceRule{Freq: domain.FrequencyMonthly, Interval: 1, Start: start},
+			Expense: domain.Expense{Label: "Rent", Value: 80000, Unit: "EUR", Tags: []domain.Tag{repo.Tags[1]}},
+		},
+		2: {
+			ID:       2,
+			Kind:     domain.TemplateActivity,
+			Rule:     domain.RecurrenceRule{Freq: domain.FrequencyWeekly, Interval: 1, Start: start, Until: start.AddDate(0, 0, 14)},
+			Activity: domain.Activity{Label: "Gym session", Duration: time.Hour},
+		},
+	}
+	return start
+}
+
+func TestRun(t *testing.T) {
+	start := initTemplates()
+	// Second gym session is not over yet
+	now := start.AddDate(0, 0, 7).Add(30 * time.Minute)
+	created, err := scheduler.Run(now)
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if created != 2 || len(repo.Expenses) != 1 || len(repo.Activities) != 1 {
+		t.Fatalf("\nExpected 1 rent and 1 gym session\nReturned: %d created, %v, %v", created, repo.Expenses, repo.Activities)
+	}
+	for _, exp := range repo.Expenses {
+		if !exp.Time.Equal(start) || exp.Value != 80000 || len(exp.Tags) != 1 {
+			t.Fatalf("\nUnexpected Expense: %v", exp)
+		}
+	}
+	// Occurrences are recorded with the created entities
+	for _, occ := range repo.Occurrences {
+		if _, ok := repo.Expenses[occ.ExpenseID]; !ok && occ.TemplateID == 1 {
+			t.Fatalf("\nExpected occurrence of a created expense\nReturned: %v", occ)
+		}
+		if _, ok := repo.Activities[occ.ActivityID]; !ok && occ.TemplateID == 2 {
+			t.Fatalf("\nExpected occurrence of a created activity\nReturned: %v", occ)
+		}
+	}
+	// Created entities are recorded in their history
+	for id := range repo.Activities {
+		events, _ := repo.FindAuditEvents(domain.AuditActivity, uint(id))
+		if len(events) != 1 || events[0].Action != domain.AuditCreate || events[0].Principal != domain.PrincipalScheduler {
+			t.Fatalf("\nExpected creation of activity %d by the scheduler\nReturned: %v", id, events)
+		}
+	}
+	if len(repo.AuditEvents) != 2 {
+		t.Fatalf("\nExpected 2 recorded creations\nReturned: %v", repo.AuditEvents)
+	}
+	// Subcase: Running again is idempotent
+	if created, err := scheduler.Run(now); err != nil || created != 0 {
+		t.Fatalf("\nExpected nothing to be created\nReturned: %d ( error: %v )", created, err)
+	}
+	// Subcase: Skipped occurrences are not materialized
+	if err := scheduler.Skip(1, start.AddDate(0, 1, 0)); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	created, err = scheduler.Run(start.AddDate(0, 2, 0))
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	// 2 gym sessions ( until reached ) and the rent of the third month
+	if created != 3 || len(repo.Expenses) != 2 || len(repo.Activities) != 3 {
+		t.Fatalf("\nExpected 3 created\nReturned: %d created, %v, %v", created, repo.Expenses, repo.Activities)
+	}
+}
+
+func TestUpcoming(t *testing.T) {
+	start := initTemplates()
+	if err := scheduler.Skip(1, start.AddDate(0, 1, 0)); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	tests := map[string]struct {
+		id          domain.TemplateID
+		limit       int
+		expected    []time.Time
+		expectedErr error
+	}{
+		"Skipped Occurrence": {
+			id: 1, limit: 2,
+			expected: []time.Time{start, start.AddDate(0, 2, 0)},
+		},
+		"Until Reached": {
+			id: 2, limit: 0,
+			expected: []time.Time{start, start.AddDate(0, 0, 7), start.AddDate(0, 0, 14)},
+		},
+		"Limit Too Big":         {id: 1, limit: 1000, expectedErr: scheduling.ErrUpcomingLimit},
+		"Non-Existing Template": {id: 3, limit: 1, expectedErr: store.ErrTemplateNotFound},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := scheduler.Upcoming(test.id, start, test.limit)
+			if err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if len(res) != len(test.expected) {
+				t.Fatalf("\nExpected: %v\nReturned: %v", test.expected, res)
+			}
+			for i := range res {
+				if !res[i].Equal(test.expected[i]) {
+					t.Fatalf("\nExpected: %v\nReturned: %v", test.expected, res)
+				}
+			}
+		})
+	}
+}
+
+func TestSkip(t *testing.T) {
+	start := initTemplates()
+	tests := map[string]struct {
+		id          domain.TemplateID
+		time        time.Time
+		expectedErr error
+	}{
+		"Correct":               {id: 1, time: start.AddDate(0, 3, 0), expectedErr: nil},
+		"Already Skipped":       {id: 1, time: start.AddDate(0, 3, 0), expectedErr: store.ErrOccurrenceExists},
+		"Not An Occurrence":     {id: 1, time: start.AddDate(0, 3, 1), expectedErr: scheduling.ErrOccurrenceInvalid},
+		"After Until":           {id: 2, time: start.AddDate(0, 0, 21), expectedErr: scheduling.ErrOccurrenceInvalid},
+		"Non-Existing Template": {id: 3, time: start, expectedErr: store.ErrTemplateNotFound},
+	}
+	for _, name := range []string{"Correct", "Already Skipped", "Not An Occurrence", "After Until", "Non-Existing Template"} {
+		test := tests[name]
+		t.Run(name, func(t *testing.T) {
+			if err := scheduler.Skip(test.id, test.time); err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package scheduling
+
+import (
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/adding"
+	"github.com/elhamza90/lifelog/internal/usecase/auditing"
+)
+
+// Service provides methods that materialize the occurrences
+// of recurring templates into expenses and activities
+type Service struct {
+	repo    Repository
+	adder   adding.Service   // Creates expenses & activities of occurrences
+	auditor auditing.Service // Records materialized expenses & activities
+}
+
+// NewService returns a new scheduling service with provided repository
+// and validation policy of occurrences
+func NewService(r Repository, p domain.Policy) Service {
+	return Service{repo: r, adder: adding.NewService(r, p), auditor: auditing.NewService(r)}
+}
+
+// Repository is the interface that wraps the methods
+// that must be implemented by the repository
+// in order for scheduling service to perform its job.
+//
+//   - FindTemplateByID and FindAllTemplates return the templates
+//     whose occurrences are materialized.
+//
+//   - FindOccurrences and SaveOccurrence record which occurrences were
+//     materialized or skipped so they are never materialized twice.
+//
+//   - SaveOccurrenceActivity and SaveOccurrenceExpense record an occurrence
+//     and create its activity or expense atomically.
+//
+//   - The adding repository methods are used to check expenses & activities.
+//
+//   - The auditing repository methods are used to record
+//     materialized expenses & activities.
+type Repository interface {
+	adding.Repository
+	auditing.Repository
+	FindTemplateByID(domain.TemplateID) (domain.Template, error)
+	FindAllTemplates() ([]domain.Template, error)
+	FindOccurrences(domain.TemplateID) ([]domain.Occurrence, error)
+	SaveOccurrence(domain.Occurrence) error
+	SaveOccurrenceActivity(domain.Occurrence, domain.Activity) (domain.ActivityID, error)
+	SaveOccurrenceExpense(domain.Occurrence, domain.Expense) (domain.ExpenseID, error)
+}
+
+// Constants for listing upcoming occurrences
+const (
+	DefaultUpcomingLimit int = 10
+	MaxUpcomingLimit     int = 100
+)
+
+// Errors
+var (
+	// ErrUpcomingLimit is returned when the number of upcoming occurrences is out of bounds
+	ErrUpcomingLimit error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "upcoming_limit",
+		Message: "Number of upcoming occurrences must be between 1 and 100",
+		Field:   "limit",
+	}
+	// ErrOccurrenceInvalid is returned when a time is not an occurrence of a template
+	ErrOccurrenceInvalid error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "occurrence_invalid",
+		Message: "Time is not an occurrence of the template",
+		Field:   "time",
+	}
+)
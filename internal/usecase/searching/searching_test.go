@@ -0,0 +1,18 @@
+package searching_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/store/memory"
+	"github.com/elhamza90/lifelog/internal/usecase/searching"
+)
+
+var searcher searching.Service // Instance of service we will be testing
+var repo memory.Repository     // Repository used by service
+
+func TestMain(m *testing.M) {
+	repo = memory.NewRepository()          // Work with In-Memory DB
+	searcher = searching.NewService(&repo) // Passing by reference to change db when testing
+	os.Exit(m.Run())
+}
@@ -0,0 +1,109 @@
+package searching
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// Service provides methods that search entities by text
+type Service struct {
+	repo Repository
+}
+
+// NewService returns a new searching service with provided repository
+func NewService(r Repository) Service {
+	return Service{repo: r}
+}
+
+// Repository is the interface that wraps the methods
+// that must be implemented by the repository
+// in order for searching service to perform its job.
+//
+//	- SearchActivities returns at most limit activities whose
+//	  Label, Desc or Place match all words of the query.
+//
+//	- SearchExpenses returns at most limit expenses whose
+//	  Label matches all words of the query.
+//
+// Both return the best ranked results first.
+type Repository interface {
+	SearchActivities(string, int) ([]domain.SearchResult, error)
+	SearchExpenses(string, int) ([]domain.SearchResult, error)
+}
+
+// Search limits
+const (
+	DefaultSearchLimit int = 20
+	MaxSearchLimit     int = 100
+	MaxQueryLength     int = 200
+)
+
+// Errors
+var (
+	ErrQueryEmpty error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "query_empty",
+		Message: "Search query must contain at least one word",
+		Field:   "q",
+	}
+	ErrQueryLength error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "query_length",
+		Message: "Search query must not exceed 200 characters",
+		Field:   "q",
+	}
+	ErrSearchLimit error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "search_limit",
+		Message: "Search limit must be between 1 and 100",
+		Field:   "limit",
+	}
+)
+
+// Search returns activities and expenses matching all words of the given query.
+// Results of both kinds are mixed and ordered from best to worst rank,
+// then from most recent to oldest.
+// A zero limit is replaced by DefaultSearchLimit.
+func (srv Service) Search(q string, limit int) ([]domain.SearchResult, error) {
+	q = strings.TrimSpace(q)
+	if len(q) > MaxQueryLength {
+		return []domain.SearchResult{}, ErrQueryLength
+	}
+	if len(store.Tokenize(q)) == 0 {
+		return []domain.SearchResult{}, ErrQueryEmpty
+	}
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit < 0 || limit > MaxSearchLimit {
+		return []domain.SearchResult{}, ErrSearchLimit
+	}
+	activities, err := srv.repo.SearchActivities(q, limit)
+	if err != nil {
+		return []domain.SearchResult{}, err
+	}
+	expenses, err := srv.repo.SearchExpenses(q, limit)
+	if err != nil {
+		return []domain.SearchResult{}, err
+	}
+	res := append(activities, expenses...)
+	sortSearchResults(res)
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+// sortSearchResults sorts search results by rank descendent
+// then by time descendent.
+func sortSearchResults(res []domain.SearchResult) {
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Rank != res[j].Rank {
+			return res[i].Rank > res[j].Rank
+		}
+		return res[i].Time.After(res[j].Time)
+	})
+}
@@ -0,0 +1,87 @@
+package searching_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/searching"
+)
+
+func TestSearch(t *testing.T) {
+	now := time.Now()
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Dinner with friends", Place: "Le Petit Bistrot de la Gare", Time: now.AddDate(0, 0, -3)},
+		2: {ID: 2, Label: "Running", Place: "Park", Desc: "Short run before dinner", Time: now.AddDate(0, 0, -2)},
+		3: {ID: 3, Label: "Reading", Place: "Home", Time: now.AddDate(0, 0, -1)},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Dinner", Value: 45, Unit: "eu", Time: now.AddDate(0, 0, -4)},
+		2: {ID: 2, Label: "Running shoes", Value: 80, Unit: "eu", Time: now.AddDate(0, -1, 0)},
+	}
+	defer func() {
+		repo.Activities = map[domain.ActivityID]domain.Activity{}
+		repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	}()
+	tests := map[string]struct {
+		q           string
+		limit       int
+		expected    []string // kind + id of results, in order !
+		expectedErr error
+	}{
+		"Label And Description": {
+			q:        "dinner",
+			expected: []string{"activity 1", "expense 1", "activity 2"},
+		},
+		"Place": {
+			q:        "bistrot",
+			expected: []string{"activity 1"},
+		},
+		"All Words Must Match": {
+			q:        "Dinner, GARE!",
+			expected: []string{"activity 1"},
+		},
+		"Limit": {
+			q:        "dinner",
+			limit:    2,
+			expected: []string{"activity 1", "expense 1"},
+		},
+		"No Match": {
+			q:        "cinema",
+			expected: []string{},
+		},
+		"Empty Query": {
+			q:           " ,; ",
+			expectedErr: searching.ErrQueryEmpty,
+		},
+		"Query Too Long": {
+			q:           strings.Repeat("dinner ", 40),
+			expectedErr: searching.ErrQueryLength,
+		},
+		"Limit Too High": {
+			q:           "dinner",
+			limit:       searching.MaxSearchLimit + 1,
+			expectedErr: searching.ErrSearchLimit,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := searcher.Search(test.q, test.limit)
+			if err != test.expectedErr {
+				t.Fatalf("\nExpected Error: %v\nReturned Error: %v", test.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			returned := []string{}
+			for _, r := range res {
+				returned = append(returned, fmt.Sprintf("%s %d", r.Kind, r.ID))
+			}
+			if fmt.Sprint(returned) != fmt.Sprint(test.expected) {
+				t.Fatalf("\nExpected: %v\nReturned: %v", test.expected, returned)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package throttling
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// Clock returns the current time.
+// It is time.Now except in tests.
+type Clock func() time.Time
+
+// Service provides methods that rate limit requests by key
+// and lock keys out after repeated failed logins
+type Service struct {
+	repo   Repository
+	clock  Clock
+	config Config
+	mu     *sync.Mutex // Serializes updates of throttles
+}
+
+// NewService returns a new throttling service with provided repository,
+// clock ( time.Now if nil ) and configuration
+func NewService(r Repository, clock Clock, config Config) Service {
+	if clock == nil {
+		clock = time.Now
+	}
+	return Service{repo: r, clock: clock, config: config, mu: &sync.Mutex{}}
+}
+
+// Repository is the interface that wraps the methods that must be
+// implemented by the repository in order for throttling service
+// to perform its job
+//
+//	- FindThrottle, SaveThrottle are used to update the state of a key
+//
+//	- DeleteThrottlesBefore is used to forget idle keys
+type Repository interface {
+	FindThrottle(key string) (domain.Throttle, error)
+	SaveThrottle(domain.Throttle) error
+	DeleteThrottlesBefore(time.Time) (int, error)
+}
+
+// Config specifies the limits of the throttling service
+type Config struct {
+	Requests    int           // Requests allowed per key in each window ( 0 disables rate limiting )
+	Window      time.Duration // Duration of a rate limiting window
+	MaxFailures int           // Consecutive failed logins allowed before lockout ( 0 disables lockouts )
+	Lockout     time.Duration // Duration of the first lockout, doubled by each further failure
+	MaxLockout  time.Duration // Maximum duration of a lockout, after which failures are forgotten
+}
+
+// DefaultConfig returns the default limits:
+// 300 requests per minute and lockouts from 1 minute to 1 hour after 5 failed logins
+func DefaultConfig() Config {
+	return Config{
+		Requests:    300,
+		Window:      time.Minute,
+		MaxFailures: 5,
+		Lockout:     time.Minute,
+		MaxLockout:  time.Hour,
+	}
+}
+
+// Errors
+var (
+	ErrConfig error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "throttling_config",
+		Message: "Rate limiting window and lockout durations must be positive",
+	}
+	ErrRateLimited error = &domain.Error{
+		Kind:    domain.KindTooManyRequests,
+		Code:    "rate_limited",
+		Message: "Too many requests: retry later",
+	}
+	ErrLockedOut error = &domain.Error{
+		Kind:    domain.KindTooManyRequests,
+		Code:    "login_locked",
+		Message: "Too many failed logins: retry later",
+	}
+)
+
+// Validate checks the durations of enabled limits are positive
+func (c Config) Validate() error {
+	if c.Requests < 0 || c.MaxFailures < 0 {
+		return ErrConfig
+	}
+	if c.Requests > 0 && c.Window <= 0 {
+		return ErrConfig
+	}
+	if c.MaxFailures > 0 && (c.Lockout <= 0 || c.MaxLockout < c.Lockout) {
+		return ErrConfig
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package throttling
+
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// update calls fn with the throttle of given key and the current time
+// and saves the updated throttle.
+func (s Service) update(key string, fn func(th *domain.Throttle, now time.Time)) (domain.Throttle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	th, err := s.repo.FindThrottle(key)
+	if err != nil {
+		return domain.Throttle{}, err
+	}
+	th.Key = key
+	now := s.clock()
+	fn(&th, now)
+	th.LastSeen = now
+	return th, s.repo.SaveThrottle(th)
+}
+
+// Hit counts a request of given key.
+// It returns ErrRateLimited and the time to wait before retrying
+// if the key made too many requests in the current window.
+func (s Service) Hit(key string) (time.Duration, error) {
+	if s.config.Requests == 0 {
+		return 0, nil
+	}
+	th, err := s.update(key, func(th *domain.Throttle, now time.Time) {
+		if !now.Before(th.WindowStart.Add(s.config.Window)) {
+			th.WindowStart = now
+			th.Hits = 0
+		}
+		th.Hits++
+	})
+	if err != nil {
+		return 0, err
+	}
+	if th.Hits > s.config.Requests {
+		return th.WindowStart.Add(s.config.Window).Sub(th.LastSeen), ErrRateLimited
+	}
+	return 0, nil
+}
+
+// Locked returns ErrLockedOut and the time to wait before retrying
+// if the given key is locked out.
+func (s Service) Locked(key string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	th, err := s.repo.FindThrottle(key)
+	if err != nil {
+		return 0, err
+	}
+	if now := s.clock(); now.Before(th.LockedUntil) {
+		return th.LockedUntil.Sub(now), ErrLockedOut
+	}
+	return 0, nil
+}
+
+// Fail records a failed login of given key.
+// After MaxFailures consecutive failures, the key is locked out
+// for Lockout, doubled by each further failure up to MaxLockout.
+// It returns the duration of the lockout ( 0 if the key is not locked out ).
+func (s Service) Fail(key string) (time.Duration, error) {
+	if s.config.MaxFailures == 0 {
+		return 0, nil
+	}
+	var lockout time.Duration
+	_, err := s.update(key, func(th *domain.Throttle, now time.Time) {
+		// Failures are forgotten after the longest lockout
+		if now.Sub(th.LastFailure) > s.config.MaxLockout {
+			th.Failures = 0
+		}
+		th.Failures++
+		th.LastFailure = now
+		if th.Failures < s.config.MaxFailures {
+			return
+		}
+		lockout = s.config.Lockout
+		for i := s.config.MaxFailures; i < th.Failures && lockout < s.config.MaxLockout; i++ {
+			lockout *= 2
+		}
+		if lockout > s.config.MaxLockout {
+			lockout = s.config.MaxLockout
+		}
+		th.LockedUntil = now.Add(lockout)
+	})
+	return lockout, err
+}
+
+// Succeed forgets the failed logins of given key
+func (s Service) Succeed(key string) error {
+	_, err := s.update(key, func(th *domain.Throttle, now time.Time) {
+		th.Failures = 0
+		th.LockedUntil = time.Time{}
+	})
+	return err
+}
+
+// Purge forgets the keys idle for longer than the window and the longest lockout.
+// It returns the number of forgotten keys.
+func (s Service) Purge() (int, error) {
+	idle := s.config.Window
+	if s.config.MaxLockout > idle {
+		idle = s.config.MaxLockout
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.repo.DeleteThrottlesBefore(s.clock().Add(-idle))
+}
@@ -0,0 +1,112 @@
+package throttling_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/usecase/throttling"
+)
+
+func TestHit(t *testing.T) {
+	defer reset()
+	for i := 0; i < config.Requests; i++ {
+		if _, err := throttler.Hit("ip"); err != nil {
+			t.Fatalf("\nUnexpected Error on request %d: %v", i+1, err)
+		}
+		now = now.Add(10 * time.Second)
+	}
+	// Limit reached 30s after the start of the window
+	retry, err := throttler.Hit("ip")
+	if !errors.Is(err, throttling.ErrRateLimited) || retry != 30*time.Second {
+		t.Fatalf("\nExpected: %v after %s\nReturned: %v after %s", throttling.ErrRateLimited, 30*time.Second, err, retry)
+	}
+	// Other keys are not limited
+	if _, err := throttler.Hit("other"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	// New window
+	now = now.Add(retry)
+	if _, err := throttler.Hit("ip"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+}
+
+func TestFail(t *testing.T) {
+	defer reset()
+	// locked returns the time to wait before retrying a login
+	locked := func() time.Duration {
+		retry, err := throttler.Locked("user")
+		if err != nil && !errors.Is(err, throttling.ErrLockedOut) {
+			t.Fatalf("\nUnexpected Error: %v", err)
+		}
+		return retry
+	}
+	tests := []struct {
+		name            string
+		expectedLockout time.Duration
+	}{
+		{name: "First Failure", expectedLockout: 0},
+		{name: "Lockout", expectedLockout: time.Minute},
+		{name: "Doubled Lockout", expectedLockout: 2 * time.Minute},
+		{name: "Doubled Again", expectedLockout: 4 * time.Minute},
+		{name: "Doubled Again", expectedLockout: 8 * time.Minute},
+		{name: "Max Lockout", expectedLockout: 10 * time.Minute},
+		{name: "Still Max Lockout", expectedLockout: 10 * time.Minute},
+	}
+	for _, test := range tests {
+		lockout, err := throttler.Fail("user")
+		if err != nil || lockout != test.expectedLockout {
+			t.Fatalf("\n%s\nExpected Lockout: %s\nReturned: %s, %v", test.name, test.expectedLockout, lockout, err)
+		}
+		if retry := locked(); retry != test.expectedLockout {
+			t.Fatalf("\n%s\nExpected Retry After: %s\nReturned: %s", test.name, test.expectedLockout, retry)
+		}
+		now = now.Add(lockout)
+	}
+	// Success forgets failures
+	if err := throttler.Succeed("user"); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if lockout, _ := throttler.Fail("user"); lockout != 0 {
+		t.Fatalf("\nExpected no lockout after a success\nReturned: %s", lockout)
+	}
+	// Failures are forgotten after the longest lockout
+	now = now.Add(config.MaxLockout + time.Second)
+	if lockout, _ := throttler.Fail("user"); lockout != 0 {
+		t.Fatalf("\nExpected old failures to be forgotten\nReturned lockout: %s", lockout)
+	}
+}
+
+func TestPurge(t *testing.T) {
+	defer reset()
+	throttler.Hit("old")
+	now = now.Add(config.MaxLockout + time.Second)
+	throttler.Hit("recent")
+	if purged, err := throttler.Purge(); err != nil || purged != 1 {
+		t.Fatalf("\nExpected 1 purged key\nReturned: %d, %v", purged, err)
+	}
+	if _, ok := repo["recent"]; !ok {
+		t.Fatal("\nExpected recent key to be kept")
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		config      throttling.Config
+		expectedErr error
+	}{
+		"Default":          {config: throttling.DefaultConfig()},
+		"Disabled":         {config: throttling.Config{}},
+		"No Window":        {config: throttling.Config{Requests: 10}, expectedErr: throttling.ErrConfig},
+		"No Lockout":       {config: throttling.Config{MaxFailures: 3}, expectedErr: throttling.ErrConfig},
+		"Short MaxLockout": {config: throttling.Config{MaxFailures: 3, Lockout: time.Hour, MaxLockout: time.Minute}, expectedErr: throttling.ErrConfig},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.config.Validate(); err != test.expectedErr {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package throttling_test
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/store/memory"
+	"github.com/elhamza90/lifelog/internal/usecase/throttling"
+)
+
+// throttler is the instance of the service to be tested
+var throttler throttling.Service
+var repo memory.Throttles
+
+// now is the time of the fake clock of the service
+var now time.Time
+
+// config specifies the limits used in tests
+var config = throttling.Config{
+	Requests:    3,
+	Window:      time.Minute,
+	MaxFailures: 2,
+	Lockout:     time.Minute,
+	MaxLockout:  10 * time.Minute,
+}
+
+func TestMain(m *testing.M) {
+	log.Println("Setting up tests")
+	repo = memory.Throttles{}
+	now = time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+	throttler = throttling.NewService(repo, func() time.Time { return now }, config)
+	os.Exit(m.Run())
+}
+
+// reset forgets all keys and resets the clock
+func reset() {
+	for key := range repo {
+		delete(repo, key)
+	}
+	now = time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,100 @@
+package transferring
+
+import (
+	"io"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// Columns of activities CSV files
+var activityColumns = []string{"id", "label", "place", "desc", "time", "duration", "tags"}
+
+// ExportActivities writes all activities sorted by time as CSV with columns
+// id,label,place,desc,time,duration,tags. Tags are names separated by ";".
+func (srv Service) ExportActivities(w io.Writer) error {
+	p := store.PageRequest{Limit: exportPageLimit, Sort: store.SortByTime}
+	now := time.Now()
+	return exportCSV(w, activityColumns, func() ([][]string, bool, error) {
+		activities, page, err := srv.repo.FindActivitiesByTimeRangePage(time.Time{}, now, p)
+		if err != nil {
+			return nil, false, err
+		}
+		rows := make([][]string, len(activities))
+		for i, act := range activities {
+			rows[i] = []string{
+				act.ID.String(),
+				act.Label,
+				act.Place,
+				act.Desc,
+				formatTime(act.Time),
+				act.Duration.String(),
+				formatTags(act.Tags),
+			}
+		}
+		p.Cursor = page.NextCursor
+		return rows, page.NextCursor != "", nil
+	})
+}
+
+// ImportActivities creates activities from a CSV file with columns label and time
+// and optional columns place, desc, duration and tags.
+// Tags are names of existing tags separated by ";".
+// The id column of exported files is ignored.
+// Activities are recorded in their history as imported by principal.
+func (srv Service) ImportActivities(r io.Reader, principal string) (Report, error) {
+	return srv.importCSV(r, []string{"label", "time"}, principal, func(rw row) (imported, error) {
+		t, err := parseTime(rw.get("time"))
+		if err != nil {
+			return imported{}, err
+		}
+		d, err := parseDuration(rw.get("duration"))
+		if err != nil {
+			return imported{}, err
+		}
+		tags, err := srv.parseTags(rw.get("tags"))
+		if err != nil {
+			return imported{}, err
+		}
+		act, err := srv.adder.CheckActivity(domain.Activity{
+			Label:    rw.get("label"),
+			Place:    rw.get("place"),
+			Desc:     rw.get("desc"),
+			Time:     t,
+			Duration: d,
+			Tags:     tags,
+		})
+		if err != nil {
+			return imported{}, err
+		}
+		id, err := srv.repo.SaveActivity(act)
+		return imported{entity: domain.AuditActivity, id: uint(id)}, err
+	})
+}
+
+// findActivity returns the activity with given label and time.
+// It returns ErrActivityAmbiguous if several activities match.
+func (srv Service) findActivity(label string, t time.Time) (domain.Activity, error) {
+	activities, err := srv.repo.FindActivitiesByTimeRange(t, t)
+	if err != nil {
+		return domain.Activity{}, err
+	}
+	var (
+		res   domain.Activity
+		found bool
+	)
+	for _, act := range activities {
+		if act.Label != label {
+			continue
+		}
+		if found {
+			return domain.Activity{}, ErrActivityAmbiguous
+		}
+		res, found = act, true
+	}
+	if !found {
+		return domain.Activity{}, store.ErrActivityNotFound
+	}
+	return res, nil
+}
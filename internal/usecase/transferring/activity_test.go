@@ -0,0 +1,84 @@
+package transferring_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/usecase/transferring"
+)
+
+func TestExportActivities(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "sport"},
+		2: {ID: 2, Name: "friends"},
+	}
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Football", Place: "stadium", Time: time.Date(2020, 10, 5, 18, 0, 0, 0, time.UTC), Duration: 90 * time.Minute, Tags: []domain.Tag{repo.Tags[1], repo.Tags[2]}},
+		2: {ID: 2, Label: "Reading, alone", Desc: "A \"good\" book", Time: time.Date(2020, 10, 1, 21, 0, 0, 0, time.UTC), Duration: time.Hour},
+	}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Activities = map[domain.ActivityID]domain.Activity{}
+	}()
+	var buf bytes.Buffer
+	if err := transferrer.ExportActivities(&buf); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	const expected string = "id,label,place,desc,time,duration,tags\n" +
+		"2,\"Reading, alone\",,\"A \"\"good\"\" book\",2020-10-01T21:00:00Z,1h0m0s,\n" +
+		"1,Football,stadium,,2020-10-05T18:00:00Z,1h30m0s,sport;friends\n"
+	if buf.String() != expected {
+		t.Fatalf("\nExpected:\n%s\nReturned:\n%s", expected, buf.String())
+	}
+}
+
+func TestImportActivities(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "sport"},
+	}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Activities = map[domain.ActivityID]domain.Activity{}
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	}()
+	const file string = "Label,Time,Duration,Tags,Place\n" +
+		"Football,2020-10-05 18:00,1:30,Sport,Stadium\n" +
+		"Swimming,2020-10-06T07:00:00+01:00,45m,,\n" +
+		"Running,2020-10-07,1 hour,,\n" +
+		"Climbing,07/10/2020,1h,,\n" +
+		"Cycling,2020-10-08,1h,unknown,\n" +
+		"Tiny,2020-10-08,1h,,\n" +
+		"Broken,\"2020-10-08,1h,,\n"
+	report, err := transferrer.ImportActivities(strings.NewReader(file), "admin")
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if report.Imported != 2 || len(repo.Activities) != 2 {
+		t.Fatalf("\nExpected 2 imported activities\nReturned: %d ( %d stored )", report.Imported, len(repo.Activities))
+	}
+	expectedErrors := []transferring.RowError{
+		{Row: 4, Err: transferring.ErrCSVDuration},
+		{Row: 5, Err: transferring.ErrCSVTime},
+		{Row: 6, Err: store.ErrTagNotFound},
+		{Row: 7, Err: domain.ErrActivityLabelLength},
+		{Row: 8, Err: transferring.ErrCSVRow},
+	}
+	if len(report.Errors) != len(expectedErrors) {
+		t.Fatalf("\nExpected Errors: %v\nReturned Errors: %v", expectedErrors, report.Errors)
+	}
+	for i, e := range expectedErrors {
+		if report.Errors[i].Row != e.Row || !errors.Is(report.Errors[i].Err, e.Err) {
+			t.Fatalf("\nExpected Errors: %v\nReturned Errors: %v", expectedErrors, report.Errors)
+		}
+	}
+	for _, act := range repo.Activities {
+		if act.Label == "Football" && (act.Duration != 90*time.Minute || act.Place != "stadium" || len(act.Tags) != 1) {
+			t.Fatalf("\nImported activity has wrong fields: %v", act)
+		}
+	}
+}
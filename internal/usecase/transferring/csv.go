@@ -0,0 +1,199 @@
+package transferring
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+)
+
+// exportPageLimit is the number of entities fetched at once when exporting
+const exportPageLimit int = 500
+
+// tagsSeparator separates tag names in a CSV field
+const tagsSeparator string = ";"
+
+// timeFormats are the accepted formats of times in imported files.
+// Times without offset are UTC. Times are exported in the first format.
+var timeFormats = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// row gives access to the fields of a CSV record by column name
+type row struct {
+	fields  []string
+	columns map[string]int
+}
+
+// get returns the trimmed field of the given column.
+// It returns an empty string if the file has no such column.
+func (r row) get(col string) string {
+	i, ok := r.columns[col]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[i])
+}
+
+// imported identifies the entity created from a row
+type imported struct {
+	entity domain.AuditEntity
+	id     uint
+}
+
+// importCSV reads a CSV file with a header row and calls importRow on each row.
+// Columns are matched by name ( case-insensitive ) and their order does not matter.
+// It returns ErrCSVHeader if the header lacks one of the required columns.
+// Rows are imported one by one and their errors are collected in the report.
+// Imported entities are recorded in their history as imported by principal:
+// rows are all imported even if recording fails and the first error is returned.
+func (srv Service) importCSV(r io.Reader, required []string, principal string, importRow func(row) (imported, error)) (Report, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	report := Report{Errors: []RowError{}}
+	header, err := reader.Read()
+	if err != nil {
+		return report, ErrCSVHeader
+	}
+	columns := make(map[string]int, len(header))
+	for i, col := range header {
+		columns[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+	for _, col := range required {
+		if _, ok := columns[col]; !ok {
+			return report, ErrCSVHeader
+		}
+	}
+	var recordErr error
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var perr *csv.ParseError
+			if errors.As(err, &perr) {
+				report.Errors = append(report.Errors, RowError{Row: perr.StartLine, Err: ErrCSVRow})
+				continue
+			}
+			return report, err
+		}
+		line, _ := reader.FieldPos(0)
+		imp, err := importRow(row{fields: fields, columns: columns})
+		if err != nil {
+			report.Errors = append(report.Errors, RowError{Row: line, Err: err})
+			continue
+		}
+		report.Imported++
+		if err := srv.recordImport(imp, principal); err != nil && recordErr == nil {
+			recordErr = err
+		}
+	}
+	return report, recordErr
+}
+
+// recordImport records the import of an entity by principal
+// with a snapshot of the stored entity
+func (srv Service) recordImport(imp imported, principal string) error {
+	var (
+		snapshot interface{}
+		err      error
+	)
+	switch imp.entity {
+	case domain.AuditTag:
+		snapshot, err = srv.repo.FindTagByID(domain.TagID(imp.id))
+	case domain.AuditActivity:
+		snapshot, err = srv.repo.FindActivityByID(domain.ActivityID(imp.id))
+	case domain.AuditExpense:
+		snapshot, err = srv.repo.FindExpenseByID(domain.ExpenseID(imp.id))
+	}
+	if err != nil {
+		return err
+	}
+	return srv.auditor.Record(imp.entity, imp.id, domain.AuditImport, principal, nil, snapshot)
+}
+
+// parseTime parses a time field of an imported file
+func parseTime(str string) (time.Time, error) {
+	for _, frmt := range timeFormats {
+		if t, err := time.Parse(frmt, str); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, ErrCSVTime
+}
+
+// formatTime formats a time field of an exported file
+func formatTime(t time.Time) string {
+	return t.Format(timeFormats[0])
+}
+
+// parseDuration parses a duration field of an imported file.
+// Both Go durations ( 1h30m ) and HH:MM are accepted. Empty means zero.
+func parseDuration(str string) (time.Duration, error) {
+	if str == "" {
+		return 0, nil
+	}
+	if d, err := time.ParseDuration(str); err == nil && d >= 0 {
+		return d, nil
+	}
+	parts := strings.Split(str, ":")
+	if len(parts) != 2 {
+		return 0, ErrCSVDuration
+	}
+	h, errH := strconv.Atoi(parts[0])
+	m, errM := strconv.Atoi(parts[1])
+	if errH != nil || errM != nil || h < 0 || m < 0 || m > 59 {
+		return 0, ErrCSVDuration
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// parseTags fetches the tags with the names listed in a field of an imported file.
+// Tags must already exist.
+func (srv Service) parseTags(str string) ([]domain.Tag, error) {
+	tags := []domain.Tag{}
+	for _, name := range strings.Split(str, tagsSeparator) {
+		if name = strings.ToLower(strings.TrimSpace(name)); name == "" {
+			continue
+		}
+		t, err := srv.repo.FindTagByName(name)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, nil
+}
+
+// formatTags joins the names of tags in a field of an exported file
+func formatTags(tags []domain.Tag) string {
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+	}
+	return strings.Join(names, tagsSeparator)
+}
+
+// exportCSV writes the header and then the rows returned by nextRows
+// until it returns no more rows. Rows are flushed after each call.
+func exportCSV(w io.Writer, header []string, nextRows func() ([][]string, bool, error)) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for {
+		rows, more, err := nextRows()
+		if err != nil {
+			return err
+		}
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package transferring
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// Columns of expenses CSV files
+var expenseColumns = []string{"id", "label", "time", "value", "unit", "activity_id", "activity_label", "activity_time", "tags"}
+
+// ExportExpenses writes all expenses sorted by time as CSV with columns
+// id,label,time,value,unit,activity_id,activity_label,activity_time,tags.
+// Values are decimals in the currency. The label & time of the linked activity
+// are exported so the link can be restored when importing in another store.
+func (srv Service) ExportExpenses(w io.Writer) error {
+	p := store.PageRequest{Limit: exportPageLimit, Sort: store.SortByTime}
+	now := time.Now()
+	activities := map[domain.ActivityID]domain.Activity{}
+	return exportCSV(w, expenseColumns, func() ([][]string, bool, error) {
+		expenses, page, err := srv.repo.FindExpensesByTimeRangePage(time.Time{}, now, p)
+		if err != nil {
+			return nil, false, err
+		}
+		rows := make([][]string, len(expenses))
+		for i, exp := range expenses {
+			var actID, actLabel, actTime string
+			if exp.ActivityID > 0 {
+				act, ok := activities[exp.ActivityID]
+				if !ok {
+					if act, err = srv.repo.FindActivityByID(exp.ActivityID); err != nil {
+						return nil, false, err
+					}
+					activities[exp.ActivityID] = act
+				}
+				actID, actLabel, actTime = act.ID.String(), act.Label, formatTime(act.Time)
+			}
+			rows[i] = []string{
+				exp.ID.String(),
+				exp.Label,
+				formatTime(exp.Time),
+				exp.Value.Format(exp.Unit),
+				string(exp.Unit),
+				actID,
+				actLabel,
+				actTime,
+				formatTags(exp.Tags),
+			}
+		}
+		p.Cursor = page.NextCursor
+		return rows, page.NextCursor != "", nil
+	})
+}
+
+// ImportExpenses creates expenses from a CSV file with columns label, time, value & unit
+// and optional columns activity_id, activity_label, activity_time and tags.
+// The linked activity is referenced by activity_id or by both activity_label
+// and activity_time ( the id is used when both are present ).
+// Tags are names of existing tags separated by ";".
+// The id column of exported files is ignored.
+// Expenses are recorded in their history as imported by principal.
+func (srv Service) ImportExpenses(r io.Reader, principal string) (Report, error) {
+	return srv.importCSV(r, []string{"label", "time", "value", "unit"}, principal, func(rw row) (imported, error) {
+		t, err := parseTime(rw.get("time"))
+		if err != nil {
+			return imported{}, err
+		}
+		cur, err := domain.ParseCurrency(rw.get("unit"))
+		if err != nil {
+			return imported{}, err
+		}
+		val, err := domain.ParseAmount(rw.get("value"), cur)
+		if err != nil {
+			return imported{}, err
+		}
+		actID, err := srv.linkedActivity(rw)
+		if err != nil {
+			return imported{}, err
+		}
+		tags, err := srv.parseTags(rw.get("tags"))
+		if err != nil {
+			return imported{}, err
+		}
+		exp, err := srv.adder.CheckExpense(domain.Expense{
+			Label:      rw.get("label"),
+			Time:       t,
+			Value:      val,
+			Unit:       cur,
+			ActivityID: actID,
+			Tags:       tags,
+		})
+		if err != nil {
+			return imported{}, err
+		}
+		id, err := srv.repo.SaveExpense(exp)
+		return imported{entity: domain.AuditExpense, id: uint(id)}, err
+	})
+}
+
+// linkedActivity returns the ID of the activity referenced by an expense row.
+// It returns 0 if the row does not reference an activity.
+func (srv Service) linkedActivity(rw row) (domain.ActivityID, error) {
+	if idStr := rw.get("activity_id"); idStr != "" {
+		id, err := strconv.ParseUint(idStr, 10, 0)
+		if err != nil || id == 0 {
+			return 0, ErrCSVActivityID
+		}
+		return domain.ActivityID(id), nil
+	}
+	label, timeStr := rw.get("activity_label"), rw.get("activity_time")
+	if label == "" && timeStr == "" {
+		return 0, nil
+	}
+	if label == "" || timeStr == "" {
+		return 0, ErrCSVActivityLink
+	}
+	t, err := parseTime(timeStr)
+	if err != nil {
+		return 0, err
+	}
+	act, err := srv.findActivity(label, t)
+	if err != nil {
+		return 0, err
+	}
+	return act.ID, nil
+}
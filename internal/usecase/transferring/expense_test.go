@@ -0,0 +1,105 @@
+package transferring_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/usecase/transferring"
+)
+
+func TestExportExpenses(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "food"},
+	}
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		3: {ID: 3, Label: "Dinner out", Time: time.Date(2020, 10, 5, 20, 0, 0, 0, time.UTC), Duration: time.Hour},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Restaurant", Time: time.Date(2020, 10, 5, 21, 0, 0, 0, time.UTC), Value: 4550, Unit: "EUR", ActivityID: 3, Tags: []domain.Tag{repo.Tags[1]}},
+		2: {ID: 2, Label: "Train", Time: time.Date(2020, 10, 2, 8, 30, 0, 0, time.UTC), Value: 1200, Unit: "JPY"},
+	}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Activities = map[domain.ActivityID]domain.Activity{}
+		repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+	}()
+	var buf bytes.Buffer
+	if err := transferrer.ExportExpenses(&buf); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	const expected string = "id,label,time,value,unit,activity_id,activity_label,activity_time,tags\n" +
+		"2,Train,2020-10-02T08:30:00Z,1200,JPY,,,,\n" +
+		"1,Restaurant,2020-10-05T21:00:00Z,45.50,EUR,3,Dinner out,2020-10-05T20:00:00Z,food\n"
+	if buf.String() != expected {
+		t.Fatalf("\nExpected:\n%s\nReturned:\n%s", expected, buf.String())
+	}
+}
+
+func TestImportExpenses(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "food"},
+	}
+	dinnerTime := time.Date(2020, 10, 5, 20, 0, 0, 0, time.UTC)
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		3: {ID: 3, Label: "Dinner out", Time: dinnerTime, Duration: time.Hour},
+		4: {ID: 4, Label: "Cinema", Time: dinnerTime, Duration: time.Hour},
+		5: {ID: 5, Label: "Cinema", Time: dinnerTime, Duration: 2 * time.Hour},
+	}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Activities = map[domain.ActivityID]domain.Activity{}
+		repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	}()
+	const file string = "id,label,time,value,unit,activity_id,activity_label,activity_time,tags\n" +
+		"1,Restaurant,2020-10-05T21:00:00Z,45.50,eur,,Dinner out,2020-10-05T20:00:00Z,food\n" +
+		"2,Tip,2020-10-05T21:00:00Z,5,EUR,3,,,\n" +
+		"3,Groceries,2020-10-03,12.5,USD,,,,\n" +
+		"4,Popcorn,2020-10-05T21:00:00Z,4,EUR,,Cinema,2020-10-05T20:00:00Z,\n" +
+		"5,Taxi,2020-10-05T21:00:00Z,10,EUR,,Dinner out,,\n" +
+		"6,Bus,2020-10-05T21:00:00Z,2,EUR,99,,,\n" +
+		"7,Metro,2020-10-05T21:00:00Z,2,EUR,abc,,,\n" +
+		"8,Coffee,2020-10-05T21:00:00Z,2.555,EUR,,,,\n" +
+		"9,Coffee,2020-10-05T21:00:00Z,2,EURO,,,,\n" +
+		"10,Coffee,2020-10-05T21:00:00Z,0,EUR,,,,\n" +
+		"11,Coffee,2020-10-05T21:00:00Z,2,EUR,,Breakfast,2020-10-05T08:00:00Z,\n"
+	report, err := transferrer.ImportExpenses(strings.NewReader(file), "admin")
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if report.Imported != 3 || len(repo.Expenses) != 3 {
+		t.Fatalf("\nExpected 3 imported expenses\nReturned: %d ( %d stored )", report.Imported, len(repo.Expenses))
+	}
+	expectedErrors := []transferring.RowError{
+		{Row: 5, Err: transferring.ErrActivityAmbiguous},
+		{Row: 6, Err: transferring.ErrCSVActivityLink},
+		{Row: 7, Err: store.ErrActivityNotFound},
+		{Row: 8, Err: transferring.ErrCSVActivityID},
+		{Row: 9, Err: domain.ErrAmountPrecision},
+		{Row: 10, Err: domain.ErrCurrencyInvalid},
+		{Row: 11, Err: domain.ErrExpenseValue},
+		{Row: 12, Err: store.ErrActivityNotFound},
+	}
+	if len(report.Errors) != len(expectedErrors) {
+		t.Fatalf("\nExpected Errors: %v\nReturned Errors: %v", expectedErrors, report.Errors)
+	}
+	for i, e := range expectedErrors {
+		if report.Errors[i].Row != e.Row || !errors.Is(report.Errors[i].Err, e.Err) {
+			t.Fatalf("\nExpected Errors: %v\nReturned Errors: %v", expectedErrors, report.Errors)
+		}
+	}
+	for _, exp := range repo.Expenses {
+		if exp.Label == "Restaurant" && (exp.Value != 4550 || exp.Unit != "EUR" || exp.ActivityID != 3 || len(exp.Tags) != 1) {
+			t.Fatalf("\nImported expense has wrong fields: %v", exp)
+		}
+		events, _ := repo.FindAuditEvents(domain.AuditExpense, uint(exp.ID))
+		if len(events) != 1 || events[0].Action != domain.AuditImport || events[0].Principal != "admin" {
+			t.Fatalf("\nExpected import of expense %s by admin\nReturned: %v", exp.Label, events)
+		}
+	}
+}
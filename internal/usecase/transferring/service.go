@@ -0,0 +1,113 @@
+package transferring
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+	"github.com/elhamza90/lifelog/internal/usecase/adding"
+	"github.com/elhamza90/lifelog/internal/usecase/auditing"
+)
+
+// Service provides methods that export entities to CSV
+// and import them from CSV
+type Service struct {
+	repo    Repository
+	adder   adding.Service   // Validates imported entities
+	auditor auditing.Service // Records imported entities
+}
+
+// NewService returns a new transferring service with provided repository
+// and validation policy of imported entities
+func NewService(r Repository, p domain.Policy) Service {
+	return Service{repo: r, adder: adding.NewService(r, p), auditor: auditing.NewService(r)}
+}
+
+// Repository is the interface that wraps the methods
+// that must be implemented by the repository
+// in order for transferring service to perform its job.
+//
+//	- FindTagsPage, FindActivitiesByTimeRangePage and FindExpensesByTimeRangePage
+//	  are used to stream entities page by page when exporting.
+//
+//	- FindActivityByID is used to export the label & time of linked activities.
+//
+//	- FindTagByName and FindActivitiesByTimeRange resolve tags by name
+//	  and activities by label & time when importing.
+//
+//	- The adding repository methods are used to check & create imported entities
+//	  ( without recording their creation, as they are recorded as imported ).
+//
+//	- FindExpenseByID and the auditing repository methods are used
+//	  to record imported entities in their history.
+type Repository interface {
+	adding.Repository
+	auditing.Repository
+	FindExpenseByID(domain.ExpenseID) (domain.Expense, error)
+	FindTagsPage(store.PageRequest) ([]domain.Tag, store.Page, error)
+	FindActivitiesByTimeRange(time.Time, time.Time) ([]domain.Activity, error)
+	FindActivitiesByTimeRangePage(time.Time, time.Time, store.PageRequest) ([]domain.Activity, store.Page, error)
+	FindExpensesByTimeRangePage(time.Time, time.Time, store.PageRequest) ([]domain.Expense, store.Page, error)
+}
+
+// Errors
+var (
+	ErrCSVHeader error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "csv_header",
+		Message: "CSV header is missing or lacks required columns",
+	}
+	ErrCSVRow error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "csv_row",
+		Message: "Row is not valid CSV or has a wrong number of fields",
+	}
+	ErrCSVTime error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "csv_time",
+		Message: "Time must be RFC3339 or YYYY-MM-DD[ HH:MM[:SS]]",
+		Field:   "time",
+	}
+	ErrCSVDuration error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "csv_duration",
+		Message: "Duration must be like 1h30m or HH:MM",
+		Field:   "duration",
+	}
+	ErrCSVActivityID error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "csv_activity_id",
+		Message: "Activity ID must be a positive integer",
+		Field:   "activity_id",
+	}
+	ErrCSVActivityLink error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "csv_activity_link",
+		Message: "Activity must be referenced by ID or by both label and time",
+	}
+	ErrActivityAmbiguous error = &domain.Error{
+		Kind:    domain.KindInvalid,
+		Code:    "activity_ambiguous",
+		Message: "Several activities have the referenced label and time",
+	}
+)
+
+// RowError is an error that occured while importing a row of a CSV file.
+// Row is the line number of the row in the file ( the header is line 1 ).
+type RowError struct {
+	Row int
+	Err error
+}
+
+// Error returns a string representation of the row error
+func (e RowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+}
+
+// Report describes the result of an import.
+// Valid rows are imported even if other rows are invalid.
+type Report struct {
+	Imported int
+	Errors   []RowError
+}
@@ -0,0 +1,43 @@
+package transferring
+
+import (
+	"io"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// Columns of tags CSV files
+var tagColumns = []string{"id", "name"}
+
+// ExportTags writes all tags sorted by name as CSV with columns id,name.
+func (srv Service) ExportTags(w io.Writer) error {
+	p := store.PageRequest{Limit: exportPageLimit, Sort: store.SortByLabel}
+	return exportCSV(w, tagColumns, func() ([][]string, bool, error) {
+		tags, page, err := srv.repo.FindTagsPage(p)
+		if err != nil {
+			return nil, false, err
+		}
+		rows := make([][]string, len(tags))
+		for i, t := range tags {
+			rows[i] = []string{t.ID.String(), t.Name}
+		}
+		p.Cursor = page.NextCursor
+		return rows, page.NextCursor != "", nil
+	})
+}
+
+// ImportTags creates tags from a CSV file with a column name.
+// The id column of exported files is ignored.
+// Rows with invalid or duplicate names are reported.
+// Tags are recorded in their history as imported by principal.
+func (srv Service) ImportTags(r io.Reader, principal string) (Report, error) {
+	return srv.importCSV(r, []string{"name"}, principal, func(rw row) (imported, error) {
+		t, err := srv.adder.CheckTag(domain.Tag{Name: rw.get("name")})
+		if err != nil {
+			return imported{}, err
+		}
+		id, err := srv.repo.SaveTag(t)
+		return imported{entity: domain.AuditTag, id: uint(id)}, err
+	})
+}
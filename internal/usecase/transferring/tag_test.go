@@ -0,0 +1,79 @@
+package transferring_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/transferring"
+)
+
+func TestExportTags(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "sport"},
+		2: {ID: 2, Name: "bills"},
+	}
+	defer func() { repo.Tags = map[domain.TagID]domain.Tag{} }()
+	var buf bytes.Buffer
+	if err := transferrer.ExportTags(&buf); err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	const expected string = "id,name\n2,bills\n1,sport\n"
+	if buf.String() != expected {
+		t.Fatalf("\nExpected:\n%s\nReturned:\n%s", expected, buf.String())
+	}
+}
+
+func TestImportTags(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "sport"},
+	}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	}()
+	const file string = "id,name\n7,Bills\n8,sport\n9,a\n,travel\n"
+	report, err := transferrer.ImportTags(strings.NewReader(file), "admin")
+	if err != nil {
+		t.Fatalf("\nUnexpected Error: %v", err)
+	}
+	if report.Imported != 2 || len(repo.Tags) != 3 {
+		t.Fatalf("\nExpected 2 imported tags\nReturned: %d ( %d stored )", report.Imported, len(repo.Tags))
+	}
+	expectedErrors := []transferring.RowError{
+		{Row: 3, Err: domain.ErrTagNameDuplicate},
+		{Row: 4, Err: domain.ErrTagNameLen},
+	}
+	if len(report.Errors) != len(expectedErrors) {
+		t.Fatalf("\nExpected Errors: %v\nReturned Errors: %v", expectedErrors, report.Errors)
+	}
+	for i, e := range expectedErrors {
+		if report.Errors[i].Row != e.Row || !errors.Is(report.Errors[i].Err, e.Err) {
+			t.Fatalf("\nExpected Errors: %v\nReturned Errors: %v", expectedErrors, report.Errors)
+		}
+	}
+	bills, err := repo.FindTagByName("bills")
+	if err != nil {
+		t.Fatalf("\nExpected tag bills to be imported: %v", err)
+	}
+	// Imported tags are recorded in their history
+	if len(repo.AuditEvents) != 2 {
+		t.Fatalf("\nExpected 2 recorded imports\nReturned: %v", repo.AuditEvents)
+	}
+	events, _ := repo.FindAuditEvents(domain.AuditTag, uint(bills.ID))
+	expectedAfter := fmt.Sprintf(`{"ID":%d,"Name":"bills"}`, bills.ID)
+	if len(events) != 1 || events[0].Action != domain.AuditImport || events[0].Principal != "admin" || string(events[0].After) != expectedAfter {
+		t.Fatalf("\nExpected import of tag bills by admin\nReturned: %v", events)
+	}
+	// Subcase: Header without name column
+	if _, err := transferrer.ImportTags(strings.NewReader("id,label\n1,bills\n"), "admin"); err != transferring.ErrCSVHeader {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", transferring.ErrCSVHeader, err)
+	}
+	// Subcase: Empty file
+	if _, err := transferrer.ImportTags(strings.NewReader(""), "admin"); err != transferring.ErrCSVHeader {
+		t.Fatalf("\nExpected Error: %v\nReturned Error: %v", transferring.ErrCSVHeader, err)
+	}
+}
@@ -0,0 +1,19 @@
+package transferring_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store/memory"
+	"github.com/elhamza90/lifelog/internal/usecase/transferring"
+)
+
+var transferrer transferring.Service // Instance of service we will be testing
+var repo memory.Repository           // Repository used by service
+
+func TestMain(m *testing.M) {
+	repo = memory.NewRepository()                                        // Work with In-Memory DB
+	transferrer = transferring.NewService(&repo, domain.DefaultPolicy()) // Passing by reference to change db when testing
+	os.Exit(m.Run())
+}
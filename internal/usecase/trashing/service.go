@@ -0,0 +1,55 @@
+package trashing
+
+import (
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/usecase/auditing"
+)
+
+// Service provides methods that list, restore and purge
+// the entities in the trash
+type Service struct {
+	repo    Repository
+	auditor auditing.Service // Records restores & purges
+}
+
+// NewService returns a new service with provided repository
+func NewService(r Repository) Service {
+	return Service{repo: r, auditor: auditing.NewService(r)}
+}
+
+// Repository is the interface that wraps the methods that must be
+// implemented by the repository in order for trashing service
+// to perform its job
+//
+//	- FindTrash, FindTrashedTagByID, FindTrashedActivityByID,
+//	  FindTrashedExpenseByID are used to read the trash
+//
+//	- RestoreTag, RestoreActivity, RestoreExpense take entities
+//	  out of the trash
+//
+//	- PurgeTag, PurgeActivity, PurgeExpense, PurgeTrash permanently
+//	  delete entities from the trash
+//
+//	- FindTagByName, FindActivityByID are used to check restored
+//	  entities do not conflict with the ones in store
+//
+//	- The auditing repository methods are used to record
+//	  restores & purges
+type Repository interface {
+	auditing.Repository
+	FindTrash() ([]domain.TrashItem, error)
+	FindTrashedTagByID(domain.TagID) (domain.Tag, error)
+	FindTrashedActivityByID(domain.ActivityID) (domain.Activity, error)
+	FindTrashedExpenseByID(domain.ExpenseID) (domain.Expense, error)
+	RestoreTag(domain.TagID) error
+	RestoreActivity(domain.ActivityID) error
+	RestoreExpense(domain.ExpenseID) error
+	PurgeTag(domain.TagID) error
+	PurgeActivity(domain.ActivityID) error
+	PurgeExpense(domain.ExpenseID) error
+	PurgeTrash(before time.Time) (int, error)
+	FindTagByName(string) (domain.Tag, error)
+	FindActivityByID(domain.ActivityID) (domain.Activity, error)
+}
@@ -0,0 +1,199 @@
+package trashing
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// Trash returns the items in the trash, most recently deleted first
+func (srv Service) Trash() ([]domain.TrashItem, error) {
+	return srv.repo.FindTrash()
+}
+
+// RestoreTag takes the tag with given ID out of the trash
+// and records it was restored by principal.
+// It fails if a tag with the same name was created since it was deleted.
+func (srv Service) RestoreTag(id domain.TagID, principal string) error {
+	t, err := srv.repo.FindTrashedTagByID(id)
+	if err != nil {
+		return err
+	}
+	if _, err := srv.repo.FindTagByName(t.Name); err == nil {
+		return domain.ErrTagNameDuplicate
+	} else if !errors.Is(err, store.ErrTagNotFound) {
+		return err
+	}
+	if err := srv.repo.RestoreTag(id); err != nil {
+		return err
+	}
+	return srv.auditor.Record(domain.AuditTag, uint(id), domain.AuditRestore, principal, nil, t)
+}
+
+// RestoreActivity takes the activity with given ID out of the trash
+// and records it was restored by principal.
+func (srv Service) RestoreActivity(id domain.ActivityID, principal string) error {
+	act, err := srv.repo.FindTrashedActivityByID(id)
+	if err != nil {
+		return err
+	}
+	if err := srv.repo.RestoreActivity(id); err != nil {
+		return err
+	}
+	return srv.auditor.Record(domain.AuditActivity, uint(id), domain.AuditRestore, principal, nil, act)
+}
+
+// RestoreExpense takes the expense with given ID out of the trash
+// and records it was restored by principal.
+// The activity of the expense, if any, must be restored first.
+func (srv Service) RestoreExpense(id domain.ExpenseID, principal string) error {
+	exp, err := srv.repo.FindTrashedExpenseByID(id)
+	if err != nil {
+		return err
+	}
+	if exp.ActivityID > 0 {
+		if _, err := srv.repo.FindActivityByID(exp.ActivityID); err != nil {
+			return err
+		}
+	}
+	if err := srv.repo.RestoreExpense(id); err != nil {
+		return err
+	}
+	return srv.auditor.Record(domain.AuditExpense, uint(id), domain.AuditRestore, principal, nil, exp)
+}
+
+// PurgeTag permanently deletes the tag with given ID from the trash
+// and records it was purged by principal.
+func (srv Service) PurgeTag(id domain.TagID, principal string) error {
+	t, err := srv.repo.FindTrashedTagByID(id)
+	if err != nil {
+		return err
+	}
+	if err := srv.repo.PurgeTag(id); err != nil {
+		return err
+	}
+	return srv.recordPurges(principal, []domain.Tag{t}, nil, nil)
+}
+
+// PurgeActivity permanently deletes the activity with given ID from the trash
+// along with its trashed expenses and records they were purged by principal.
+func (srv Service) PurgeActivity(id domain.ActivityID, principal string) error {
+	act, err := srv.repo.FindTrashedActivityByID(id)
+	if err != nil {
+		return err
+	}
+	items, err := srv.repo.FindTrash()
+	if err != nil {
+		return err
+	}
+	expenses, err := srv.trashedExpenses(items, func(_ domain.TrashItem, exp domain.Expense) bool {
+		return exp.ActivityID == id
+	})
+	if err != nil {
+		return err
+	}
+	if err := srv.repo.PurgeActivity(id); err != nil {
+		return err
+	}
+	return srv.recordPurges(principal, nil, []domain.Activity{act}, expenses)
+}
+
+// PurgeExpense permanently deletes the expense with given ID from the trash
+// and records it was purged by principal.
+func (srv Service) PurgeExpense(id domain.ExpenseID, principal string) error {
+	exp, err := srv.repo.FindTrashedExpenseByID(id)
+	if err != nil {
+		return err
+	}
+	if err := srv.repo.PurgeExpense(id); err != nil {
+		return err
+	}
+	return srv.recordPurges(principal, nil, nil, []domain.Expense{exp})
+}
+
+// Purge permanently deletes the items deleted before given time,
+// records they were purged by principal and returns how many were purged.
+// The trashed expenses of purged activities are purged and recorded too.
+func (srv Service) Purge(before time.Time, principal string) (int, error) {
+	items, err := srv.repo.FindTrash()
+	if err != nil {
+		return 0, err
+	}
+	var (
+		tags       []domain.Tag
+		activities []domain.Activity
+		purgedActs = map[domain.ActivityID]bool{}
+	)
+	for _, it := range items {
+		if !it.DeletedAt.Before(before) {
+			continue
+		}
+		switch it.Kind {
+		case domain.TrashTag:
+			t, err := srv.repo.FindTrashedTagByID(domain.TagID(it.ID))
+			if err != nil {
+				return 0, err
+			}
+			tags = append(tags, t)
+		case domain.TrashActivity:
+			act, err := srv.repo.FindTrashedActivityByID(domain.ActivityID(it.ID))
+			if err != nil {
+				return 0, err
+			}
+			activities = append(activities, act)
+			purgedActs[act.ID] = true
+		}
+	}
+	expenses, err := srv.trashedExpenses(items, func(it domain.TrashItem, exp domain.Expense) bool {
+		return it.DeletedAt.Before(before) || purgedActs[exp.ActivityID]
+	})
+	if err != nil {
+		return 0, err
+	}
+	purged, err := srv.repo.PurgeTrash(before)
+	if err != nil {
+		return purged, err
+	}
+	return purged, srv.recordPurges(principal, tags, activities, expenses)
+}
+
+// trashedExpenses returns the expenses among the trashed items for which purged returns true
+func (srv Service) trashedExpenses(items []domain.TrashItem, purged func(domain.TrashItem, domain.Expense) bool) ([]domain.Expense, error) {
+	res := []domain.Expense{}
+	for _, it := range items {
+		if it.Kind != domain.TrashExpense {
+			continue
+		}
+		exp, err := srv.repo.FindTrashedExpenseByID(domain.ExpenseID(it.ID))
+		if err != nil {
+			return nil, err
+		}
+		if purged(it, exp) {
+			res = append(res, exp)
+		}
+	}
+	return res, nil
+}
+
+// recordPurges records the given entities were purged by principal.
+// All purges are recorded even if one fails and the first error is returned.
+func (srv Service) recordPurges(principal string, tags []domain.Tag, activities []domain.Activity, expenses []domain.Expense) error {
+	var firstErr error
+	record := func(entity domain.AuditEntity, id uint, before interface{}) {
+		if err := srv.auditor.Record(entity, id, domain.AuditPurge, principal, before, nil); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, t := range tags {
+		record(domain.AuditTag, uint(t.ID), t)
+	}
+	for _, act := range activities {
+		record(domain.AuditActivity, uint(act.ID), act)
+	}
+	for _, exp := range expenses {
+		record(domain.AuditExpense, uint(exp.ID), exp)
+	}
+	return firstErr
+}
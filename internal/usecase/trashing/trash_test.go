@@ -0,0 +1,153 @@
+package trashing_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elhamza90/lifelog/internal/domain"
+	"github.com/elhamza90/lifelog/internal/store"
+)
+
+// checkRecorded checks the last change of the entity with given kind & ID
+// is the given action made by the given principal
+func checkRecorded(t *testing.T, entity domain.AuditEntity, id uint, action domain.AuditAction, principal string) {
+	t.Helper()
+	events, _ := repo.FindAuditEvents(entity, id)
+	if len(events) == 0 || events[len(events)-1].Action != action || events[len(events)-1].Principal != principal {
+		t.Fatalf("\nExpected %s of %s %d by %s to be recorded\nReturned: %v", action, entity, id, principal, events)
+	}
+}
+
+func TestRestoreTag(t *testing.T) {
+	repo.Tags = map[domain.TagID]domain.Tag{
+		1: {ID: 1, Name: "sport"},
+		2: {ID: 2, Name: "work"},
+		3: {ID: 3, Name: "work"},
+	}
+	repo.Trash = map[string]time.Time{"tag/1": time.Now(), "tag/2": time.Now()}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Trash = map[string]time.Time{}
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	}()
+	tests := map[string]struct {
+		id          domain.TagID
+		expectedErr error
+	}{
+		"Trashed tag":      {id: 1, expectedErr: nil},
+		"Duplicate name":   {id: 2, expectedErr: domain.ErrTagNameDuplicate},
+		"Tag not in trash": {id: 3, expectedErr: store.ErrTrashItemNotFound},
+		"Non-existing tag": {id: 4, expectedErr: store.ErrTrashItemNotFound},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := trasher.RestoreTag(test.id, "admin")
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("\nExpected Err: %v\nReturned Err: %v", test.expectedErr, err)
+			}
+		})
+	}
+	if _, err := repo.FindTagByID(1); err != nil {
+		t.Fatalf("\nExpected restored tag\nReturned Err: %v", err)
+	}
+	checkRecorded(t, domain.AuditTag, 1, domain.AuditRestore, "admin")
+	if events, _ := repo.FindAuditEvents(domain.AuditTag, 2); len(events) != 0 {
+		t.Fatalf("\nExpected failed restore not to be recorded\nReturned: %v", events)
+	}
+}
+
+func TestRestoreExpense(t *testing.T) {
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Football", Time: time.Now().AddDate(0, 0, -1), Duration: time.Hour},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Ticket", Value: 10, Unit: "EUR", ActivityID: 1},
+		2: {ID: 2, Label: "Coffee", Value: 2, Unit: "EUR"},
+	}
+	repo.Trash = map[string]time.Time{"activity/1": time.Now(), "expense/1": time.Now(), "expense/2": time.Now()}
+	defer func() {
+		repo.Activities = map[domain.ActivityID]domain.Activity{}
+		repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+		repo.Trash = map[string]time.Time{}
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	}()
+	if err := trasher.RestoreExpense(1, "admin"); !errors.Is(err, store.ErrActivityNotFound) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", store.ErrActivityNotFound, err)
+	}
+	if err := trasher.RestoreExpense(2, "admin"); err != nil {
+		t.Fatalf("\nUnexpected Err: %v", err)
+	}
+	if err := trasher.RestoreActivity(1, "admin"); err != nil {
+		t.Fatalf("\nUnexpected Err: %v", err)
+	}
+	if err := trasher.RestoreExpense(1, "admin"); err != nil {
+		t.Fatalf("\nUnexpected Err: %v", err)
+	}
+	if items, _ := trasher.Trash(); len(items) != 0 {
+		t.Fatalf("\nExpected empty trash\nReturned: %v", items)
+	}
+	checkRecorded(t, domain.AuditActivity, 1, domain.AuditRestore, "admin")
+	checkRecorded(t, domain.AuditExpense, 1, domain.AuditRestore, "admin")
+	checkRecorded(t, domain.AuditExpense, 2, domain.AuditRestore, "admin")
+}
+
+func TestPurge(t *testing.T) {
+	now := time.Now()
+	repo.Tags = map[domain.TagID]domain.Tag{1: {ID: 1, Name: "sport"}}
+	repo.Activities = map[domain.ActivityID]domain.Activity{
+		1: {ID: 1, Label: "Football", Time: now.AddDate(0, 0, -40), Duration: time.Hour},
+		2: {ID: 2, Label: "Tennis", Time: now.AddDate(0, 0, -2), Duration: time.Hour},
+	}
+	repo.Expenses = map[domain.ExpenseID]domain.Expense{
+		1: {ID: 1, Label: "Ticket", Value: 10, Unit: "EUR", ActivityID: 1},
+		2: {ID: 2, Label: "Balls", Value: 20, Unit: "EUR", ActivityID: 2},
+		3: {ID: 3, Label: "Drinks", Value: 5, Unit: "EUR", ActivityID: 1},
+	}
+	repo.Trash = map[string]time.Time{
+		"tag/1":      now.AddDate(0, 0, -1),
+		"activity/1": now.AddDate(0, 0, -31),
+		"expense/1":  now.AddDate(0, 0, -31),
+		"expense/3":  now.AddDate(0, 0, -1),
+		"activity/2": now.AddDate(0, 0, -1),
+	}
+	defer func() {
+		repo.Tags = map[domain.TagID]domain.Tag{}
+		repo.Activities = map[domain.ActivityID]domain.Activity{}
+		repo.Expenses = map[domain.ExpenseID]domain.Expense{}
+		repo.Trash = map[string]time.Time{}
+		repo.AuditEvents = map[domain.AuditEventID]domain.AuditEvent{}
+	}()
+	purged, err := trasher.Purge(now.AddDate(0, 0, -30), domain.PrincipalTrashPurge)
+	if err != nil || purged != 2 {
+		t.Fatalf("\nExpected 2 purged items\nReturned: %d, %v", purged, err)
+	}
+	if len(repo.Activities) != 1 || len(repo.Expenses) != 1 {
+		t.Fatalf("\nExpected old activity and expense purged\nReturned: %v, %v", repo.Activities, repo.Expenses)
+	}
+	checkRecorded(t, domain.AuditActivity, 1, domain.AuditPurge, domain.PrincipalTrashPurge)
+	checkRecorded(t, domain.AuditExpense, 1, domain.AuditPurge, domain.PrincipalTrashPurge)
+	// Trashed expenses are purged along with their activity
+	checkRecorded(t, domain.AuditExpense, 3, domain.AuditPurge, domain.PrincipalTrashPurge)
+	if err := trasher.PurgeActivity(2, "admin"); err != nil {
+		t.Fatalf("\nUnexpected Err: %v", err)
+	}
+	// Live expenses of purged activities are kept
+	if exp, ok := repo.Expenses[2]; !ok || exp.ActivityID != 0 {
+		t.Fatalf("\nExpected live expense kept without activity\nReturned: %v", repo.Expenses)
+	}
+	if err := trasher.PurgeTag(1, "admin"); err != nil {
+		t.Fatalf("\nUnexpected Err: %v", err)
+	}
+	if err := trasher.PurgeExpense(1, "admin"); !errors.Is(err, store.ErrTrashItemNotFound) {
+		t.Fatalf("\nExpected Err: %v\nReturned Err: %v", store.ErrTrashItemNotFound, err)
+	}
+	if items, _ := trasher.Trash(); len(items) != 0 {
+		t.Fatalf("\nExpected empty trash\nReturned: %v", items)
+	}
+	checkRecorded(t, domain.AuditActivity, 2, domain.AuditPurge, "admin")
+	checkRecorded(t, domain.AuditTag, 1, domain.AuditPurge, "admin")
+	if events, _ := repo.FindAuditEvents(domain.AuditExpense, 2); len(events) != 0 {
+		t.Fatalf("\nExpected live expense not to be recorded as purged\nReturned: %v", events)
+	}
+}
@@ -0,0 +1,20 @@
+package trashing_test
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/elhamza90/lifelog/internal/store/memory"
+	"github.com/elhamza90/lifelog/internal/usecase/trashing"
+)
+
+var trasher trashing.Service
+var repo memory.Repository
+
+func TestMain(m *testing.M) {
+	log.Println("Setting up tests")
+	repo = memory.NewRepository()        // Work with In-Memory DB
+	trasher = trashing.NewService(&repo) // Passing by reference to change db when testing
+	os.Exit(m.Run())
+}